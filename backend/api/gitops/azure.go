@@ -19,12 +19,19 @@ func getAzurePullRequestInfo(ctx context.Context, vcsProvider *store.VCSProvider
 		return nil, errors.Errorf("failed to unmarshal push event, error %v", err)
 	}
 
-	if strings.ToLower(pushEvent.Resource.Status) != "completed" {
-		return nil, errors.Errorf("invalid pull request status: %v", pushEvent.Resource.Status)
-	}
 	if strings.ToLower(pushEvent.Resource.MergeStatus) != "succeeded" {
 		return nil, errors.Errorf("invalid pull request merge status: %v", pushEvent.Resource.MergeStatus)
 	}
+	// Azure DevOps also attempts to merge the active pull request after each push to its branch.
+	var action pullRequestAction
+	switch strings.ToLower(pushEvent.Resource.Status) {
+	case "active":
+		action = pullRequestActionUpdate
+	case "completed":
+		action = pullRequestActionMerge
+	default:
+		return nil, errors.Errorf("invalid pull request status: %v", pushEvent.Resource.Status)
+	}
 
 	targetBranch, err := vcs.Branch(pushEvent.Resource.TargetRefName)
 	if err != nil {
@@ -48,11 +55,13 @@ func getAzurePullRequestInfo(ctx context.Context, vcsProvider *store.VCSProvider
 	}
 
 	prInfo := &pullRequestInfo{
+		action: action,
 		// TODO(ed): get the email.
 		url:         pushEvent.Resource.Links.Web.Href,
+		commit:      pushEvent.Resource.LastMergeCommit.CommitID,
 		title:       pushEvent.Resource.Title,
 		description: pushEvent.Resource.Description,
-		changes:     getChangesByFileList(mrFiles, vcsConnector.Payload.BaseDirectory),
+		changes:     getChangesByFileList(mrFiles, vcsConnector.Payload),
 	}
 
 	for _, file := range prInfo.changes {
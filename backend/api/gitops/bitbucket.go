@@ -13,7 +13,17 @@ import (
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
-func getBitBucketPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProviderMessage, vcsConnector *store.VCSConnectorMessage, body []byte) (*pullRequestInfo, error) {
+func getBitBucketPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProviderMessage, vcsConnector *store.VCSConnectorMessage, eventType string, body []byte) (*pullRequestInfo, error) {
+	var action pullRequestAction
+	switch eventType {
+	case "pullrequest:created", "pullrequest:updated":
+		action = pullRequestActionUpdate
+	case "pullrequest:fulfilled":
+		action = pullRequestActionMerge
+	default:
+		return nil, errors.Errorf("skip webhook event type, got %s, want pullrequest:created, pullrequest:updated or pullrequest:fulfilled", eventType)
+	}
+
 	var pushEvent bitbucket.PullRequestPushEvent
 	if err := json.Unmarshal(body, &pushEvent); err != nil {
 		return nil, errors.Errorf("failed to unmarshal push event, error %v", err)
@@ -29,11 +39,13 @@ func getBitBucketPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProv
 	}
 
 	prInfo := &pullRequestInfo{
+		action: action,
 		// email. How do we determine the user for BitBucket user?
 		url:         pushEvent.PullRequest.Links.HTML.Href,
+		commit:      pushEvent.PullRequest.Source.Commit.Hash,
 		title:       pushEvent.PullRequest.Title,
 		description: pushEvent.PullRequest.Description,
-		changes:     getChangesByFileList(mrFiles, vcsConnector.Payload.BaseDirectory),
+		changes:     getChangesByFileList(mrFiles, vcsConnector.Payload),
 	}
 
 	for _, file := range prInfo.changes {
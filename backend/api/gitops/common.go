@@ -9,7 +9,9 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
@@ -17,11 +19,23 @@ var (
 	versionRE = regexp.MustCompile(`^[0-9]+`)
 )
 
+// pullRequestAction is the action taken on the pull request.
+type pullRequestAction int
+
+const (
+	// pullRequestActionUpdate is for opening the pull request or pushing to its branch.
+	pullRequestActionUpdate pullRequestAction = iota
+	// pullRequestActionMerge is for merging the pull request.
+	pullRequestActionMerge
+)
+
 type pullRequestInfo struct {
+	action      pullRequestAction
 	email       string
 	title       string
 	description string
 	url         string
+	commit      string
 	changes     []*fileChange
 }
 
@@ -31,9 +45,36 @@ type fileChange struct {
 	changeType  v1pb.Plan_ChangeDatabaseConfig_Type
 	description string
 	content     string
+	// environmentID and databaseName are the target addressed by the file path template.
+	// Empty means any.
+	environmentID string
+	databaseName  string
+}
+
+// matchDatabase returns true if the change applies to the database.
+func (c *fileChange) matchDatabase(database *store.DatabaseMessage) bool {
+	if c.environmentID != "" && c.environmentID != database.EffectiveEnvironmentID {
+		return false
+	}
+	if c.databaseName != "" && c.databaseName != database.DatabaseName {
+		return false
+	}
+	return true
 }
 
-func getChangesByFileList(files []*vcs.PullRequestFile, rootDir string) []*fileChange {
+func getChangesByFileList(files []*vcs.PullRequestFile, vcsConnector *storepb.VCSConnector) []*fileChange {
+	rootDir := vcsConnector.BaseDirectory
+	isSDL := vcsConnector.Mode == storepb.VCSConnector_SDL
+	var template *vcs.FilePathTemplate
+	if vcsConnector.FilePathTemplate != "" {
+		t, err := vcs.ParseFilePathTemplate(vcsConnector.FilePathTemplate)
+		if err != nil {
+			slog.Error("failed to parse file path template", slog.String("template", vcsConnector.FilePathTemplate), log.BBError(err))
+			return []*fileChange{}
+		}
+		template = t
+	}
+
 	changes := []*fileChange{}
 	for _, v := range files {
 		if v.IsDeleted {
@@ -43,12 +84,25 @@ func getChangesByFileList(files []*vcs.PullRequestFile, rootDir string) []*fileC
 		if !strings.HasPrefix(prFilePath, "/") {
 			prFilePath = fmt.Sprintf("/%s", prFilePath)
 		}
-		if filepath.Dir(prFilePath) != rootDir {
+
+		var change *fileChange
+		switch {
+		case template != nil:
+			relativePath, ok := getRelativePath(prFilePath, rootDir)
+			if !ok {
+				continue
+			}
+			change = getFileChangeByTemplate(template, relativePath, isSDL)
+		case filepath.Dir(prFilePath) != rootDir:
 			continue
-		}
-		change, err := getFileChange(v.Path)
-		if err != nil {
-			slog.Error("failed to get file change info", slog.String("path", v.Path), log.BBError(err))
+		case isSDL:
+			change = getSDLFileChange(v.Path)
+		default:
+			c, err := getFileChange(v.Path)
+			if err != nil {
+				slog.Error("failed to get file change info", slog.String("path", v.Path), log.BBError(err))
+			}
+			change = c
 		}
 		if change != nil {
 			change.path = v.Path
@@ -58,6 +112,49 @@ func getChangesByFileList(files []*vcs.PullRequestFile, rootDir string) []*fileC
 	return changes
 }
 
+// getRelativePath returns the path relative to the root directory, and false if the path is not under the root directory.
+func getRelativePath(path, rootDir string) (string, bool) {
+	prefix := strings.TrimSuffix(rootDir, "/") + "/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(path, prefix), true
+}
+
+func getFileChangeByTemplate(template *vcs.FilePathTemplate, relativePath string, isSDL bool) *fileChange {
+	match := template.Match(relativePath)
+	if match == nil {
+		return nil
+	}
+	changeType := v1pb.Plan_ChangeDatabaseConfig_MIGRATE
+	switch {
+	case isSDL:
+		changeType = v1pb.Plan_ChangeDatabaseConfig_MIGRATE_SDL
+	case match.Type == "dml":
+		changeType = v1pb.Plan_ChangeDatabaseConfig_DATA
+	case match.Type == "ghost":
+		changeType = v1pb.Plan_ChangeDatabaseConfig_MIGRATE_GHOST
+	}
+	return &fileChange{
+		version:       match.Version,
+		changeType:    changeType,
+		description:   match.Description,
+		environmentID: match.EnvironmentID,
+		databaseName:  match.DatabaseName,
+	}
+}
+
+// getSDLFileChange returns the change for the SDL file holding the desired full schema.
+func getSDLFileChange(path string) *fileChange {
+	if filepath.Ext(path) != ".sql" {
+		return nil
+	}
+	return &fileChange{
+		path:       path,
+		changeType: v1pb.Plan_ChangeDatabaseConfig_MIGRATE_SDL,
+	}
+}
+
 func getFileChange(path string) (*fileChange, error) {
 	filename := filepath.Base(path)
 	if filepath.Ext(filename) != ".sql" {
@@ -92,18 +189,6 @@ func getFileChange(path string) (*fileChange, error) {
 	}, nil
 }
 
-func getPullRequestID(url string) string {
-	fields := strings.Split(url, "/")
-	if len(fields) == 0 {
-		return ""
-	}
-	return fields[len(fields)-1]
-}
-
-func getPullRequestComment(externalURL, issue string) string {
-	return fmt.Sprintf("Bytebase Bot: this pull request has triggered a Bytebase rollout 🚀. Check out the status at %s/%s.", externalURL, issue)
-}
-
 func convertFileContentToUTF8String(content string) string {
 	convertedContent, err := utils.ConvertBytesToUTF8String([]byte(content))
 	if err != nil {
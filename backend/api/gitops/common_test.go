@@ -0,0 +1,68 @@
+package gitops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestGetChangesByFileList(t *testing.T) {
+	files := []*vcs.PullRequestFile{
+		{Path: "bbtest/0001_ddl_create_table.sql"},
+		{Path: "bbtest/prod/employee/0002_dml_insert.sql"},
+		{Path: "bbtest/test/employee/0003_ghost_alter.sql"},
+		{Path: "bbtest/prod/employee/0004_ddl_removed.sql", IsDeleted: true},
+		{Path: "bbtest/prod/employee/README.md"},
+		{Path: "other/prod/employee/0005_ddl_create_table.sql"},
+	}
+
+	a := require.New(t)
+	changes := getChangesByFileList(files, &storepb.VCSConnector{BaseDirectory: "/bbtest"})
+	a.Len(changes, 1)
+	a.Equal("bbtest/0001_ddl_create_table.sql", changes[0].path)
+	a.Equal("0001", changes[0].version)
+	a.Equal(v1pb.Plan_ChangeDatabaseConfig_MIGRATE, changes[0].changeType)
+
+	changes = getChangesByFileList(files, &storepb.VCSConnector{BaseDirectory: "/bbtest", FilePathTemplate: "{{ENV_ID}}/{{DB_NAME}}/{{VERSION}}_{{TYPE}}_{{DESC}}.sql"})
+	a.Equal([]*fileChange{
+		{
+			path:          "bbtest/prod/employee/0002_dml_insert.sql",
+			version:       "0002",
+			changeType:    v1pb.Plan_ChangeDatabaseConfig_DATA,
+			description:   "insert",
+			environmentID: "prod",
+			databaseName:  "employee",
+		},
+		{
+			path:          "bbtest/test/employee/0003_ghost_alter.sql",
+			version:       "0003",
+			changeType:    v1pb.Plan_ChangeDatabaseConfig_MIGRATE_GHOST,
+			description:   "alter",
+			environmentID: "test",
+			databaseName:  "employee",
+		},
+	}, changes)
+
+	changes = getChangesByFileList(files, &storepb.VCSConnector{BaseDirectory: "/", FilePathTemplate: "{{DB_NAME}}/{{VERSION}}_{{TYPE}}_{{DESC}}.sql"})
+	a.Len(changes, 1)
+	a.Equal("bbtest", changes[0].databaseName)
+	changes = getChangesByFileList(files, &storepb.VCSConnector{BaseDirectory: "/", FilePathTemplate: "bbtest/{{VERSION}}_{{TYPE}}_{{DESC}}.sql"})
+	a.Len(changes, 1)
+	a.Equal("create_table", changes[0].description)
+
+	// SDL mode.
+	changes = getChangesByFileList(files, &storepb.VCSConnector{BaseDirectory: "/bbtest", Mode: storepb.VCSConnector_SDL})
+	a.Len(changes, 1)
+	a.Equal(v1pb.Plan_ChangeDatabaseConfig_MIGRATE_SDL, changes[0].changeType)
+	a.Empty(changes[0].version)
+	changes = getChangesByFileList(files, &storepb.VCSConnector{BaseDirectory: "/bbtest", FilePathTemplate: "{{ENV_ID}}/{{DB_NAME}}/{{DESC}}.sql", Mode: storepb.VCSConnector_SDL})
+	a.Len(changes, 2)
+	for _, change := range changes {
+		a.Equal(v1pb.Plan_ChangeDatabaseConfig_MIGRATE_SDL, change.changeType)
+		a.Equal("employee", change.databaseName)
+	}
+}
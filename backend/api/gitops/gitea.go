@@ -0,0 +1,62 @@
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/plugin/vcs/gitea"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	giteaSynchronizeAction = "synchronized"
+)
+
+func getGiteaPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProviderMessage, vcsConnector *store.VCSConnectorMessage, body []byte) (*pullRequestInfo, error) {
+	var pushEvent gitea.PullRequestPushEvent
+	if err := json.Unmarshal(body, &pushEvent); err != nil {
+		return nil, errors.Errorf("failed to unmarshal push event, error %v", err)
+	}
+	var action pullRequestAction
+	switch {
+	// Unlike GitHub, Gitea sends "synchronized" for pushing to the pull request branch.
+	case pushEvent.Action == openAction, pushEvent.Action == reopenAction, pushEvent.Action == giteaSynchronizeAction:
+		action = pullRequestActionUpdate
+	case pushEvent.Action == closeAction && pushEvent.PullRequest.Merged:
+		action = pullRequestActionMerge
+	default:
+		return nil, errors.Errorf("skip webhook event action, got %s, want opened, reopened, synchronized or closed with merged", pushEvent.Action)
+	}
+
+	if pushEvent.PullRequest.Base.Ref != vcsConnector.Payload.Branch {
+		return nil, errors.Errorf("skip branch, got %q, want %q", pushEvent.PullRequest.Base.Ref, vcsConnector.Payload.Branch)
+	}
+
+	mrFiles, err := vcs.Get(storepb.VCSType_GITEA, vcs.ProviderConfig{InstanceURL: vcsProvider.InstanceURL, AuthToken: vcsProvider.AccessToken}).ListPullRequestFile(ctx, vcsConnector.Payload.ExternalId, fmt.Sprintf("%d", pushEvent.Number))
+	if err != nil {
+		return nil, errors.Errorf("failed to list merge %q request files, error %v", pushEvent.PullRequest.HTMLURL, err)
+	}
+
+	prInfo := &pullRequestInfo{
+		action:      action,
+		url:         pushEvent.PullRequest.HTMLURL,
+		commit:      pushEvent.PullRequest.Head.SHA,
+		title:       pushEvent.PullRequest.Title,
+		description: pushEvent.PullRequest.Body,
+		changes:     getChangesByFileList(mrFiles, vcsConnector.Payload),
+	}
+
+	for _, file := range prInfo.changes {
+		content, err := vcs.Get(storepb.VCSType_GITEA, vcs.ProviderConfig{InstanceURL: vcsProvider.InstanceURL, AuthToken: vcsProvider.AccessToken}).ReadFileContent(ctx, vcsConnector.Payload.ExternalId, file.path, vcs.RefInfo{RefType: vcs.RefTypeCommit, RefName: pushEvent.PullRequest.Head.SHA})
+		if err != nil {
+			return nil, errors.Errorf("failed read file content, merge request %q, file %q, error %v", pushEvent.PullRequest.HTMLURL, file.path, err)
+		}
+		file.content = convertFileContentToUTF8String(content)
+	}
+	return prInfo, nil
+}
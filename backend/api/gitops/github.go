@@ -14,7 +14,10 @@ import (
 )
 
 const (
-	closeAction = "closed"
+	closeAction       = "closed"
+	openAction        = "opened"
+	reopenAction      = "reopened"
+	synchronizeAction = "synchronize"
 )
 
 func getGitHubPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProviderMessage, vcsConnector *store.VCSConnectorMessage, body []byte) (*pullRequestInfo, error) {
@@ -22,8 +25,14 @@ func getGitHubPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProvide
 	if err := json.Unmarshal(body, &pushEvent); err != nil {
 		return nil, errors.Errorf("failed to unmarshal push event, error %v", err)
 	}
-	if pushEvent.Action != closeAction || !pushEvent.PullRequest.Merged {
-		return nil, errors.Errorf("skip webhook event action, got %s, want closed with merged", pushEvent.Action)
+	var action pullRequestAction
+	switch {
+	case pushEvent.Action == openAction, pushEvent.Action == reopenAction, pushEvent.Action == synchronizeAction:
+		action = pullRequestActionUpdate
+	case pushEvent.Action == closeAction && pushEvent.PullRequest.Merged:
+		action = pullRequestActionMerge
+	default:
+		return nil, errors.Errorf("skip webhook event action, got %s, want opened, reopened, synchronize or closed with merged", pushEvent.Action)
 	}
 
 	if pushEvent.PullRequest.Base.Ref != vcsConnector.Payload.Branch {
@@ -36,11 +45,13 @@ func getGitHubPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProvide
 	}
 
 	prInfo := &pullRequestInfo{
+		action: action,
 		// email. How do we determine the user for GitHub user?
 		url:         pushEvent.PullRequest.HTMLURL,
+		commit:      pushEvent.PullRequest.Head.SHA,
 		title:       pushEvent.PullRequest.Title,
 		description: pushEvent.PullRequest.Body,
-		changes:     getChangesByFileList(mrFiles, vcsConnector.Payload.BaseDirectory),
+		changes:     getChangesByFileList(mrFiles, vcsConnector.Payload),
 	}
 
 	for _, file := range prInfo.changes {
@@ -14,8 +14,11 @@ import (
 )
 
 const (
-	mergeRequestObjectKind = "merge_request"
-	mergeAction            = "merge"
+	mergeRequestObjectKind   = "merge_request"
+	mergeAction              = "merge"
+	openMergeRequestAction   = "open"
+	reopenMergeRequestAction = "reopen"
+	updateMergeRequestAction = "update"
 )
 
 func getGitLabPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProviderMessage, vcsConnector *store.VCSConnectorMessage, body []byte) (*pullRequestInfo, error) {
@@ -26,8 +29,14 @@ func getGitLabPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProvide
 	if pushEvent.ObjectKind != mergeRequestObjectKind {
 		return nil, errors.Errorf("skip webhook event type, got %s, want push", pushEvent.ObjectKind)
 	}
-	if pushEvent.ObjectAttributes.Action != mergeAction {
-		return nil, errors.Errorf("skip webhook event action, got %s, want merge", pushEvent.ObjectAttributes.Action)
+	var action pullRequestAction
+	switch pushEvent.ObjectAttributes.Action {
+	case openMergeRequestAction, reopenMergeRequestAction, updateMergeRequestAction:
+		action = pullRequestActionUpdate
+	case mergeAction:
+		action = pullRequestActionMerge
+	default:
+		return nil, errors.Errorf("skip webhook event action, got %s, want open, reopen, update or merge", pushEvent.ObjectAttributes.Action)
 	}
 
 	if pushEvent.ObjectAttributes.TargetBranch != vcsConnector.Payload.Branch {
@@ -40,11 +49,13 @@ func getGitLabPullRequestInfo(ctx context.Context, vcsProvider *store.VCSProvide
 	}
 
 	prInfo := &pullRequestInfo{
+		action:      action,
 		email:       pushEvent.User.Email,
 		url:         pushEvent.ObjectAttributes.URL,
+		commit:      pushEvent.ObjectAttributes.LastCommit.ID,
 		title:       pushEvent.ObjectAttributes.Title,
 		description: pushEvent.ObjectAttributes.Description,
-		changes:     getChangesByFileList(mrFiles, vcsConnector.Payload.BaseDirectory),
+		changes:     getChangesByFileList(mrFiles, vcsConnector.Payload),
 	}
 
 	for _, file := range prInfo.changes {
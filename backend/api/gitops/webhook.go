@@ -23,8 +23,6 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
-	"github.com/bytebase/bytebase/backend/plugin/vcs"
-
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
@@ -121,14 +119,12 @@ func (s *Service) RegisterWebhookRoutes(g *echo.Group) {
 		case storepb.VCSType_BITBUCKET:
 			eventType := c.Request().Header.Get("X-Event-Key")
 			switch eventType {
-			case "pullrequest:created", "pullrequest:updated":
-				return c.String(http.StatusOK, "OK")
-			case "pullrequest:fulfilled":
+			case "pullrequest:created", "pullrequest:updated", "pullrequest:fulfilled":
 			default:
 				return c.String(http.StatusOK, "OK")
 			}
 
-			prInfo, err = getBitBucketPullRequestInfo(ctx, vcsProvider, vcsConnector, body)
+			prInfo, err = getBitBucketPullRequestInfo(ctx, vcsProvider, vcsConnector, eventType, body)
 			if err != nil {
 				return c.String(http.StatusOK, fmt.Sprintf("failed to get pr info from pull request, error %v", err))
 			}
@@ -142,24 +138,36 @@ func (s *Service) RegisterWebhookRoutes(g *echo.Group) {
 			if err != nil {
 				return c.String(http.StatusOK, fmt.Sprintf("failed to get pr info from pull request, error %v", err))
 			}
+		case storepb.VCSType_GITEA:
+			// Forgejo sends the X-Forgejo-* headers along with the X-Gitea-* ones.
+			secretToken := getHeaderWithFallback(c.Request().Header, "X-Gitea-Signature", "X-Forgejo-Signature")
+			ok, err := validateGiteaWebhookSignature(secretToken, vcsConnector.Payload.WebhookSecretToken, body)
+			if err != nil {
+				return c.String(http.StatusOK, fmt.Sprintf("failed to validate webhook signature %q, error %v", secretToken, err))
+			}
+			if !ok {
+				return c.String(http.StatusOK, fmt.Sprintf("invalid webhook secret token %q", secretToken))
+			}
+			if eventType := getHeaderWithFallback(c.Request().Header, "X-Gitea-Event", "X-Forgejo-Event"); eventType != "pull_request" {
+				return c.String(http.StatusOK, "OK")
+			}
+
+			prInfo, err = getGiteaPullRequestInfo(ctx, vcsProvider, vcsConnector, body)
+			if err != nil {
+				return c.String(http.StatusOK, fmt.Sprintf("failed to get pr info from pull request, error %v", err))
+			}
 		default:
 			return nil
 		}
 		if len(prInfo.changes) == 0 {
-			return c.String(http.StatusOK, fmt.Sprintf("no relevant file change directly under the base directory %q for pull request %q", vcsConnector.Payload.BaseDirectory, prInfo.url))
+			return c.String(http.StatusOK, fmt.Sprintf("no relevant file change under the base directory %q for pull request %q", vcsConnector.Payload.BaseDirectory, prInfo.url))
 		}
-		issue, err := s.createIssueFromPRInfo(ctx, project, vcsProvider, vcsConnector, prInfo)
+		planUID, err := s.reconcilePullRequest(ctx, project, vcsProvider, vcsConnector, prInfo)
 		if err != nil {
-			return c.String(http.StatusOK, fmt.Sprintf("failed to create issue from pull request %s, error %v", prInfo.url, err))
-		}
-		comment := getPullRequestComment(setting.ExternalUrl, issue.Name)
-		pullRequestID := getPullRequestID(prInfo.url)
-		if err := vcs.Get(
-			vcsProvider.Type,
-			vcs.ProviderConfig{InstanceURL: vcsProvider.InstanceURL, AuthToken: vcsProvider.AccessToken},
-		).CreatePullRequestComment(ctx, vcsConnector.Payload.ExternalId, pullRequestID, comment); err != nil {
-			return c.String(http.StatusOK, fmt.Sprintf("failed to create pull request comment, error %v", err))
+			return c.String(http.StatusOK, fmt.Sprintf("failed to reconcile pull request %s, error %v", prInfo.url, err))
 		}
+		// Sync the summary comment of the pull request.
+		s.stateCfg.PullRequestSyncs.Store(planUID, true)
 		return nil
 	})
 }
@@ -180,20 +188,118 @@ func validateGitHubWebhookSignature256(signature, key string, body []byte) (bool
 	return subtle.ConstantTimeCompare([]byte(signature), []byte(got)) == 1, nil
 }
 
-func (s *Service) createIssueFromPRInfo(ctx context.Context, project *store.ProjectMessage, vcsProvider *store.VCSProviderMessage, vcsConnector *store.VCSConnectorMessage, prInfo *pullRequestInfo) (*v1pb.Issue, error) {
-	creatorID := api.SystemBotID
-	creatorName := common.FormatUserEmail(api.SystemBotEmail)
-	user, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: &prInfo.email})
+// validateGiteaWebhookSignature returns true if the signature matches the
+// HMAC hex digested SHA256 hash of the body using the given key.
+//
+// Unlike GitHub, Gitea and Forgejo send the hex digest without the "sha256=" prefix.
+func validateGiteaWebhookSignature(signature, key string, body []byte) (bool, error) {
+	if signature == "" {
+		return false, nil
+	}
+	return validateGitHubWebhookSignature256(signature, key, body)
+}
+
+// getHeaderWithFallback returns the value of the first non-empty header in keys.
+func getHeaderWithFallback(header http.Header, keys ...string) string {
+	for _, key := range keys {
+		if v := header.Get(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// reconcilePullRequest keeps the plan and issue linked to the pull request in sync with the changes in the pull request,
+// and creates the rollout once the pull request is merged. It returns the UID of the linked plan.
+func (s *Service) reconcilePullRequest(ctx context.Context, project *store.ProjectMessage, vcsProvider *store.VCSProviderMessage, vcsConnector *store.VCSConnectorMessage, prInfo *pullRequestInfo) (int64, error) {
+	plans, err := s.store.ListPlans(ctx, &store.FindPlanMessage{ProjectID: &project.ResourceID, PullRequestURL: &prInfo.url})
 	if err != nil {
-		slog.Error("failed to find user by email", slog.String("email", prInfo.email), log.BBError(err))
+		return 0, errors.Wrapf(err, "failed to list plans for pull request")
+	}
+	if len(plans) == 0 {
+		issue, err := s.createIssueFromPRInfo(ctx, project, vcsProvider, vcsConnector, prInfo)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to create issue")
+		}
+		planUID, err := common.GetPlanID(issue.Plan)
+		if err != nil {
+			return 0, err
+		}
+		if err := s.updatePlanLastCommit(ctx, planUID, prInfo.commit); err != nil {
+			return 0, err
+		}
+		return planUID, nil
+	}
+
+	plan := plans[len(plans)-1]
+	if plan.PipelineUID != nil {
+		return 0, errors.Errorf("plan %d of the pull request has been rolled out", plan.UID)
+	}
+	creatorID, _, user := s.getCreator(ctx, prInfo.email)
+	if err := s.updatePlanFromPRInfo(ctx, project, vcsConnector, plan, prInfo, creatorID); err != nil {
+		return 0, errors.Wrapf(err, "failed to update plan %d", plan.UID)
+	}
+	if err := s.updatePlanLastCommit(ctx, plan.UID, prInfo.commit); err != nil {
+		return 0, err
+	}
+	if prInfo.action == pullRequestActionMerge {
+		if err := s.createRollout(getCreatorContext(ctx, creatorID, user), project, fmt.Sprintf("%s%s/%s%d", common.ProjectNamePrefix, project.ResourceID, common.PlanPrefix, plan.UID)); err != nil {
+			return 0, err
+		}
+	}
+	return plan.UID, nil
+}
+
+// updatePlanLastCommit records the head commit of the pull request in the plan, whose commit status reports the plan check results and the rollout progress.
+// The commit is updated on every push even if the files are unchanged, since the status is set per commit.
+func (s *Service) updatePlanLastCommit(ctx context.Context, planUID int64, commit string) error {
+	if commit == "" {
+		return nil
+	}
+	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planUID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get plan %d", planUID)
+	}
+	if plan == nil {
+		return errors.Errorf("plan %d not found", planUID)
+	}
+	vcsSource := plan.Config.GetVcsSource()
+	if vcsSource == nil || vcsSource.LastCommit == commit {
+		return nil
+	}
+	vcsSource.LastCommit = commit
+	if err := s.store.UpdatePlan(ctx, &store.UpdatePlanMessage{
+		UID:       plan.UID,
+		Config:    plan.Config,
+		UpdaterID: api.SystemBotID,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to update the last commit of plan %d", plan.UID)
+	}
+	return nil
+}
+
+// getCreator returns the Bytebase user of the pull request author, or the system bot if the author is not a Bytebase user.
+func (s *Service) getCreator(ctx context.Context, email string) (int, string, *store.UserMessage) {
+	user, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: &email})
+	if err != nil {
+		slog.Error("failed to find user by email", slog.String("email", email), log.BBError(err))
 	}
 	if user != nil {
-		creatorID = user.ID
-		creatorName = common.FormatUserEmail(user.Email)
+		return user.ID, common.FormatUserEmail(user.Email), user
 	}
+	return api.SystemBotID, common.FormatUserEmail(api.SystemBotEmail), nil
+}
+
+func getCreatorContext(ctx context.Context, creatorID int, user *store.UserMessage) context.Context {
+	childCtx := context.WithValue(ctx, common.PrincipalIDContextKey, creatorID)
+	childCtx = context.WithValue(childCtx, common.UserContextKey, user)
+	childCtx = context.WithValue(childCtx, common.LoopbackContextKey, true)
+	return childCtx
+}
 
+func (s *Service) createSheets(ctx context.Context, project *store.ProjectMessage, creatorID int, changes []*fileChange) ([]int, error) {
 	var sheets []int
-	for _, change := range prInfo.changes {
+	for _, change := range changes {
 		sheet, err := s.store.CreateSheet(ctx, &store.SheetMessage{
 			CreatorID:  creatorID,
 			ProjectUID: project.UID,
@@ -205,15 +311,35 @@ func (s *Service) createIssueFromPRInfo(ctx context.Context, project *store.Proj
 		}
 		sheets = append(sheets, sheet.UID)
 	}
+	return sheets, nil
+}
+
+func (s *Service) createRollout(ctx context.Context, project *store.ProjectMessage, plan string) error {
+	if _, err := s.rolloutService.CreateRollout(ctx, &v1pb.CreateRolloutRequest{
+		Parent: fmt.Sprintf("projects/%s", project.ResourceID),
+		Rollout: &v1pb.Rollout{
+			Plan: plan,
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to create rollout")
+	}
+	return nil
+}
+
+func (s *Service) createIssueFromPRInfo(ctx context.Context, project *store.ProjectMessage, vcsProvider *store.VCSProviderMessage, vcsConnector *store.VCSConnectorMessage, prInfo *pullRequestInfo) (*v1pb.Issue, error) {
+	creatorID, creatorName, user := s.getCreator(ctx, prInfo.email)
+
+	sheets, err := s.createSheets(ctx, project, creatorID, prInfo.changes)
+	if err != nil {
+		return nil, err
+	}
 
 	steps, err := s.getChangeSteps(ctx, project, vcsConnector, prInfo.changes, sheets)
 	if err != nil {
 		return nil, err
 	}
 
-	childCtx := context.WithValue(ctx, common.PrincipalIDContextKey, creatorID)
-	childCtx = context.WithValue(childCtx, common.UserContextKey, user)
-	childCtx = context.WithValue(childCtx, common.LoopbackContextKey, true)
+	childCtx := getCreatorContext(ctx, creatorID, user)
 	plan, err := s.rolloutService.CreatePlan(childCtx, &v1pb.CreatePlanRequest{
 		Parent: fmt.Sprintf("projects/%s", project.ResourceID),
 		Plan: &v1pb.Plan{
@@ -242,13 +368,11 @@ func (s *Service) createIssueFromPRInfo(ctx context.Context, project *store.Proj
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create issue")
 	}
-	if _, err := s.rolloutService.CreateRollout(childCtx, &v1pb.CreateRolloutRequest{
-		Parent: fmt.Sprintf("projects/%s", project.ResourceID),
-		Rollout: &v1pb.Rollout{
-			Plan: plan.Name,
-		},
-	}); err != nil {
-		return nil, errors.Wrapf(err, "failed to create rollout")
+	// The pull request is rolled out once merged.
+	if prInfo.action == pullRequestActionMerge {
+		if err := s.createRollout(childCtx, project, plan.Name); err != nil {
+			return nil, err
+		}
 	}
 
 	issueUID, err := strconv.Atoi(issue.Uid)
@@ -295,6 +419,66 @@ func (s *Service) createIssueFromPRInfo(ctx context.Context, project *store.Proj
 	return issue, nil
 }
 
+// updatePlanFromPRInfo replaces the sheets of the plan with the latest changes of the pull request,
+// and reruns the plan checks. It does nothing if the files are unchanged.
+func (s *Service) updatePlanFromPRInfo(ctx context.Context, project *store.ProjectMessage, vcsConnector *store.VCSConnectorMessage, plan *store.PlanMessage, prInfo *pullRequestInfo, creatorID int) error {
+	changed, err := s.isPlanChanged(ctx, plan, prInfo.changes)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	sheets, err := s.createSheets(ctx, project, creatorID, prInfo.changes)
+	if err != nil {
+		return err
+	}
+	steps, err := s.getChangeSteps(ctx, project, vcsConnector, prInfo.changes, sheets)
+	if err != nil {
+		return err
+	}
+	return s.rolloutService.ReplacePlanSteps(ctx, plan, steps, creatorID)
+}
+
+// isPlanChanged returns true if the files in the plan differ from the changes.
+func (s *Service) isPlanChanged(ctx context.Context, plan *store.PlanMessage, changes []*fileChange) (bool, error) {
+	planFiles := make(map[string]string)
+	seen := make(map[int]bool)
+	for _, step := range plan.Config.GetSteps() {
+		for _, spec := range step.GetSpecs() {
+			config := spec.GetChangeDatabaseConfig()
+			if config == nil {
+				continue
+			}
+			_, sheetUID, err := common.GetProjectResourceIDSheetUID(config.Sheet)
+			if err != nil {
+				return false, err
+			}
+			if seen[sheetUID] {
+				continue
+			}
+			seen[sheetUID] = true
+			sheet, err := s.store.GetSheet(ctx, &store.FindSheetMessage{UID: &sheetUID, LoadFull: true})
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to get sheet %d", sheetUID)
+			}
+			if sheet == nil {
+				return false, errors.Errorf("sheet %d not found", sheetUID)
+			}
+			planFiles[sheet.Title] = sheet.Statement
+		}
+	}
+	if len(planFiles) != len(changes) {
+		return true, nil
+	}
+	for _, change := range changes {
+		if content, ok := planFiles[change.path]; !ok || content != change.content {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (s *Service) getChangeSteps(
 	ctx context.Context,
 	project *store.ProjectMessage,
@@ -327,14 +511,18 @@ func (s *Service) getChangeSteps(
 		return nil, err
 	}
 
+	// Each change only applies to the databases addressed by its file path, and the databases are grouped into steps by environment.
 	var steps []*v1pb.Plan_Step
-	for i, database := range databases {
-		if i == 0 || databases[i].EffectiveEnvironmentID != databases[i-1].EffectiveEnvironmentID {
-			steps = append(steps, &v1pb.Plan_Step{})
-		}
-		step := steps[len(steps)-1]
+	var lastEnvironmentID string
+	matched := make([]bool, len(changes))
+	for _, database := range databases {
+		var specs []*v1pb.Plan_Spec
 		for i, change := range changes {
-			step.Specs = append(step.Specs, &v1pb.Plan_Spec{
+			if !change.matchDatabase(database) {
+				continue
+			}
+			matched[i] = true
+			specs = append(specs, &v1pb.Plan_Spec{
 				Id: uuid.NewString(),
 				Config: &v1pb.Plan_Spec_ChangeDatabaseConfig{
 					ChangeDatabaseConfig: &v1pb.Plan_ChangeDatabaseConfig{
@@ -346,6 +534,20 @@ func (s *Service) getChangeSteps(
 				},
 			})
 		}
+		if len(specs) == 0 {
+			continue
+		}
+		if len(steps) == 0 || database.EffectiveEnvironmentID != lastEnvironmentID {
+			steps = append(steps, &v1pb.Plan_Step{})
+			lastEnvironmentID = database.EffectiveEnvironmentID
+		}
+		step := steps[len(steps)-1]
+		step.Specs = append(step.Specs, specs...)
+	}
+	for i, change := range changes {
+		if !matched[i] {
+			return nil, errors.Errorf("file %q does not match any database in project %q", change.path, project.ResourceID)
+		}
 	}
 
 	return steps, nil
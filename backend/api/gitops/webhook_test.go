@@ -1,9 +1,13 @@
 package gitops
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateGitHubWebhookSignature256(t *testing.T) {
@@ -40,3 +44,30 @@ func TestValidateGitHubWebhookSignature256(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestValidateGiteaWebhookSignature(t *testing.T) {
+	const payload = `{"action":"closed","number":1,"pull_request":{"merged":true}}`
+	const key = "bZovosSKsJ8QKCG9"
+	m := hmac.New(sha256.New, []byte(key))
+	_, err := m.Write([]byte(payload))
+	require.NoError(t, err)
+	signature := hex.EncodeToString(m.Sum(nil))
+
+	tests := []struct {
+		name      string
+		signature string
+		key       string
+		want      bool
+	}{
+		{name: "success", signature: signature, key: key, want: true},
+		{name: "wrong key", signature: signature, key: "abadkey", want: false},
+		{name: "empty signature", signature: "", key: key, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := validateGiteaWebhookSignature(test.signature, test.key, []byte(payload))
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+)
+
+// CodeAction is the code action to fix the diagnostics, which is not defined in go-lsp.
+type CodeAction struct {
+	Title       string             `json:"title"`
+	Kind        lsp.CodeActionKind `json:"kind,omitempty"`
+	Diagnostics []lsp.Diagnostic   `json:"diagnostics,omitempty"`
+	IsPreferred bool               `json:"isPreferred,omitempty"`
+	Edit        *lsp.WorkspaceEdit `json:"edit,omitempty"`
+}
+
+// handleTextDocumentCodeAction returns the quick fixes of the SQL review advices in the range.
+// The advices are the ones of the last published diagnostics, and no fix is returned if the document is changed after that.
+func (h *Handler) handleTextDocumentCodeAction(ctx context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request, params lsp.CodeActionParams) ([]CodeAction, error) {
+	if !IsURI(params.TextDocument.URI) {
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeInvalidParams,
+			Message: fmt.Sprintf("textDocument/codeAction not yet supported for out-of-workspace URI (%q)", params.TextDocument.URI),
+		}
+	}
+	content, err := h.readFile(ctx, params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	actions := []CodeAction{}
+	for _, advice := range h.getAdviceList(params.TextDocument.URI, string(content)) {
+		if advice.Status == advisor.Success || advice.Fix == nil {
+			continue
+		}
+		diagnostic := convertAdviceToDiagnostic(lines, advice)
+		if !isRangeOverlapped(diagnostic.Range, params.Range) {
+			continue
+		}
+		actions = append(actions, CodeAction{
+			Title:       advice.Fix.Title,
+			Kind:        lsp.CAKQuickFix,
+			Diagnostics: []lsp.Diagnostic{diagnostic},
+			IsPreferred: true,
+			Edit: &lsp.WorkspaceEdit{
+				Changes: map[string][]lsp.TextEdit{
+					string(params.TextDocument.URI): {convertFixToTextEdit(lines, advice.Fix)},
+				},
+			},
+		})
+	}
+	return actions, nil
+}
+
+// convertFixToTextEdit converts the fix to the text edit, the fix line is one-based.
+// The fix columns are in characters, which are converted to UTF-16 code units of LSP.
+func convertFixToTextEdit(lines []string, fix *advisor.Fix) lsp.TextEdit {
+	return lsp.TextEdit{
+		Range: lsp.Range{
+			Start: lsp.Position{Line: fix.StartLine - 1, Character: getUTF16Column(lines, fix.StartLine-1, fix.StartColumn)},
+			End:   lsp.Position{Line: fix.EndLine - 1, Character: getUTF16Column(lines, fix.EndLine-1, fix.EndColumn)},
+		},
+		NewText: fix.NewText,
+	}
+}
+
+// getUTF16Column returns the column in UTF-16 code units of the zero-based line.
+func getUTF16Column(lines []string, line, column int) int {
+	if line < 0 || line >= len(lines) {
+		return column
+	}
+	return utf16Length(lines[line], column)
+}
+
+func isRangeOverlapped(a, b lsp.Range) bool {
+	return !isPositionBefore(a.End, b.Start) && !isPositionBefore(b.End, a.Start)
+}
+
+func isPositionBefore(a, b lsp.Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
@@ -0,0 +1,92 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func (h *Handler) handleTextDocumentDefinition(ctx context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request, params lsp.TextDocumentPositionParams) ([]lsp.Location, error) {
+	if !IsURI(params.TextDocument.URI) {
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeInvalidParams,
+			Message: fmt.Sprintf("textDocument/definition not yet supported for out-of-workspace URI (%q)", params.TextDocument.URI),
+		}
+	}
+	nc, offset, err := h.newNavigationContext(ctx, params.TextDocument.URI, params.Position)
+	if err != nil {
+		return nil, err
+	}
+	if nc == nil {
+		return []lsp.Location{}, nil
+	}
+	object, err := nc.resolve(ctx, offset)
+	if err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return []lsp.Location{}, nil
+	}
+
+	location, definition := getDefinitionLocation(nc, params.TextDocument.URI, h.getInstanceID(), object)
+	if location == nil {
+		return []lsp.Location{}, nil
+	}
+	if definition != "" {
+		h.GetFS().set(location.URI, []byte(definition))
+	}
+	return []lsp.Location{*location}, nil
+}
+
+// getDefinitionLocation returns the location of the object's definition.
+// The CTEs are defined in the document, while the tables and views are not in the workspace,
+// so their DDL is returned as well for the editor to open in a virtual document.
+func getDefinitionLocation(nc *navigationContext, uri lsp.DocumentURI, instanceID string, object *resolvedObject) (*lsp.Location, string) {
+	if object.cte != nil {
+		content := []byte(nc.content)
+		return &lsp.Location{
+			URI: uri,
+			Range: lsp.Range{
+				Start: positionForOffset(content, object.cte.name.start),
+				End:   positionForOffset(content, object.cte.name.end),
+			},
+		}, ""
+	}
+
+	definition := object.getDefinition(nc.engine)
+	if definition == "" {
+		return nil, ""
+	}
+	line := 0
+	if object.column != nil {
+		line = findDefinitionLine(definition, object.column.Name)
+	}
+	return &lsp.Location{
+		URI: getDefinitionURI(instanceID, object),
+		Range: lsp.Range{
+			Start: lsp.Position{Line: line, Character: 0},
+			End:   lsp.Position{Line: line, Character: 0},
+		},
+	}, definition
+}
+
+// getDefinitionURI returns the URI of the virtual document for the object's DDL.
+func getDefinitionURI(instanceID string, object *resolvedObject) lsp.DocumentURI {
+	return lsp.DocumentURI(fmt.Sprintf("file:///.definitions/instances/%s/databases/%s/%s.sql", instanceID, object.database, object.getTableName()))
+}
+
+// findDefinitionLine returns the line of the column in the table DDL, or the first line if not found.
+func findDefinitionLine(definition, column string) int {
+	for i, line := range strings.Split(definition, "\n") {
+		line = strings.TrimSpace(line)
+		for _, quoted := range []string{column, fmt.Sprintf(`"%s"`, column), fmt.Sprintf("`%s`", column), fmt.Sprintf("[%s]", column)} {
+			if strings.HasPrefix(line, quoted+" ") {
+				return i
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,327 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// diagnosticsDebounceDelay is the delay to check the document after the last change.
+	diagnosticsDebounceDelay = 500 * time.Millisecond
+	// diagnosticsTimeout is the timeout to check the document.
+	diagnosticsTimeout = 30 * time.Second
+	// diagnosticsSource is the source of the diagnostics shown in the editor.
+	diagnosticsSource = "Bytebase"
+)
+
+// diagnosticsState is the pending diagnostics of a document.
+type diagnosticsState struct {
+	timer *time.Timer
+	// version increases on every change of the document, so that the stale diagnostics are discarded.
+	version int
+	// content and adviceList are the last checked content and its advices, used by the code actions.
+	content    string
+	adviceList []advisor.Advice
+}
+
+// scheduleDiagnostics schedules the diagnostics of the document after the debounce delay.
+// The pending diagnostics of the same document are canceled.
+func (h *Handler) scheduleDiagnostics(conn *jsonrpc2.Conn, uri lsp.DocumentURI) {
+	h.debounceDiagnostics(uri, func(version int) {
+		h.publishDiagnostics(conn, uri, version)
+	})
+}
+
+// debounceDiagnostics bumps the document version, and calls the check with the version after the debounce delay
+// unless the document changes again in the meantime.
+func (h *Handler) debounceDiagnostics(uri lsp.DocumentURI, check func(version int)) {
+	h.diagnosticsMu.Lock()
+	defer h.diagnosticsMu.Unlock()
+	if h.diagnostics == nil {
+		h.diagnostics = make(map[lsp.DocumentURI]*diagnosticsState)
+	}
+	state, ok := h.diagnostics[uri]
+	if !ok {
+		state = &diagnosticsState{}
+		h.diagnostics[uri] = state
+	}
+	if state.timer != nil {
+		state.timer.Stop()
+	}
+	state.version++
+	version := state.version
+	state.timer = time.AfterFunc(diagnosticsDebounceDelay, func() {
+		check(version)
+	})
+}
+
+func (h *Handler) stopDiagnostics() {
+	h.diagnosticsMu.Lock()
+	defer h.diagnosticsMu.Unlock()
+	for _, state := range h.diagnostics {
+		if state.timer != nil {
+			state.timer.Stop()
+		}
+	}
+	h.diagnostics = nil
+}
+
+func (h *Handler) publishDiagnostics(conn *jsonrpc2.Conn, uri lsp.DocumentURI, version int) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = errors.Errorf("panic: %v", r)
+			}
+			slog.Error("Panic in LSP diagnostics", log.BBError(err), log.BBStack("panic-stack"))
+		}
+	}()
+
+	if h.checkReady() != nil || h.GetFS() == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticsTimeout)
+	defer cancel()
+
+	content, err := h.readFile(ctx, uri)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Error("Failed to read file for diagnostics", log.BBError(err), slog.String("uri", string(uri)))
+			return
+		}
+		// The document is closed, clear the diagnostics in the editor.
+		h.diagnosticsMu.Lock()
+		state, ok := h.diagnostics[uri]
+		if !ok || state.version != version {
+			h.diagnosticsMu.Unlock()
+			return
+		}
+		delete(h.diagnostics, uri)
+		h.diagnosticsMu.Unlock()
+		h.notifyDiagnostics(ctx, conn, uri, []lsp.Diagnostic{})
+		return
+	}
+
+	adviceList, err := h.checkStatement(ctx, string(content))
+	if err != nil {
+		// Return errors will close the websocket connection, so we just log the error.
+		slog.Error("Failed to check statement for diagnostics", log.BBError(err))
+		return
+	}
+	if !h.saveDiagnostics(uri, version, string(content), adviceList) {
+		return
+	}
+	h.notifyDiagnostics(ctx, conn, uri, convertAdviceListToDiagnostics(string(content), adviceList))
+}
+
+// saveDiagnostics saves the advices of the content if there is no change of the document after the given version.
+func (h *Handler) saveDiagnostics(uri lsp.DocumentURI, version int, content string, adviceList []advisor.Advice) bool {
+	h.diagnosticsMu.Lock()
+	defer h.diagnosticsMu.Unlock()
+	state, ok := h.diagnostics[uri]
+	if !ok || state.version != version {
+		return false
+	}
+	state.content = content
+	state.adviceList = adviceList
+	return true
+}
+
+// getAdviceList returns the advices of the document if they are checked against the content.
+func (h *Handler) getAdviceList(uri lsp.DocumentURI, content string) []advisor.Advice {
+	h.diagnosticsMu.Lock()
+	defer h.diagnosticsMu.Unlock()
+	state, ok := h.diagnostics[uri]
+	if !ok || state.content != content {
+		return nil
+	}
+	return state.adviceList
+}
+
+func (*Handler) notifyDiagnostics(ctx context.Context, conn *jsonrpc2.Conn, uri lsp.DocumentURI, diagnostics []lsp.Diagnostic) {
+	if err := conn.Notify(ctx, string(LSPMethodPublishDiagnostics), lsp.PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnostics,
+	}); err != nil {
+		slog.Error("Failed to publish diagnostics", log.BBError(err), slog.String("uri", string(uri)))
+	}
+}
+
+// checkStatement checks the statement against the syntax and the SQL review policy of the database environment.
+// The rules requiring the database connection, such as the dry run, are skipped.
+func (h *Handler) checkStatement(ctx context.Context, statement string) ([]advisor.Advice, error) {
+	if strings.TrimSpace(statement) == "" || len(statement) > common.MaxSheetCheckSize {
+		return nil, nil
+	}
+	instanceID := h.getInstanceID()
+	if instanceID == "" {
+		return nil, nil
+	}
+	instance, err := h.store.GetInstanceV2(ctx, &store.FindInstanceMessage{
+		ResourceID: &instanceID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance")
+	}
+	if instance == nil || !isDiagnosticsSupported(instance.Engine) {
+		return nil, nil
+	}
+
+	checkContext := advisor.SQLReviewCheckContext{
+		DbType:  instance.Engine,
+		Context: ctx,
+	}
+	var ruleList []*storepb.SQLReviewRule
+	database, err := h.getDatabase(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if database != nil {
+		ruleList, err = h.getSQLReviewRuleList(ctx, database)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(ruleList) > 0 {
+		dbSchema, err := h.store.GetDBSchema(ctx, database.UID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get database schema")
+		}
+		if dbSchema == nil {
+			// The database schema is not synced yet, check the syntax only.
+			ruleList = nil
+		} else {
+			dbMetadata := dbSchema.GetMetadata()
+			c, err := h.store.NewCatalog(ctx, database.UID, instance.Engine, store.IgnoreDatabaseAndTableCaseSensitive(instance), nil /* overrideDatabaseMetadata */, advisor.SyntaxModeNormal)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to create catalog")
+			}
+			checkContext.Charset = dbMetadata.CharacterSet
+			checkContext.Collation = dbMetadata.Collation
+			checkContext.DBSchema = dbMetadata
+			checkContext.Catalog = c
+			checkContext.CurrentDatabase = database.DatabaseName
+		}
+	}
+	adviceList, err := advisor.SQLReviewCheck(statement, ruleList, checkContext)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check SQL review policy")
+	}
+	return adviceList, nil
+}
+
+func (h *Handler) getDatabase(ctx context.Context, instanceID string) (*store.DatabaseMessage, error) {
+	databaseName := h.getDefaultDatabase()
+	if databaseName == "" {
+		return nil, nil
+	}
+	database, err := h.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
+		InstanceID:   &instanceID,
+		DatabaseName: &databaseName,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database")
+	}
+	return database, nil
+}
+
+func (h *Handler) getSQLReviewRuleList(ctx context.Context, database *store.DatabaseMessage) ([]*storepb.SQLReviewRule, error) {
+	environment, err := h.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{
+		ResourceID: &database.EffectiveEnvironmentID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get environment")
+	}
+	if environment == nil {
+		return nil, nil
+	}
+	policy, err := h.store.GetSQLReviewPolicy(ctx, environment.UID)
+	if err != nil {
+		if common.ErrorCode(err) == common.NotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get SQL review policy")
+	}
+	return policy.RuleList, nil
+}
+
+func isDiagnosticsSupported(engine storepb.Engine) bool {
+	switch engine {
+	case storepb.Engine_POSTGRES, storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_OCEANBASE, storepb.Engine_SNOWFLAKE, storepb.Engine_MSSQL:
+		return true
+	default:
+		return false
+	}
+}
+
+// convertAdviceListToDiagnostics converts the advice list to the diagnostics.
+func convertAdviceListToDiagnostics(content string, adviceList []advisor.Advice) []lsp.Diagnostic {
+	lines := strings.Split(content, "\n")
+	diagnostics := []lsp.Diagnostic{}
+	for _, advice := range adviceList {
+		if advice.Status == advisor.Success {
+			continue
+		}
+		diagnostics = append(diagnostics, convertAdviceToDiagnostic(lines, advice))
+	}
+	return diagnostics
+}
+
+// convertAdviceToDiagnostic converts the advice to the diagnostic.
+// The advice line is one-based, and the diagnostic covers the rest of the line from the advice column.
+// The advice column is in characters, which is converted to UTF-16 code units of LSP.
+func convertAdviceToDiagnostic(lines []string, advice advisor.Advice) lsp.Diagnostic {
+	line := advice.Line - 1
+	if line < 0 {
+		line = 0
+	}
+	if line >= len(lines) {
+		line = len(lines) - 1
+	}
+	text := strings.TrimSuffix(lines[line], "\r")
+	lineLength := utf16Length(text, utf8.RuneCountInString(text))
+	start := utf16Length(text, advice.Column)
+	if advice.Column < 0 || start >= lineLength {
+		start = 0
+	}
+	message := advice.Content
+	if advice.Title != "" && advice.Title != message {
+		message = fmt.Sprintf("%s: %s", advice.Title, advice.Content)
+	}
+	return lsp.Diagnostic{
+		Range: lsp.Range{
+			Start: lsp.Position{Line: line, Character: start},
+			End:   lsp.Position{Line: line, Character: lineLength},
+		},
+		Severity: convertAdviceStatus(advice.Status),
+		Code:     fmt.Sprintf("%d", advice.Code),
+		Source:   diagnosticsSource,
+		Message:  message,
+	}
+}
+
+func convertAdviceStatus(status advisor.Status) lsp.DiagnosticSeverity {
+	switch status {
+	case advisor.Error:
+		return lsp.Error
+	case advisor.Warn:
+		return lsp.Warning
+	default:
+		return lsp.Information
+	}
+}
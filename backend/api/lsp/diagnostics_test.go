@@ -0,0 +1,152 @@
+package lsp
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+)
+
+func TestDebounceDiagnostics(t *testing.T) {
+	a := require.New(t)
+	const uri = lsp.DocumentURI("file:///untitled.sql")
+	h := &Handler{}
+
+	var mu sync.Mutex
+	var versions []int
+	check := func(version int) {
+		mu.Lock()
+		defer mu.Unlock()
+		versions = append(versions, version)
+	}
+	getVersions := func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int{}, versions...)
+	}
+
+	// Only the last of the changes within the debounce delay is checked.
+	for i := 0; i < 3; i++ {
+		h.debounceDiagnostics(uri, check)
+	}
+	time.Sleep(2 * diagnosticsDebounceDelay)
+	a.Equal([]int{3}, getVersions())
+
+	h.debounceDiagnostics(uri, check)
+	time.Sleep(2 * diagnosticsDebounceDelay)
+	a.Equal([]int{3, 4}, getVersions())
+
+	// The pending checks are canceled on shutdown.
+	h.debounceDiagnostics(uri, check)
+	h.stopDiagnostics()
+	time.Sleep(2 * diagnosticsDebounceDelay)
+	a.Equal([]int{3, 4}, getVersions())
+}
+
+func TestSaveDiagnostics(t *testing.T) {
+	a := require.New(t)
+	const uri = lsp.DocumentURI("file:///untitled.sql")
+	h := &Handler{}
+	defer h.stopDiagnostics()
+	check := func(int) {}
+	adviceList := []advisor.Advice{{Status: advisor.Warn, Code: advisor.StatementNoWhere, Title: "statement.where.require", Content: "WHERE clause is required", Line: 1}}
+
+	// The document isn't scheduled for the diagnostics.
+	a.False(h.saveDiagnostics(uri, 1, "DELETE FROM t", adviceList))
+
+	h.debounceDiagnostics(uri, check)
+	h.debounceDiagnostics(uri, check)
+	// The diagnostics of the stale version are dropped.
+	a.False(h.saveDiagnostics(uri, 1, "DELETE FROM", adviceList))
+	a.Nil(h.getAdviceList(uri, "DELETE FROM"))
+
+	a.True(h.saveDiagnostics(uri, 2, "DELETE FROM t", adviceList))
+	a.Equal(adviceList, h.getAdviceList(uri, "DELETE FROM t"))
+	// The advices are not for the changed content.
+	a.Nil(h.getAdviceList(uri, "DELETE FROM t WHERE id = 1"))
+}
+
+func TestConvertAdviceToDiagnostic(t *testing.T) {
+	tests := []struct {
+		content string
+		advice  advisor.Advice
+		want    lsp.Diagnostic
+	}{
+		{
+			content: "SELECT 1;\nDELETE FROM t;",
+			advice:  advisor.Advice{Status: advisor.Warn, Code: advisor.StatementNoWhere, Title: "statement.where.require", Content: "WHERE clause is required", Line: 2},
+			want: lsp.Diagnostic{
+				Range: lsp.Range{
+					Start: lsp.Position{Line: 1, Character: 0},
+					End:   lsp.Position{Line: 1, Character: 14},
+				},
+				Severity: lsp.Warning,
+				Code:     "202",
+				Source:   diagnosticsSource,
+				Message:  "statement.where.require: WHERE clause is required",
+			},
+		},
+		{
+			// The columns are in UTF-16 code units, the emoji is encoded in two code units.
+			content: "SELECT '😀', 'é' FROM\r\nSELECT 1",
+			advice:  advisor.Advice{Status: advisor.Error, Code: advisor.StatementSyntaxError, Title: "Syntax error", Content: "Syntax error", Line: 1, Column: 17},
+			want: lsp.Diagnostic{
+				Range: lsp.Range{
+					Start: lsp.Position{Line: 0, Character: 18},
+					End:   lsp.Position{Line: 0, Character: 21},
+				},
+				Severity: lsp.Error,
+				Code:     "201",
+				Source:   diagnosticsSource,
+				Message:  "Syntax error",
+			},
+		},
+		{
+			// The line and column out of the content are clamped.
+			content: "SELECT '😀'",
+			advice:  advisor.Advice{Status: advisor.Warn, Code: advisor.StatementNoWhere, Content: "WHERE clause is required", Line: 3, Column: 20},
+			want: lsp.Diagnostic{
+				Range: lsp.Range{
+					Start: lsp.Position{Line: 0, Character: 0},
+					End:   lsp.Position{Line: 0, Character: 11},
+				},
+				Severity: lsp.Warning,
+				Code:     "202",
+				Source:   diagnosticsSource,
+				Message:  "WHERE clause is required",
+			},
+		},
+	}
+
+	a := require.New(t)
+	for _, tc := range tests {
+		got := convertAdviceToDiagnostic(strings.Split(tc.content, "\n"), tc.advice)
+		a.Equal(tc.want, got, tc.content)
+	}
+}
+
+func TestPositionForOffset(t *testing.T) {
+	a := require.New(t)
+	content := []byte("SELECT '😀é',\n  id FROM t")
+	for _, tc := range []struct {
+		offset   int
+		position lsp.Position
+	}{
+		{offset: 0, position: lsp.Position{Line: 0, Character: 0}},
+		// After the emoji of 4 bytes and 2 UTF-16 code units.
+		{offset: 12, position: lsp.Position{Line: 0, Character: 10}},
+		// After "é" of 2 bytes and 1 UTF-16 code unit.
+		{offset: 14, position: lsp.Position{Line: 0, Character: 11}},
+		{offset: 19, position: lsp.Position{Line: 1, Character: 2}},
+	} {
+		a.Equal(tc.position, positionForOffset(content, tc.offset), tc.offset)
+		offset, valid, _ := offsetForPosition(content, tc.position)
+		a.True(valid, tc.offset)
+		a.Equal(tc.offset, offset)
+	}
+}
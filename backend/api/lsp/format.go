@@ -0,0 +1,142 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// FormattingOptions is the formatting options with the keyword case.
+// The LSP formatting options allow the additional properties, e.g. {"tabSize": 2, "insertSpaces": true, "keywordCase": "lower"}.
+type FormattingOptions struct {
+	lsp.FormattingOptions
+	// KeywordCase is one of "upper", "lower" and "preserve", defaults to "upper".
+	KeywordCase string `json:"keywordCase,omitempty"`
+}
+
+// DocumentFormattingParams is the params of textDocument/formatting.
+type DocumentFormattingParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+	Options      FormattingOptions          `json:"options"`
+}
+
+// DocumentRangeFormattingParams is the params of textDocument/rangeFormatting.
+type DocumentRangeFormattingParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+	Range        lsp.Range                  `json:"range"`
+	Options      FormattingOptions          `json:"options"`
+}
+
+func (h *Handler) handleTextDocumentFormatting(ctx context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request, params DocumentFormattingParams) ([]lsp.TextEdit, error) {
+	if !IsURI(params.TextDocument.URI) {
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeInvalidParams,
+			Message: fmt.Sprintf("textDocument/formatting not yet supported for out-of-workspace URI (%q)", params.TextDocument.URI),
+		}
+	}
+	content, err := h.readFile(ctx, params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	return h.formatRange(ctx, content, 0, len(content), params.Options), nil
+}
+
+func (h *Handler) handleTextDocumentRangeFormatting(ctx context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request, params DocumentRangeFormattingParams) ([]lsp.TextEdit, error) {
+	if !IsURI(params.TextDocument.URI) {
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeInvalidParams,
+			Message: fmt.Sprintf("textDocument/rangeFormatting not yet supported for out-of-workspace URI (%q)", params.TextDocument.URI),
+		}
+	}
+	content, err := h.readFile(ctx, params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	start, valid, why := offsetForPosition(content, params.Range.Start)
+	if !valid {
+		return nil, errors.Errorf("invalid position %d:%d (%s)", params.Range.Start.Line, params.Range.Start.Character, why)
+	}
+	end, valid, why := offsetForPosition(content, params.Range.End)
+	if !valid {
+		return nil, errors.Errorf("invalid position %d:%d (%s)", params.Range.End.Line, params.Range.End.Character, why)
+	}
+	start, end = expandToStatements(string(content), h.getEngineType(ctx), start, end)
+	return h.formatRange(ctx, content, start, end, params.Options), nil
+}
+
+// formatRange formats the statements in content[start:end], and returns the edit replacing them.
+// Return errors will close the websocket connection, so we log the errors and return no edits, e.g. for the statements with syntax errors.
+func (h *Handler) formatRange(ctx context.Context, content []byte, start, end int, options FormattingOptions) []lsp.TextEdit {
+	statement := string(content[start:end])
+	if strings.TrimSpace(statement) == "" {
+		return []lsp.TextEdit{}
+	}
+	engine := h.getEngineType(ctx)
+	formatted, err := base.Format(engine, statement, convertFormattingOptions(options))
+	if err != nil {
+		slog.Debug("Failed to format statement", log.BBError(err), slog.String("engine", engine.String()))
+		return []lsp.TextEdit{}
+	}
+	if end < len(content) {
+		// Keep the text after the range as it is.
+		formatted = strings.TrimSuffix(formatted, "\n")
+	}
+	if formatted == statement {
+		return []lsp.TextEdit{}
+	}
+	return []lsp.TextEdit{
+		{
+			Range: lsp.Range{
+				Start: positionForOffset(content, start),
+				End:   positionForOffset(content, end),
+			},
+			NewText: formatted,
+		},
+	}
+}
+
+func convertFormattingOptions(options FormattingOptions) base.FormatOptions {
+	result := base.FormatOptions{
+		KeywordCase: base.KeywordCase(strings.ToLower(options.KeywordCase)),
+	}
+	switch {
+	case !options.InsertSpaces:
+		result.Indent = "\t"
+	case options.TabSize > 0:
+		result.Indent = strings.Repeat(" ", options.TabSize)
+	}
+	return result
+}
+
+// expandToStatements expands the range to cover the whole statements in it.
+// The range starts at the first statement token, and ends after the semicolon of the last statement if any.
+func expandToStatements(content string, engine storepb.Engine, start, end int) (int, int) {
+	tokens := scanTokens(content, engine)
+	newStart, newEnd := -1, len(content)
+	for i, token := range tokens {
+		if newStart < 0 && token.end > start {
+			// The first token of the statement containing the range start.
+			newStart = token.start
+			for j := i - 1; j >= 0 && !tokens[j].isPunctuation(";"); j-- {
+				newStart = tokens[j].start
+			}
+		}
+		if newStart >= 0 && token.isPunctuation(";") && token.end >= end {
+			newEnd = token.end
+			break
+		}
+	}
+	if newStart < 0 {
+		return start, start
+	}
+	return newStart, newEnd
+}
@@ -20,14 +20,22 @@ import (
 type Method string
 
 const (
-	LSPMethodInitialize     Method = "initialize"
-	LSPMethodInitialized    Method = "initialized"
-	LSPMethodShutdown       Method = "shutdown"
-	LSPMethodExit           Method = "exit"
-	LSPMethodCancelRequest  Method = "$/cancelRequest"
-	LSPMethodSetTrace       Method = "$/setTrace"
-	LSPMethodExecuteCommand Method = "workspace/executeCommand"
-	LSPMethodCompletion     Method = "textDocument/completion"
+	LSPMethodInitialize      Method = "initialize"
+	LSPMethodInitialized     Method = "initialized"
+	LSPMethodShutdown        Method = "shutdown"
+	LSPMethodExit            Method = "exit"
+	LSPMethodCancelRequest   Method = "$/cancelRequest"
+	LSPMethodSetTrace        Method = "$/setTrace"
+	LSPMethodExecuteCommand  Method = "workspace/executeCommand"
+	LSPMethodCompletion      Method = "textDocument/completion"
+	LSPMethodHover           Method = "textDocument/hover"
+	LSPMethodDefinition      Method = "textDocument/definition"
+	LSPMethodSignatureHelp   Method = "textDocument/signatureHelp"
+	LSPMethodFormatting      Method = "textDocument/formatting"
+	LSPMethodRangeFormatting Method = "textDocument/rangeFormatting"
+	LSPMethodCodeAction      Method = "textDocument/codeAction"
+
+	LSPMethodPublishDiagnostics Method = "textDocument/publishDiagnostics"
 
 	LSPMethodTextDocumentDidOpen   Method = "textDocument/didOpen"
 	LSPMethodTextDocumentDidChange Method = "textDocument/didChange"
@@ -60,6 +68,9 @@ type Handler struct {
 	metadata *SetMetadataCommandArguments
 	store    *store.Store
 
+	diagnosticsMu sync.Mutex
+	diagnostics   map[lsp.DocumentURI]*diagnosticsState
+
 	shutDown bool
 }
 
@@ -72,6 +83,7 @@ func (h *Handler) ShutDown() {
 	}
 	h.shutDown = true
 	h.fs = nil
+	h.stopDiagnostics()
 }
 
 func (h *Handler) setMetadata(arg SetMetadataCommandArguments) {
@@ -195,6 +207,14 @@ func (h *Handler) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2
 				CompletionProvider: &lsp.CompletionOptions{
 					TriggerCharacters: []string{".", " ", "\n"},
 				},
+				HoverProvider:      true,
+				DefinitionProvider: true,
+				SignatureHelpProvider: &lsp.SignatureHelpOptions{
+					TriggerCharacters: []string{"(", ","},
+				},
+				CodeActionProvider:              true,
+				DocumentFormattingProvider:      true,
+				DocumentRangeFormattingProvider: true,
 				ExecuteCommandProvider: &lsp.ExecuteCommandOptions{
 					Commands: []string{string(CommandNameSetMetadata)},
 				},
@@ -248,9 +268,66 @@ func (h *Handler) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2
 			return nil, err
 		}
 		return h.handleTextDocumentCompletion(ctx, conn, req, params)
+	case LSPMethodHover:
+		if req.Params == nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+		}
+		var params lsp.TextDocumentPositionParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return h.handleTextDocumentHover(ctx, conn, req, params)
+	case LSPMethodDefinition:
+		if req.Params == nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+		}
+		var params lsp.TextDocumentPositionParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return h.handleTextDocumentDefinition(ctx, conn, req, params)
+	case LSPMethodSignatureHelp:
+		if req.Params == nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+		}
+		var params lsp.TextDocumentPositionParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return h.handleTextDocumentSignatureHelp(ctx, conn, req, params)
+	case LSPMethodFormatting:
+		if req.Params == nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+		}
+		var params DocumentFormattingParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return h.handleTextDocumentFormatting(ctx, conn, req, params)
+	case LSPMethodRangeFormatting:
+		if req.Params == nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+		}
+		var params DocumentRangeFormattingParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return h.handleTextDocumentRangeFormatting(ctx, conn, req, params)
+	case LSPMethodCodeAction:
+		if req.Params == nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+		}
+		var params lsp.CodeActionParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return h.handleTextDocumentCodeAction(ctx, conn, req, params)
 	default:
 		if isFileSystemRequest(req.Method) {
-			_, _, err := h.handleFileSystemRequest(ctx, req)
+			uri, changed, err := h.handleFileSystemRequest(ctx, req)
+			if changed {
+				h.scheduleDiagnostics(conn, uri)
+			}
 			return nil, err
 		}
 		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", req.Method)}
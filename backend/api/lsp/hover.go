@@ -0,0 +1,109 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func (h *Handler) handleTextDocumentHover(ctx context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request, params lsp.TextDocumentPositionParams) (*lsp.Hover, error) {
+	if !IsURI(params.TextDocument.URI) {
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeInvalidParams,
+			Message: fmt.Sprintf("textDocument/hover not yet supported for out-of-workspace URI (%q)", params.TextDocument.URI),
+		}
+	}
+	nc, offset, err := h.newNavigationContext(ctx, params.TextDocument.URI, params.Position)
+	if err != nil {
+		return nil, err
+	}
+	if nc == nil {
+		return nil, nil
+	}
+	object, err := nc.resolve(ctx, offset)
+	if err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return nil, nil
+	}
+	content := []byte(nc.content)
+	start, end := positionForOffset(content, object.token.start), positionForOffset(content, object.token.end)
+	return &lsp.Hover{
+		Contents: []lsp.MarkedString{lsp.RawMarkedString(getHoverContent(nc, object))},
+		Range:    &lsp.Range{Start: start, End: end},
+	}, nil
+}
+
+// getHoverContent returns the markdown content to show on hover.
+func getHoverContent(nc *navigationContext, object *resolvedObject) string {
+	var buf strings.Builder
+	switch {
+	case object.cte != nil:
+		_, _ = fmt.Fprintf(&buf, "**CTE** `%s`\n", object.cte.name.value)
+		if body := object.cte.body; len(body) > 0 {
+			_, _ = fmt.Fprintf(&buf, "\n```sql\n%s AS (%s)\n```\n", object.cte.name.text, nc.content[body[0].start:body[len(body)-1].end])
+		}
+	case object.column != nil:
+		column := object.column
+		_, _ = fmt.Fprintf(&buf, "**Column** `%s`\n\n", object.getQualifiedName())
+		attributes := []string{fmt.Sprintf("`%s`", column.Type)}
+		if column.Nullable {
+			attributes = append(attributes, "NULL")
+		} else {
+			attributes = append(attributes, "NOT NULL")
+		}
+		if defaultValue := getColumnDefault(column); defaultValue != "" {
+			attributes = append(attributes, fmt.Sprintf("DEFAULT `%s`", defaultValue))
+		}
+		_, _ = buf.WriteString(strings.Join(attributes, " · "))
+		_, _ = buf.WriteString("\n")
+		if column.Classification != "" {
+			_, _ = fmt.Fprintf(&buf, "\nClassification: `%s`\n", column.Classification)
+		}
+		if comment := getComment(column.UserComment, column.Comment); comment != "" {
+			_, _ = fmt.Fprintf(&buf, "\n%s\n", comment)
+		}
+	case object.table != nil:
+		table := object.table.GetProto()
+		_, _ = fmt.Fprintf(&buf, "**Table** `%s`\n", object.getQualifiedName())
+		if table.GetClassification() != "" {
+			_, _ = fmt.Fprintf(&buf, "\nClassification: `%s`\n", table.GetClassification())
+		}
+		if comment := getComment(table.GetUserComment(), table.GetComment()); comment != "" {
+			_, _ = fmt.Fprintf(&buf, "\n%s\n", comment)
+		}
+		_, _ = buf.WriteString("\n")
+		for _, column := range object.table.GetColumns() {
+			_, _ = fmt.Fprintf(&buf, "- `%s` %s\n", column.Name, column.Type)
+		}
+	case object.view != nil:
+		_, _ = fmt.Fprintf(&buf, "**View** `%s`\n\n```sql\n%s\n```\n", object.getQualifiedName(), object.getDefinition(nc.engine))
+	}
+	return buf.String()
+}
+
+func getColumnDefault(column *storepb.ColumnMetadata) string {
+	switch {
+	case column.GetDefault() != nil:
+		return column.GetDefault().GetValue()
+	case column.GetDefaultExpression() != "":
+		return column.GetDefaultExpression()
+	case column.GetDefaultNull():
+		return "NULL"
+	default:
+		return ""
+	}
+}
+
+func getComment(userComment, comment string) string {
+	if userComment != "" {
+		return userComment
+	}
+	return comment
+}
@@ -0,0 +1,419 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-lsp"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	"github.com/bytebase/bytebase/backend/plugin/schema"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/store/model"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// resolvedObject is the object referenced by the identifier under the cursor.
+// It's either a CTE defined in the statement, or a table, view or column in the database.
+type resolvedObject struct {
+	// token is the identifier token under the cursor.
+	token *sqlToken
+
+	cte *cteDefinition
+
+	database string
+	schema   string
+	name     string
+	table    *model.TableMetadata
+	view     *model.ViewMetadata
+	column   *storepb.ColumnMetadata
+}
+
+// navigationContext is the context to resolve the identifiers in the document.
+type navigationContext struct {
+	engine              storepb.Engine
+	defaultDatabase     string
+	ignoreCaseSensitive bool
+	content             string
+	// tokens are the tokens of the statement containing the cursor.
+	tokens []*sqlToken
+	scope  *statementScope
+
+	getDatabaseMetadataFunc base.GetDatabaseMetadataFunc
+	listDatabaseNamesFunc   base.ListDatabaseNamesFunc
+}
+
+func (h *Handler) newNavigationContext(ctx context.Context, uri lsp.DocumentURI, position lsp.Position) (*navigationContext, int, error) {
+	content, err := h.readFile(ctx, uri)
+	if err != nil {
+		return nil, 0, err
+	}
+	offset, valid, why := offsetForPosition(content, position)
+	if !valid {
+		return nil, 0, errors.Errorf("invalid position %d:%d (%s)", position.Line, position.Character, why)
+	}
+	instanceID := h.getInstanceID()
+	if instanceID == "" {
+		return nil, 0, nil
+	}
+	instance, err := h.store.GetInstanceV2(ctx, &store.FindInstanceMessage{
+		ResourceID: &instanceID,
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get instance")
+	}
+	if instance == nil {
+		return nil, 0, nil
+	}
+	nc := &navigationContext{
+		engine:                  instance.Engine,
+		defaultDatabase:         h.getDefaultDatabase(),
+		ignoreCaseSensitive:     store.IgnoreDatabaseAndTableCaseSensitive(instance),
+		getDatabaseMetadataFunc: h.GetDatabaseMetadataFunc,
+		listDatabaseNamesFunc:   h.ListDatabaseNamesFunc,
+	}
+	nc.setContent(string(content), offset)
+	return nc, offset, nil
+}
+
+// setContent scans the statement containing the offset in the content.
+func (nc *navigationContext) setContent(content string, offset int) {
+	nc.content = content
+	nc.tokens = getStatementTokens(scanTokens(content, nc.engine), offset)
+	nc.scope = getStatementScope(nc.tokens)
+}
+
+// resolve resolves the identifier at the offset.
+func (nc *navigationContext) resolve(ctx context.Context, offset int) (*resolvedObject, error) {
+	index := getTokenAt(nc.tokens, offset)
+	if index < 0 {
+		return nil, nil
+	}
+	token := nc.tokens[index]
+	chain, position := getIdentifierChain(nc.tokens, index)
+
+	// The token is the name of a CTE.
+	for _, cte := range nc.scope.ctes {
+		if cte.name == token {
+			return &resolvedObject{token: token, cte: cte}, nil
+		}
+	}
+	for _, reference := range nc.scope.references {
+		// The token is the alias of a table reference.
+		if reference.alias == token {
+			return nc.resolveReference(ctx, reference, token)
+		}
+		// The token is in the table name of a table reference.
+		if reference.parts[0] == chain[0] {
+			if position == len(chain)-1 {
+				return nc.resolveReference(ctx, reference, token)
+			}
+			return nil, nil
+		}
+	}
+
+	if position < len(chain)-1 {
+		// The token is the qualifier of a column, e.g. "t" in "t.id".
+		if position == 0 {
+			if reference := nc.scope.findReference(token.value); reference != nil {
+				return nc.resolveReference(ctx, reference, token)
+			}
+		}
+		return nc.resolveTable(ctx, chain[:position+1], token)
+	}
+
+	if len(chain) == 1 {
+		if cte := nc.scope.findCTE(token.value); cte != nil {
+			return &resolvedObject{token: token, cte: cte}, nil
+		}
+		if reference := nc.scope.findReference(token.value); reference != nil && reference.alias != nil {
+			return nc.resolveReference(ctx, reference, token)
+		}
+		if object, err := nc.resolveUnqualifiedColumn(ctx, token); err != nil || object != nil {
+			return object, err
+		}
+		return nc.resolveTable(ctx, chain, token)
+	}
+
+	// The token is the column qualified by a table or alias.
+	qualifier := chain[:len(chain)-1]
+	var object *resolvedObject
+	var err error
+	if reference := nc.scope.findReference(qualifier[0].value); len(qualifier) == 1 && reference != nil {
+		object, err = nc.resolveReference(ctx, reference, token)
+	} else {
+		object, err = nc.resolveTable(ctx, qualifier, token)
+	}
+	if err != nil || object == nil || object.table == nil {
+		return object, err
+	}
+	if column := findColumn(object.table.GetColumns(), token.value); column != nil {
+		object.column = column
+	}
+	return object, nil
+}
+
+// resolveReference resolves the table reference in the statement, which might refer to a CTE.
+func (nc *navigationContext) resolveReference(ctx context.Context, reference *tableReference, token *sqlToken) (*resolvedObject, error) {
+	if len(reference.parts) == 1 {
+		if cte := nc.scope.findCTE(reference.name().value); cte != nil {
+			return &resolvedObject{token: token, cte: cte}, nil
+		}
+	}
+	return nc.resolveTable(ctx, reference.parts, token)
+}
+
+// resolveUnqualifiedColumn resolves the unqualified column with the query span of the statement.
+// The tables referenced in the statement are searched if the query span is not available, for example, the statement is not a query.
+func (nc *navigationContext) resolveUnqualifiedColumn(ctx context.Context, token *sqlToken) (*resolvedObject, error) {
+	if len(nc.tokens) == 0 {
+		return nil, nil
+	}
+	statement := nc.content[nc.tokens[0].start:nc.tokens[len(nc.tokens)-1].end]
+	spans, err := base.GetQuerySpan(ctx, nc.engine, statement, nc.defaultDatabase, "", nc.getDatabaseMetadataFunc, nc.listDatabaseNamesFunc, nc.ignoreCaseSensitive)
+	if err != nil {
+		slog.Debug("Failed to get query span", log.BBError(err))
+	}
+	for _, span := range spans {
+		if span == nil {
+			continue
+		}
+		var resources []base.ColumnResource
+		for resource := range span.SourceColumns {
+			if identifierEqual(resource.Column, token.value) {
+				resources = append(resources, resource)
+			}
+		}
+		sort.Slice(resources, func(i, j int) bool {
+			return fmt.Sprintf("%s.%s.%s", resources[i].Database, resources[i].Schema, resources[i].Table) < fmt.Sprintf("%s.%s.%s", resources[j].Database, resources[j].Schema, resources[j].Table)
+		})
+		for _, resource := range resources {
+			object, err := nc.findTable(ctx, resource.Database, resource.Schema, resource.Table, token)
+			if err != nil {
+				return nil, err
+			}
+			if object == nil || object.table == nil {
+				continue
+			}
+			if column := findColumn(object.table.GetColumns(), token.value); column != nil {
+				object.column = column
+				return object, nil
+			}
+		}
+	}
+
+	for _, reference := range nc.scope.references {
+		object, err := nc.resolveReference(ctx, reference, token)
+		if err != nil {
+			return nil, err
+		}
+		if object == nil || object.table == nil {
+			continue
+		}
+		if column := findColumn(object.table.GetColumns(), token.value); column != nil {
+			object.column = column
+			return object, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveTable resolves the table or view by the dotted name.
+func (nc *navigationContext) resolveTable(ctx context.Context, parts []*sqlToken, token *sqlToken) (*resolvedObject, error) {
+	database, schemaName, name := splitTableName(nc.engine, parts, nc.defaultDatabase)
+	if database == "" || name == "" {
+		return nil, nil
+	}
+	return nc.findTable(ctx, database, schemaName, name, token)
+}
+
+func (nc *navigationContext) findTable(ctx context.Context, database, schemaName, name string, token *sqlToken) (*resolvedObject, error) {
+	if database == "" {
+		database = nc.defaultDatabase
+	}
+	databaseName, metadata, err := nc.getDatabaseMetadataFunc(ctx, database)
+	if err != nil {
+		// The database might not exist while typing, so we don't return the error.
+		slog.Debug("Failed to get database metadata", log.BBError(err), slog.String("database", database))
+		return nil, nil
+	}
+
+	var schemaNames []string
+	if schemaName != "" {
+		schemaNames = append(schemaNames, schemaName)
+	} else {
+		schemaNames = append(schemaNames, getDefaultSchemas(nc.engine)...)
+		allSchemaNames := metadata.ListSchemaNames()
+		sort.Strings(allSchemaNames)
+		schemaNames = append(schemaNames, allSchemaNames...)
+	}
+	for _, candidate := range schemaNames {
+		schemaMetadata, schemaName := findSchema(metadata, candidate)
+		if schemaMetadata == nil {
+			continue
+		}
+		if tableName := findName(schemaMetadata.ListTableNames(), name); tableName != "" {
+			return &resolvedObject{
+				token:    token,
+				database: databaseName,
+				schema:   schemaName,
+				name:     tableName,
+				table:    schemaMetadata.GetTable(tableName),
+			}, nil
+		}
+		if viewName := findName(schemaMetadata.ListViewNames(), name); viewName != "" {
+			return &resolvedObject{
+				token:    token,
+				database: databaseName,
+				schema:   schemaName,
+				name:     viewName,
+				view:     schemaMetadata.GetView(viewName),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func findSchema(metadata *model.DatabaseMetadata, name string) (*model.SchemaMetadata, string) {
+	if schemaMetadata := metadata.GetSchema(name); schemaMetadata != nil {
+		return schemaMetadata, name
+	}
+	schemaName := findName(metadata.ListSchemaNames(), name)
+	if schemaName == "" {
+		return nil, ""
+	}
+	return metadata.GetSchema(schemaName), schemaName
+}
+
+// findName finds the name in the list, the exact match is preferred over the case-insensitive match.
+func findName(names []string, name string) string {
+	for _, candidate := range names {
+		if candidate == name {
+			return candidate
+		}
+	}
+	for _, candidate := range names {
+		if identifierEqual(candidate, name) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func findColumn(columns []*storepb.ColumnMetadata, name string) *storepb.ColumnMetadata {
+	var names []string
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+	columnName := findName(names, name)
+	for _, column := range columns {
+		if column.Name == columnName {
+			return column
+		}
+	}
+	return nil
+}
+
+// splitTableName splits the dotted table name to the database, schema and table name.
+func splitTableName(engine storepb.Engine, parts []*sqlToken, defaultDatabase string) (string, string, string) {
+	var names []string
+	for _, part := range parts {
+		names = append(names, part.value)
+	}
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE, storepb.Engine_CLICKHOUSE, storepb.Engine_STARROCKS, storepb.Engine_DORIS,
+		storepb.Engine_ORACLE, storepb.Engine_DM, storepb.Engine_OCEANBASE_ORACLE:
+		// The database is the schema for these engines.
+		switch len(names) {
+		case 1:
+			return defaultDatabase, "", names[0]
+		case 2:
+			return names[0], "", names[1]
+		}
+	default:
+		switch len(names) {
+		case 1:
+			return defaultDatabase, "", names[0]
+		case 2:
+			return defaultDatabase, names[0], names[1]
+		case 3:
+			return names[0], names[1], names[2]
+		}
+	}
+	return "", "", ""
+}
+
+func getDefaultSchemas(engine storepb.Engine) []string {
+	switch engine {
+	case storepb.Engine_POSTGRES, storepb.Engine_REDSHIFT, storepb.Engine_RISINGWAVE:
+		return []string{"public"}
+	case storepb.Engine_MSSQL:
+		return []string{"dbo"}
+	case storepb.Engine_SNOWFLAKE:
+		return []string{"PUBLIC"}
+	default:
+		return []string{""}
+	}
+}
+
+// getQualifiedName returns the dotted name of the object for display.
+func (o *resolvedObject) getQualifiedName() string {
+	var parts []string
+	if o.schema != "" {
+		parts = append(parts, o.schema)
+	}
+	parts = append(parts, o.name)
+	if o.column != nil {
+		parts = append(parts, o.column.Name)
+	}
+	return strings.Join(parts, ".")
+}
+
+// getDefinition returns the DDL of the table or view.
+func (o *resolvedObject) getDefinition(engine storepb.Engine) string {
+	if o.view != nil {
+		return fmt.Sprintf("CREATE VIEW %s AS\n%s", o.getTableName(), strings.TrimSpace(o.view.Definition))
+	}
+	if o.table == nil {
+		return ""
+	}
+	definition, err := schema.GetDesignSchema(engine, "", "", &storepb.DatabaseSchemaMetadata{
+		Name: o.database,
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name:   o.schema,
+				Tables: []*storepb.TableMetadata{o.table.GetProto()},
+			},
+		},
+	})
+	if err == nil && strings.TrimSpace(definition) != "" {
+		return definition
+	}
+
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "CREATE TABLE %s (\n", o.getTableName())
+	for i, column := range o.table.GetColumns() {
+		_, _ = fmt.Fprintf(&buf, "  %s %s", column.Name, column.Type)
+		if !column.Nullable {
+			_, _ = buf.WriteString(" NOT NULL")
+		}
+		if i != len(o.table.GetColumns())-1 {
+			_, _ = buf.WriteString(",")
+		}
+		_, _ = buf.WriteString("\n")
+	}
+	_, _ = buf.WriteString(");\n")
+	return buf.String()
+}
+
+func (o *resolvedObject) getTableName() string {
+	if o.schema == "" {
+		return o.name
+	}
+	return fmt.Sprintf("%s.%s", o.schema, o.name)
+}
@@ -0,0 +1,150 @@
+package lsp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/store/model"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestHover(t *testing.T) {
+	tests := []struct {
+		// content contains the cursor "|".
+		content string
+		want    string
+	}{
+		{
+			content: "SELECT * FROM t|",
+			want:    "**Table** `public.t`\n\nThe books.\n\n- `id` integer\n- `name` text\n",
+		},
+		{
+			content: "SELECT x.na|me FROM public.t x",
+			want:    "**Column** `public.t.name`\n\n`text` · NULL\n",
+		},
+		{
+			content: "SELECT id| FROM t WHERE name = 'a'",
+			want:    "**Column** `public.t.id`\n\n`integer` · NOT NULL · DEFAULT `nextval('t_id_seq')`\n",
+		},
+		{
+			// The brackets are the array subscripts in PostgreSQL.
+			content: "SELECT ARRAY[x.id|], a[1] FROM t x",
+			want:    "**Column** `public.t.id`\n\n`integer` · NOT NULL · DEFAULT `nextval('t_id_seq')`\n",
+		},
+		{
+			content: "WITH c AS (SELECT 1) SELECT * FROM c|",
+			want:    "**CTE** `c`\n\n```sql\nc AS (SELECT 1)\n```\n",
+		},
+		{
+			content: "SELECT * FROM unknown|",
+			want:    "",
+		},
+	}
+
+	a := require.New(t)
+	for _, tc := range tests {
+		nc, offset := newTestNavigationContext(tc.content)
+		object, err := nc.resolve(context.Background(), offset)
+		a.NoError(err)
+		if tc.want == "" {
+			a.Nil(object, tc.content)
+			continue
+		}
+		a.NotNil(object, tc.content)
+		a.Equal(tc.want, getHoverContent(nc, object), tc.content)
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	const documentURI = lsp.DocumentURI("file:///untitled.sql")
+	tests := []struct {
+		// content contains the cursor "|".
+		content string
+		wantURI lsp.DocumentURI
+		// wantRange is the range of the CTE name in the document.
+		wantRange *lsp.Range
+		// wantLine contains the text, which is the line of the location in the DDL.
+		wantLine string
+	}{
+		{
+			content: "WITH c AS (SELECT 1) SELECT * FROM c|",
+			wantURI: documentURI,
+			wantRange: &lsp.Range{
+				Start: lsp.Position{Line: 0, Character: 5},
+				End:   lsp.Position{Line: 0, Character: 6},
+			},
+		},
+		{
+			content:  "SELECT x.name|\nFROM t x",
+			wantURI:  "file:///.definitions/instances/test/databases/db/public.t.sql",
+			wantLine: "name",
+		},
+		{
+			content:  "SELECT * FROM public.t|",
+			wantURI:  "file:///.definitions/instances/test/databases/db/public.t.sql",
+			wantLine: "CREATE TABLE",
+		},
+	}
+
+	a := require.New(t)
+	for _, tc := range tests {
+		nc, offset := newTestNavigationContext(tc.content)
+		object, err := nc.resolve(context.Background(), offset)
+		a.NoError(err)
+		a.NotNil(object, tc.content)
+		location, definition := getDefinitionLocation(nc, documentURI, "test", object)
+		a.NotNil(location, tc.content)
+		a.Equal(tc.wantURI, location.URI, tc.content)
+		if tc.wantRange != nil {
+			a.Equal(*tc.wantRange, location.Range, tc.content)
+			a.Empty(definition, tc.content)
+			continue
+		}
+		lines := strings.Split(definition, "\n")
+		a.Less(location.Range.Start.Line, len(lines), tc.content)
+		a.Contains(lines[location.Range.Start.Line], tc.wantLine, tc.content)
+	}
+}
+
+// newTestNavigationContext returns the navigation context of the PostgreSQL database "db" with the table "public.t".
+func newTestNavigationContext(content string) (*navigationContext, int) {
+	metadata := model.NewDatabaseMetadata(&storepb.DatabaseSchemaMetadata{
+		Name: "db",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "public",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name:    "t",
+						Comment: "The books.",
+						Columns: []*storepb.ColumnMetadata{
+							{Name: "id", Type: "integer", DefaultValue: &storepb.ColumnMetadata_DefaultExpression{DefaultExpression: "nextval('t_id_seq')"}},
+							{Name: "name", Type: "text", Nullable: true},
+						},
+					},
+				},
+			},
+		},
+	})
+	nc := &navigationContext{
+		engine:          storepb.Engine_POSTGRES,
+		defaultDatabase: "db",
+		getDatabaseMetadataFunc: func(_ context.Context, database string) (string, *model.DatabaseMetadata, error) {
+			if database != "db" {
+				return "", nil, errors.Errorf("database %q not found", database)
+			}
+			return database, metadata, nil
+		},
+		listDatabaseNamesFunc: func(context.Context) ([]string, error) {
+			return []string{"db"}, nil
+		},
+	}
+	content, offset := getContentAndOffset(content)
+	nc.setContent(content, offset)
+	return nc, offset
+}
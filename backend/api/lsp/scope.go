@@ -0,0 +1,391 @@
+package lsp
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+type sqlTokenKind int
+
+const (
+	sqlTokenIdentifier sqlTokenKind = iota
+	sqlTokenPunctuation
+	sqlTokenOther
+)
+
+// sqlToken is the token of the lexical scan for navigating the document.
+// We don't use the engine parsers because the statements being edited are usually incomplete.
+type sqlToken struct {
+	kind sqlTokenKind
+	// text is the original text of the token.
+	text string
+	// value is the unquoted text of the identifier tokens.
+	value  string
+	quoted bool
+	// start and end are the byte offsets in the document.
+	start int
+	end   int
+}
+
+func (t *sqlToken) isKeyword(keyword string) bool {
+	return t.kind == sqlTokenIdentifier && !t.quoted && strings.EqualFold(t.value, keyword)
+}
+
+func (t *sqlToken) isPunctuation(p string) bool {
+	return t.kind == sqlTokenPunctuation && t.text == p
+}
+
+// scanTokens scans the tokens in the content, skipping the whitespaces and comments.
+// The identifier quotes differ by engine, e.g. the brackets are the array subscripts rather than quotes in PostgreSQL.
+func scanTokens(content string, engine storepb.Engine) []*sqlToken {
+	identifierQuotes := getIdentifierQuotes(engine)
+	var tokens []*sqlToken
+	i := 0
+	for i < len(content) {
+		r, size := utf8.DecodeRuneInString(content[i:])
+		closing, isIdentifierQuote := identifierQuotes[r]
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(content[i:], "--"):
+			i = skipUntil(content, i+2, "\n")
+		case strings.HasPrefix(content[i:], "/*"):
+			i = skipUntil(content, i+2, "*/")
+		case isIdentifierQuote:
+			end := skipQuoted(content, i, byte(closing))
+			text := content[i:end]
+			value := strings.TrimPrefix(text, string(r))
+			value = strings.TrimSuffix(value, string(closing))
+			value = strings.ReplaceAll(value, string(closing)+string(closing), string(closing))
+			tokens = append(tokens, &sqlToken{kind: sqlTokenIdentifier, text: text, value: value, quoted: true, start: i, end: end})
+			i = end
+		case r == '\'' || r == '"':
+			// The double quotes not quoting identifiers are the string literals, e.g. in MySQL.
+			end := skipQuoted(content, i, byte(r))
+			tokens = append(tokens, &sqlToken{kind: sqlTokenOther, text: content[i:end], start: i, end: end})
+			i = end
+		case r == '$' && isDollarQuoteStart(content[i:]):
+			tag := content[i : i+strings.Index(content[i+1:], "$")+2]
+			end := skipUntil(content, i+len(tag), tag)
+			tokens = append(tokens, &sqlToken{kind: sqlTokenOther, text: content[i:end], start: i, end: end})
+			i = end
+		case isIdentifierRune(r):
+			end := i
+			for end < len(content) {
+				r, size := utf8.DecodeRuneInString(content[end:])
+				if !isIdentifierRune(r) {
+					break
+				}
+				end += size
+			}
+			text := content[i:end]
+			kind := sqlTokenIdentifier
+			if unicode.IsDigit(r) {
+				kind = sqlTokenOther
+			}
+			tokens = append(tokens, &sqlToken{kind: kind, text: text, value: text, start: i, end: end})
+			i = end
+		default:
+			tokens = append(tokens, &sqlToken{kind: sqlTokenPunctuation, text: content[i : i+size], start: i, end: i + size})
+			i += size
+		}
+	}
+	return tokens
+}
+
+// getIdentifierQuotes returns the opening quotes of the quoted identifiers and their closing quotes for the engine.
+func getIdentifierQuotes(engine storepb.Engine) map[rune]rune {
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE, storepb.Engine_STARROCKS, storepb.Engine_DORIS,
+		storepb.Engine_SPANNER, storepb.Engine_HIVE:
+		return map[rune]rune{'`': '`'}
+	case storepb.Engine_CLICKHOUSE:
+		return map[rune]rune{'`': '`', '"': '"'}
+	case storepb.Engine_MSSQL:
+		return map[rune]rune{'"': '"', '[': ']'}
+	case storepb.Engine_SQLITE:
+		return map[rune]rune{'"': '"', '`': '`', '[': ']'}
+	default:
+		return map[rune]rune{'"': '"'}
+	}
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || r == '$' || r == '@' || r == '#' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isDollarQuoteStart(s string) bool {
+	end := strings.Index(s[1:], "$")
+	if end < 0 {
+		return false
+	}
+	for _, r := range s[1 : end+1] {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// skipUntil returns the offset after the terminator, or the end of the content if the terminator is not found.
+func skipUntil(content string, start int, terminator string) int {
+	if start > len(content) {
+		return len(content)
+	}
+	idx := strings.Index(content[start:], terminator)
+	if idx < 0 {
+		return len(content)
+	}
+	return start + idx + len(terminator)
+}
+
+// skipQuoted returns the offset after the closing quote, the doubled quotes are treated as escaped.
+func skipQuoted(content string, start int, closing byte) int {
+	i := start + 1
+	for i < len(content) {
+		if content[i] == closing {
+			if i+1 < len(content) && content[i+1] == closing {
+				i += 2
+				continue
+			}
+			return i + 1
+		}
+		i++
+	}
+	return len(content)
+}
+
+// getStatementTokens returns the tokens of the statement containing the offset, which are separated by semicolons.
+func getStatementTokens(tokens []*sqlToken, offset int) []*sqlToken {
+	start := 0
+	for i, token := range tokens {
+		if !token.isPunctuation(";") {
+			continue
+		}
+		if token.end > offset {
+			return tokens[start:i]
+		}
+		start = i + 1
+	}
+	return tokens[start:]
+}
+
+// getTokenAt returns the index of the identifier token at the offset, or -1 if not found.
+// The offset right after the identifier is accepted as well, which is where the cursor usually is.
+func getTokenAt(tokens []*sqlToken, offset int) int {
+	for i, token := range tokens {
+		if token.kind == sqlTokenIdentifier && token.start <= offset && offset <= token.end {
+			return i
+		}
+	}
+	return -1
+}
+
+// getIdentifierChain returns the dotted identifier chain containing the token at index, and the index of the token in the chain.
+func getIdentifierChain(tokens []*sqlToken, index int) ([]*sqlToken, int) {
+	begin := index
+	for begin >= 2 && tokens[begin-1].isPunctuation(".") && tokens[begin-1].start == tokens[begin-2].end && tokens[begin-2].kind == sqlTokenIdentifier {
+		begin -= 2
+	}
+	end := index
+	for end+2 < len(tokens) && tokens[end+1].isPunctuation(".") && tokens[end+1].start == tokens[end].end && tokens[end+2].kind == sqlTokenIdentifier {
+		end += 2
+	}
+	var chain []*sqlToken
+	position := 0
+	for i := begin; i <= end; i += 2 {
+		if i == index {
+			position = len(chain)
+		}
+		chain = append(chain, tokens[i])
+	}
+	return chain, position
+}
+
+// cteDefinition is the common table expression defined in the statement.
+type cteDefinition struct {
+	name *sqlToken
+	// body is the tokens in the parentheses of the CTE.
+	body []*sqlToken
+}
+
+// tableReference is the table referenced in the FROM, JOIN, UPDATE or INTO clauses.
+type tableReference struct {
+	parts []*sqlToken
+	alias *sqlToken
+	// insert is true if the table is the target of INSERT INTO.
+	insert bool
+}
+
+func (r *tableReference) name() *sqlToken {
+	return r.parts[len(r.parts)-1]
+}
+
+// statementScope is the names visible in the statement.
+type statementScope struct {
+	ctes       []*cteDefinition
+	references []*tableReference
+}
+
+// aliasStopWords are the keywords that cannot be the alias following a table reference.
+var aliasStopWords = map[string]bool{
+	"WHERE": true, "ON": true, "USING": true, "JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true,
+	"FULL": true, "OUTER": true, "CROSS": true, "NATURAL": true, "GROUP": true, "ORDER": true, "HAVING": true,
+	"LIMIT": true, "OFFSET": true, "UNION": true, "EXCEPT": true, "INTERSECT": true, "MINUS": true, "SET": true,
+	"VALUES": true, "WINDOW": true, "FETCH": true, "FOR": true, "RETURNING": true, "SELECT": true, "WITH": true,
+	"DEFAULT": true, "OUTPUT": true, "PARTITION": true, "LATERAL": true, "STRAIGHT_JOIN": true, "QUALIFY": true,
+	"START": true, "CONNECT": true, "PIVOT": true, "UNPIVOT": true, "SAMPLE": true, "TABLESAMPLE": true,
+	"FORCE": true, "IGNORE": true, "OVERRIDING": true, "DO": true, "WHEN": true, "THEN": true, "AS": true,
+}
+
+// tableReferenceKeywords are the keywords followed by a table reference.
+var tableReferenceKeywords = map[string]bool{
+	"FROM": true, "JOIN": true, "UPDATE": true, "INTO": true, "STRAIGHT_JOIN": true,
+}
+
+// getStatementScope extracts the CTEs and table references in the statement tokens.
+func getStatementScope(tokens []*sqlToken) *statementScope {
+	scope := &statementScope{}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.kind != sqlTokenIdentifier {
+			continue
+		}
+
+		// WITH [RECURSIVE] name [(columns)] AS [NOT] [MATERIALIZED] (...)
+		if i > 0 && (tokens[i-1].isKeyword("WITH") || tokens[i-1].isKeyword("RECURSIVE") || tokens[i-1].isPunctuation(",")) {
+			if cte := parseCTE(tokens, i); cte != nil {
+				scope.ctes = append(scope.ctes, cte)
+				continue
+			}
+		}
+
+		if token.quoted || !tableReferenceKeywords[strings.ToUpper(token.value)] {
+			continue
+		}
+		next := i + 1
+		for next < len(tokens) {
+			reference, end := parseTableReference(tokens, next, token.isKeyword("INTO"))
+			if reference == nil && end == next {
+				break
+			}
+			if reference != nil {
+				scope.references = append(scope.references, reference)
+			}
+			// The comma separated table references in the FROM clause.
+			if end < len(tokens) && tokens[end].isPunctuation(",") && token.isKeyword("FROM") {
+				next = end + 1
+				continue
+			}
+			break
+		}
+	}
+	return scope
+}
+
+func parseCTE(tokens []*sqlToken, i int) *cteDefinition {
+	j := i + 1
+	if j < len(tokens) && tokens[j].isPunctuation("(") {
+		j = skipParentheses(tokens, j)
+	}
+	if j >= len(tokens) || !tokens[j].isKeyword("AS") {
+		return nil
+	}
+	j++
+	for j < len(tokens) && (tokens[j].isKeyword("NOT") || tokens[j].isKeyword("MATERIALIZED")) {
+		j++
+	}
+	if j >= len(tokens) || !tokens[j].isPunctuation("(") {
+		return nil
+	}
+	end := skipParentheses(tokens, j)
+	bodyEnd := end - 1
+	if bodyEnd < j+1 || !tokens[bodyEnd].isPunctuation(")") {
+		bodyEnd = end
+	}
+	return &cteDefinition{name: tokens[i], body: tokens[j+1 : bodyEnd]}
+}
+
+// skipParentheses returns the index after the closing parenthesis matching the opening one at index i.
+func skipParentheses(tokens []*sqlToken, i int) int {
+	depth := 0
+	for ; i < len(tokens); i++ {
+		switch {
+		case tokens[i].isPunctuation("("):
+			depth++
+		case tokens[i].isPunctuation(")"):
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(tokens)
+}
+
+// parseTableReference parses the dotted table name with the optional alias at index i, and returns the index after it.
+// The parentheses following the table name are the column list for INSERT INTO, and the arguments of the table function otherwise.
+func parseTableReference(tokens []*sqlToken, i int, isInsert bool) (*tableReference, int) {
+	if i < len(tokens) && (tokens[i].isKeyword("ONLY") || tokens[i].isKeyword("LATERAL")) {
+		i++
+	}
+	if i >= len(tokens) || tokens[i].kind != sqlTokenIdentifier || (!tokens[i].quoted && aliasStopWords[strings.ToUpper(tokens[i].value)]) {
+		return nil, i
+	}
+	chain, _ := getIdentifierChain(tokens, i)
+	end := i + 2*len(chain) - 1
+	if end < len(tokens) && tokens[end].isPunctuation("(") {
+		if isInsert {
+			return &tableReference{parts: chain, insert: true}, end
+		}
+		// Table functions, e.g. FROM generate_series(1, 10) AS g, are skipped with their aliases.
+		_, end = parseAlias(tokens, skipParentheses(tokens, end))
+		return nil, end
+	}
+	reference := &tableReference{parts: chain, insert: isInsert}
+	reference.alias, end = parseAlias(tokens, end)
+	return reference, end
+}
+
+// parseAlias parses the optional alias at index i, and returns the index after it.
+func parseAlias(tokens []*sqlToken, i int) (*sqlToken, int) {
+	if i < len(tokens) && tokens[i].isKeyword("AS") {
+		i++
+	}
+	if i < len(tokens) && tokens[i].kind == sqlTokenIdentifier && (tokens[i].quoted || !aliasStopWords[strings.ToUpper(tokens[i].value)]) {
+		return tokens[i], i + 1
+	}
+	return nil, i
+}
+
+// findCTE finds the CTE by name.
+func (s *statementScope) findCTE(name string) *cteDefinition {
+	for _, cte := range s.ctes {
+		if identifierEqual(cte.name.value, name) {
+			return cte
+		}
+	}
+	return nil
+}
+
+// findReference finds the table reference by the alias, or the table name if it has no alias.
+func (s *statementScope) findReference(name string) *tableReference {
+	for _, reference := range s.references {
+		if reference.alias != nil && identifierEqual(reference.alias.value, name) {
+			return reference
+		}
+	}
+	for _, reference := range s.references {
+		if reference.alias == nil && identifierEqual(reference.name().value, name) {
+			return reference
+		}
+	}
+	return nil
+}
+
+func identifierEqual(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
@@ -0,0 +1,139 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestScanTokens(t *testing.T) {
+	tests := []struct {
+		engine  storepb.Engine
+		content string
+		// want is the tokens formatted by formatToken.
+		want []string
+	}{
+		{
+			engine:  storepb.Engine_POSTGRES,
+			content: `SELECT a[1], "b""c" FROM t -- comment`,
+			want:    []string{"id(SELECT)", "id(a)", "p([)", "o(1)", "p(])", "p(,)", `qid(b"c)`, "id(FROM)", "id(t)"},
+		},
+		{
+			engine:  storepb.Engine_POSTGRES,
+			content: "SELECT ARRAY[x.id] /* comment */ FROM $$a;b$$;",
+			want:    []string{"id(SELECT)", "id(ARRAY)", "p([)", "id(x)", "p(.)", "id(id)", "p(])", "id(FROM)", "o($$a;b$$)", "p(;)"},
+		},
+		{
+			engine:  storepb.Engine_MSSQL,
+			content: `SELECT [a b], "c" FROM [dbo].[t]]x]`,
+			want:    []string{"id(SELECT)", "qid(a b)", "p(,)", "qid(c)", "id(FROM)", "qid(dbo)", "p(.)", "qid(t]x)"},
+		},
+		{
+			engine:  storepb.Engine_MYSQL,
+			content: "SELECT `a` FROM t WHERE b = \"x'y\" AND c = 'z'",
+			want:    []string{"id(SELECT)", "qid(a)", "id(FROM)", "id(t)", "id(WHERE)", "id(b)", "p(=)", `o("x'y")`, "id(AND)", "id(c)", "p(=)", "o('z')"},
+		},
+		{
+			engine:  storepb.Engine_SQLITE,
+			content: "SELECT [a], `b`, \"c\"",
+			want:    []string{"id(SELECT)", "qid(a)", "p(,)", "qid(b)", "p(,)", "qid(c)"},
+		},
+	}
+
+	a := require.New(t)
+	for _, tc := range tests {
+		var got []string
+		for _, token := range scanTokens(tc.content, tc.engine) {
+			a.Equal(token.text, tc.content[token.start:token.end], tc.content)
+			got = append(got, formatToken(token))
+		}
+		a.Equal(tc.want, got, tc.content)
+	}
+}
+
+func TestGetStatementScope(t *testing.T) {
+	tests := []struct {
+		engine storepb.Engine
+		// content contains the cursor "|", which is removed before scanning.
+		content        string
+		wantCTEs       []string
+		wantReferences []string
+	}{
+		{
+			engine:         storepb.Engine_POSTGRES,
+			content:        "SELECT 1; WITH c AS (SELECT 1), d (x) AS MATERIALIZED (SELECT 2) SELECT * FROM c, public.t AS x JOIN u ON x.id = u.id|; SELECT 2",
+			wantCTEs:       []string{"c", "d"},
+			wantReferences: []string{"c", "public.t x", "u"},
+		},
+		{
+			engine:         storepb.Engine_POSTGRES,
+			content:        "INSERT INTO t (a, b) VALUES (1|, 2)",
+			wantReferences: []string{"t insert"},
+		},
+		{
+			engine:         storepb.Engine_POSTGRES,
+			content:        "SELECT a[1], x.id| FROM t x WHERE x.b[2] = 1",
+			wantReferences: []string{"t x"},
+		},
+		{
+			engine:         storepb.Engine_POSTGRES,
+			content:        "SELECT * FROM generate_series(1, 10) g, t| WHERE id = 1",
+			wantReferences: []string{"t"},
+		},
+		{
+			engine:         storepb.Engine_MSSQL,
+			content:        "UPDATE [dbo].[t] SET a = 1 FROM [dbo].[t] AS [x]|",
+			wantReferences: []string{"dbo.t", "dbo.t x"},
+		},
+	}
+
+	a := require.New(t)
+	for _, tc := range tests {
+		content, offset := getContentAndOffset(tc.content)
+		scope := getStatementScope(getStatementTokens(scanTokens(content, tc.engine), offset))
+		var ctes []string
+		for _, cte := range scope.ctes {
+			ctes = append(ctes, cte.name.value)
+		}
+		var references []string
+		for _, reference := range scope.references {
+			var parts []string
+			for _, part := range reference.parts {
+				parts = append(parts, part.value)
+			}
+			s := strings.Join(parts, ".")
+			if reference.alias != nil {
+				s += " " + reference.alias.value
+			}
+			if reference.insert {
+				s += " insert"
+			}
+			references = append(references, s)
+		}
+		a.Equal(tc.wantCTEs, ctes, tc.content)
+		a.Equal(tc.wantReferences, references, tc.content)
+	}
+}
+
+func formatToken(token *sqlToken) string {
+	switch {
+	case token.kind == sqlTokenIdentifier && token.quoted:
+		return fmt.Sprintf("qid(%s)", token.value)
+	case token.kind == sqlTokenIdentifier:
+		return fmt.Sprintf("id(%s)", token.value)
+	case token.kind == sqlTokenPunctuation:
+		return fmt.Sprintf("p(%s)", token.text)
+	default:
+		return fmt.Sprintf("o(%s)", token.text)
+	}
+}
+
+// getContentAndOffset removes the cursor "|" from the content, and returns the offset of the cursor.
+func getContentAndOffset(content string) (string, int) {
+	offset := strings.Index(content, "|")
+	return strings.Replace(content, "|", "", 1), offset
+}
@@ -0,0 +1,167 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func newEmptySignatureHelp() *lsp.SignatureHelp {
+	return &lsp.SignatureHelp{
+		Signatures: []lsp.SignatureInformation{},
+	}
+}
+
+// handleTextDocumentSignatureHelp shows the table columns in the column list and the VALUES clause of the INSERT statement.
+func (h *Handler) handleTextDocumentSignatureHelp(ctx context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request, params lsp.TextDocumentPositionParams) (*lsp.SignatureHelp, error) {
+	if !IsURI(params.TextDocument.URI) {
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeInvalidParams,
+			Message: fmt.Sprintf("textDocument/signatureHelp not yet supported for out-of-workspace URI (%q)", params.TextDocument.URI),
+		}
+	}
+	nc, offset, err := h.newNavigationContext(ctx, params.TextDocument.URI, params.Position)
+	if err != nil {
+		return nil, err
+	}
+	if nc == nil {
+		return newEmptySignatureHelp(), nil
+	}
+
+	open, activeParameter := findEnclosingParenthesis(nc.tokens, offset)
+	if open < 0 {
+		return newEmptySignatureHelp(), nil
+	}
+	var reference *tableReference
+	for _, r := range nc.scope.references {
+		if r.insert {
+			reference = r
+			break
+		}
+	}
+	if reference == nil {
+		return newEmptySignatureHelp(), nil
+	}
+
+	// The column list follows the table name, e.g. INSERT INTO t (a, b).
+	columnListOpen := -1
+	for i, token := range nc.tokens {
+		if token == reference.name() {
+			if i+1 < len(nc.tokens) && nc.tokens[i+1].isPunctuation("(") {
+				columnListOpen = i + 1
+			}
+			break
+		}
+	}
+	if open != columnListOpen && !isValuesParenthesis(nc.tokens, open) {
+		return newEmptySignatureHelp(), nil
+	}
+
+	object, err := nc.resolveReference(ctx, reference, reference.name())
+	if err != nil {
+		return nil, err
+	}
+	if object == nil || object.table == nil {
+		return newEmptySignatureHelp(), nil
+	}
+	columns := object.table.GetColumns()
+	if open != columnListOpen && columnListOpen >= 0 {
+		// The values are for the columns in the column list.
+		columns = nil
+		for i := columnListOpen + 1; i < len(nc.tokens) && !nc.tokens[i].isPunctuation(")"); i++ {
+			if nc.tokens[i].kind != sqlTokenIdentifier {
+				continue
+			}
+			if column := findColumn(object.table.GetColumns(), nc.tokens[i].value); column != nil {
+				columns = append(columns, column)
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return newEmptySignatureHelp(), nil
+	}
+
+	return &lsp.SignatureHelp{
+		Signatures:      []lsp.SignatureInformation{getTableSignature(object, columns)},
+		ActiveSignature: 0,
+		ActiveParameter: activeParameter,
+	}, nil
+}
+
+// findEnclosingParenthesis returns the index of the unclosed opening parenthesis before the offset,
+// and the number of the commas between the parenthesis and the offset.
+func findEnclosingParenthesis(tokens []*sqlToken, offset int) (int, int) {
+	depth := 0
+	commas := 0
+	for i := len(tokens) - 1; i >= 0; i-- {
+		token := tokens[i]
+		if token.start >= offset {
+			continue
+		}
+		switch {
+		case token.isPunctuation(")"):
+			depth++
+		case token.isPunctuation("("):
+			if depth == 0 {
+				return i, commas
+			}
+			depth--
+		case token.isPunctuation(",") && depth == 0:
+			commas++
+		}
+	}
+	return -1, 0
+}
+
+// isValuesParenthesis returns true if the opening parenthesis at index starts a row of the VALUES clause.
+func isValuesParenthesis(tokens []*sqlToken, index int) bool {
+	for i := index - 1; i >= 0; i-- {
+		switch {
+		case tokens[i].isKeyword("VALUES"), tokens[i].isKeyword("VALUE"):
+			return true
+		case tokens[i].isPunctuation(","):
+			// The separator between the rows, e.g. VALUES (1, 2), (3, 4).
+			if i == 0 || !tokens[i-1].isPunctuation(")") {
+				return false
+			}
+			// Skip the previous row.
+			depth := 0
+			for i = i - 1; i >= 0; i-- {
+				if tokens[i].isPunctuation(")") {
+					depth++
+				} else if tokens[i].isPunctuation("(") {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func getTableSignature(object *resolvedObject, columns []*storepb.ColumnMetadata) lsp.SignatureInformation {
+	var parameters []lsp.ParameterInformation
+	var labels []string
+	for _, column := range columns {
+		label := fmt.Sprintf("%s %s", column.Name, column.Type)
+		labels = append(labels, label)
+		parameters = append(parameters, lsp.ParameterInformation{
+			Label:         label,
+			Documentation: getComment(column.UserComment, column.Comment),
+		})
+	}
+	return lsp.SignatureInformation{
+		Label:         fmt.Sprintf("%s(%s)", object.getTableName(), strings.Join(labels, ", ")),
+		Documentation: getComment(object.table.GetProto().GetUserComment(), object.table.GetProto().GetComment()),
+		Parameters:    parameters,
+	}
+}
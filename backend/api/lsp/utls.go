@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/sourcegraph/go-lsp"
 )
@@ -26,22 +28,25 @@ func URIToPath(uri lsp.DocumentURI) string {
 	return u.Path
 }
 
+// offsetForPosition converts the position to the byte offset in the content.
+// The position character is in UTF-16 code units, which is the default position encoding of LSP.
 func offsetForPosition(content []byte, p lsp.Position) (offset int, valid bool, whyInvalid string) {
 	line := 0
 	col := 0
-	for _, b := range content {
+	for offset < len(content) {
 		if line == p.Line && col == p.Character {
 			return offset, true, ""
 		}
 		if (line == p.Line && col > p.Character) || line > p.Line {
 			return 0, false, fmt.Sprintf("character %d (zero-based) is beyond line %d boundary (zero-based)", p.Character, p.Line)
 		}
-		offset++
-		if b == '\n' {
+		r, size := utf8.DecodeRune(content[offset:])
+		offset += size
+		if r == '\n' {
 			line++
 			col = 0
 		} else {
-			col++
+			col += utf16RuneLen(r)
 		}
 	}
 	if line == p.Line && col == p.Character {
@@ -52,3 +57,44 @@ func offsetForPosition(content []byte, p lsp.Position) (offset int, valid bool,
 	}
 	return 0, false, fmt.Sprintf("file only has %d lines", line+1)
 }
+
+// positionForOffset converts the byte offset to the position, which is the reverse of offsetForPosition.
+func positionForOffset(content []byte, offset int) lsp.Position {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	line, col := 0, 0
+	for i := 0; i < offset; {
+		r, size := utf8.DecodeRune(content[i:])
+		i += size
+		if r == '\n' {
+			line++
+			col = 0
+		} else {
+			col += utf16RuneLen(r)
+		}
+	}
+	return lsp.Position{Line: line, Character: col}
+}
+
+// utf16Length returns the length of the first n characters of the text in UTF-16 code units.
+// The advisors count the columns in characters, while LSP counts them in UTF-16 code units.
+func utf16Length(text string, n int) int {
+	length := 0
+	for _, r := range text {
+		if n <= 0 {
+			break
+		}
+		length += utf16RuneLen(r)
+		n--
+	}
+	return length
+}
+
+// utf16RuneLen returns the number of UTF-16 code units encoding the rune.
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 && r <= unicode.MaxRune {
+		return 2
+	}
+	return 1
+}
@@ -75,6 +75,8 @@ var methodPermissionMap = map[string]iam.Permission{
 	v1pb.ProjectService_UpdateWebhook_FullMethodName:                iam.PermissionProjectsUpdate,
 	v1pb.ProjectService_RemoveWebhook_FullMethodName:                iam.PermissionProjectsUpdate,
 	v1pb.ProjectService_TestWebhook_FullMethodName:                  iam.PermissionProjectsUpdate,
+	v1pb.ProjectService_ListWebhookDeliveries_FullMethodName:        iam.PermissionProjectsGet,
+	v1pb.ProjectService_RedeliverWebhookDelivery_FullMethodName:     iam.PermissionProjectsUpdate,
 	v1pb.ProjectService_ListDatabaseGroups_FullMethodName:           iam.PermissionProjectsGet,
 	v1pb.ProjectService_GetDatabaseGroup_FullMethodName:             iam.PermissionProjectsGet,
 	v1pb.ProjectService_CreateDatabaseGroup_FullMethodName:          iam.PermissionProjectsUpdate,
@@ -175,6 +175,8 @@ func (p *ContextProvider) do(ctx context.Context, fullMethod string, req any) ([
 		v1pb.ProjectService_UpdateWebhook_FullMethodName,
 		v1pb.ProjectService_RemoveWebhook_FullMethodName,
 		v1pb.ProjectService_TestWebhook_FullMethodName,
+		v1pb.ProjectService_ListWebhookDeliveries_FullMethodName,
+		v1pb.ProjectService_RedeliverWebhookDelivery_FullMethodName,
 
 		v1pb.ProjectService_GetDatabaseGroup_FullMethodName,
 		v1pb.ProjectService_CreateDatabaseGroup_FullMethodName,
@@ -340,7 +342,7 @@ func (*ContextProvider) getProjectIDsForBranchService(_ context.Context, req any
 }
 
 func (*ContextProvider) getProjectIDsForProjectService(_ context.Context, req any) ([]string, error) {
-	var projects, projectDeploymentConfigs, projectWebhooks, databaseGroups, schemaGroups, protectionRules []string
+	var projects, projectDeploymentConfigs, projectWebhooks, webhookDeliveries, databaseGroups, schemaGroups, protectionRules []string
 
 	switch r := req.(type) {
 	case *v1pb.GetProjectRequest:
@@ -365,6 +367,10 @@ func (*ContextProvider) getProjectIDsForProjectService(_ context.Context, req an
 		projectWebhooks = append(projectWebhooks, r.GetWebhook().GetName())
 	case *v1pb.TestWebhookRequest:
 		projects = append(projects, r.GetProject())
+	case *v1pb.ListWebhookDeliveriesRequest:
+		projectWebhooks = append(projectWebhooks, r.GetParent())
+	case *v1pb.RedeliverWebhookDeliveryRequest:
+		webhookDeliveries = append(webhookDeliveries, r.GetName())
 	case *v1pb.GetDatabaseGroupRequest:
 		databaseGroups = append(databaseGroups, r.GetName())
 	case *v1pb.CreateDatabaseGroupRequest:
@@ -410,6 +416,13 @@ func (*ContextProvider) getProjectIDsForProjectService(_ context.Context, req an
 		}
 		projectIDs = append(projectIDs, projectID)
 	}
+	for _, webhookDelivery := range webhookDeliveries {
+		projectID, _, _, err := common.GetProjectIDWebhookIDDeliveryID(webhookDelivery)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q", webhookDelivery)
+		}
+		projectIDs = append(projectIDs, projectID)
+	}
 	for _, databaseGroup := range databaseGroups {
 		projectID, _, err := common.GetProjectIDDatabaseGroupID(databaseGroup)
 		if err != nil {
@@ -84,6 +84,67 @@ func convertStoreDatabaseMetadata(metadata *storepb.DatabaseSchemaMetadata, conf
 			}
 			s.Procedures = append(s.Procedures, v1Procedure)
 		}
+		for _, trigger := range schema.Triggers {
+			if trigger == nil {
+				continue
+			}
+			v1Trigger := &v1pb.TriggerMetadata{
+				Name:       trigger.Name,
+				TableName:  trigger.TableName,
+				Event:      trigger.Event,
+				Timing:     trigger.Timing,
+				Definition: trigger.Definition,
+			}
+			s.Triggers = append(s.Triggers, v1Trigger)
+		}
+		for _, sequence := range schema.Sequences {
+			if sequence == nil {
+				continue
+			}
+			v1Sequence := &v1pb.SequenceMetadata{
+				Name:        sequence.Name,
+				DataType:    sequence.DataType,
+				Start:       sequence.Start,
+				MinValue:    sequence.MinValue,
+				MaxValue:    sequence.MaxValue,
+				Increment:   sequence.Increment,
+				Cycle:       sequence.Cycle,
+				CacheSize:   sequence.CacheSize,
+				OwnerTable:  sequence.OwnerTable,
+				OwnerColumn: sequence.OwnerColumn,
+			}
+			s.Sequences = append(s.Sequences, v1Sequence)
+		}
+		for _, enumType := range schema.EnumTypes {
+			if enumType == nil {
+				continue
+			}
+			v1EnumType := &v1pb.EnumTypeMetadata{
+				Name:    enumType.Name,
+				Values:  enumType.Values,
+				Comment: enumType.Comment,
+			}
+			s.EnumTypes = append(s.EnumTypes, v1EnumType)
+		}
+		for _, compositeType := range schema.CompositeTypes {
+			if compositeType == nil {
+				continue
+			}
+			v1CompositeType := &v1pb.CompositeTypeMetadata{
+				Name:    compositeType.Name,
+				Comment: compositeType.Comment,
+			}
+			for _, attribute := range compositeType.Attributes {
+				if attribute == nil {
+					continue
+				}
+				v1CompositeType.Attributes = append(v1CompositeType.Attributes, &v1pb.CompositeTypeAttribute{
+					Name: attribute.Name,
+					Type: attribute.Type,
+				})
+			}
+			s.CompositeTypes = append(s.CompositeTypes, v1CompositeType)
+		}
 		for _, task := range schema.Tasks {
 			if task == nil {
 				continue
@@ -197,6 +258,15 @@ func convertStoreTableMetadata(table *storepb.TableMetadata) *v1pb.TableMetadata
 			MatchType:         foreignKey.MatchType,
 		})
 	}
+	for _, checkConstraint := range table.CheckConstraints {
+		if checkConstraint == nil {
+			continue
+		}
+		t.CheckConstraints = append(t.CheckConstraints, &v1pb.CheckConstraintMetadata{
+			Name:       checkConstraint.Name,
+			Expression: checkConstraint.Expression,
+		})
+	}
 	return t
 }
 
@@ -413,6 +483,67 @@ func convertV1DatabaseMetadata(metadata *v1pb.DatabaseMetadata) (*storepb.Databa
 			}
 			s.Procedures = append(s.Procedures, storeProcedure)
 		}
+		for _, trigger := range schema.Triggers {
+			if trigger == nil {
+				continue
+			}
+			storeTrigger := &storepb.TriggerMetadata{
+				Name:       trigger.Name,
+				TableName:  trigger.TableName,
+				Event:      trigger.Event,
+				Timing:     trigger.Timing,
+				Definition: trigger.Definition,
+			}
+			s.Triggers = append(s.Triggers, storeTrigger)
+		}
+		for _, sequence := range schema.Sequences {
+			if sequence == nil {
+				continue
+			}
+			storeSequence := &storepb.SequenceMetadata{
+				Name:        sequence.Name,
+				DataType:    sequence.DataType,
+				Start:       sequence.Start,
+				MinValue:    sequence.MinValue,
+				MaxValue:    sequence.MaxValue,
+				Increment:   sequence.Increment,
+				Cycle:       sequence.Cycle,
+				CacheSize:   sequence.CacheSize,
+				OwnerTable:  sequence.OwnerTable,
+				OwnerColumn: sequence.OwnerColumn,
+			}
+			s.Sequences = append(s.Sequences, storeSequence)
+		}
+		for _, enumType := range schema.EnumTypes {
+			if enumType == nil {
+				continue
+			}
+			storeEnumType := &storepb.EnumTypeMetadata{
+				Name:    enumType.Name,
+				Values:  enumType.Values,
+				Comment: enumType.Comment,
+			}
+			s.EnumTypes = append(s.EnumTypes, storeEnumType)
+		}
+		for _, compositeType := range schema.CompositeTypes {
+			if compositeType == nil {
+				continue
+			}
+			storeCompositeType := &storepb.CompositeTypeMetadata{
+				Name:    compositeType.Name,
+				Comment: compositeType.Comment,
+			}
+			for _, attribute := range compositeType.Attributes {
+				if attribute == nil {
+					continue
+				}
+				storeCompositeType.Attributes = append(storeCompositeType.Attributes, &storepb.CompositeTypeAttribute{
+					Name: attribute.Name,
+					Type: attribute.Type,
+				})
+			}
+			s.CompositeTypes = append(s.CompositeTypes, storeCompositeType)
+		}
 		for _, task := range schema.Tasks {
 			if task == nil {
 				continue
@@ -526,6 +657,15 @@ func convertV1TableMetadata(table *v1pb.TableMetadata) *storepb.TableMetadata {
 		}
 		t.Partitions = append(t.Partitions, convertV1TablePartitionMetadata(partition))
 	}
+	for _, checkConstraint := range table.CheckConstraints {
+		if checkConstraint == nil {
+			continue
+		}
+		t.CheckConstraints = append(t.CheckConstraints, &storepb.CheckConstraintMetadata{
+			Name:       checkConstraint.Name,
+			Expression: checkConstraint.Expression,
+		})
+	}
 	return t
 }
 
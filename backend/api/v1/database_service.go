@@ -1169,6 +1169,10 @@ func (s *DatabaseService) getParserEngine(ctx context.Context, request *v1pb.Dif
 		engine = storepb.Engine_TIDB
 	case storepb.Engine_ORACLE, storepb.Engine_DM, storepb.Engine_OCEANBASE_ORACLE:
 		engine = storepb.Engine_ORACLE
+	case storepb.Engine_MSSQL:
+		engine = storepb.Engine_MSSQL
+	case storepb.Engine_SNOWFLAKE:
+		engine = storepb.Engine_SNOWFLAKE
 	default:
 		return engine, status.Errorf(codes.InvalidArgument, fmt.Sprintf("invalid engine type %v", instance.Engine))
 	}
@@ -405,7 +405,7 @@ func (s *InstanceService) syncSlowQueriesForInstance(ctx context.Context, instan
 
 func (s *InstanceService) syncSlowQueriesImpl(ctx context.Context, project *store.ProjectMessage, instance *store.InstanceMessage) error {
 	switch instance.Engine {
-	case storepb.Engine_MYSQL:
+	case storepb.Engine_MYSQL, storepb.Engine_ORACLE, storepb.Engine_MSSQL, storepb.Engine_SNOWFLAKE:
 		driver, err := s.dbFactory.GetAdminDatabaseDriver(ctx, instance, nil /* database */, db.ConnectionContext{})
 		if err != nil {
 			return err
@@ -499,7 +499,7 @@ func (s *InstanceService) syncSlowQueriesForProject(ctx context.Context, project
 		}
 
 		switch instance.Engine {
-		case storepb.Engine_MYSQL, storepb.Engine_POSTGRES:
+		case storepb.Engine_MYSQL, storepb.Engine_POSTGRES, storepb.Engine_ORACLE, storepb.Engine_MSSQL, storepb.Engine_SNOWFLAKE:
 			if instance.Deleted {
 				continue
 			}
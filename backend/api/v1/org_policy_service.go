@@ -1111,6 +1111,16 @@ func validateSQLReviewPolicy(policy *v1pb.SQLReviewPolicy) error {
 			if _, err := advisor.UnmarshalNamingCaseRulePayload(rule.Payload); err != nil {
 				return err
 			}
+		case advisor.SchemaRuleStatementCustomCEL:
+			payload, err := advisor.UnmarshalCustomCELRulePayload(rule.Payload)
+			if err != nil {
+				return err
+			}
+			for _, customRule := range payload.Rules {
+				if _, _, err := common.ValidateSQLReviewCustomRuleCELExpr(customRule.Expression); err != nil {
+					return errors.Wrapf(err, "invalid custom CEL rule %q", customRule.Name)
+				}
+			}
 		}
 	}
 	return nil
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"reflect"
@@ -14,7 +15,9 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
@@ -583,10 +586,25 @@ func (s *ProjectService) UpdateWebhook(ctx context.Context, request *v1pb.Update
 				return nil, status.Errorf(codes.InvalidArgument, "notification types should not be empty")
 			}
 			update.ActivityList = types
+		case "signing_secret":
+			if update.Payload == nil {
+				update.Payload = proto.Clone(webhook.Payload).(*storepb.ProjectWebhookPayload)
+			}
+			update.Payload.SigningSecret = request.Webhook.SigningSecret
+		case "body_template":
+			if update.Payload == nil {
+				update.Payload = proto.Clone(webhook.Payload).(*storepb.ProjectWebhookPayload)
+			}
+			update.Payload.BodyTemplate = request.Webhook.BodyTemplate
 		default:
 			return nil, status.Errorf(codes.InvalidArgument, "invalid field %q", path)
 		}
 	}
+	if update.Payload != nil {
+		if err := validateProjectWebhookPayload(webhook.Type, update.Payload); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
 
 	principalID, ok := ctx.Value(common.PrincipalIDContextKey).(int)
 	if !ok {
@@ -684,6 +702,18 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
+	// The signing secret is never returned, so we test the existing webhook with the stored one unless a new one is given.
+	if request.Webhook.Name != "" && webhook.Payload.SigningSecret == "" {
+		_, webhookID, err := common.GetProjectIDWebhookID(request.Webhook.Name)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		existing, err := s.getProjectWebhook(ctx, projectID, webhookID)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Payload.SigningSecret = existing.Payload.GetSigningSecret()
+	}
 
 	resp := &v1pb.TestWebhookResponse{}
 	err = webhookplugin.Post(
@@ -707,7 +737,9 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 				Type:        "bb.issue.database.create",
 				Description: "This is a test issue",
 			},
-			Project: &webhookplugin.Project{Name: project.Title},
+			Project:       &webhookplugin.Project{Name: project.Title},
+			SigningSecret: webhook.Payload.SigningSecret,
+			BodyTemplate:  webhook.Payload.BodyTemplate,
 		},
 	)
 	if err != nil {
@@ -717,6 +749,140 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 	return resp, nil
 }
 
+// ListWebhookDeliveries lists the deliveries of the webhook.
+func (s *ProjectService) ListWebhookDeliveries(ctx context.Context, request *v1pb.ListWebhookDeliveriesRequest) (*v1pb.ListWebhookDeliveriesResponse, error) {
+	if request.PageSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("page size must be non-negative: %d", request.PageSize))
+	}
+	projectID, webhookID, err := common.GetProjectIDWebhookID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	webhook, err := s.getProjectWebhook(ctx, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := parseLimitAndOffset(request.PageToken, int(request.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	limitPlusOne := limit + 1
+
+	deliveries, err := s.store.ListProjectWebhookDeliveries(ctx, &store.FindProjectWebhookDeliveryMessage{
+		ProjectWebhookUID: &webhook.ID,
+		Limit:             &limitPlusOne,
+		Offset:            &offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook deliveries, err: %v", err)
+	}
+	var nextPageToken string
+	if len(deliveries) == limitPlusOne {
+		pageToken, err := getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+		nextPageToken = pageToken
+		deliveries = deliveries[:limit]
+	}
+
+	response := &v1pb.ListWebhookDeliveriesResponse{
+		NextPageToken: nextPageToken,
+	}
+	for _, delivery := range deliveries {
+		v1Delivery, err := convertToWebhookDelivery(request.Parent, delivery)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, err.Error())
+		}
+		response.Deliveries = append(response.Deliveries, v1Delivery)
+	}
+	return response, nil
+}
+
+// RedeliverWebhookDelivery resets the delivery to be posted again by the webhook delivery runner.
+func (s *ProjectService) RedeliverWebhookDelivery(ctx context.Context, request *v1pb.RedeliverWebhookDeliveryRequest) (*v1pb.WebhookDelivery, error) {
+	projectID, webhookID, deliveryID, err := common.GetProjectIDWebhookIDDeliveryID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	deliveryUID, err := strconv.Atoi(deliveryID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid delivery id %q", deliveryID)
+	}
+	webhook, err := s.getProjectWebhook(ctx, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.store.GetProjectWebhookDelivery(ctx, &store.FindProjectWebhookDeliveryMessage{
+		UID:               &deliveryUID,
+		ProjectWebhookUID: &webhook.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if delivery == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook delivery %q not found", request.Name)
+	}
+	if delivery.Status == store.WebhookDeliveryPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "webhook delivery %q is pending", request.Name)
+	}
+
+	// The attempt count is reset so that the redelivery gets the full retries, while the attempt history is kept.
+	pending := store.WebhookDeliveryPending
+	attempt := 0
+	nextAttemptTime := time.Now()
+	if err := s.store.UpdateProjectWebhookDelivery(ctx, &store.UpdateProjectWebhookDeliveryMessage{
+		UID:             delivery.UID,
+		Status:          &pending,
+		Attempt:         &attempt,
+		NextAttemptTime: &nextAttemptTime,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update webhook delivery, err: %v", err)
+	}
+	delivery.Status = pending
+	delivery.Attempt = attempt
+	delivery.NextAttemptTime = nextAttemptTime
+
+	parent := fmt.Sprintf("%s%s/%s%d", common.ProjectNamePrefix, projectID, common.WebhookIDPrefix, webhook.ID)
+	v1Delivery, err := convertToWebhookDelivery(parent, delivery)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return v1Delivery, nil
+}
+
+func (s *ProjectService) getProjectWebhook(ctx context.Context, projectID, webhookID string) (*store.ProjectWebhookMessage, error) {
+	webhookIDInt, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook id %q", webhookID)
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{
+		ResourceID: &projectID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, "project %q not found", projectID)
+	}
+	if project.Deleted {
+		return nil, status.Errorf(codes.NotFound, "project %q has been deleted", projectID)
+	}
+	webhook, err := s.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
+		ProjectID: &project.UID,
+		ID:        &webhookIDInt,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if webhook == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook %q not found", webhookID)
+	}
+	return webhook, nil
+}
+
 // CreateDatabaseGroup creates a database group.
 func (s *ProjectService) CreateDatabaseGroup(ctx context.Context, request *v1pb.CreateDatabaseGroupRequest) (*v1pb.DatabaseGroup, error) {
 	if err := s.licenseService.IsFeatureEnabled(api.FeatureDatabaseGrouping); err != nil {
@@ -1486,14 +1652,33 @@ func convertToStoreProjectWebhookMessage(webhook *v1pb.Webhook) (*store.ProjectW
 	if err != nil {
 		return nil, err
 	}
+	payload := &storepb.ProjectWebhookPayload{
+		SigningSecret: webhook.SigningSecret,
+		BodyTemplate:  webhook.BodyTemplate,
+	}
+	if err := validateProjectWebhookPayload(tp, payload); err != nil {
+		return nil, err
+	}
 	return &store.ProjectWebhookMessage{
 		Type:         tp,
 		URL:          webhook.Url,
 		Title:        webhook.Title,
 		ActivityList: activityTypes,
+		Payload:      payload,
 	}, nil
 }
 
+// validateProjectWebhookPayload validates the signing secret and body template, which are only supported by the custom webhook.
+func validateProjectWebhookPayload(tp string, payload *storepb.ProjectWebhookPayload) error {
+	if tp != customWebhookType && (payload.SigningSecret != "" || payload.BodyTemplate != "") {
+		return common.Errorf(common.Invalid, "signing secret and body template are only supported by the custom webhook")
+	}
+	if err := webhookplugin.ValidateBodyTemplate(payload.BodyTemplate); err != nil {
+		return common.Errorf(common.Invalid, "invalid body template: %v", err)
+	}
+	return nil
+}
+
 func convertToActivityTypeStrings(types []v1pb.Activity_Type) ([]string, error) {
 	var result []string
 	for _, tp := range types {
@@ -1602,6 +1787,42 @@ func convertNotificationTypeStrings(types []string) []v1pb.Activity_Type {
 	return result
 }
 
+func convertToWebhookDelivery(parent string, delivery *store.ProjectWebhookDeliveryMessage) (*v1pb.WebhookDelivery, error) {
+	var event webhookplugin.Context
+	if err := json.Unmarshal(delivery.Event, &event); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal event of webhook delivery %d", delivery.UID)
+	}
+	v1Delivery := &v1pb.WebhookDelivery{
+		Name:       fmt.Sprintf("%s/%s%d", parent, common.WebhookDeliveryIDPrefix, delivery.UID),
+		Title:      event.Title,
+		CreateTime: timestamppb.New(delivery.CreatedTime),
+	}
+	if types := convertNotificationTypeStrings([]string{event.ActivityType}); len(types) > 0 {
+		v1Delivery.ActivityType = types[0]
+	}
+	switch delivery.Status {
+	case store.WebhookDeliveryPending:
+		v1Delivery.Status = v1pb.WebhookDelivery_PENDING
+		v1Delivery.NextAttemptTime = timestamppb.New(delivery.NextAttemptTime)
+	case store.WebhookDeliverySucceeded:
+		v1Delivery.Status = v1pb.WebhookDelivery_SUCCEEDED
+	case store.WebhookDeliveryFailed:
+		v1Delivery.Status = v1pb.WebhookDelivery_FAILED
+	}
+	for _, attempt := range delivery.Payload.GetAttempts() {
+		v1Delivery.Attempts = append(v1Delivery.Attempts, &v1pb.WebhookDelivery_Attempt{
+			Time:         attempt.Time,
+			StatusCode:   attempt.StatusCode,
+			Latency:      attempt.Latency,
+			ResponseBody: attempt.ResponseBody,
+			Error:        attempt.Error,
+		})
+	}
+	return v1Delivery, nil
+}
+
+const customWebhookType = "bb.plugin.webhook.custom"
+
 func convertToAPIWebhookTypeString(tp v1pb.Webhook_Type) (string, error) {
 	switch tp {
 	case v1pb.Webhook_TYPE_UNSPECIFIED:
@@ -1620,7 +1841,7 @@ func convertToAPIWebhookTypeString(tp v1pb.Webhook_Type) (string, error) {
 	case v1pb.Webhook_TYPE_WECOM:
 		return "bb.plugin.webhook.wecom", nil
 	case v1pb.Webhook_TYPE_CUSTOM:
-		return "bb.plugin.webhook.custom", nil
+		return customWebhookType, nil
 	default:
 		return "", common.Errorf(common.Invalid, "webhook type %q is not supported", tp)
 	}
@@ -1640,7 +1861,7 @@ func convertWebhookTypeString(tp string) v1pb.Webhook_Type {
 		return v1pb.Webhook_TYPE_FEISHU
 	case "bb.plugin.webhook.wecom":
 		return v1pb.Webhook_TYPE_WECOM
-	case "bb.plugin.webhook.custom":
+	case customWebhookType:
 		return v1pb.Webhook_TYPE_CUSTOM
 	default:
 		return v1pb.Webhook_TYPE_UNSPECIFIED
@@ -1823,6 +2044,8 @@ func convertToProject(projectMessage *store.ProjectMessage) *v1pb.Project {
 			Title:             webhook.Title,
 			Url:               webhook.URL,
 			NotificationTypes: convertNotificationTypeStrings(webhook.ActivityList),
+			HasSigningSecret:  webhook.Payload.GetSigningSecret() != "",
+			BodyTemplate:      webhook.Payload.GetBodyTemplate(),
 		})
 	}
 
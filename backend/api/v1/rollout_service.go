@@ -21,7 +21,6 @@ import (
 	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
-	"github.com/bytebase/bytebase/backend/component/ghost"
 	"github.com/bytebase/bytebase/backend/component/iam"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
@@ -403,6 +402,70 @@ func (s *RolloutService) RunPlanChecks(ctx context.Context, request *v1pb.RunPla
 	return &v1pb.RunPlanChecksResponse{}, nil
 }
 
+// ReplacePlanSteps replaces the steps of the plan that has not been rolled out yet, and reruns the plan checks.
+// GitOps uses it to reconcile the plan with the latest changes of the pull request.
+func (s *RolloutService) ReplacePlanSteps(ctx context.Context, plan *store.PlanMessage, steps []*v1pb.Plan_Step, updaterID int) error {
+	if plan.PipelineUID != nil {
+		return errors.Errorf("plan %d has been rolled out", plan.UID)
+	}
+	if err := validateSteps(steps); err != nil {
+		return errors.Wrapf(err, "failed to validate plan steps")
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &plan.ProjectID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get project %q", plan.ProjectID)
+	}
+	if project == nil {
+		return errors.Errorf("project %q not found", plan.ProjectID)
+	}
+	config := &storepb.PlanConfig{
+		Steps:     convertPlanSteps(steps),
+		VcsSource: plan.Config.GetVcsSource(),
+	}
+	if _, err := GetPipelineCreate(ctx, s.store, s.licenseService, s.dbFactory, config.Steps, project); err != nil {
+		return errors.Wrapf(err, "failed to get pipeline from the plan")
+	}
+	if err := s.store.UpdatePlan(ctx, &store.UpdatePlanMessage{
+		UID:       plan.UID,
+		UpdaterID: updaterID,
+		Config:    config,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to update plan %d", plan.UID)
+	}
+	plan.Config = config
+
+	planCheckRuns, err := getPlanCheckRunsFromPlan(ctx, s.store, plan)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get plan check runs for plan")
+	}
+	if err := s.store.CreatePlanCheckRuns(ctx, planCheckRuns...); err != nil {
+		return errors.Wrapf(err, "failed to create plan check runs")
+	}
+
+	// Tickle plan check scheduler.
+	s.stateCfg.PlanCheckTickleChan <- 0
+
+	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PlanUID: &plan.UID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get issue for plan %d", plan.UID)
+	}
+	if issue != nil {
+		// The statements are changed, find the approval again.
+		updatedIssue, err := s.store.UpdateIssueV2(ctx, issue.UID, &store.UpdateIssueMessage{
+			PayloadUpsert: &storepb.IssuePayload{
+				Approval: &storepb.IssuePayloadApproval{
+					ApprovalFindingDone: false,
+				},
+			},
+		}, api.SystemBotID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update issue %d", issue.UID)
+		}
+		s.stateCfg.ApprovalFinding.Store(updatedIssue.UID, updatedIssue)
+	}
+	return nil
+}
+
 // ListTaskRuns lists rollout task runs.
 func (s *RolloutService) ListTaskRuns(ctx context.Context, request *v1pb.ListTaskRunsRequest) (*v1pb.ListTaskRunsResponse, error) {
 	projectID, rolloutID, maybeStageID, maybeTaskID, err := common.GetProjectIDRolloutIDMaybeStageIDMaybeTaskID(request.Parent)
@@ -959,7 +1022,14 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 					return status.Errorf(codes.Internal, "failed to unmarshal task payload: %v", err)
 				}
 				newFlags := spec.GetChangeDatabaseConfig().GetGhostFlags()
-				if _, err := ghost.GetUserFlags(newFlags); err != nil {
+				instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+				if err != nil {
+					return status.Errorf(codes.Internal, "failed to get instance %d: %v", task.InstanceID, err)
+				}
+				if instance == nil {
+					return status.Errorf(codes.NotFound, "instance %d not found", task.InstanceID)
+				}
+				if err := validateGhostFlags(instance.Engine, newFlags); err != nil {
 					return status.Errorf(codes.InvalidArgument, "invalid ghost flags %q, error %v", newFlags, err)
 				}
 				oldFlags := payload.Flags
@@ -1251,7 +1321,8 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 		UID:       oldPlan.UID,
 		UpdaterID: user.ID,
 		Config: &storepb.PlanConfig{
-			Steps: convertPlanSteps(request.Plan.Steps),
+			Steps:     convertPlanSteps(request.Plan.Steps),
+			VcsSource: oldPlan.Config.GetVcsSource(),
 		},
 	}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update plan %q: %v", request.Plan.Name, err)
@@ -15,6 +15,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/ghost"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
 	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
@@ -576,7 +577,7 @@ func getTaskCreatesFromChangeDatabaseConfigDatabaseTarget(ctx context.Context, s
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "failed to get sheet id from sheet %q", c.Sheet)
 		}
-		if _, err := ghost.GetUserFlags(c.GhostFlags); err != nil {
+		if err := validateGhostFlags(instance.Engine, c.GhostFlags); err != nil {
 			return nil, nil, errors.Wrapf(err, "invalid ghost flags %q, error: %v", c.GhostFlags, err)
 		}
 		var taskCreateList []*store.TaskMessage
@@ -876,3 +877,13 @@ func getOrDefaultSchemaVersionWithSuffix(v string, suffix string) string {
 	}
 	return common.DefaultMigrationVersion().Version + suffix
 }
+
+// validateGhostFlags validates the flags of the online migration, which is run by pgosc for PostgreSQL and gh-ost for the others.
+func validateGhostFlags(engine storepb.Engine, flags map[string]string) error {
+	if engine == storepb.Engine_POSTGRES {
+		_, err := pgosc.GetUserFlags(flags)
+		return err
+	}
+	_, err := ghost.GetUserFlags(flags)
+	return err
+}
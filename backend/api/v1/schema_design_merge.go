@@ -106,7 +106,9 @@ type metadataDiffSchemaNode struct {
 	functions  map[string]*metadataDiffFunctioNnode
 	procedures map[string]*metadataDiffProcedureNode
 
-	// SchemaMetadata contains other object types, likes function, view etc. But we do not support them yet.
+	sequences      map[string]*metadataDiffObjectNode[*storepb.SequenceMetadata]
+	enumTypes      map[string]*metadataDiffObjectNode[*storepb.EnumTypeMetadata]
+	compositeTypes map[string]*metadataDiffObjectNode[*storepb.CompositeTypeMetadata]
 }
 
 func (n *metadataDiffSchemaNode) tryMerge(other *metadataDiffSchemaNode) (bool, string) {
@@ -195,6 +197,20 @@ func (n *metadataDiffSchemaNode) tryMerge(other *metadataDiffSchemaNode) (bool,
 		delete(other.procedures, procedureName)
 	}
 
+	for _, remainingProcedure := range other.procedures {
+		n.procedures[remainingProcedure.name] = remainingProcedure
+	}
+
+	if conflict, msg := tryMergeObjectNodes(n.sequences, other.sequences); conflict {
+		return true, msg
+	}
+	if conflict, msg := tryMergeObjectNodes(n.enumTypes, other.enumTypes); conflict {
+		return true, msg
+	}
+	if conflict, msg := tryMergeObjectNodes(n.compositeTypes, other.compositeTypes); conflict {
+		return true, msg
+	}
+
 	return false, ""
 }
 
@@ -253,6 +269,9 @@ func (n *metadataDiffSchemaNode) applyDiffTo(target *storepb.DatabaseSchemaMetad
 				return errors.Wrapf(err, "failed to apply diff to procedure %q", procedure.name)
 			}
 		}
+		newSchema.Sequences = applyObjectNodes(n.sequences, newSchema.Sequences)
+		newSchema.EnumTypes = applyObjectNodes(n.enumTypes, newSchema.EnumTypes)
+		newSchema.CompositeTypes = applyObjectNodes(n.compositeTypes, newSchema.CompositeTypes)
 		target.Schemas = append(target.Schemas, newSchema)
 	case diffActionDrop:
 		for i, schema := range target.Schemas {
@@ -265,11 +284,15 @@ func (n *metadataDiffSchemaNode) applyDiffTo(target *storepb.DatabaseSchemaMetad
 		for idx, schema := range target.Schemas {
 			if schema.Name == n.name {
 				newSchema := &storepb.SchemaMetadata{
-					Name:       n.name,
-					Tables:     schema.Tables,
-					Views:      schema.Views,
-					Functions:  schema.Functions,
-					Procedures: schema.Procedures,
+					Name:           n.name,
+					Tables:         schema.Tables,
+					Views:          schema.Views,
+					Functions:      schema.Functions,
+					Procedures:     schema.Procedures,
+					Triggers:       schema.Triggers,
+					Sequences:      schema.Sequences,
+					EnumTypes:      schema.EnumTypes,
+					CompositeTypes: schema.CompositeTypes,
 				}
 				for _, tableName := range sortedTableNames {
 					table := n.tables[tableName]
@@ -295,6 +318,9 @@ func (n *metadataDiffSchemaNode) applyDiffTo(target *storepb.DatabaseSchemaMetad
 						return errors.Wrapf(err, "failed to apply diff to procedure %q", procedure.name)
 					}
 				}
+				newSchema.Sequences = applyObjectNodes(n.sequences, newSchema.Sequences)
+				newSchema.EnumTypes = applyObjectNodes(n.enumTypes, newSchema.EnumTypes)
+				newSchema.CompositeTypes = applyObjectNodes(n.compositeTypes, newSchema.CompositeTypes)
 				target.Schemas[idx] = newSchema
 			}
 		}
@@ -318,7 +344,9 @@ type metadataDiffTableNode struct {
 
 	foreignKeys map[string]*metadataDiffForeignKeyNode
 	indexes     map[string]*metadataDiffIndexNode
-	// TableMetaData contains other object types, likes trigger, index etc. But we do not support them yet.
+	// checkConstraints are compared as a whole.
+	checkConstraints map[string]*metadataDiffObjectNode[*storepb.CheckConstraintMetadata]
+	// TableMetaData contains other object types, likes trigger etc. But we do not support them yet.
 
 	// partitionNames is designed to help to handle the partition orders.
 	// The size of partitionNames is always equal to the size of partitionsMap,
@@ -469,6 +497,10 @@ func (n *metadataDiffTableNode) tryMerge(other *metadataDiffTableNode) (bool, st
 		n.indexes[remainingIndex.name] = remainingIndex
 	}
 
+	if conflict, msg := tryMergeObjectNodes(n.checkConstraints, other.checkConstraints); conflict {
+		return true, msg
+	}
+
 	return false, ""
 }
 
@@ -525,6 +557,7 @@ func (n *metadataDiffTableNode) applyDiffTo(target *storepb.SchemaMetadata) erro
 				return errors.Wrapf(err, "failed to apply diff to index %q", index.name)
 			}
 		}
+		newTable.CheckConstraints = applyObjectNodes(n.checkConstraints, newTable.CheckConstraints)
 
 		for _, partitionName := range n.partitionNames {
 			partition := n.partitionsMap[partitionName]
@@ -543,19 +576,20 @@ func (n *metadataDiffTableNode) applyDiffTo(target *storepb.SchemaMetadata) erro
 		}
 	case diffActionUpdate:
 		for idx, table := range target.Tables {
-			// Update table currently is only contains diff of columns, foreign keys and indexes.
-			// So we do apply column and foreign key diff to target table.
+			// Update table currently is only contains diff of columns, foreign keys, indexes and check constraints.
+			// So we do apply them to target table.
 			if table.Name == n.name {
 				newTable := &storepb.TableMetadata{
-					Name:           n.name,
-					Engine:         n.head.Engine,
-					Collation:      n.head.Collation,
-					Comment:        n.head.Comment,
-					UserComment:    n.head.UserComment,
-					Classification: n.head.Classification,
-					Columns:        table.Columns,
-					ForeignKeys:    table.ForeignKeys,
-					Indexes:        table.Indexes,
+					Name:             n.name,
+					Engine:           n.head.Engine,
+					Collation:        n.head.Collation,
+					Comment:          n.head.Comment,
+					UserComment:      n.head.UserComment,
+					Classification:   n.head.Classification,
+					Columns:          table.Columns,
+					ForeignKeys:      table.ForeignKeys,
+					Indexes:          table.Indexes,
+					CheckConstraints: table.CheckConstraints,
 				}
 				for _, columnName := range n.columnNames {
 					if columnNode, in := n.columnsMap[columnName]; in {
@@ -576,6 +610,7 @@ func (n *metadataDiffTableNode) applyDiffTo(target *storepb.SchemaMetadata) erro
 						return errors.Wrapf(err, "failed to apply diff to index %q", index.name)
 					}
 				}
+				newTable.CheckConstraints = applyObjectNodes(n.checkConstraints, newTable.CheckConstraints)
 				// XXX(zp): We need to find a better way to solve the problem of column position.
 				// We need to sort the columns by position after applying the diff.
 				for idx := range newTable.Columns {
@@ -1335,6 +1370,9 @@ func diffSchemaMetadata(base, head *storepb.SchemaMetadata) (*metadataDiffSchema
 		functions:  make(map[string]*metadataDiffFunctioNnode),
 		procedures: make(map[string]*metadataDiffProcedureNode),
 	}
+	schemaNode.sequences = diffObjectMetadata("sequence", base.GetSequences(), head.GetSequences())
+	schemaNode.enumTypes = diffObjectMetadata("enum type", base.GetEnumTypes(), head.GetEnumTypes())
+	schemaNode.compositeTypes = diffObjectMetadata("composite type", base.GetCompositeTypes(), head.GetCompositeTypes())
 
 	tableNamesMap := make(map[string]bool)
 
@@ -1450,7 +1488,8 @@ func diffSchemaMetadata(base, head *storepb.SchemaMetadata) (*metadataDiffSchema
 	}
 
 	if action == diffActionUpdate {
-		if len(schemaNode.tables) == 0 && len(schemaNode.views) == 0 && len(schemaNode.functions) == 0 && len(schemaNode.procedures) == 0 {
+		if len(schemaNode.tables) == 0 && len(schemaNode.views) == 0 && len(schemaNode.functions) == 0 && len(schemaNode.procedures) == 0 &&
+			len(schemaNode.sequences) == 0 && len(schemaNode.enumTypes) == 0 && len(schemaNode.compositeTypes) == 0 {
 			return nil, nil
 		}
 	}
@@ -1592,6 +1631,7 @@ func diffTableMetadata(base, head *storepb.TableMetadata) (*metadataDiffTableNod
 		indexes:       make(map[string]*metadataDiffIndexNode),
 		partitionsMap: make(map[string]*metadataDiffPartitionNode),
 	}
+	tableNode.checkConstraints = diffObjectMetadata("check constraint", base.GetCheckConstraints(), head.GetCheckConstraints())
 
 	columnNamesMap := make(map[string]bool)
 	var columnNameSlice []string
@@ -1741,7 +1781,7 @@ func diffTableMetadata(base, head *storepb.TableMetadata) (*metadataDiffTableNod
 	}
 
 	if action == diffActionUpdate {
-		if len(tableNode.columnsMap) == 0 && len(tableNode.foreignKeys) == 0 && len(tableNode.indexes) == 0 {
+		if len(tableNode.columnsMap) == 0 && len(tableNode.foreignKeys) == 0 && len(tableNode.indexes) == 0 && len(tableNode.checkConstraints) == 0 {
 			return nil, nil
 		}
 	}
@@ -1931,3 +1971,137 @@ func diffPartitionMetadata(base, head *storepb.TablePartitionMetadata) (*metadat
 
 	return partitionNode, nil
 }
+
+// Schema object related.
+
+// namedMetadata is the metadata of the objects compared as a whole, such as sequences, types and check constraints.
+type namedMetadata interface {
+	proto.Message
+	GetName() string
+}
+
+type metadataDiffObjectNode[T namedMetadata] struct {
+	metadataDiffBaseNode
+	// kind is the object kind used in the conflict messages, such as "sequence".
+	kind string
+	name string
+	//nolint
+	base T
+	head T
+}
+
+func (n *metadataDiffObjectNode[T]) tryMerge(other *metadataDiffObjectNode[T]) (bool, string) {
+	if other == nil {
+		return true, fmt.Sprintf("other node check conflict with %s node must not be nil", n.kind)
+	}
+
+	if n.name != other.name {
+		return true, fmt.Sprintf("non-expected %s node pair, one is %s, the other is %s", n.kind, n.name, other.name)
+	}
+	if n.action != other.action {
+		return true, fmt.Sprintf("conflict %s action, one is %s, the other is %s", n.kind, n.action, other.action)
+	}
+	if n.action == diffActionDrop {
+		return false, ""
+	}
+	if n.action == diffActionCreate {
+		if !proto.Equal(n.head, other.head) {
+			return true, fmt.Sprintf("conflict %s %s, both sides create it differently", n.kind, n.name)
+		}
+	}
+
+	if n.action == diffActionUpdate {
+		if !proto.Equal(other.base, other.head) {
+			if !proto.Equal(n.base, n.head) {
+				if !proto.Equal(n.head, other.head) {
+					return true, fmt.Sprintf("conflict %s %s, both sides update it differently", n.kind, n.name)
+				}
+			} else {
+				n.head = other.head
+			}
+		}
+	}
+
+	return false, ""
+}
+
+func (n *metadataDiffObjectNode[T]) applyDiffTo(target []T) []T {
+	switch n.action {
+	case diffActionCreate:
+		return append(target, n.head)
+	case diffActionDrop:
+		for i, object := range target {
+			if object.GetName() == n.name {
+				return append(target[:i], target[i+1:]...)
+			}
+		}
+	case diffActionUpdate:
+		for i, object := range target {
+			if object.GetName() == n.name {
+				target[i] = n.head
+			}
+		}
+	}
+	return target
+}
+
+// diffObjectMetadata diffs the objects by name, the unchanged objects are omitted.
+func diffObjectMetadata[T namedMetadata](kind string, base, head []T) map[string]*metadataDiffObjectNode[T] {
+	baseMap := make(map[string]T)
+	for _, object := range base {
+		baseMap[object.GetName()] = object
+	}
+	headMap := make(map[string]T)
+	for _, object := range head {
+		headMap[object.GetName()] = object
+	}
+
+	nodes := make(map[string]*metadataDiffObjectNode[T])
+	for name, baseObject := range baseMap {
+		headObject, in := headMap[name]
+		if !in {
+			nodes[name] = &metadataDiffObjectNode[T]{metadataDiffBaseNode: metadataDiffBaseNode{action: diffActionDrop}, kind: kind, name: name, base: baseObject}
+			continue
+		}
+		if !proto.Equal(baseObject, headObject) {
+			nodes[name] = &metadataDiffObjectNode[T]{metadataDiffBaseNode: metadataDiffBaseNode{action: diffActionUpdate}, kind: kind, name: name, base: baseObject, head: headObject}
+		}
+	}
+	for name, headObject := range headMap {
+		if _, in := baseMap[name]; !in {
+			nodes[name] = &metadataDiffObjectNode[T]{metadataDiffBaseNode: metadataDiffBaseNode{action: diffActionCreate}, kind: kind, name: name, head: headObject}
+		}
+	}
+	return nodes
+}
+
+// tryMergeObjectNodes merges other object nodes to nodes, stop and return error if conflict occurs.
+func tryMergeObjectNodes[T namedMetadata](nodes, others map[string]*metadataDiffObjectNode[T]) (bool, string) {
+	for name, node := range nodes {
+		otherNode, in := others[name]
+		if !in {
+			continue
+		}
+		if conflict, msg := node.tryMerge(otherNode); conflict {
+			return true, msg
+		}
+		delete(others, name)
+	}
+	for name, remaining := range others {
+		nodes[name] = remaining
+	}
+	return false, ""
+}
+
+// applyObjectNodes applies the object nodes to target in the name order.
+func applyObjectNodes[T namedMetadata](nodes map[string]*metadataDiffObjectNode[T], target []T) []T {
+	sortedNames := make([]string, 0, len(nodes))
+	for name := range nodes {
+		sortedNames = append(sortedNames, name)
+	}
+	slices.Sort(sortedNames)
+	for _, name := range sortedNames {
+		target = nodes[name].applyDiffTo(target)
+	}
+	return target
+}
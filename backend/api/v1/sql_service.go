@@ -1379,11 +1379,30 @@ func convertAdviceList(list []advisor.Advice) []*v1pb.Advice {
 			Line:    int32(advice.Line),
 			Column:  int32(advice.Column),
 			Detail:  advice.Details,
+			Fix:     convertAdviceFix(advice.Fix),
 		})
 	}
 	return result
 }
 
+func convertAdviceFix(fix *advisor.Fix) *v1pb.Advice_Fix {
+	if fix == nil {
+		return nil
+	}
+	return &v1pb.Advice_Fix{
+		Title: fix.Title,
+		Start: &v1pb.Advice_Position{
+			Line:   int32(fix.StartLine),
+			Column: int32(fix.StartColumn),
+		},
+		End: &v1pb.Advice_Position{
+			Line:   int32(fix.EndLine),
+			Column: int32(fix.EndColumn),
+		},
+		NewText: fix.NewText,
+	}
+}
+
 func convertAdviceStatus(status advisor.Status) v1pb.Advice_Status {
 	switch status {
 	case advisor.Success:
@@ -19,6 +19,7 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/vcs"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/azure"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/bitbucket"
+	"github.com/bytebase/bytebase/backend/plugin/vcs/gitea"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/github"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/gitlab"
 	"github.com/bytebase/bytebase/backend/store"
@@ -108,6 +109,9 @@ func (s *VCSConnectorService) CreateVCSConnector(ctx context.Context, request *v
 	if !strings.HasPrefix(baseDirectory, "/") {
 		return nil, status.Errorf(codes.InvalidArgument, `base directory should start with "/"`)
 	}
+	if err := validateFilePathTemplate(request.GetVcsConnector().FilePathTemplate, request.GetVcsConnector().DatabaseGroup, storepb.VCSConnector_Mode(request.GetVcsConnector().Mode)); err != nil {
+		return nil, err
+	}
 
 	workspaceID, err := s.store.GetWorkspaceID(ctx)
 	if err != nil {
@@ -132,6 +136,8 @@ func (s *VCSConnectorService) CreateVCSConnector(ctx context.Context, request *v
 			ExternalId:         request.GetVcsConnector().ExternalId,
 			WebhookSecretToken: secretToken,
 			DatabaseGroup:      request.GetVcsConnector().DatabaseGroup,
+			FilePathTemplate:   request.GetVcsConnector().FilePathTemplate,
+			Mode:               storepb.VCSConnector_Mode(request.GetVcsConnector().Mode),
 		},
 	}
 
@@ -288,6 +294,27 @@ func (s *VCSConnectorService) UpdateVCSConnector(ctx context.Context, request *v
 			update.BaseDirectory = &baseDir
 		case "database_group":
 			update.DatabaseGroup = &request.GetVcsConnector().DatabaseGroup
+		case "file_path_template":
+			update.FilePathTemplate = &request.GetVcsConnector().FilePathTemplate
+		case "mode":
+			mode := storepb.VCSConnector_Mode(request.GetVcsConnector().Mode)
+			update.Mode = &mode
+		}
+	}
+
+	if update.DatabaseGroup != nil || update.FilePathTemplate != nil || update.Mode != nil {
+		filePathTemplate, databaseGroup, mode := vcsConnector.Payload.FilePathTemplate, vcsConnector.Payload.DatabaseGroup, vcsConnector.Payload.Mode
+		if v := update.FilePathTemplate; v != nil {
+			filePathTemplate = *v
+		}
+		if v := update.DatabaseGroup; v != nil {
+			databaseGroup = *v
+		}
+		if v := update.Mode; v != nil {
+			mode = *v
+		}
+		if err := validateFilePathTemplate(filePathTemplate, databaseGroup, mode); err != nil {
+			return nil, err
 		}
 	}
 
@@ -386,23 +413,54 @@ func convertStoreVCSConnector(ctx context.Context, stores *store.Store, vcsConne
 	}
 
 	v1VCSConnector := &v1pb.VCSConnector{
-		Name:          fmt.Sprintf("%s%s/%s%s", common.ProjectNamePrefix, vcsConnector.ProjectID, common.VCSConnectorPrefix, vcsConnector.ResourceID),
-		CreateTime:    timestamppb.New(vcsConnector.CreatedTime),
-		UpdateTime:    timestamppb.New(vcsConnector.UpdatedTime),
-		Creator:       fmt.Sprintf("users/%s", creator.Email),
-		Updater:       fmt.Sprintf("users/%s", updater.Email),
-		Title:         vcsConnector.Payload.Title,
-		VcsProvider:   fmt.Sprintf("%s%s", common.VCSProviderPrefix, vcsConnector.VCSResourceID),
-		ExternalId:    vcsConnector.Payload.ExternalId,
-		BaseDirectory: vcsConnector.Payload.BaseDirectory,
-		Branch:        vcsConnector.Payload.Branch,
-		FullPath:      vcsConnector.Payload.FullPath,
-		WebUrl:        vcsConnector.Payload.WebUrl,
-		DatabaseGroup: vcsConnector.Payload.DatabaseGroup,
+		Name:             fmt.Sprintf("%s%s/%s%s", common.ProjectNamePrefix, vcsConnector.ProjectID, common.VCSConnectorPrefix, vcsConnector.ResourceID),
+		CreateTime:       timestamppb.New(vcsConnector.CreatedTime),
+		UpdateTime:       timestamppb.New(vcsConnector.UpdatedTime),
+		Creator:          fmt.Sprintf("users/%s", creator.Email),
+		Updater:          fmt.Sprintf("users/%s", updater.Email),
+		Title:            vcsConnector.Payload.Title,
+		VcsProvider:      fmt.Sprintf("%s%s", common.VCSProviderPrefix, vcsConnector.VCSResourceID),
+		ExternalId:       vcsConnector.Payload.ExternalId,
+		BaseDirectory:    vcsConnector.Payload.BaseDirectory,
+		Branch:           vcsConnector.Payload.Branch,
+		FullPath:         vcsConnector.Payload.FullPath,
+		WebUrl:           vcsConnector.Payload.WebUrl,
+		DatabaseGroup:    vcsConnector.Payload.DatabaseGroup,
+		FilePathTemplate: vcsConnector.Payload.FilePathTemplate,
+		Mode:             v1pb.VCSConnector_Mode(vcsConnector.Payload.Mode),
 	}
 	return v1VCSConnector, nil
 }
 
+// validateFilePathTemplate validates the file path template and the database group against the mode of the VCS connector.
+// The template addressing databases cannot be used with the database group because each file picks its own target databases.
+func validateFilePathTemplate(filePathTemplate, databaseGroup string, mode storepb.VCSConnector_Mode) error {
+	if mode == storepb.VCSConnector_SDL && databaseGroup != "" {
+		return status.Errorf(codes.InvalidArgument, "database group is not supported in SDL mode")
+	}
+	if filePathTemplate == "" {
+		return nil
+	}
+	template, err := vcs.ParseFilePathTemplate(filePathTemplate)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if template.AddressDatabase() && databaseGroup != "" {
+		return status.Errorf(codes.InvalidArgument, "file path template with %s or %s cannot be used with database group", vcs.PlaceholderEnvironmentID, vcs.PlaceholderDatabaseName)
+	}
+	switch mode {
+	case storepb.VCSConnector_SDL:
+		if template.Has(vcs.PlaceholderType) {
+			return status.Errorf(codes.InvalidArgument, "file path template cannot contain %s in SDL mode", vcs.PlaceholderType)
+		}
+	default:
+		if !template.Has(vcs.PlaceholderVersion) {
+			return status.Errorf(codes.InvalidArgument, "file path template should contain %s", vcs.PlaceholderVersion)
+		}
+	}
+	return nil
+}
+
 func checkBranchExistence(ctx context.Context, vcsProvider *store.VCSProviderMessage, externalID, branch string) error {
 	if branch == "" {
 		return status.Errorf(codes.InvalidArgument, "branch name is required")
@@ -458,6 +516,21 @@ func createVCSWebhook(ctx context.Context, vcsProvider *store.VCSProviderMessage
 		if err != nil {
 			return "", errors.Wrap(err, "failed to marshal request body for creating webhook")
 		}
+	case storepb.VCSType_GITEA:
+		webhookPost := gitea.WebhookCreateOrUpdate{
+			Type: "gitea",
+			Config: gitea.WebhookConfig{
+				URL:         fmt.Sprintf("%s/hook/%s", bytebaseEndpointURL, webhookEndpointID),
+				ContentType: "json",
+				Secret:      webhookSecretToken,
+			},
+			Events: []string{"pull_request", "pull_request_sync", "pull_request_comment"},
+			Active: true,
+		}
+		webhookCreatePayload, err = json.Marshal(webhookPost)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal request body for creating webhook")
+		}
 	case storepb.VCSType_AZURE_DEVOPS:
 		part := strings.Split(externalRepoID, "/")
 		if len(part) != 3 {
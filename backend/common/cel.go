@@ -74,6 +74,18 @@ var MaskingExceptionPolicyCELAttributes = []cel.EnvOption{
 	cel.ParserExpressionSizeLimit(celLimit),
 }
 
+// SQLReviewCustomRuleCELAttributes are the variables when evaluating custom SQL review rules.
+var SQLReviewCustomRuleCELAttributes = []cel.EnvOption{
+	cel.Variable("statement.type", cel.StringType),
+	cel.Variable("statement.text", cel.StringType),
+	cel.Variable("statement.tables", cel.ListType(cel.StringType)),
+	cel.Variable("statement.columns", cel.ListType(cel.StringType)),
+	cel.Variable("statement.object_names", cel.ListType(cel.StringType)),
+	cel.Variable("statement.has_where", cel.BoolType),
+	cel.Variable("statement.affected_rows", cel.IntType),
+	cel.ParserExpressionSizeLimit(celLimit),
+}
+
 // ConvertUnparsedRisk converts unparsed risk to parsed format.
 func ConvertUnparsedRisk(expression *expr.Expr) (*exprproto.ParsedExpr, error) {
 	if expression == nil || expression.Expression == "" {
@@ -154,6 +166,39 @@ func ValidateMaskingRuleCELExpr(expr string) (cel.Program, error) {
 	return prog, nil
 }
 
+// ValidateSQLReviewCustomRuleCELExpr validates custom SQL review rule expr.
+// It returns the checked AST along with the program, so the caller can inspect the referenced variables.
+func ValidateSQLReviewCustomRuleCELExpr(expr string) (*cel.Ast, cel.Program, error) {
+	e, err := cel.NewEnv(
+		SQLReviewCustomRuleCELAttributes...,
+	)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, err.Error())
+	}
+	ast, issues := e.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, issues.Err().Error())
+	}
+	if !ast.OutputType().IsExactType(cel.BoolType) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "expression %q must return a boolean, but got %s", expr, ast.OutputType())
+	}
+	prog, err := e.Program(ast)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	return ast, prog, nil
+}
+
+// IsCELVariableReferenced returns true if the checked AST references the variable.
+func IsCELVariableReferenced(ast *cel.Ast, variable string) bool {
+	for _, reference := range ast.NativeRep().ReferenceMap() {
+		if reference.Name == variable {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateMaskingExceptionCELExpr validates masking exception expr.
 func ValidateMaskingExceptionCELExpr(expr string) (cel.Program, error) {
 	e, err := cel.NewEnv(
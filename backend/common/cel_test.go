@@ -114,3 +114,50 @@ func TestGetQueryExportFactors(t *testing.T) {
 		a.Equal(tt.want, *factors)
 	}
 }
+
+func TestValidateSQLReviewCustomRuleCELExpr(t *testing.T) {
+	a := require.New(t)
+
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: `statement.type == "DELETE" && !statement.has_where`},
+		{expr: `"secret" in statement.columns || statement.affected_rows > 1000`},
+		{expr: `statement.tables.exists(t, t.startsWith("tmp_"))`},
+		// Not a boolean expression.
+		{expr: `statement.affected_rows`, wantErr: true},
+		// Unknown variable.
+		{expr: `statement.unknown == 1`, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		_, _, err := ValidateSQLReviewCustomRuleCELExpr(tc.expr)
+		if tc.wantErr {
+			a.Error(err, tc.expr)
+		} else {
+			a.NoError(err, tc.expr)
+		}
+	}
+}
+
+func TestIsCELVariableReferenced(t *testing.T) {
+	a := require.New(t)
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `statement.affected_rows > 1000`, want: true},
+		{expr: `statement.type == "UPDATE" && statement.affected_rows > 1000`, want: true},
+		// The variable name in a string literal is not a reference.
+		{expr: `statement.text.contains("statement.affected_rows")`, want: false},
+		{expr: `statement.type == "DELETE" && !statement.has_where`, want: false},
+	}
+
+	for _, tc := range tests {
+		ast, _, err := ValidateSQLReviewCustomRuleCELExpr(tc.expr)
+		a.NoError(err, tc.expr)
+		a.Equal(tc.want, IsCELVariableReferenced(ast, "statement.affected_rows"), tc.expr)
+	}
+}
@@ -17,8 +17,8 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 
-	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/store"
@@ -29,7 +29,8 @@ import (
 
 // Manager is the activity manager.
 type Manager struct {
-	store *store.Store
+	store    *store.Store
+	stateCfg *state.State
 }
 
 // Metadata is the activity metadata.
@@ -38,9 +39,10 @@ type Metadata struct {
 }
 
 // NewManager creates an activity manager.
-func NewManager(store *store.Store) *Manager {
+func NewManager(store *store.Store, stateCfg *state.State) *Manager {
 	return &Manager{
-		store: store,
+		store:    store,
+		stateCfg: stateCfg,
 	}
 }
 
@@ -125,8 +127,8 @@ func (m *Manager) BatchCreateActivitiesForRunTasks(ctx context.Context, tasks []
 		CreatorName:  user.Name,
 		CreatorEmail: user.Email,
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
+	// The webhook delivery runner calls the external webhook endpoints to avoid blocking web serving thread.
+	m.createWebhookDeliveries(ctx, &webhookCtx, webhookList)
 
 	return nil
 }
@@ -211,8 +213,8 @@ func (m *Manager) BatchCreateActivitiesForSkipTasks(ctx context.Context, tasks [
 		CreatorName:  user.Name,
 		CreatorEmail: user.Email,
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
+	// The webhook delivery runner calls the external webhook endpoints to avoid blocking web serving thread.
+	m.createWebhookDeliveries(ctx, &webhookCtx, webhookList)
 
 	return nil
 }
@@ -298,8 +300,8 @@ func (m *Manager) BatchCreateActivitiesForCancelTaskRuns(ctx context.Context, ta
 		CreatorName:  user.Name,
 		CreatorEmail: user.Email,
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
+	// The webhook delivery runner calls the external webhook endpoints to avoid blocking web serving thread.
+	m.createWebhookDeliveries(ctx, &webhookCtx, webhookList)
 
 	return nil
 }
@@ -341,31 +343,44 @@ func (m *Manager) CreateActivity(ctx context.Context, create *store.ActivityMess
 			log.BBError(err))
 		return activity, nil
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, webhookCtx, webhookList)
+	// The webhook delivery runner calls the external webhook endpoints to avoid blocking web serving thread.
+	m.createWebhookDeliveries(ctx, webhookCtx, webhookList)
 
 	return activity, nil
 }
 
-func postWebhookList(ctx context.Context, webhookCtx *webhook.Context, webhookList []*store.ProjectWebhookMessage) {
+// createWebhookDeliveries persists the webhook event for each webhook, and tickles the webhook delivery runner to post them.
+func (m *Manager) createWebhookDeliveries(ctx context.Context, webhookCtx *webhook.Context, webhookList []*store.ProjectWebhookMessage) {
 	for _, hook := range webhookList {
 		webhookCtx := *webhookCtx
 		webhookCtx.URL = hook.URL
 		webhookCtx.CreatedTs = time.Now().Unix()
-		go func(webhookCtx *webhook.Context, hook *store.ProjectWebhookMessage) {
-			if err := common.Retry(ctx, func() error {
-				return webhook.Post(hook.Type, *webhookCtx)
-			}); err != nil {
-				// The external webhook endpoint might be invalid which is out of our code control, so we just emit a warning
-				slog.Warn("Failed to post webhook event on activity",
-					slog.String("webhook type", hook.Type),
-					slog.String("webhook name", hook.Title),
-					slog.String("activity type", webhookCtx.ActivityType),
-					slog.String("title", webhookCtx.Title),
-					log.BBError(err))
-				return
-			}
-		}(&webhookCtx, hook)
+		event, err := json.Marshal(webhookCtx)
+		if err != nil {
+			slog.Warn("Failed to marshal webhook event on activity",
+				slog.String("webhook name", hook.Title),
+				slog.String("activity type", webhookCtx.ActivityType),
+				log.BBError(err))
+			continue
+		}
+		if _, err := m.store.CreateProjectWebhookDelivery(ctx, &store.ProjectWebhookDeliveryMessage{
+			ProjectWebhookUID: hook.ID,
+			Status:            store.WebhookDeliveryPending,
+			NextAttemptTime:   time.Now(),
+			Event:             event,
+		}); err != nil {
+			slog.Warn("Failed to create webhook delivery on activity",
+				slog.String("webhook type", hook.Type),
+				slog.String("webhook name", hook.Title),
+				slog.String("activity type", webhookCtx.ActivityType),
+				slog.String("title", webhookCtx.Title),
+				log.BBError(err))
+			continue
+		}
+	}
+	select {
+	case m.stateCfg.WebhookDeliveryTickleChan <- 0:
+	default:
 	}
 }
 
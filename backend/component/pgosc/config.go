@@ -0,0 +1,126 @@
+// Package pgosc implements the online schema change for PostgreSQL.
+// Like gh-ost, it migrates a shadow table and switches it with the original table in the cutover.
+// The changes to the original table during the migration are captured by a trigger into a changelog table
+// and replayed to the shadow table after the rows are copied.
+package pgosc
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+var defaultConfig = struct {
+	chunkSize                 int64
+	defaultNumRetries         int64
+	cutoverLockTimeoutSeconds int64
+	maxLagRows                int64
+}{
+	chunkSize:                 1000, // chunk-size
+	defaultNumRetries:         60,   // default-retries
+	cutoverLockTimeoutSeconds: 10,   // cut-over-lock-timeout-seconds
+	maxLagRows:                1000, // max-lag-rows
+}
+
+// UserFlags is the user flags for the online schema change.
+type UserFlags struct {
+	chunkSize                 *int64
+	defaultRetries            *int64
+	cutoverLockTimeoutSeconds *int64
+	maxLagRows                *int64
+}
+
+var knownKeys = map[string]bool{
+	"chunk-size":                    true,
+	"default-retries":               true,
+	"cut-over-lock-timeout-seconds": true,
+	"max-lag-rows":                  true,
+}
+
+// GetUserFlags gets and validates the user flags.
+func GetUserFlags(flags map[string]string) (*UserFlags, error) {
+	f := &UserFlags{}
+	if flags == nil {
+		return f, nil
+	}
+
+	for k := range flags {
+		if !knownKeys[k] {
+			return nil, errors.Errorf("unsupported flag: %s", k)
+		}
+	}
+
+	if v, ok := flags["chunk-size"]; ok {
+		chunkSize, err := parsePositiveInt(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert chunk-size %q to int", v)
+		}
+		f.chunkSize = &chunkSize
+	}
+	if v, ok := flags["default-retries"]; ok {
+		defaultRetries, err := parsePositiveInt(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert default-retries %q to int", v)
+		}
+		f.defaultRetries = &defaultRetries
+	}
+	if v, ok := flags["cut-over-lock-timeout-seconds"]; ok {
+		cutoverLockTimeoutSeconds, err := parsePositiveInt(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert cut-over-lock-timeout-seconds %q to int", v)
+		}
+		f.cutoverLockTimeoutSeconds = &cutoverLockTimeoutSeconds
+	}
+	if v, ok := flags["max-lag-rows"]; ok {
+		maxLagRows, err := parsePositiveInt(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert max-lag-rows %q to int", v)
+		}
+		f.maxLagRows = &maxLagRows
+	}
+	return f, nil
+}
+
+func parsePositiveInt(v string) (int64, error) {
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if i <= 0 {
+		return 0, errors.Errorf("must be positive")
+	}
+	return i, nil
+}
+
+type config struct {
+	chunkSize                 int64
+	defaultNumRetries         int64
+	cutoverLockTimeoutSeconds int64
+	maxLagRows                int64
+}
+
+func newConfig(flags map[string]string) (*config, error) {
+	userFlags, err := GetUserFlags(flags)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get user flags")
+	}
+	c := &config{
+		chunkSize:                 defaultConfig.chunkSize,
+		defaultNumRetries:         defaultConfig.defaultNumRetries,
+		cutoverLockTimeoutSeconds: defaultConfig.cutoverLockTimeoutSeconds,
+		maxLagRows:                defaultConfig.maxLagRows,
+	}
+	if v := userFlags.chunkSize; v != nil {
+		c.chunkSize = *v
+	}
+	if v := userFlags.defaultRetries; v != nil {
+		c.defaultNumRetries = *v
+	}
+	if v := userFlags.cutoverLockTimeoutSeconds; v != nil {
+		c.cutoverLockTimeoutSeconds = *v
+	}
+	if v := userFlags.maxLagRows; v != nil {
+		c.maxLagRows = *v
+	}
+	return c, nil
+}
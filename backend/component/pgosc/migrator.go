@@ -0,0 +1,699 @@
+package pgosc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+)
+
+const (
+	// changelogIDColumn is the ordered id column of the changelog table.
+	changelogIDColumn = "_ghc_id"
+	// lockNotAvailableCode is the SQLSTATE raised on lock_timeout.
+	lockNotAvailableCode = "55P03"
+)
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type column struct {
+	name string
+	tp   string
+}
+
+// Migrator migrates the table online.
+// The migration goes through the following steps:
+//  1. create the shadow table like the original table and apply the ALTER TABLE statements to it.
+//  2. create the trigger on the original table to record the primary keys of the changed rows in the changelog table.
+//  3. copy the rows from the original table to the shadow table in chunks ordered by the primary key.
+//  4. replay the changelog to the shadow table until cutover, the migration is synced if the lag is small enough.
+//  5. cutover: lock the original table, replay the remaining changelog and swap the tables by renaming.
+//
+// The original table is kept as the _<table>_<timestamp>_del table after the cutover.
+type Migrator struct {
+	db        *sql.DB
+	statement *AlterTableStatement
+	config    *config
+	noop      bool
+
+	shadowTable    string
+	changelogTable string
+	oldTable       string
+	functionName   string
+	triggerName    string
+
+	tableOID   int64
+	primaryKey []*column
+	// columns are the columns copied to the shadow table, which exist in both tables and are not generated.
+	columns []string
+
+	rowsEstimate int64
+	rowsCopied   int64
+	synced       atomic.Bool
+
+	cutoverOnce sync.Once
+	cutoverCh   chan struct{}
+}
+
+// NewMigrator creates the migrator. If noop is true, Migrate only checks the statement on a shadow table and rolls back.
+func NewMigrator(db *sql.DB, statement string, noop bool, flags map[string]string) (*Migrator, error) {
+	alterTableStatement, err := ParseAlterTableStatement(statement)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse statement")
+	}
+	c, err := newConfig(flags)
+	if err != nil {
+		return nil, err
+	}
+	table := alterTableStatement.Table
+	return &Migrator{
+		db:             db,
+		statement:      alterTableStatement,
+		config:         c,
+		noop:           noop,
+		shadowTable:    getInternalTableName(table, "_gho"),
+		changelogTable: getInternalTableName(table, "_ghc"),
+		oldTable:       getInternalTableName(table, fmt.Sprintf("_%s_del", time.Now().Format("20060102150405"))),
+		functionName:   getInternalTableName(table, "_ghc_func"),
+		triggerName:    getInternalTableName(table, "_ghc_trigger"),
+		cutoverCh:      make(chan struct{}),
+	}, nil
+}
+
+// GetRowsEstimate returns the estimated row count of the original table.
+func (m *Migrator) GetRowsEstimate() int64 {
+	return atomic.LoadInt64(&m.rowsEstimate)
+}
+
+// GetTotalRowsCopied returns the row count copied to the shadow table.
+func (m *Migrator) GetTotalRowsCopied() int64 {
+	return atomic.LoadInt64(&m.rowsCopied)
+}
+
+// IsSynced returns true if the rows are copied and the changelog lag is below the threshold.
+func (m *Migrator) IsSynced() bool {
+	return m.synced.Load()
+}
+
+// Cutover triggers the cutover of a synced migration. The result is returned by Migrate.
+func (m *Migrator) Cutover() {
+	m.cutoverOnce.Do(func() {
+		close(m.cutoverCh)
+	})
+}
+
+// Migrate runs the migration and returns after the cutover.
+func (m *Migrator) Migrate(ctx context.Context) (err error) {
+	if err := m.inspect(ctx); err != nil {
+		return err
+	}
+	if m.noop {
+		return m.dryRun(ctx)
+	}
+
+	defer func() {
+		m.cleanup(err == nil)
+	}()
+	if err := m.prepare(ctx); err != nil {
+		return errors.Wrapf(err, "failed to prepare the shadow table")
+	}
+	if err := m.copyRows(ctx); err != nil {
+		return errors.Wrapf(err, "failed to copy rows")
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		if err := m.replayAll(ctx); err != nil {
+			return errors.Wrapf(err, "failed to replay changelog")
+		}
+		var lag int64
+		if err := m.db.QueryRowContext(ctx, fmt.Sprintf("SELECT count(*) FROM %s", m.quotedTable(m.changelogTable))).Scan(&lag); err != nil {
+			return errors.Wrapf(err, "failed to get changelog lag")
+		}
+		if lag <= m.config.maxLagRows {
+			m.synced.Store(true)
+		}
+
+		select {
+		case <-m.cutoverCh:
+			return m.cutoverWithRetries(ctx)
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *Migrator) quotedTable(table string) string {
+	return quoteQualifiedName(m.statement.Schema, table)
+}
+
+func (m *Migrator) primaryKeyNames() []string {
+	var names []string
+	for _, c := range m.primaryKey {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// inspect checks whether the original table can be migrated online.
+func (m *Migrator) inspect(ctx context.Context) error {
+	schema, table := m.statement.Schema, m.statement.Table
+	var relkind string
+	var hasRowSecurity bool
+	if err := m.db.QueryRowContext(ctx, `
+		SELECT c.oid, c.relkind, c.relrowsecurity, GREATEST(c.reltuples::bigint, 0)
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2`, schema, table).Scan(&m.tableOID, &relkind, &hasRowSecurity, &m.rowsEstimate); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Errorf("table %q.%q not found", schema, table)
+		}
+		return err
+	}
+	if relkind != "r" {
+		return errors.Errorf("%q.%q is not a regular table", schema, table)
+	}
+	if hasRowSecurity {
+		return errors.Errorf("table %q.%q with row level security is not supported", schema, table)
+	}
+
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod)
+		FROM pg_catalog.pg_index i
+		JOIN LATERAL unnest(i.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord) ON true
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+		WHERE i.indrelid = $1 AND i.indisprimary
+		ORDER BY k.ord`, m.tableOID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c := &column{}
+		if err := rows.Scan(&c.name, &c.tp); err != nil {
+			return err
+		}
+		m.primaryKey = append(m.primaryKey, c)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(m.primaryKey) == 0 {
+		return errors.Errorf("table %q.%q must have a primary key", schema, table)
+	}
+
+	// The dependent objects still point to the original table after the cutover.
+	checks := []struct {
+		query   string
+		message string
+	}{
+		{
+			query:   `SELECT conname FROM pg_catalog.pg_constraint WHERE confrelid = $1 AND contype = 'f' LIMIT 1`,
+			message: "table %q.%q is referenced by the foreign key %q",
+		},
+		{
+			query: `SELECT DISTINCT v.relname
+				FROM pg_catalog.pg_depend d
+				JOIN pg_catalog.pg_rewrite r ON r.oid = d.objid
+				JOIN pg_catalog.pg_class v ON v.oid = r.ev_class
+				WHERE d.classid = 'pg_catalog.pg_rewrite'::regclass AND d.refobjid = $1 AND v.oid <> $1
+				LIMIT 1`,
+			message: "table %q.%q is referenced by the view %q",
+		},
+		{
+			query:   `SELECT tgname FROM pg_catalog.pg_trigger WHERE tgrelid = $1 AND NOT tgisinternal LIMIT 1`,
+			message: "table %q.%q has the trigger %q",
+		},
+		{
+			query:   `SELECT inhrelid::regclass::text FROM pg_catalog.pg_inherits WHERE inhparent = $1 OR inhrelid = $1 LIMIT 1`,
+			message: "table %q.%q is in the inheritance or partition hierarchy with %q",
+		},
+	}
+	for _, check := range checks {
+		var name string
+		err := m.db.QueryRowContext(ctx, check.query, m.tableOID).Scan(&name)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return errors.Errorf(check.message+", which is not supported by the online migration", schema, table, name)
+	}
+	return nil
+}
+
+// createShadowTable creates the shadow table with the ALTER TABLE statements applied.
+// The foreign keys are not copied by LIKE, so they are added separately before applying the statements.
+func (m *Migrator) createShadowTable(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)", m.quotedTable(m.shadowTable), m.quotedTable(m.statement.Table))); err != nil {
+		return errors.Wrapf(err, "failed to create the shadow table")
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT conname, pg_get_constraintdef(oid) FROM pg_catalog.pg_constraint WHERE conrelid = $1 AND contype = 'f' ORDER BY conname`, m.tableOID)
+	if err != nil {
+		return err
+	}
+	var foreignKeys []string
+	for rows.Next() {
+		var name, definition string
+		if err := rows.Scan(&name, &definition); err != nil {
+			rows.Close()
+			return err
+		}
+		foreignKeys = append(foreignKeys, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s", m.quotedTable(m.shadowTable), quoteIdentifier(name), definition))
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+	for _, foreignKey := range foreignKeys {
+		if _, err := tx.ExecContext(ctx, foreignKey); err != nil {
+			return errors.Wrapf(err, "failed to add foreign key to the shadow table")
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, m.statement.Render(m.shadowTable)); err != nil {
+		return errors.Wrapf(err, "failed to alter the shadow table")
+	}
+
+	columns, err := m.getCopiedColumns(ctx, tx)
+	if err != nil {
+		return err
+	}
+	columnSet := make(map[string]bool)
+	for _, c := range columns {
+		columnSet[c] = true
+	}
+	for _, c := range m.primaryKey {
+		if !columnSet[c.name] {
+			return errors.Errorf("primary key column %q must not be dropped or changed to a generated column", c.name)
+		}
+	}
+	m.columns = columns
+	return nil
+}
+
+func (m *Migrator) getCopiedColumns(ctx context.Context, q querier) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT a.attname
+		FROM pg_catalog.pg_attribute a
+		WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped AND a.attgenerated = ''
+		AND EXISTS (
+			SELECT 1 FROM pg_catalog.pg_attribute b
+			WHERE b.attrelid = $2::regclass AND b.attname = a.attname AND b.attnum > 0 AND NOT b.attisdropped AND b.attgenerated = ''
+		)
+		ORDER BY a.attnum`, m.tableOID, m.quotedTable(m.shadowTable))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns = append(columns, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+func (m *Migrator) dryRun(ctx context.Context) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	return m.createShadowTable(ctx, tx)
+}
+
+func (m *Migrator) prepare(ctx context.Context) error {
+	// Clean up the leftovers of the previous failed run.
+	m.cleanup(false)
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// Creating the trigger takes the SHARE ROW EXCLUSIVE lock on the original table.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL lock_timeout = '%ds'", m.config.cutoverLockTimeoutSeconds)); err != nil {
+		return err
+	}
+	if err := m.createShadowTable(ctx, tx); err != nil {
+		return err
+	}
+
+	var columnDefs, oldValues, newValues []string
+	for _, c := range m.primaryKey {
+		columnDefs = append(columnDefs, fmt.Sprintf("%s %s", quoteIdentifier(c.name), c.tp))
+		oldValues = append(oldValues, fmt.Sprintf("OLD.%s", quoteIdentifier(c.name)))
+		newValues = append(newValues, fmt.Sprintf("NEW.%s", quoteIdentifier(c.name)))
+	}
+	changelogTable := m.quotedTable(m.changelogTable)
+	primaryKey := quoteIdentifiers(m.primaryKeyNames())
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE %s (%s BIGSERIAL PRIMARY KEY, %s)", changelogTable, quoteIdentifier(changelogIDColumn), strings.Join(columnDefs, ", ")),
+		fmt.Sprintf(`CREATE FUNCTION %s() RETURNS TRIGGER AS $$
+BEGIN
+	IF TG_OP IN ('UPDATE', 'DELETE') THEN
+		INSERT INTO %s (%s) VALUES (%s);
+	END IF;
+	IF TG_OP IN ('INSERT', 'UPDATE') THEN
+		INSERT INTO %s (%s) VALUES (%s);
+	END IF;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql`, m.quotedTable(m.functionName), changelogTable, primaryKey, strings.Join(oldValues, ", "), changelogTable, primaryKey, strings.Join(newValues, ", ")),
+		fmt.Sprintf("CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION %s()", quoteIdentifier(m.triggerName), m.quotedTable(m.statement.Table), m.quotedTable(m.functionName)),
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// copyRows copies the rows in chunks ordered by the primary key.
+// The rows changed during the copy are recorded in the changelog and replayed later.
+func (m *Migrator) copyRows(ctx context.Context) error {
+	primaryKey := quoteIdentifiers(m.primaryKeyNames())
+	columns := quoteIdentifiers(m.columns)
+	var placeholders, lastKeyColumns, descOrder []string
+	for i, c := range m.primaryKey {
+		placeholders = append(placeholders, fmt.Sprintf("$%d::%s", i+1, c.tp))
+		lastKeyColumns = append(lastKeyColumns, fmt.Sprintf("%s::text", quoteIdentifier(c.name)))
+		descOrder = append(descOrder, fmt.Sprintf("%s DESC", quoteIdentifier(c.name)))
+	}
+
+	var lastKey []any
+	for {
+		where := ""
+		if lastKey != nil {
+			where = fmt.Sprintf("WHERE (%s) > (%s)", primaryKey, strings.Join(placeholders, ", "))
+		}
+		query := fmt.Sprintf(`
+			WITH chunk AS (
+				SELECT %s FROM %s %s ORDER BY %s LIMIT %d
+			), copied AS (
+				INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM chunk
+			)
+			SELECT (SELECT count(*) FROM chunk), %s FROM chunk ORDER BY %s LIMIT 1`,
+			columns, m.quotedTable(m.statement.Table), where, primaryKey, m.config.chunkSize,
+			m.quotedTable(m.shadowTable), columns, columns,
+			strings.Join(lastKeyColumns, ", "), strings.Join(descOrder, ", "),
+		)
+
+		var count int64
+		key := make([]sql.NullString, len(m.primaryKey))
+		dest := []any{&count}
+		for i := range key {
+			dest = append(dest, &key[i])
+		}
+		if err := m.db.QueryRowContext(ctx, query, lastKey...).Scan(dest...); err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+		atomic.AddInt64(&m.rowsCopied, count)
+		lastKey = nil
+		for _, k := range key {
+			lastKey = append(lastKey, k.String)
+		}
+		if count < m.config.chunkSize {
+			return nil
+		}
+	}
+}
+
+func (m *Migrator) replayAll(ctx context.Context) error {
+	for {
+		tx, err := m.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		count, err := m.replay(ctx, tx)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		if count < m.config.chunkSize {
+			return nil
+		}
+	}
+}
+
+// replay replays a chunk of the changelog by re-copying the current rows of the recorded primary keys.
+// It returns the count of the replayed changelog rows.
+func (m *Migrator) replay(ctx context.Context, tx *sql.Tx) (int64, error) {
+	changelogTable := m.quotedTable(m.changelogTable)
+	shadowTable := m.quotedTable(m.shadowTable)
+	id := quoteIdentifier(changelogIDColumn)
+	primaryKey := quoteIdentifiers(m.primaryKeyNames())
+	columns := quoteIdentifiers(m.columns)
+
+	var maxID sql.NullInt64
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT max(%s) FROM (SELECT %s FROM %s ORDER BY %s LIMIT %d) t", id, id, changelogTable, id, m.config.chunkSize)).Scan(&maxID); err != nil {
+		return 0, err
+	}
+	if !maxID.Valid {
+		return 0, nil
+	}
+
+	var conditions []string
+	for _, c := range m.primaryKey {
+		conditions = append(conditions, fmt.Sprintf("s.%s = c.%s", quoteIdentifier(c.name), quoteIdentifier(c.name)))
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s s USING (SELECT DISTINCT %s FROM %s WHERE %s <= $1) c WHERE %s", shadowTable, primaryKey, changelogTable, id, strings.Join(conditions, " AND ")), maxID.Int64); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM %s WHERE (%s) IN (SELECT %s FROM %s WHERE %s <= $1)", shadowTable, columns, columns, m.quotedTable(m.statement.Table), primaryKey, primaryKey, changelogTable, id), maxID.Int64); err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s <= $1", changelogTable, id), maxID.Int64)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (m *Migrator) cutoverWithRetries(ctx context.Context) error {
+	var err error
+	for i := int64(0); i < m.config.defaultNumRetries; i++ {
+		err = m.cutover(ctx)
+		if err == nil {
+			return nil
+		}
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != lockNotAvailableCode {
+			return err
+		}
+		slog.Warn("failed to acquire the lock for cutover, retrying", slog.String("table", m.statement.Table), log.BBError(err))
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errors.Wrapf(err, "failed to cutover after %d retries", m.config.defaultNumRetries)
+}
+
+func (m *Migrator) cutover(ctx context.Context) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	originalTable := m.quotedTable(m.statement.Table)
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL lock_timeout = '%ds'", m.config.cutoverLockTimeoutSeconds)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("LOCK TABLE %s IN ACCESS EXCLUSIVE MODE", originalTable)); err != nil {
+		return err
+	}
+	for {
+		count, err := m.replay(ctx, tx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to replay changelog")
+		}
+		if count == 0 {
+			break
+		}
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TRIGGER %s ON %s", quoteIdentifier(m.triggerName), originalTable)); err != nil {
+		return err
+	}
+	if err := m.syncSequences(ctx, tx); err != nil {
+		return errors.Wrapf(err, "failed to sync sequences")
+	}
+	if err := m.swapIndexNames(ctx, tx); err != nil {
+		return errors.Wrapf(err, "failed to rename indexes")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", originalTable, quoteIdentifier(m.oldTable))); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", m.quotedTable(m.shadowTable), quoteIdentifier(m.statement.Table))); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// syncSequences moves the sequences owned by the original table to the shadow table, and advances the identity sequences of the shadow table.
+func (m *Migrator) syncSequences(ctx context.Context, tx *sql.Tx) error {
+	var statements []string
+
+	// The serial columns of the shadow table share the sequences with the original table.
+	rows, err := tx.QueryContext(ctx, `
+		SELECT s.oid::regclass::text, a.attname
+		FROM pg_catalog.pg_depend d
+		JOIN pg_catalog.pg_class s ON s.oid = d.objid AND s.relkind = 'S'
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+		WHERE d.refobjid = $1 AND d.deptype = 'a'
+		AND EXISTS (SELECT 1 FROM pg_catalog.pg_attribute b WHERE b.attrelid = $2::regclass AND b.attname = a.attname AND NOT b.attisdropped)`, m.tableOID, m.quotedTable(m.shadowTable))
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var sequence, columnName string
+		if err := rows.Scan(&sequence, &columnName); err != nil {
+			rows.Close()
+			return err
+		}
+		statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.%s", sequence, m.quotedTable(m.shadowTable), quoteIdentifier(columnName)))
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	// The identity columns of the shadow table have their own sequences.
+	rows, err = tx.QueryContext(ctx, `
+		SELECT pg_get_serial_sequence($1, a.attname), pg_get_serial_sequence($2, a.attname)
+		FROM pg_catalog.pg_attribute a
+		JOIN pg_catalog.pg_attribute b ON b.attrelid = $3 AND b.attname = a.attname AND b.attidentity <> ''
+		WHERE a.attrelid = $2::regclass AND a.attidentity <> '' AND NOT a.attisdropped`, m.quotedTable(m.statement.Table), m.quotedTable(m.shadowTable), m.tableOID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var original, shadow sql.NullString
+		if err := rows.Scan(&original, &shadow); err != nil {
+			rows.Close()
+			return err
+		}
+		if !original.Valid || !shadow.Valid {
+			continue
+		}
+		statements = append(statements, fmt.Sprintf("SELECT setval('%s', last_value, is_called) FROM %s", strings.ReplaceAll(shadow.String, "'", "''"), original.String))
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swapIndexNames gives the indexes of the shadow table the names of the original ones.
+// LIKE names the indexes after the shadow table, so the indexes following the default naming convention
+// in the original table are matched by the name suffix, and the others keep the generated names.
+func (m *Migrator) swapIndexNames(ctx context.Context, tx *sql.Tx) error {
+	getIndexNames := func(table string) (map[string]bool, error) {
+		rows, err := tx.QueryContext(ctx, `SELECT c.relname FROM pg_catalog.pg_index i JOIN pg_catalog.pg_class c ON c.oid = i.indexrelid WHERE i.indrelid = $1::regclass`, m.quotedTable(table))
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		names := make(map[string]bool)
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return nil, err
+			}
+			names[name] = true
+		}
+		return names, rows.Err()
+	}
+	originalIndexes, err := getIndexNames(m.statement.Table)
+	if err != nil {
+		return err
+	}
+	shadowIndexes, err := getIndexNames(m.shadowTable)
+	if err != nil {
+		return err
+	}
+
+	var statements []string
+	for shadowIndex := range shadowIndexes {
+		suffix, ok := strings.CutPrefix(shadowIndex, m.shadowTable)
+		if !ok {
+			continue
+		}
+		originalIndex := m.statement.Table + suffix
+		if len(originalIndex) > 63 || !originalIndexes[originalIndex] {
+			continue
+		}
+		statements = append(statements,
+			fmt.Sprintf("ALTER INDEX %s RENAME TO %s", m.quotedTable(originalIndex), quoteIdentifier(truncateString(m.oldTable+suffix, 63))),
+			fmt.Sprintf("ALTER INDEX %s RENAME TO %s", m.quotedTable(shadowIndex), quoteIdentifier(originalIndex)),
+		)
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanup drops the trigger and the changelog table, and the shadow table if the migration fails.
+func (m *Migrator) cleanup(succeeded bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	// Dropping the function drops the trigger on the original table as well.
+	statements := []string{
+		fmt.Sprintf("DROP FUNCTION IF EXISTS %s() CASCADE", m.quotedTable(m.functionName)),
+		fmt.Sprintf("DROP TABLE IF EXISTS %s", m.quotedTable(m.changelogTable)),
+	}
+	if !succeeded {
+		statements = append(statements, fmt.Sprintf("DROP TABLE IF EXISTS %s", m.quotedTable(m.shadowTable)))
+	}
+	for _, statement := range statements {
+		if _, err := m.db.ExecContext(ctx, statement); err != nil {
+			slog.Error("failed to clean up online migration", slog.String("statement", statement), log.BBError(err))
+		}
+	}
+}
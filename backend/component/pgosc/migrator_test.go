@@ -0,0 +1,257 @@
+package pgosc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyRows(t *testing.T) {
+	a := require.New(t)
+	stub := &stubDB{rules: []*stubRule{
+		{
+			contains: "WITH chunk AS",
+			responses: []*stubResponse{
+				{rows: [][]driver.Value{{int64(2), "2"}}},
+				{rows: [][]driver.Value{{int64(2), "4"}}},
+				{},
+			},
+		},
+	}}
+	m := newTestMigrator(t, stub)
+
+	a.NoError(m.copyRows(context.Background()))
+	a.Equal(int64(4), m.GetTotalRowsCopied())
+	a.Len(stub.queries, 3)
+	a.Empty(stub.queries[0].args)
+	a.NotContains(stub.queries[0].query, "WHERE")
+	a.Contains(stub.queries[1].query, `WHERE ("id") > ($1::integer)`)
+	a.Contains(stub.queries[1].query, `INSERT INTO "public"."_t_gho" ("id", "c") OVERRIDING SYSTEM VALUE SELECT "id", "c" FROM chunk`)
+	a.Equal([]driver.Value{"2"}, stub.queries[1].args)
+	a.Equal([]driver.Value{"4"}, stub.queries[2].args)
+}
+
+func TestReplayAll(t *testing.T) {
+	a := require.New(t)
+	stub := &stubDB{rules: []*stubRule{
+		{
+			contains: "SELECT max(",
+			responses: []*stubResponse{
+				{rows: [][]driver.Value{{int64(2)}}},
+				{rows: [][]driver.Value{{nil}}},
+			},
+		},
+		{
+			contains:  `DELETE FROM "public"."_t_ghc"`,
+			responses: []*stubResponse{{rowsAffected: 2}},
+		},
+	}}
+	m := newTestMigrator(t, stub)
+
+	a.NoError(m.replayAll(context.Background()))
+	a.Equal([]string{
+		`DELETE FROM "public"."_t_gho" s USING (SELECT DISTINCT "id" FROM "public"."_t_ghc" WHERE "_ghc_id" <= $1) c WHERE s."id" = c."id"`,
+		`INSERT INTO "public"."_t_gho" ("id", "c") OVERRIDING SYSTEM VALUE SELECT "id", "c" FROM "public"."t" WHERE ("id") IN (SELECT "id" FROM "public"."_t_ghc" WHERE "_ghc_id" <= $1)`,
+		`DELETE FROM "public"."_t_ghc" WHERE "_ghc_id" <= $1`,
+		"COMMIT",
+		"COMMIT",
+	}, stub.execStatements())
+	a.Equal([]driver.Value{int64(2)}, stub.execs[0].args)
+}
+
+func TestCutover(t *testing.T) {
+	a := require.New(t)
+	stub := &stubDB{rules: []*stubRule{
+		{
+			contains: "LOCK TABLE",
+			responses: []*stubResponse{
+				{err: &pgconn.PgError{Code: lockNotAvailableCode}},
+				{},
+			},
+		},
+		{
+			contains:  "SELECT max(",
+			responses: []*stubResponse{{rows: [][]driver.Value{{nil}}}},
+		},
+		{
+			contains: "pg_catalog.pg_index i",
+			responses: []*stubResponse{
+				{rows: [][]driver.Value{{"t_pkey"}, {"t_custom_idx"}}},
+				{rows: [][]driver.Value{{"_t_gho_pkey"}, {"_t_gho_c_idx"}}},
+			},
+		},
+	}}
+	m := newTestMigrator(t, stub)
+
+	a.NoError(m.cutoverWithRetries(context.Background()))
+	a.Equal([]string{
+		"SET LOCAL lock_timeout = '10s'",
+		`LOCK TABLE "public"."t" IN ACCESS EXCLUSIVE MODE`,
+		"ROLLBACK",
+		"SET LOCAL lock_timeout = '10s'",
+		`LOCK TABLE "public"."t" IN ACCESS EXCLUSIVE MODE`,
+		`DROP TRIGGER "_t_ghc_trigger" ON "public"."t"`,
+		`ALTER INDEX "public"."t_pkey" RENAME TO "` + m.oldTable + `_pkey"`,
+		`ALTER INDEX "public"."_t_gho_pkey" RENAME TO "t_pkey"`,
+		`ALTER TABLE "public"."t" RENAME TO "` + m.oldTable + `"`,
+		`ALTER TABLE "public"."_t_gho" RENAME TO "t"`,
+		"COMMIT",
+	}, stub.execStatements())
+}
+
+func newTestMigrator(t *testing.T, stub *stubDB) *Migrator {
+	db := sql.OpenDB(stub)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	m, err := NewMigrator(db, "ALTER TABLE t ADD COLUMN c INT;", false, map[string]string{"chunk-size": "2"})
+	require.NoError(t, err)
+	m.tableOID = 1
+	m.primaryKey = []*column{{name: "id", tp: "integer"}}
+	m.columns = []string{"id", "c"}
+	return m
+}
+
+// stubDB is a database/sql connector answering the statements by the first matching rule.
+// The executed statements and queries are recorded in order, including the transaction commits and rollbacks.
+type stubDB struct {
+	sync.Mutex
+	rules   []*stubRule
+	execs   []*stubCall
+	queries []*stubCall
+}
+
+type stubRule struct {
+	contains string
+	// responses are returned in order, and the last one is repeated.
+	responses []*stubResponse
+}
+
+type stubResponse struct {
+	rows         [][]driver.Value
+	rowsAffected int64
+	err          error
+}
+
+type stubCall struct {
+	query string
+	args  []driver.Value
+}
+
+func (s *stubDB) Connect(context.Context) (driver.Conn, error) {
+	return &stubConn{db: s}, nil
+}
+
+func (s *stubDB) Driver() driver.Driver {
+	return nil
+}
+
+func (s *stubDB) execStatements() []string {
+	var statements []string
+	for _, exec := range s.execs {
+		statements = append(statements, exec.query)
+	}
+	return statements
+}
+
+func (s *stubDB) respond(query string) *stubResponse {
+	for _, rule := range s.rules {
+		if !strings.Contains(query, rule.contains) {
+			continue
+		}
+		response := rule.responses[0]
+		if len(rule.responses) > 1 {
+			rule.responses = rule.responses[1:]
+		}
+		return response
+	}
+	return &stubResponse{}
+}
+
+func (s *stubDB) record(calls *[]*stubCall, query string, args []driver.NamedValue) *stubResponse {
+	s.Lock()
+	defer s.Unlock()
+	call := &stubCall{query: query}
+	for _, arg := range args {
+		call.args = append(call.args, arg.Value)
+	}
+	*calls = append(*calls, call)
+	return s.respond(query)
+}
+
+type stubConn struct {
+	db *stubDB
+}
+
+func (*stubConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (*stubConn) Close() error {
+	return nil
+}
+
+func (c *stubConn) Begin() (driver.Tx, error) {
+	return &stubTx{db: c.db}, nil
+}
+
+func (c *stubConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	response := c.db.record(&c.db.execs, query, args)
+	if response.err != nil {
+		return nil, response.err
+	}
+	return driver.RowsAffected(response.rowsAffected), nil
+}
+
+func (c *stubConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	response := c.db.record(&c.db.queries, query, args)
+	if response.err != nil {
+		return nil, response.err
+	}
+	return &stubRows{values: response.rows}, nil
+}
+
+type stubTx struct {
+	db *stubDB
+}
+
+func (tx *stubTx) Commit() error {
+	tx.db.record(&tx.db.execs, "COMMIT", nil)
+	return nil
+}
+
+func (tx *stubTx) Rollback() error {
+	tx.db.record(&tx.db.execs, "ROLLBACK", nil)
+	return nil
+}
+
+type stubRows struct {
+	values [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string {
+	if len(r.values) == 0 {
+		return nil
+	}
+	return make([]string, len(r.values[0]))
+}
+
+func (*stubRows) Close() error {
+	return nil
+}
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
@@ -0,0 +1,119 @@
+package pgosc
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	parser "github.com/bytebase/postgresql-parser"
+	"github.com/pkg/errors"
+
+	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
+)
+
+const defaultSchema = "public"
+
+// AlterTableStatement is the ALTER TABLE statements on one table to be migrated online.
+type AlterTableStatement struct {
+	Schema string
+	Table  string
+	// segments are the statement texts split by the table names.
+	segments []string
+}
+
+// ParseAlterTableStatement parses the statement for the online schema change.
+// The statement must consist of the ALTER TABLE statements on the same table.
+func ParseAlterTableStatement(statement string) (*AlterTableStatement, error) {
+	parseResult, err := pgparser.ParsePostgreSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := parseResult.Tree.(*parser.RootContext)
+	if !ok {
+		return nil, errors.New("failed to convert to RootContext")
+	}
+	if root.Stmtblock() == nil || root.Stmtblock().Stmtmulti() == nil {
+		return nil, errors.New("statement must not be empty")
+	}
+
+	// The positions in the input stream are counted by runes.
+	runes := []rune(statement)
+	result := &AlterTableStatement{}
+	last := 0
+	for _, stmt := range root.Stmtblock().Stmtmulti().AllStmt() {
+		// The rows are copied by the column names, so the renamed columns would lose their data.
+		if stmt.Renamestmt() != nil {
+			return nil, errors.Errorf("renaming is not supported by the online migration, but got %q", parseResult.Tokens.GetTextFromRuleContext(stmt))
+		}
+		alter := stmt.Altertablestmt()
+		if alter == nil || alter.TABLE() == nil || alter.Relation_expr() == nil || alter.Alter_table_cmds() == nil {
+			return nil, errors.Errorf("only ALTER TABLE statements are supported, but got %q", parseResult.Tokens.GetTextFromRuleContext(stmt))
+		}
+		for _, cmd := range alter.Alter_table_cmds().AllAlter_table_cmd() {
+			// The copied rows are converted by the assignment casts instead of the USING expression.
+			if cmd.Alter_using() != nil {
+				return nil, errors.Errorf("ALTER COLUMN TYPE with USING is not supported by the online migration, but got %q", parseResult.Tokens.GetTextFromRuleContext(cmd))
+			}
+		}
+		name := alter.Relation_expr().Qualified_name()
+		schemaName, tableName, err := pgparser.NormalizePostgreSQLQualifiedNameAsTableName(name)
+		if err != nil {
+			return nil, err
+		}
+		if schemaName == "" {
+			schemaName = defaultSchema
+		}
+		if result.Table == "" {
+			result.Schema, result.Table = schemaName, tableName
+		} else if result.Schema != schemaName || result.Table != tableName {
+			return nil, errors.Errorf("all statements must alter the same table %q.%q, but got %q.%q", result.Schema, result.Table, schemaName, tableName)
+		}
+		start, stop := name.GetStart().GetStart(), name.GetStop().GetStop()
+		result.segments = append(result.segments, string(runes[last:start]))
+		last = stop + 1
+	}
+	result.segments = append(result.segments, string(runes[last:]))
+	return result, nil
+}
+
+// Render renders the statement against the given table in the same schema.
+func (s *AlterTableStatement) Render(table string) string {
+	return strings.Join(s.segments, quoteQualifiedName(s.Schema, table))
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
+
+func quoteQualifiedName(schema, name string) string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(schema), quoteIdentifier(name))
+}
+
+func quoteIdentifiers(list []string) string {
+	var quoted []string
+	for _, s := range list {
+		quoted = append(quoted, quoteIdentifier(s))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// getInternalTableName gets the name of the internal tables, such as the shadow table, in the limit of 63 bytes.
+func getInternalTableName(table string, suffix string) string {
+	const maxIdentifierLength = 63
+	prefix := fmt.Sprintf("_%s", table)
+	if len(prefix)+len(suffix) > maxIdentifierLength {
+		prefix = truncateString(prefix, maxIdentifierLength-len(suffix))
+	}
+	return prefix + suffix
+}
+
+// truncateString truncates the string to at most n bytes without breaking the UTF-8 characters.
+func truncateString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
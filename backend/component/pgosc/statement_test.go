@@ -0,0 +1,70 @@
+package pgosc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlterTableStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		schema    string
+		table     string
+		rendered  string
+		err       bool
+	}{
+		{
+			statement: `ALTER TABLE t ADD COLUMN c INT;`,
+			schema:    "public",
+			table:     "t",
+			rendered:  `ALTER TABLE "public"."_t_gho" ADD COLUMN c INT;`,
+		},
+		{
+			statement: `ALTER TABLE "Sales"."Order" ALTER COLUMN amount TYPE numeric(20, 2);
+ALTER TABLE ONLY "Sales"."Order" ADD CONSTRAINT amount_check CHECK (amount > 0);`,
+			schema: "Sales",
+			table:  "Order",
+			rendered: `ALTER TABLE "Sales"."_Order_gho" ALTER COLUMN amount TYPE numeric(20, 2);
+ALTER TABLE ONLY "Sales"."_Order_gho" ADD CONSTRAINT amount_check CHECK (amount > 0);`,
+		},
+		{
+			statement: `ALTER TABLE t1 ADD COLUMN c INT; ALTER TABLE t2 ADD COLUMN c INT;`,
+			err:       true,
+		},
+		{
+			statement: `CREATE INDEX idx ON t (c);`,
+			err:       true,
+		},
+		{
+			statement: `ALTER TABLE t RENAME COLUMN a TO b;`,
+			err:       true,
+		},
+		{
+			statement: `ALTER TABLE t ALTER COLUMN a TYPE int USING a::int;`,
+			err:       true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		result, err := ParseAlterTableStatement(test.statement)
+		if test.err {
+			a.Error(err, test.statement)
+			continue
+		}
+		a.NoError(err, test.statement)
+		a.Equal(test.schema, result.Schema)
+		a.Equal(test.table, result.Table)
+		a.Equal(test.rendered, result.Render(getInternalTableName(result.Table, "_gho")))
+	}
+}
+
+func TestGetInternalTableName(t *testing.T) {
+	a := require.New(t)
+	a.Equal("_t_gho", getInternalTableName("t", "_gho"))
+	long := "a_very_long_table_name_that_is_close_to_the_identifier_limit_"
+	got := getInternalTableName(long, "_gho")
+	a.Len(got, 63)
+	a.Equal("_gho", got[len(got)-4:])
+}
@@ -30,6 +30,9 @@ type State struct {
 	// ApprovalFinding is the set of issues for finding the approval template.
 	ApprovalFinding sync.Map // map[issue.ID]*store.IssueMessage
 
+	// PullRequestSyncs is the set of plans for syncing their status to the linked pull requests.
+	PullRequestSyncs sync.Map // map[plan.UID]bool
+
 	// TaskProgress is the map from task ID to task progress.
 	TaskProgress sync.Map // map[taskID]api.Progress
 	// GhostTaskState is the map from task ID to gh-ost state.
@@ -60,6 +63,8 @@ type State struct {
 	PlanCheckTickleChan chan int
 	// TaskRunTickleChan is the tickler for task run scheduler.
 	TaskRunTickleChan chan int
+	// WebhookDeliveryTickleChan is the tickler for webhook delivery runner.
+	WebhookDeliveryTickleChan chan int
 
 	ExpireCache *lru.Cache[string, bool]
 
@@ -79,6 +84,7 @@ func New() (*State, error) {
 		InstanceSyncTickleChan:               make(chan int, 1000),
 		PlanCheckTickleChan:                  make(chan int, 1000),
 		TaskRunTickleChan:                    make(chan int, 1000),
+		WebhookDeliveryTickleChan:            make(chan int, 1000),
 		ExpireCache:                          expireCache,
 	}, nil
 }
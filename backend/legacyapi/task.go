@@ -161,7 +161,7 @@ type TaskDatabaseDataUpdatePayload struct {
 	// RollbackSQLStatus is the status of the rollback generation.
 	RollbackSQLStatus RollbackSQLStatus `json:"rollbackSqlStatus,omitempty"`
 	// TransactionID is the ID of the transaction executing the migration.
-	// It is only use for Oracle to find Rollback SQL statement now.
+	// It is used for Oracle and PostgreSQL to find the changes of the migration transaction.
 	TransactionID string `json:"transactionId,omitempty"`
 	// ThreadID is the ID of the connection executing the migration.
 	// We use it to filter the binlog events of the migration transaction.
@@ -176,6 +176,13 @@ type TaskDatabaseDataUpdatePayload struct {
 	BinlogPosStart  int64  `json:"binlogPosStart,omitempty"`
 	BinlogPosEnd    int64  `json:"binlogPosEnd,omitempty"`
 	RollbackError   string `json:"rollbackError,omitempty"`
+	// ReplicationSlot is the PostgreSQL logical replication slot created before executing the migration.
+	// We use it to decode the changes of the migration transaction.
+	ReplicationSlot string `json:"replicationSlot,omitempty"`
+	// LSNXxx are the PostgreSQL WAL locations obtained before and after executing the migration.
+	// We use the end location to limit the changes decoded from the replication slot.
+	LSNStart string `json:"lsnStart,omitempty"`
+	LSNEnd   string `json:"lsnEnd,omitempty"`
 	// RollbackSheetID is the generated rollback SQL statement for the DML task.
 	RollbackSheetID int `json:"rollbackSheetId,omitempty"`
 	// RollbackFromIssueID is the issue ID containing the original task from which the rollback SQL statement is generated for this task.
@@ -191,6 +198,17 @@ type PreUpdateBackupDetail struct {
 	// The database for keeping the backup data.
 	// Format: instances/{instance}/databases/{database}
 	Database string `json:"database,omitempty"`
+	// Tables are the backup tables taken before the update.
+	// They are only recorded by engines supporting restore.
+	Tables []*PreUpdateBackupTable `json:"tables,omitempty"`
+}
+
+// PreUpdateBackupTable is a backup table and the table it is taken from.
+type PreUpdateBackupTable struct {
+	Schema       string `json:"schema,omitempty"`
+	Table        string `json:"table,omitempty"`
+	SourceSchema string `json:"sourceSchema,omitempty"`
+	SourceTable  string `json:"sourceTable,omitempty"`
 }
 
 // TaskDatabaseDataExportPayload is the task payload for database data export.
@@ -0,0 +1,17 @@
+CREATE TABLE project_webhook_delivery (
+  id BIGSERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+  updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+  project_webhook_id INTEGER NOT NULL REFERENCES project_webhook (id) ON DELETE CASCADE,
+  status TEXT NOT NULL CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')),
+  attempt INTEGER NOT NULL DEFAULT 0,
+  next_attempt_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+  event JSONB NOT NULL DEFAULT '{}',
+  payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_project_webhook_delivery_project_webhook_id ON project_webhook_delivery(project_webhook_id);
+
+CREATE INDEX idx_project_webhook_delivery_status_next_attempt_ts ON project_webhook_delivery(status, next_attempt_ts);
+
+ALTER SEQUENCE project_webhook_delivery_id_seq RESTART WITH 101;
@@ -251,6 +251,9 @@ const (
 	// MySQLStatementDMLDryRun is an advisor type for MySQL DML dry run.
 	MySQLStatementDMLDryRun Type = "bb.plugin.advisor.mysql.statement.dml-dry-run"
 
+	// MySQLStatementCustomCEL is an advisor type for MySQL user-defined rules expressed in CEL.
+	MySQLStatementCustomCEL Type = "bb.plugin.advisor.mysql.statement.custom-cel"
+
 	// MySQLStatementSelectFullTableScan is an advisor type for checking MySQL select full table scan or not.
 	MySQLStatementSelectFullTableScan Type = "bb.plugin.advisor.mysql.statement.select-full-table-scan"
 
@@ -400,6 +403,9 @@ const (
 	// PostgreSQLStatementAffectedRowLimit is an advisor type for PostgreSQL UPDATE/DELETE affected row limit.
 	PostgreSQLStatementAffectedRowLimit Type = "bb.plugin.advisor.postgresql.statement.affected-row-limit"
 
+	// PostgreSQLStatementCustomCEL is an advisor type for PostgreSQL user-defined rules expressed in CEL.
+	PostgreSQLStatementCustomCEL Type = "bb.plugin.advisor.postgresql.statement.custom-cel"
+
 	// PostgreSQLMergeAlterTable is an advisor type for PostgreSQL no redundant ALTER TABLE statements.
 	PostgreSQLMergeAlterTable Type = "bb.plugin.advisor.postgresql.statement.merge-alter-table"
 
@@ -594,6 +600,45 @@ const (
 
 	// MSSQLTableDisallowDML is an advisor type for MSSQL disallow DML for specific tables.
 	MSSQLTableDisallowDML = "bb.plugin.advisor.mssql.table.disallow-dml"
+
+	// MSSQLNamingIndexConvention is an advisor type for MSSQL index naming convention.
+	MSSQLNamingIndexConvention Type = "bb.plugin.advisor.mssql.naming.index-idx"
+
+	// MSSQLNamingUKConvention is an advisor type for MSSQL unique key naming convention.
+	MSSQLNamingUKConvention Type = "bb.plugin.advisor.mssql.naming.index-uk"
+
+	// MSSQLNamingPKConvention is an advisor type for MSSQL primary key naming convention.
+	MSSQLNamingPKConvention Type = "bb.plugin.advisor.mssql.naming.index-pk"
+
+	// MSSQLNamingFKConvention is an advisor type for MSSQL foreign key naming convention.
+	MSSQLNamingFKConvention Type = "bb.plugin.advisor.mssql.naming.index-fk"
+
+	// MSSQLIndexKeyNumberLimit is an advisor type for MSSQL index key number limit.
+	MSSQLIndexKeyNumberLimit Type = "bb.plugin.advisor.mssql.index.key-number-limit"
+
+	// MSSQLStatementDisallowMixDDLDML is an advisor type for MSSQL disallow mix DDL and DML.
+	MSSQLStatementDisallowMixDDLDML Type = "bb.plugin.advisor.mssql.statement.disallow-mix-ddl-dml"
+
+	// MSSQLStatementAffectedRowLimit is an advisor type for MSSQL UPDATE/DELETE affected row limit.
+	MSSQLStatementAffectedRowLimit Type = "bb.plugin.advisor.mssql.statement.affected-row-limit"
+
+	// MSSQLInsertMustSpecifyColumn is an advisor type for MSSQL to enforce column specified.
+	MSSQLInsertMustSpecifyColumn Type = "bb.plugin.advisor.mssql.insert.must-specify-column"
+
+	// MSSQLNoLeadingWildcardLike is an advisor type for MSSQL no leading wildcard LIKE.
+	MSSQLNoLeadingWildcardLike Type = "bb.plugin.advisor.mssql.where.no-leading-wildcard-like"
+
+	// MSSQLRequireColumnDefault is an advisor type for MSSQL column default requirement.
+	MSSQLRequireColumnDefault Type = "bb.plugin.advisor.mssql.column.require-default"
+
+	// MSSQLStatementDisallowSelectInto is an advisor type for MSSQL disallow SELECT INTO.
+	MSSQLStatementDisallowSelectInto Type = "bb.plugin.advisor.mssql.statement.disallow-select-into"
+
+	// MSSQLStatementDisallowNoLockHint is an advisor type for MSSQL disallow NOLOCK table hint.
+	MSSQLStatementDisallowNoLockHint Type = "bb.plugin.advisor.mssql.statement.disallow-nolock"
+
+	// MSSQLStatementDisallowCascade is an advisor type for MSSQL disallow cascading foreign keys.
+	MSSQLStatementDisallowCascade Type = "bb.plugin.advisor.mssql.statement.disallow-cascade"
 )
 
 // Advice is the result of an advisor.
@@ -607,6 +652,8 @@ type Advice struct {
 	Line    int    `json:"line"`
 	Column  int    `json:"column"`
 	Details string `json:"details,omitempty"`
+	// Fix is the optional suggested text edit to fix the advice.
+	Fix *Fix `json:"fix,omitempty" yaml:"fix,omitempty"`
 }
 
 // SyntaxMode is the type of syntax mode.
@@ -524,6 +524,19 @@ func (col *ColumnState) copy() *ColumnState {
 	}
 }
 
+// Name returns name for the column.
+func (col *ColumnState) Name() string {
+	return col.name
+}
+
+// Position returns position for the column.
+func (col *ColumnState) Position() int {
+	if col.position != nil {
+		return *col.position
+	}
+	return 0
+}
+
 // Nullable returns nullable for the column.
 func (col *ColumnState) Nullable() bool {
 	return col.nullable != nil && *col.nullable
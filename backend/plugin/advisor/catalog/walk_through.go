@@ -232,6 +232,10 @@ func (d *DatabaseState) WalkThrough(stmt string) error {
 			d.usable = false
 		}
 		return nil
+	case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		return d.oracleWalkThrough(stmt)
+	case storepb.Engine_MSSQL:
+		return d.mssqlWalkThrough(stmt)
 	default:
 		return &WalkThroughError{
 			Type:    ErrorTypeUnsupported,
@@ -0,0 +1,627 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+)
+
+const (
+	mssqlDefaultSchemaName = "dbo"
+)
+
+func (d *DatabaseState) mssqlWalkThrough(stmt string) error {
+	if d.deleted {
+		return &WalkThroughError{
+			Type:    ErrorTypeDatabaseIsDeleted,
+			Content: fmt.Sprintf("Database `%s` is deleted", d.name),
+		}
+	}
+
+	result, err := tsqlparser.ParseTSQL(stmt)
+	if err != nil {
+		return NewParseError(err.Error())
+	}
+
+	listener := &mssqlListener{
+		databaseState: d,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, result.Tree)
+	if listener.err != nil {
+		if listener.err.Line == 0 {
+			listener.err.Line = listener.lineNumber
+		}
+		return listener.err
+	}
+	return nil
+}
+
+type mssqlListener struct {
+	*parser.BaseTSqlParserListener
+
+	lineNumber    int
+	databaseState *DatabaseState
+	err           *WalkThroughError
+}
+
+// EnterSql_clauses is called when production sql_clauses is entered.
+func (l *mssqlListener) EnterSql_clauses(ctx *parser.Sql_clausesContext) {
+	if l.err != nil {
+		return
+	}
+	l.lineNumber = ctx.GetStart().GetLine()
+}
+
+// EnterCreate_schema is called when production create_schema is entered.
+func (l *mssqlListener) EnterCreate_schema(ctx *parser.Create_schemaContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName := ""
+	if ctx.GetSchema_name() != nil {
+		schemaName, _ = tsqlparser.NormalizeTSQLIdentifier(ctx.GetSchema_name())
+	} else if ctx.GetOwner_name() != nil {
+		// CREATE SCHEMA AUTHORIZATION owner creates the schema with the same name as the owner.
+		schemaName, _ = tsqlparser.NormalizeTSQLIdentifier(ctx.GetOwner_name())
+	}
+	if _, exists := l.databaseState.mssqlGetSchema(schemaName); exists {
+		l.err = &WalkThroughError{
+			Type:    ErrorTypeSchemaExists,
+			Content: fmt.Sprintf("Schema `%s` already exists", schemaName),
+		}
+		return
+	}
+	l.databaseState.createSchema(schemaName)
+}
+
+// EnterDrop_schema is called when production drop_schema is entered.
+func (l *mssqlListener) EnterDrop_schema(ctx *parser.Drop_schemaContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName, _ := tsqlparser.NormalizeTSQLIdentifier(ctx.GetSchema_name())
+	schema, exists := l.databaseState.mssqlGetSchema(schemaName)
+	if !exists {
+		// Like the objects in the unknown schemas, we don't check the integrity for the unknown schemas.
+		return
+	}
+	delete(l.databaseState.schemaSet, schema.name)
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *mssqlListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+	fallbackSchemaName := mssqlDefaultSchemaName
+	// CREATE SCHEMA s CREATE TABLE t(...) creates the table in the schema s.
+	if createSchema, ok := ctx.GetParent().(*parser.Create_schemaContext); ok && createSchema.GetSchema_name() != nil {
+		fallbackSchemaName, _ = tsqlparser.NormalizeTSQLIdentifier(createSchema.GetSchema_name())
+	}
+	schema, tableName, ok := l.resolveTableName(ctx.Table_name(), fallbackSchemaName)
+	if !ok {
+		return
+	}
+	if _, exists := schema.mssqlGetTable(tableName); exists {
+		l.err = NewTableExistsError(tableName)
+		return
+	}
+
+	table := &TableState{
+		name:      tableName,
+		engine:    newEmptyStringPointer(),
+		collation: newEmptyStringPointer(),
+		comment:   newEmptyStringPointer(),
+		columnSet: make(columnStateMap),
+		indexSet:  make(IndexStateMap),
+	}
+	schema.tableSet[table.name] = table
+
+	if ctx.Column_def_table_constraints() != nil {
+		if err := table.mssqlCreateColumnsAndConstraints(ctx.Column_def_table_constraints(), true /* checkIntegrity */); err != nil {
+			l.err = err
+			return
+		}
+	}
+	for _, tableIndex := range ctx.AllTable_indices() {
+		if err := table.mssqlCreateTableIndex(tableIndex); err != nil {
+			err.Line = tableIndex.GetStart().GetLine()
+			l.err = err
+			return
+		}
+	}
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *mssqlListener) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	if l.err != nil {
+		return
+	}
+	for _, tableNameCtx := range ctx.AllTable_name() {
+		schema, tableName, ok := l.resolveTableName(tableNameCtx, mssqlDefaultSchemaName)
+		if !ok {
+			continue
+		}
+		table, exists := schema.mssqlGetTable(tableName)
+		if !exists {
+			if ctx.EXISTS() == nil && schema.ctx.CheckIntegrity {
+				l.err = NewTableNotExistsError(tableName)
+				return
+			}
+			continue
+		}
+		delete(schema.tableSet, table.name)
+	}
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *mssqlListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if l.err != nil {
+		return
+	}
+	schema, table, err := l.findTable(ctx.Table_name(0))
+	if err != nil {
+		l.err = err
+		return
+	}
+	if table == nil {
+		return
+	}
+
+	switch {
+	case ctx.ADD() != nil && ctx.Column_def_table_constraints() != nil:
+		if err := table.mssqlCreateColumnsAndConstraints(ctx.Column_def_table_constraints(), schema.ctx.CheckIntegrity); err != nil {
+			l.err = err
+		}
+	case ctx.COLUMN() != nil && ctx.Column_definition() != nil:
+		if err := table.mssqlAlterColumn(schema.ctx, ctx.Column_definition()); err != nil {
+			l.err = err
+		}
+	case ctx.DROP() != nil && ctx.COLUMN() != nil:
+		for _, id := range ctx.AllId_() {
+			columnName, _ := tsqlparser.NormalizeTSQLIdentifier(id)
+			if err := table.mssqlDropColumn(schema.ctx, columnName); err != nil {
+				l.err = err
+				return
+			}
+		}
+	case ctx.DROP() != nil && ctx.CONSTRAINT() != nil && ctx.GetConstraint() != nil:
+		constraintName, _ := tsqlparser.NormalizeTSQLIdentifier(ctx.GetConstraint())
+		// The constraint may be the foreign key, check or default constraint which is not in the catalog.
+		if index, exists := table.mssqlGetIndex(constraintName); exists {
+			delete(table.indexSet, index.name)
+		}
+	}
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *mssqlListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	if l.err != nil {
+		return
+	}
+	schema, table, err := l.findTable(ctx.Table_name())
+	if err != nil {
+		l.err = err
+		return
+	}
+	if table == nil {
+		return
+	}
+	indexName, _ := tsqlparser.NormalizeTSQLIdentifier(ctx.Id_(0))
+	keyList := mssqlColumnNameListWithOrder(ctx.Column_name_list_with_order())
+	if schema.ctx.CheckIntegrity {
+		if err := table.mssqlValidateKeyList(keyList); err != nil {
+			l.err = err
+			return
+		}
+	}
+	if err := table.mssqlCreateIndex(indexName, keyList, ctx.UNIQUE() != nil, false /* primary */, mssqlIndexType(ctx.Clustered())); err != nil {
+		l.err = err
+	}
+}
+
+// EnterDrop_index is called when production drop_index is entered.
+func (l *mssqlListener) EnterDrop_index(ctx *parser.Drop_indexContext) {
+	if l.err != nil {
+		return
+	}
+	ifExists := ctx.EXISTS() != nil
+	for _, dropIndex := range ctx.AllDrop_relational_or_xml_or_spatial_index() {
+		fullTableName := dropIndex.Full_table_name()
+		if fullTableName == nil {
+			continue
+		}
+		databaseName, _ := tsqlparser.NormalizeTSQLIdentifier(fullTableName.GetDatabase())
+		schemaName, _ := tsqlparser.NormalizeTSQLIdentifier(fullTableName.GetSchema())
+		tableName, _ := tsqlparser.NormalizeTSQLIdentifier(fullTableName.GetTable())
+		indexName, _ := tsqlparser.NormalizeTSQLIdentifier(dropIndex.GetIndex_name())
+		if err := l.dropIndex(databaseName, schemaName, tableName, indexName, ifExists); err != nil {
+			l.err = err
+			return
+		}
+	}
+	for _, dropIndex := range ctx.AllDrop_backward_compatible_index() {
+		schemaName, _ := tsqlparser.NormalizeTSQLIdentifier(dropIndex.GetOwner_name())
+		tableName, _ := tsqlparser.NormalizeTSQLIdentifier(dropIndex.GetTable_or_view_name())
+		indexName, _ := tsqlparser.NormalizeTSQLIdentifier(dropIndex.GetIndex_name())
+		if err := l.dropIndex("" /* databaseName */, schemaName, tableName, indexName, ifExists); err != nil {
+			l.err = err
+			return
+		}
+	}
+}
+
+func (l *mssqlListener) dropIndex(databaseName string, schemaName string, tableName string, indexName string, ifExists bool) *WalkThroughError {
+	if databaseName != "" && !strings.EqualFold(databaseName, l.databaseState.name) {
+		return nil
+	}
+	if schemaName == "" {
+		schemaName = mssqlDefaultSchemaName
+	}
+	schema := l.databaseState.mssqlGetOrCreateSchema(schemaName)
+	table, exists := schema.mssqlGetTable(tableName)
+	if !exists {
+		if ifExists || !schema.ctx.CheckIntegrity {
+			return nil
+		}
+		return NewTableNotExistsError(tableName)
+	}
+	index, exists := table.mssqlGetIndex(indexName)
+	if !exists {
+		if ifExists || !schema.ctx.CheckIntegrity {
+			return nil
+		}
+		return NewIndexNotExistsError(table.name, indexName)
+	}
+	delete(table.indexSet, index.name)
+	return nil
+}
+
+// resolveTableName returns the schema state and the table name for the table name context.
+// It returns false if the table is in other databases, which is out of the catalog.
+func (l *mssqlListener) resolveTableName(ctx parser.ITable_nameContext, fallbackSchemaName string) (*SchemaState, string, bool) {
+	if ctx == nil || ctx.GetTable() == nil {
+		return nil, "", false
+	}
+	databaseName, _ := tsqlparser.NormalizeTSQLIdentifier(ctx.GetDatabase())
+	if databaseName != "" && !strings.EqualFold(databaseName, l.databaseState.name) {
+		return nil, "", false
+	}
+	schemaName, _ := tsqlparser.NormalizeTSQLIdentifier(ctx.GetSchema())
+	if schemaName == "" {
+		schemaName = fallbackSchemaName
+	}
+	tableName, _ := tsqlparser.NormalizeTSQLIdentifier(ctx.GetTable())
+	return l.databaseState.mssqlGetOrCreateSchema(schemaName), tableName, true
+}
+
+// findTable returns the schema state and the table state for the table name context.
+// It returns nil table without error if the table is out of the catalog.
+func (l *mssqlListener) findTable(ctx parser.ITable_nameContext) (*SchemaState, *TableState, *WalkThroughError) {
+	schema, tableName, ok := l.resolveTableName(ctx, mssqlDefaultSchemaName)
+	if !ok {
+		return nil, nil, nil
+	}
+	table, exists := schema.mssqlGetTable(tableName)
+	if !exists {
+		if schema.ctx.CheckIntegrity {
+			return nil, nil, NewTableNotExistsError(tableName)
+		}
+		table = schema.createIncompleteTable(tableName)
+	}
+	return schema, table, nil
+}
+
+// mssqlGetSchema finds the schema case-insensitively.
+func (d *DatabaseState) mssqlGetSchema(name string) (*SchemaState, bool) {
+	for schemaName, schema := range d.schemaSet {
+		if strings.EqualFold(schemaName, name) {
+			return schema, true
+		}
+	}
+	return nil, false
+}
+
+// mssqlGetOrCreateSchema finds the schema case-insensitively.
+// If the schema is not in the catalog, we create it and skip the integrity checking for the objects in it,
+// because we cannot tell whether the schema is empty or the catalog is incomplete.
+func (d *DatabaseState) mssqlGetOrCreateSchema(name string) *SchemaState {
+	if schema, exists := d.mssqlGetSchema(name); exists {
+		return schema
+	}
+	schema := d.createSchema(name)
+	schema.ctx.CheckIntegrity = false
+	return schema
+}
+
+// mssqlGetTable finds the table case-insensitively.
+func (s *SchemaState) mssqlGetTable(name string) (*TableState, bool) {
+	for tableName, table := range s.tableSet {
+		if strings.EqualFold(tableName, name) {
+			return table, true
+		}
+	}
+	return nil, false
+}
+
+// mssqlGetColumn finds the column case-insensitively.
+func (t *TableState) mssqlGetColumn(name string) (*ColumnState, bool) {
+	for columnName, column := range t.columnSet {
+		if strings.EqualFold(columnName, name) {
+			return column, true
+		}
+	}
+	return nil, false
+}
+
+// mssqlGetIndex finds the index case-insensitively.
+func (t *TableState) mssqlGetIndex(name string) (*IndexState, bool) {
+	for indexName, index := range t.indexSet {
+		if strings.EqualFold(indexName, name) {
+			return index, true
+		}
+	}
+	return nil, false
+}
+
+// mssqlCreateColumnsAndConstraints creates the columns first, then the constraints, because the constraints may refer to the columns defined after them.
+func (t *TableState) mssqlCreateColumnsAndConstraints(ctx parser.IColumn_def_table_constraintsContext, checkIntegrity bool) *WalkThroughError {
+	for _, element := range ctx.AllColumn_def_table_constraint() {
+		if element.Column_definition() == nil {
+			continue
+		}
+		if err := t.mssqlCreateColumn(element.Column_definition()); err != nil {
+			err.Line = element.GetStart().GetLine()
+			return err
+		}
+	}
+	for _, element := range ctx.AllColumn_def_table_constraint() {
+		if element.Table_constraint() == nil {
+			continue
+		}
+		if err := t.mssqlCreateTableConstraint(element.Table_constraint(), checkIntegrity); err != nil {
+			err.Line = element.GetStart().GetLine()
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TableState) mssqlCreateColumn(ctx parser.IColumn_definitionContext) *WalkThroughError {
+	columnName, _ := tsqlparser.NormalizeTSQLIdentifier(ctx.Id_())
+	if _, exists := t.mssqlGetColumn(columnName); exists {
+		return &WalkThroughError{
+			Type:    ErrorTypeColumnExists,
+			Content: fmt.Sprintf("Column `%s` already exists in table `%s`", columnName, t.name),
+		}
+	}
+
+	column := &ColumnState{
+		name:     columnName,
+		position: newIntPointer(len(t.columnSet) + 1),
+		nullable: newTruePointer(),
+	}
+	if ctx.Data_type() != nil {
+		column.columnType = newStringPointer(ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx.Data_type()))
+	}
+	t.columnSet[column.name] = column
+
+	for _, element := range ctx.AllColumn_definition_element() {
+		switch {
+		case element.DEFAULT() != nil && element.GetConstant_expr() != nil:
+			column.defaultValue = newStringPointer(element.GetParser().GetTokenStream().GetTextFromRuleContext(element.GetConstant_expr()))
+		case element.COLLATE() != nil && element.GetCollation_name() != nil:
+			column.collation = newStringPointer(element.GetCollation_name().GetText())
+		case element.IDENTITY() != nil:
+			// The IDENTITY columns are NOT NULL implicitly.
+			column.nullable = newFalsePointer()
+		case element.Column_constraint() != nil:
+			if err := t.mssqlCreateColumnConstraint(column, element.Column_constraint()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if columnIndex := ctx.Column_index(); columnIndex != nil {
+		indexName, _ := tsqlparser.NormalizeTSQLIdentifier(columnIndex.GetIndex_name())
+		if err := t.mssqlCreateIndex(indexName, []string{column.name}, false /* unique */, false /* primary */, mssqlIndexType(columnIndex.Clustered())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TableState) mssqlCreateColumnConstraint(column *ColumnState, ctx parser.IColumn_constraintContext) *WalkThroughError {
+	switch {
+	case ctx.Null_notnull() != nil:
+		column.nullable = newBoolPointer(ctx.Null_notnull().NOT() == nil)
+	case ctx.PRIMARY() != nil:
+		column.nullable = newFalsePointer()
+		return t.mssqlCreateIndex(mssqlConstraintName(ctx.GetConstraint()), []string{column.name}, true /* unique */, true /* primary */, mssqlIndexType(ctx.Clustered()))
+	case ctx.UNIQUE() != nil:
+		return t.mssqlCreateIndex(mssqlConstraintName(ctx.GetConstraint()), []string{column.name}, true /* unique */, false /* primary */, mssqlIndexType(ctx.Clustered()))
+	}
+	return nil
+}
+
+func (t *TableState) mssqlCreateTableConstraint(ctx parser.ITable_constraintContext, checkIntegrity bool) *WalkThroughError {
+	if ctx.PRIMARY() == nil && ctx.UNIQUE() == nil {
+		// We don't store the foreign key, check and default constraints in the catalog.
+		return nil
+	}
+	keyList := mssqlColumnNameListWithOrder(ctx.Column_name_list_with_order())
+	if checkIntegrity {
+		if err := t.mssqlValidateKeyList(keyList); err != nil {
+			return err
+		}
+	}
+	if ctx.PRIMARY() != nil {
+		for _, key := range keyList {
+			if column, exists := t.mssqlGetColumn(key); exists {
+				column.nullable = newFalsePointer()
+			}
+		}
+	}
+	return t.mssqlCreateIndex(mssqlConstraintName(ctx.GetConstraint()), keyList, true /* unique */, ctx.PRIMARY() != nil, mssqlIndexType(ctx.Clustered()))
+}
+
+func (t *TableState) mssqlCreateTableIndex(ctx parser.ITable_indicesContext) *WalkThroughError {
+	indexName, _ := tsqlparser.NormalizeTSQLIdentifier(ctx.Id_(0))
+	var keyList []string
+	if ctx.Column_name_list_with_order() != nil {
+		keyList = mssqlColumnNameListWithOrder(ctx.Column_name_list_with_order())
+	} else if ctx.Column_name_list() != nil {
+		for _, id := range ctx.Column_name_list().AllId_() {
+			columnName, _ := tsqlparser.NormalizeTSQLIdentifier(id)
+			keyList = append(keyList, columnName)
+		}
+	}
+	if err := t.mssqlValidateKeyList(keyList); err != nil {
+		return err
+	}
+	return t.mssqlCreateIndex(indexName, keyList, ctx.UNIQUE() != nil, false /* primary */, mssqlIndexType(ctx.Clustered()))
+}
+
+// mssqlValidateKeyList checks the key columns exist in the table.
+func (t *TableState) mssqlValidateKeyList(keyList []string) *WalkThroughError {
+	for _, key := range keyList {
+		if _, exists := t.mssqlGetColumn(key); !exists {
+			return NewColumnNotExistsError(t.name, key)
+		}
+	}
+	return nil
+}
+
+func (t *TableState) mssqlCreateIndex(name string, keyList []string, unique bool, primary bool, tp string) *WalkThroughError {
+	if primary {
+		for _, index := range t.indexSet {
+			if index.Primary() {
+				return &WalkThroughError{
+					Type:    ErrorTypePrimaryKeyExists,
+					Content: fmt.Sprintf("Primary key exists in table `%s`", t.name),
+				}
+			}
+		}
+	}
+	if name == "" {
+		// SQL Server generates the name such as PK__Book__3214EC07ABCDEF12, we use a stable one instead.
+		prefix := "UQ"
+		if primary {
+			prefix = "PK"
+		}
+		name = generateConstraintName(t, fmt.Sprintf("%s__%s", prefix, t.name))
+	}
+	if _, exists := t.mssqlGetIndex(name); exists {
+		return NewIndexExistsError(t.name, name)
+	}
+
+	t.indexSet[name] = &IndexState{
+		name:           name,
+		expressionList: keyList,
+		indexType:      newStringPointer(tp),
+		unique:         newBoolPointer(unique),
+		primary:        newBoolPointer(primary),
+		visible:        newTruePointer(),
+		comment:        newEmptyStringPointer(),
+	}
+	return nil
+}
+
+func (t *TableState) mssqlAlterColumn(ctx *FinderContext, definition parser.IColumn_definitionContext) *WalkThroughError {
+	columnName, _ := tsqlparser.NormalizeTSQLIdentifier(definition.Id_())
+	column, exists := t.mssqlGetColumn(columnName)
+	if !exists {
+		if ctx.CheckIntegrity {
+			return NewColumnNotExistsError(t.name, columnName)
+		}
+		column = t.createIncompleteColumn(columnName)
+	}
+	if definition.Data_type() != nil {
+		column.columnType = newStringPointer(definition.GetParser().GetTokenStream().GetTextFromRuleContext(definition.Data_type()))
+	}
+	// ALTER COLUMN without NOT NULL makes the column nullable.
+	column.nullable = newTruePointer()
+	for _, element := range definition.AllColumn_definition_element() {
+		switch {
+		case element.COLLATE() != nil && element.GetCollation_name() != nil:
+			column.collation = newStringPointer(element.GetCollation_name().GetText())
+		case element.Column_constraint() != nil && element.Column_constraint().Null_notnull() != nil:
+			column.nullable = newBoolPointer(element.Column_constraint().Null_notnull().NOT() == nil)
+		}
+	}
+	return nil
+}
+
+func (t *TableState) mssqlDropColumn(ctx *FinderContext, columnName string) *WalkThroughError {
+	column, exists := t.mssqlGetColumn(columnName)
+	if !exists {
+		if ctx.CheckIntegrity {
+			return NewColumnNotExistsError(t.name, columnName)
+		}
+		return nil
+	}
+
+	// SQL Server disallows dropping the columns used in an index, but the index may be dropped
+	// in the same ALTER TABLE statement, so we remove the column from the indexes instead.
+	for _, index := range t.indexSet {
+		var expressionList []string
+		for _, expression := range index.expressionList {
+			if !strings.EqualFold(expression, column.name) {
+				expressionList = append(expressionList, expression)
+			}
+		}
+		index.expressionList = expressionList
+		if len(index.expressionList) == 0 {
+			delete(t.indexSet, index.name)
+		}
+	}
+
+	if column.position != nil {
+		for _, col := range t.columnSet {
+			if col.position != nil && *col.position > *column.position {
+				*col.position--
+			}
+		}
+	}
+	delete(t.columnSet, column.name)
+	return nil
+}
+
+func mssqlColumnNameListWithOrder(ctx parser.IColumn_name_list_with_orderContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	var result []string
+	for _, id := range ctx.AllId_() {
+		columnName, _ := tsqlparser.NormalizeTSQLIdentifier(id)
+		result = append(result, columnName)
+	}
+	return result
+}
+
+func mssqlConstraintName(id parser.IId_Context) string {
+	name, _ := tsqlparser.NormalizeTSQLIdentifier(id)
+	return name
+}
+
+func mssqlIndexType(ctx parser.IClusteredContext) string {
+	if ctx != nil && ctx.CLUSTERED() != nil {
+		return "CLUSTERED"
+	}
+	return "NONCLUSTERED"
+}
+
+// generateConstraintName generates the unique name with the prefix for the unnamed constraint.
+func generateConstraintName(t *TableState, prefix string) string {
+	name := prefix
+	for suffix := 2; ; suffix++ {
+		if _, exists := t.indexSet[name]; !exists {
+			return name
+		}
+		name = fmt.Sprintf("%s_%d", prefix, suffix)
+	}
+}
@@ -0,0 +1,619 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/plsql-parser"
+
+	plsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
+)
+
+func (d *DatabaseState) oracleWalkThrough(stmt string) error {
+	if d.deleted {
+		return &WalkThroughError{
+			Type:    ErrorTypeDatabaseIsDeleted,
+			Content: fmt.Sprintf("Database `%s` is deleted", d.name),
+		}
+	}
+
+	tree, _, err := plsqlparser.ParsePLSQL(stmt + ";")
+	if err != nil {
+		return NewParseError(err.Error())
+	}
+
+	listener := &oracleListener{
+		databaseState: d,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	if listener.err != nil {
+		if listener.err.Line == 0 {
+			listener.err.Line = listener.lineNumber
+		}
+		return listener.err
+	}
+	return nil
+}
+
+type oracleListener struct {
+	*parser.BasePlSqlParserListener
+
+	lineNumber    int
+	databaseState *DatabaseState
+	err           *WalkThroughError
+
+	// currentSchema and currentTable are the schema and table of the ALTER TABLE statement being walked through.
+	currentSchema *SchemaState
+	currentTable  *TableState
+}
+
+// EnterUnit_statement is called when production unit_statement is entered.
+func (l *oracleListener) EnterUnit_statement(ctx *parser.Unit_statementContext) {
+	if l.err != nil {
+		return
+	}
+	l.lineNumber = ctx.GetStart().GetLine()
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *oracleListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName := l.databaseState.name
+	if ctx.Schema_name() != nil {
+		schemaName = plsqlparser.NormalizeSchemaName(ctx.Schema_name())
+	}
+	tableName := plsqlparser.NormalizeTableName(ctx.Table_name())
+	if schemaName != l.databaseState.name {
+		// In Oracle, the schema is regarded as the database, so the tables in other schemas are out of the catalog.
+		return
+	}
+	schema := l.databaseState.oracleGetOrCreateSchema(schemaName)
+	if _, exists := schema.tableSet[tableName]; exists {
+		l.err = NewTableExistsError(tableName)
+		return
+	}
+
+	table := &TableState{
+		name:      tableName,
+		engine:    newEmptyStringPointer(),
+		collation: newEmptyStringPointer(),
+		comment:   newEmptyStringPointer(),
+		columnSet: make(columnStateMap),
+		indexSet:  make(IndexStateMap),
+	}
+	schema.tableSet[table.name] = table
+
+	if ctx.Relational_table() == nil {
+		return
+	}
+	propertyList := ctx.Relational_table().AllRelational_property()
+	// Create the columns first, because the out-of-line constraints may refer to the columns defined after them.
+	for _, property := range propertyList {
+		if property.Column_definition() == nil {
+			continue
+		}
+		if err := table.oracleCreateColumn(property.Column_definition()); err != nil {
+			err.Line = property.GetStart().GetLine()
+			l.err = err
+			return
+		}
+	}
+	for _, property := range propertyList {
+		if property.Out_of_line_constraint() == nil {
+			continue
+		}
+		if err := table.oracleCreateOutOfLineConstraint(property.Out_of_line_constraint(), true /* checkIntegrity */); err != nil {
+			err.Line = property.GetStart().GetLine()
+			l.err = err
+			return
+		}
+	}
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *oracleListener) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName, tableName := plsqlparser.NormalizeTableViewName(l.databaseState.name, ctx.Tableview_name())
+	if tableName == "" || schemaName != l.databaseState.name {
+		return
+	}
+	schema := l.databaseState.oracleGetOrCreateSchema(schemaName)
+	if _, exists := schema.tableSet[tableName]; !exists {
+		if schema.ctx.CheckIntegrity {
+			l.err = NewTableNotExistsError(tableName)
+		}
+		return
+	}
+	delete(schema.tableSet, tableName)
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *oracleListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if l.err != nil {
+		return
+	}
+	schema, table, err := l.findTable(ctx.Tableview_name())
+	if err != nil {
+		l.err = err
+		return
+	}
+	if table == nil {
+		return
+	}
+	l.currentSchema = schema
+	l.currentTable = table
+
+	if properties := ctx.Alter_table_properties(); properties != nil && properties.RENAME() != nil && properties.Tableview_name() != nil {
+		_, newTableName := plsqlparser.NormalizeTableViewName(schema.name, properties.Tableview_name())
+		if _, exists := schema.tableSet[newTableName]; exists {
+			l.err = NewTableExistsError(newTableName)
+			return
+		}
+		delete(schema.tableSet, table.name)
+		table.name = newTableName
+		schema.tableSet[table.name] = table
+	}
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *oracleListener) ExitAlter_table(*parser.Alter_tableContext) {
+	l.currentSchema = nil
+	l.currentTable = nil
+}
+
+// EnterAdd_column_clause is called when production add_column_clause is entered.
+func (l *oracleListener) EnterAdd_column_clause(ctx *parser.Add_column_clauseContext) {
+	if l.err != nil || l.currentTable == nil {
+		return
+	}
+	for _, column := range ctx.AllColumn_definition() {
+		if err := l.currentTable.oracleCreateColumn(column); err != nil {
+			err.Line = column.GetStart().GetLine()
+			l.err = err
+			return
+		}
+	}
+}
+
+// EnterModify_col_properties is called when production modify_col_properties is entered.
+func (l *oracleListener) EnterModify_col_properties(ctx *parser.Modify_col_propertiesContext) {
+	if l.err != nil || l.currentTable == nil {
+		return
+	}
+	_, _, columnName := plsqlparser.NormalizeColumnName(ctx.Column_name())
+	column, exists := l.currentTable.columnSet[columnName]
+	if !exists {
+		if l.currentSchema.ctx.CheckIntegrity {
+			l.err = NewColumnNotExistsError(l.currentTable.name, columnName)
+			return
+		}
+		column = &ColumnState{name: columnName}
+		l.currentTable.columnSet[columnName] = column
+	}
+	if ctx.Datatype() != nil {
+		column.columnType = newStringPointer(ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx.Datatype()))
+	}
+	if ctx.DEFAULT() != nil && ctx.Expression() != nil {
+		column.defaultValue = newStringPointer(ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx.Expression()))
+	}
+	for _, constraint := range ctx.AllInline_constraint() {
+		if err := l.currentTable.oracleCreateInlineConstraint(column, constraint); err != nil {
+			l.err = err
+			return
+		}
+	}
+}
+
+// EnterDrop_column_clause is called when production drop_column_clause is entered.
+func (l *oracleListener) EnterDrop_column_clause(ctx *parser.Drop_column_clauseContext) {
+	if l.err != nil || l.currentTable == nil {
+		return
+	}
+	// SET UNUSED makes the columns invisible forever, so we regard it as dropping the columns.
+	for _, columnNameCtx := range ctx.AllColumn_name() {
+		_, _, columnName := plsqlparser.NormalizeColumnName(columnNameCtx)
+		if err := l.currentTable.oracleDropColumn(l.currentSchema.ctx, columnName); err != nil {
+			l.err = err
+			return
+		}
+	}
+}
+
+// EnterRename_column_clause is called when production rename_column_clause is entered.
+func (l *oracleListener) EnterRename_column_clause(ctx *parser.Rename_column_clauseContext) {
+	if l.err != nil || l.currentTable == nil {
+		return
+	}
+	_, _, oldName := plsqlparser.NormalizeColumnName(ctx.Old_column_name().Column_name())
+	_, _, newName := plsqlparser.NormalizeColumnName(ctx.New_column_name().Column_name())
+	column, exists := l.currentTable.columnSet[oldName]
+	if !exists {
+		if l.currentSchema.ctx.CheckIntegrity {
+			l.err = NewColumnNotExistsError(l.currentTable.name, oldName)
+			return
+		}
+		column = &ColumnState{name: oldName}
+	}
+	if _, exists := l.currentTable.columnSet[newName]; exists {
+		l.err = &WalkThroughError{
+			Type:    ErrorTypeColumnExists,
+			Content: fmt.Sprintf("Column `%s` already exists in table `%s`", newName, l.currentTable.name),
+		}
+		return
+	}
+	delete(l.currentTable.columnSet, oldName)
+	column.name = newName
+	l.currentTable.columnSet[newName] = column
+	for _, index := range l.currentTable.indexSet {
+		for i, expression := range index.expressionList {
+			if expression == oldName {
+				index.expressionList[i] = newName
+			}
+		}
+	}
+}
+
+// EnterConstraint_clauses is called when production constraint_clauses is entered.
+func (l *oracleListener) EnterConstraint_clauses(ctx *parser.Constraint_clausesContext) {
+	if l.err != nil || l.currentTable == nil {
+		return
+	}
+	table := l.currentTable
+	switch {
+	case ctx.ADD() != nil:
+		for _, constraint := range ctx.AllOut_of_line_constraint() {
+			if err := table.oracleCreateOutOfLineConstraint(constraint, l.currentSchema.ctx.CheckIntegrity); err != nil {
+				l.err = err
+				return
+			}
+		}
+	case ctx.RENAME() != nil:
+		_, oldName := plsqlparser.NormalizeConstraintName(ctx.Old_constraint_name().Constraint_name())
+		_, newName := plsqlparser.NormalizeConstraintName(ctx.New_constraint_name().Constraint_name())
+		// The constraint may be the foreign key or check constraint which is not in the catalog.
+		index, exists := table.indexSet[oldName]
+		if !exists {
+			return
+		}
+		if _, exists := table.indexSet[newName]; exists {
+			l.err = NewIndexExistsError(table.name, newName)
+			return
+		}
+		delete(table.indexSet, oldName)
+		index.name = newName
+		table.indexSet[newName] = index
+	default:
+		for _, dropConstraint := range ctx.AllDrop_constraint_clause() {
+			if err := table.oracleDropConstraint(l.currentSchema.ctx, dropConstraint.Drop_primary_key_or_unique_or_generic_clause()); err != nil {
+				l.err = err
+				return
+			}
+		}
+	}
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *oracleListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	if l.err != nil {
+		return
+	}
+	tableIndex := ctx.Table_index_clause()
+	if tableIndex == nil {
+		return
+	}
+	schema, table, err := l.findTable(tableIndex.Tableview_name())
+	if err != nil {
+		l.err = err
+		return
+	}
+	if table == nil {
+		return
+	}
+	_, indexName := plsqlparser.NormalizeIndexName(ctx.Index_name())
+	// In Oracle, the index name is unique in the schema.
+	if indexTable, _ := schema.oracleGetIndex(indexName); indexTable != nil {
+		l.err = NewIndexExistsError(indexTable.name, indexName)
+		return
+	}
+
+	var keyList []string
+	for _, option := range tableIndex.AllIndex_expr_option() {
+		indexExpr := option.Index_expr()
+		if indexExpr.Column_name() != nil {
+			_, _, columnName := plsqlparser.NormalizeColumnName(indexExpr.Column_name())
+			if _, exists := table.columnSet[columnName]; !exists && schema.ctx.CheckIntegrity {
+				l.err = NewColumnNotExistsError(table.name, columnName)
+				return
+			}
+			keyList = append(keyList, columnName)
+			continue
+		}
+		keyList = append(keyList, indexExpr.GetParser().GetTokenStream().GetTextFromRuleContext(indexExpr))
+	}
+
+	tp := "NORMAL"
+	if ctx.BITMAP() != nil {
+		tp = "BITMAP"
+	}
+	if err := table.oracleCreateIndex(indexName, keyList, ctx.UNIQUE() != nil, false /* primary */, tp); err != nil {
+		l.err = err
+	}
+}
+
+// EnterDrop_index is called when production drop_index is entered.
+func (l *oracleListener) EnterDrop_index(ctx *parser.Drop_indexContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName, indexName := plsqlparser.NormalizeIndexName(ctx.Index_name())
+	if schemaName == "" {
+		schemaName = l.databaseState.name
+	}
+	if schemaName != l.databaseState.name {
+		return
+	}
+	schema := l.databaseState.oracleGetOrCreateSchema(schemaName)
+	table, index := schema.oracleGetIndex(indexName)
+	if table == nil {
+		if schema.ctx.CheckIntegrity {
+			l.err = &WalkThroughError{
+				Type:    ErrorTypeIndexNotExists,
+				Content: fmt.Sprintf("Index `%s` does not exist in schema `%s`", indexName, schema.name),
+			}
+		}
+		return
+	}
+	delete(table.indexSet, index.name)
+}
+
+// findTable returns the schema state and the table state for the table name context.
+// It returns nil table without error if the table is out of the catalog.
+func (l *oracleListener) findTable(ctx parser.ITableview_nameContext) (*SchemaState, *TableState, *WalkThroughError) {
+	if ctx == nil {
+		return nil, nil, nil
+	}
+	schemaName, tableName := plsqlparser.NormalizeTableViewName(l.databaseState.name, ctx)
+	if tableName == "" || schemaName != l.databaseState.name {
+		return nil, nil, nil
+	}
+	schema := l.databaseState.oracleGetOrCreateSchema(schemaName)
+	table, exists := schema.tableSet[tableName]
+	if !exists {
+		if schema.ctx.CheckIntegrity {
+			return nil, nil, NewTableNotExistsError(tableName)
+		}
+		table = schema.createIncompleteTable(tableName)
+	}
+	return schema, table, nil
+}
+
+// oracleGetOrCreateSchema returns the schema state.
+// If the schema is not in the catalog, we create it and skip the integrity checking for the objects in it,
+// because we cannot tell whether the schema is empty or the catalog is incomplete.
+func (d *DatabaseState) oracleGetOrCreateSchema(name string) *SchemaState {
+	if schema, exists := d.schemaSet[name]; exists {
+		return schema
+	}
+	schema := d.createSchema(name)
+	schema.ctx.CheckIntegrity = false
+	return schema
+}
+
+// oracleGetIndex returns the index and its table in the schema.
+func (s *SchemaState) oracleGetIndex(name string) (*TableState, *IndexState) {
+	for _, table := range s.tableSet {
+		if index, exists := table.indexSet[name]; exists {
+			return table, index
+		}
+	}
+	return nil, nil
+}
+
+func (t *TableState) oracleCreateColumn(ctx parser.IColumn_definitionContext) *WalkThroughError {
+	_, _, columnName := plsqlparser.NormalizeColumnName(ctx.Column_name())
+	if _, exists := t.columnSet[columnName]; exists {
+		return &WalkThroughError{
+			Type:    ErrorTypeColumnExists,
+			Content: fmt.Sprintf("Column `%s` already exists in table `%s`", columnName, t.name),
+		}
+	}
+
+	column := &ColumnState{
+		name:     columnName,
+		position: newIntPointer(len(t.columnSet) + 1),
+		nullable: newTruePointer(),
+	}
+	tokens := ctx.GetParser().GetTokenStream()
+	switch {
+	case ctx.Datatype() != nil:
+		column.columnType = newStringPointer(tokens.GetTextFromRuleContext(ctx.Datatype()))
+	case ctx.Regular_id() != nil:
+		column.columnType = newStringPointer(tokens.GetTextFromRuleContext(ctx.Regular_id()))
+	}
+	if ctx.DEFAULT() != nil && ctx.Expression() != nil {
+		column.defaultValue = newStringPointer(tokens.GetTextFromRuleContext(ctx.Expression()))
+	}
+	if ctx.Identity_clause() != nil {
+		// The identity columns are NOT NULL implicitly.
+		column.nullable = newFalsePointer()
+	}
+	t.columnSet[column.name] = column
+
+	for _, constraint := range ctx.AllInline_constraint() {
+		if err := t.oracleCreateInlineConstraint(column, constraint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TableState) oracleCreateInlineConstraint(column *ColumnState, ctx parser.IInline_constraintContext) *WalkThroughError {
+	_, constraintName := plsqlparser.NormalizeConstraintName(ctx.Constraint_name())
+	switch {
+	case ctx.NULL_() != nil:
+		column.nullable = newBoolPointer(ctx.NOT() == nil)
+	case ctx.PRIMARY() != nil:
+		column.nullable = newFalsePointer()
+		return t.oracleCreateIndex(constraintName, []string{column.name}, true /* unique */, true /* primary */, "NORMAL")
+	case ctx.UNIQUE() != nil:
+		return t.oracleCreateIndex(constraintName, []string{column.name}, true /* unique */, false /* primary */, "NORMAL")
+	}
+	return nil
+}
+
+func (t *TableState) oracleCreateOutOfLineConstraint(ctx parser.IOut_of_line_constraintContext, checkIntegrity bool) *WalkThroughError {
+	if ctx.PRIMARY() == nil && ctx.UNIQUE() == nil {
+		// We don't store the foreign key and check constraints in the catalog.
+		return nil
+	}
+	var keyList []string
+	for _, columnNameCtx := range ctx.AllColumn_name() {
+		_, _, columnName := plsqlparser.NormalizeColumnName(columnNameCtx)
+		if _, exists := t.columnSet[columnName]; !exists && checkIntegrity {
+			return NewColumnNotExistsError(t.name, columnName)
+		}
+		keyList = append(keyList, columnName)
+	}
+	if ctx.PRIMARY() != nil {
+		for _, key := range keyList {
+			if column, exists := t.columnSet[key]; exists {
+				column.nullable = newFalsePointer()
+			}
+		}
+	}
+	_, constraintName := plsqlparser.NormalizeConstraintName(ctx.Constraint_name())
+	return t.oracleCreateIndex(constraintName, keyList, true /* unique */, ctx.PRIMARY() != nil, "NORMAL")
+}
+
+func (t *TableState) oracleCreateIndex(name string, keyList []string, unique bool, primary bool, tp string) *WalkThroughError {
+	if primary {
+		for _, index := range t.indexSet {
+			if index.Primary() {
+				return &WalkThroughError{
+					Type:    ErrorTypePrimaryKeyExists,
+					Content: fmt.Sprintf("Primary key exists in table `%s`", t.name),
+				}
+			}
+		}
+	}
+	if name == "" {
+		// Oracle generates the name such as SYS_C008315, we use a stable one instead.
+		prefix := "UK"
+		if primary {
+			prefix = "PK"
+		}
+		name = generateConstraintName(t, fmt.Sprintf("%s_%s", t.name, prefix))
+	}
+	if _, exists := t.indexSet[name]; exists {
+		return NewIndexExistsError(t.name, name)
+	}
+
+	t.indexSet[name] = &IndexState{
+		name:           name,
+		expressionList: keyList,
+		indexType:      newStringPointer(tp),
+		unique:         newBoolPointer(unique),
+		primary:        newBoolPointer(primary),
+		visible:        newTruePointer(),
+		comment:        newEmptyStringPointer(),
+	}
+	return nil
+}
+
+func (t *TableState) oracleDropConstraint(ctx *FinderContext, clause parser.IDrop_primary_key_or_unique_or_generic_clauseContext) *WalkThroughError {
+	if clause == nil {
+		return nil
+	}
+	switch {
+	case clause.PRIMARY() != nil:
+		for _, index := range t.indexSet {
+			if index.Primary() {
+				delete(t.indexSet, index.name)
+				return nil
+			}
+		}
+		if ctx.CheckIntegrity {
+			return &WalkThroughError{
+				Type:    ErrorTypePrimaryKeyNotExists,
+				Content: fmt.Sprintf("Primary key does not exist in table `%s`", t.name),
+			}
+		}
+	case clause.UNIQUE() != nil:
+		var keyList []string
+		for _, columnNameCtx := range clause.AllColumn_name() {
+			_, _, columnName := plsqlparser.NormalizeColumnName(columnNameCtx)
+			keyList = append(keyList, columnName)
+		}
+		for _, index := range t.indexSet {
+			if index.Unique() && !index.Primary() && equalStringSlice(index.expressionList, keyList) {
+				delete(t.indexSet, index.name)
+				return nil
+			}
+		}
+		if ctx.CheckIntegrity {
+			return &WalkThroughError{
+				Type:    ErrorTypeConstraintNotExists,
+				Content: fmt.Sprintf("Unique constraint on (%s) does not exist in table `%s`", strings.Join(keyList, ", "), t.name),
+			}
+		}
+	default:
+		_, constraintName := plsqlparser.NormalizeConstraintName(clause.Constraint_name())
+		// The constraint may be the foreign key or check constraint which is not in the catalog.
+		delete(t.indexSet, constraintName)
+	}
+	return nil
+}
+
+func (t *TableState) oracleDropColumn(ctx *FinderContext, columnName string) *WalkThroughError {
+	column, exists := t.columnSet[columnName]
+	if !exists {
+		if ctx.CheckIntegrity {
+			return NewColumnNotExistsError(t.name, columnName)
+		}
+		return nil
+	}
+	if ctx.CheckIntegrity && len(t.columnSet) == 1 {
+		return &WalkThroughError{
+			Type: ErrorTypeDropAllColumns,
+			// Error content comes from Oracle error ORA-12983.
+			Content: fmt.Sprintf("Cannot drop all columns in table `%s`", t.name),
+		}
+	}
+
+	// The indexes and constraints on the dropped column are dropped as well.
+	for _, index := range t.indexSet {
+		for _, expression := range index.expressionList {
+			if expression == columnName {
+				delete(t.indexSet, index.name)
+				break
+			}
+		}
+	}
+
+	if column.position != nil {
+		for _, col := range t.columnSet {
+			if col.position != nil && *col.position > *column.position {
+				*col.position--
+			}
+		}
+	}
+	delete(t.columnSet, columnName)
+	return nil
+}
+
+func equalStringSlice(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
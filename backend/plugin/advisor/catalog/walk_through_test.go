@@ -120,6 +120,78 @@ func TestPostgreSQLWalkThrough(t *testing.T) {
 	}
 }
 
+func TestOracleWalkThrough(t *testing.T) {
+	originDatabase := &storepb.DatabaseSchemaMetadata{
+		Name: "TEST_DB",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "TEST_DB",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "TEST",
+						Columns: []*storepb.ColumnMetadata{
+							{
+								Name:     "ID",
+								Type:     "NUMBER",
+								Nullable: false,
+							},
+							{
+								Name:     "NAME",
+								Type:     "VARCHAR2(20)",
+								Nullable: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []string{
+		"oracle_walk_through",
+	}
+
+	for _, test := range tests {
+		runWalkThroughTest(t, test, storepb.Engine_ORACLE, originDatabase, false /* record */)
+	}
+}
+
+func TestMSSQLWalkThrough(t *testing.T) {
+	originDatabase := &storepb.DatabaseSchemaMetadata{
+		Name: "test",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "dbo",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "test",
+						Columns: []*storepb.ColumnMetadata{
+							{
+								Name:     "id",
+								Type:     "int",
+								Nullable: false,
+							},
+							{
+								Name:     "name",
+								Type:     "nvarchar(20)",
+								Nullable: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []string{
+		"mssql_walk_through",
+	}
+
+	for _, test := range tests {
+		runWalkThroughTest(t, test, storepb.Engine_MSSQL, originDatabase, false /* record */)
+	}
+}
+
 func convertInterfaceSliceToStringSlice(slice []any) []string {
 	var res []string
 	for _, item := range slice {
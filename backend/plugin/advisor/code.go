@@ -55,6 +55,9 @@ const (
 	StatementPriorBackupCheck                 Code = 228
 	StatementAddFKWithValidation              Code = 229
 	StatementNonTransactional                 Code = 230
+	StatementDisallowSelectInto               Code = 231
+	StatementDisallowNoLockHint               Code = 232
+	StatementMatchCustomRule                  Code = 233
 
 	// 301 ～ 399 naming error code
 	// 301 table naming advisor error code.
@@ -0,0 +1,176 @@
+package advisor
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// StatementView is the engine-neutral view of a statement exposed to the custom CEL rules.
+type StatementView struct {
+	// Type is the statement type, such as "UPDATE" or "CREATE_TABLE".
+	Type string
+	// Text is the normalized statement text.
+	Text string
+	// Tables are the tables touched by the statement.
+	Tables []string
+	// Columns are the columns referenced or defined by the statement.
+	Columns []string
+	// ObjectNames are the names of the objects created, altered or dropped by the statement.
+	ObjectNames []string
+	// HasWhere is true if the statement has a WHERE clause.
+	HasWhere bool
+	// AffectedRows is the estimated affected rows.
+	AffectedRows int64
+	// AffectedRowsErr is the error of estimating the affected rows.
+	// The rules referring to the affected rows report it instead of evaluating the statement.
+	AffectedRowsErr error
+	// Line is the line of the statement.
+	Line int
+}
+
+// CustomCELRuleEvaluator evaluates the user-defined CEL rules against statements.
+type CustomCELRuleEvaluator struct {
+	level Status
+	rules []*customCELRule
+}
+
+type customCELRule struct {
+	program    cel.Program
+	expression string
+	title      string
+	message    string
+	// needAffectedRows is true if the expression refers to the affected rows.
+	needAffectedRows bool
+}
+
+// NewCustomCELRuleEvaluator creates a new CustomCELRuleEvaluator for the rules in the payload.
+func NewCustomCELRuleEvaluator(rule *storepb.SQLReviewRule) (*CustomCELRuleEvaluator, error) {
+	level, err := NewStatusBySQLReviewRuleLevel(rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := UnmarshalCustomCELRulePayload(rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	evaluator := &CustomCELRuleEvaluator{level: level}
+	for _, customRule := range payload.Rules {
+		ast, program, err := common.ValidateSQLReviewCustomRuleCELExpr(customRule.Expression)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid expression %q of custom CEL rule %q", customRule.Expression, customRule.Name)
+		}
+		title := customRule.Title
+		if title == "" {
+			title = customRule.Name
+		}
+		evaluator.rules = append(evaluator.rules, &customCELRule{
+			program:          program,
+			expression:       customRule.Expression,
+			title:            title,
+			message:          customRule.Message,
+			needAffectedRows: common.IsCELVariableReferenced(ast, "statement.affected_rows"),
+		})
+	}
+	return evaluator, nil
+}
+
+// NeedAffectedRows returns true if any expression refers to the affected rows,
+// so the caller can skip the costly estimation otherwise.
+func (e *CustomCELRuleEvaluator) NeedAffectedRows() bool {
+	for _, rule := range e.rules {
+		if rule.needAffectedRows {
+			return true
+		}
+	}
+	return false
+}
+
+// Check evaluates the expressions against each statement and returns the advice list.
+func (e *CustomCELRuleEvaluator) Check(views []*StatementView) ([]Advice, error) {
+	var adviceList []Advice
+	for _, view := range views {
+		vars := map[string]any{
+			"statement.type":          view.Type,
+			"statement.text":          view.Text,
+			"statement.tables":        nonNilStrings(view.Tables),
+			"statement.columns":       nonNilStrings(view.Columns),
+			"statement.object_names":  nonNilStrings(view.ObjectNames),
+			"statement.has_where":     view.HasWhere,
+			"statement.affected_rows": view.AffectedRows,
+		}
+		for _, rule := range e.rules {
+			if rule.needAffectedRows && view.AffectedRowsErr != nil {
+				adviceList = append(adviceList, Advice{
+					Status:  e.level,
+					Code:    Internal,
+					Title:   rule.title,
+					Content: fmt.Sprintf("failed to estimate the affected rows of \"%s\": %s", view.Text, view.AffectedRowsErr.Error()),
+					Line:    view.Line,
+				})
+				continue
+			}
+			out, _, err := rule.program.Eval(vars)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to evaluate expression %q", rule.expression)
+			}
+			if matched, ok := out.Equal(celtypes.True).Value().(bool); !ok || !matched {
+				continue
+			}
+			content := rule.message
+			if content == "" {
+				content = fmt.Sprintf("\"%s\" violates the rule \"%s\"", view.Text, rule.title)
+			}
+			adviceList = append(adviceList, Advice{
+				Status:  e.level,
+				Code:    StatementMatchCustomRule,
+				Title:   rule.title,
+				Content: content,
+				Line:    view.Line,
+			})
+		}
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, Advice{
+			Status:  Success,
+			Code:    Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
+
+// NewGetTableDataSizeFunc returns the function getting the row count of the table from the database schema metadata.
+func NewGetTableDataSizeFunc(dbSchema *storepb.DatabaseSchemaMetadata, defaultSchema string) base.GetTableDataSizeFunc {
+	return func(schemaName, tableName string) int64 {
+		if schemaName == "" {
+			schemaName = defaultSchema
+		}
+		for _, schema := range dbSchema.GetSchemas() {
+			if schema.Name != schemaName {
+				continue
+			}
+			for _, table := range schema.Tables {
+				if table.Name == tableName {
+					return table.RowCount
+				}
+			}
+		}
+		return 0
+	}
+}
+
+func nonNilStrings(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
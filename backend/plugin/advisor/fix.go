@@ -0,0 +1,63 @@
+package advisor
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Fix is the suggested text edit to fix the advice.
+// The lines are one-based and the columns are zero-based in characters, the same as the advice.
+type Fix struct {
+	// Title describes the fix, e.g. "Create the index concurrently".
+	Title       string `json:"title"`
+	StartLine   int    `json:"startLine" yaml:"startLine"`
+	StartColumn int    `json:"startColumn" yaml:"startColumn"`
+	EndLine     int    `json:"endLine" yaml:"endLine"`
+	EndColumn   int    `json:"endColumn" yaml:"endColumn"`
+	// NewText is the text replacing the range.
+	NewText string `json:"newText" yaml:"newText"`
+}
+
+// NewFix returns the fix replacing text[start:end] with the new text, where the text is one statement in the statements.
+// The start and end are the byte offsets in the text, and the line is one of the one-based lines of the statement,
+// e.g. the last line, to tell apart the same statements.
+// Returns nil if the text is not found in the statements.
+func NewFix(title, statements, text string, line, start, end int, newText string) *Fix {
+	if text == "" || start < 0 || start > end || end > len(text) {
+		return nil
+	}
+	offset := -1
+	for i := 0; i <= len(statements); {
+		index := strings.Index(statements[i:], text)
+		if index < 0 {
+			break
+		}
+		// Choose the last occurrence starting at or before the line.
+		if strings.Count(statements[:i+index], "\n")+1 > line && offset >= 0 {
+			break
+		}
+		offset = i + index
+		i = offset + 1
+	}
+	if offset < 0 {
+		return nil
+	}
+	startLine, startColumn := getPosition(statements, offset+start)
+	endLine, endColumn := getPosition(statements, offset+end)
+	return &Fix{
+		Title:       title,
+		StartLine:   startLine,
+		StartColumn: startColumn,
+		EndLine:     endLine,
+		EndColumn:   endColumn,
+		NewText:     newText,
+	}
+}
+
+// getPosition returns the one-based line and zero-based column in characters of the byte offset.
+func getPosition(statements string, offset int) (int, int) {
+	prefix := statements[:offset]
+	line := strings.Count(prefix, "\n") + 1
+	lineStart := strings.LastIndex(prefix, "\n") + 1
+	return line, utf8.RuneCountInString(prefix[lineStart:])
+}
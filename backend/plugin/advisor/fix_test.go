@@ -0,0 +1,24 @@
+package advisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFix(t *testing.T) {
+	a := require.New(t)
+	statements := "CREATE INDEX ON t(a);\nSELECT '表';\nCREATE INDEX ON t(a);"
+	text := "CREATE INDEX ON t(a);"
+
+	fix := NewFix("title", statements, text, 3, 12, 12, " CONCURRENTLY")
+	a.Equal(&Fix{Title: "title", StartLine: 3, StartColumn: 12, EndLine: 3, EndColumn: 12, NewText: " CONCURRENTLY"}, fix)
+
+	fix = NewFix("title", statements, text, 1, 12, 12, " CONCURRENTLY")
+	a.Equal(1, fix.StartLine)
+
+	fix = NewFix("title", statements, "SELECT '表';", 2, 11, 11, " -- comment")
+	a.Equal(&Fix{Title: "title", StartLine: 2, StartColumn: 9, EndLine: 2, EndColumn: 9, NewText: " -- comment"}, fix)
+
+	a.Nil(NewFix("title", statements, "DROP TABLE t;", 1, 0, 0, ""))
+}
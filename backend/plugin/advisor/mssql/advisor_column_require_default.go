@@ -0,0 +1,190 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnRequireDefaultAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLRequireColumnDefault, &ColumnRequireDefaultAdvisor{})
+}
+
+// ColumnRequireDefaultAdvisor is the advisor checking for column default requirement.
+type ColumnRequireDefaultAdvisor struct {
+}
+
+// Check checks for column default requirement.
+func (*ColumnRequireDefaultAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &columnRequireDefaultChecker{
+		level:         level,
+		title:         string(ctx.Rule.Type),
+		columnSet:     make(map[string]*columnRequireDefaultData),
+		hasDefaultSet: make(map[string]bool),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+type columnRequireDefaultData struct {
+	table  string
+	column string
+	line   int
+}
+
+// columnRequireDefaultChecker is the listener for column default requirement.
+type columnRequireDefaultChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+
+	// currentNormalizedTableName is the normalized table name of the current table.
+	currentNormalizedTableName string
+	// currentOriginalTableName is the original table name of the current table.
+	currentOriginalTableName string
+	// columnSet is the map of the normalized "table.column" to the column without DEFAULT in the definition.
+	columnSet map[string]*columnRequireDefaultData
+	// hasDefaultSet is the set of the normalized "table.column" which doesn't need DEFAULT,
+	// e.g. the columns with the table level DEFAULT constraint and the primary key columns.
+	hasDefaultSet map[string]bool
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *columnRequireDefaultChecker) generateAdvice() ([]advisor.Advice, error) {
+	var columnList []*columnRequireDefaultData
+	for key, column := range l.columnSet {
+		if l.hasDefaultSet[key] {
+			continue
+		}
+		columnList = append(columnList, column)
+	}
+	sort.Slice(columnList, func(i, j int) bool {
+		return columnList[i].line < columnList[j].line
+	})
+	for _, column := range columnList {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.NoDefault,
+			Title:   l.title,
+			Content: fmt.Sprintf("Column [%s] in table [%s] doesn't have DEFAULT.", column.column, column.table),
+			Line:    column.line,
+		})
+	}
+
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *columnRequireDefaultChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.enterTable(ctx.Table_name())
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *columnRequireDefaultChecker) ExitCreate_table(*parser.Create_tableContext) {
+	l.currentNormalizedTableName = ""
+	l.currentOriginalTableName = ""
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *columnRequireDefaultChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if ctx.ADD() != nil && ctx.Column_def_table_constraints() != nil {
+		l.enterTable(ctx.Table_name(0))
+	}
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *columnRequireDefaultChecker) ExitAlter_table(*parser.Alter_tableContext) {
+	l.currentNormalizedTableName = ""
+	l.currentOriginalTableName = ""
+}
+
+func (l *columnRequireDefaultChecker) enterTable(tableName parser.ITable_nameContext) {
+	if tableName == nil {
+		return
+	}
+	l.currentNormalizedTableName = tsqlparser.NormalizeTSQLTableName(tableName, "" /* fallbackDatabase */, "dbo" /* fallbackSchema */, false /* caseSensitive */)
+	l.currentOriginalTableName = tableName.GetText()
+}
+
+// EnterColumn_definition is called when production column_definition is entered.
+func (l *columnRequireDefaultChecker) EnterColumn_definition(ctx *parser.Column_definitionContext) {
+	if l.currentNormalizedTableName == "" {
+		return
+	}
+	// The computed column.
+	if ctx.Data_type() == nil {
+		return
+	}
+	// The timestamp and rowversion columns are generated by SQL Server and cannot have DEFAULT.
+	switch strings.ToLower(ctx.Data_type().GetText()) {
+	case "timestamp", "rowversion":
+		return
+	}
+	for _, element := range ctx.AllColumn_definition_element() {
+		if element.DEFAULT() != nil || element.IDENTITY() != nil {
+			return
+		}
+		if constraint := element.Column_constraint(); constraint != nil && constraint.PRIMARY() != nil {
+			return
+		}
+	}
+	originalColumnName, normalizedColumnName := tsqlparser.NormalizeTSQLIdentifier(ctx.Id_())
+	l.columnSet[l.currentNormalizedTableName+"."+normalizedColumnName] = &columnRequireDefaultData{
+		table:  l.currentOriginalTableName,
+		column: originalColumnName,
+		line:   ctx.Id_().GetStart().GetLine(),
+	}
+}
+
+// EnterTable_constraint is called when production table_constraint is entered.
+func (l *columnRequireDefaultChecker) EnterTable_constraint(ctx *parser.Table_constraintContext) {
+	if l.currentNormalizedTableName == "" {
+		return
+	}
+	switch {
+	case ctx.DEFAULT() != nil && ctx.GetColumn() != nil:
+		_, normalizedColumnName := tsqlparser.NormalizeTSQLIdentifier(ctx.GetColumn())
+		l.hasDefaultSet[l.currentNormalizedTableName+"."+normalizedColumnName] = true
+	case ctx.PRIMARY() != nil && ctx.Column_name_list_with_order() != nil:
+		for _, column := range ctx.Column_name_list_with_order().AllId_() {
+			_, normalizedColumnName := tsqlparser.NormalizeTSQLIdentifier(column)
+			l.hasDefaultSet[l.currentNormalizedTableName+"."+normalizedColumnName] = true
+		}
+	}
+}
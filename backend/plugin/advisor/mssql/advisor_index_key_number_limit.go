@@ -0,0 +1,66 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexKeyNumberLimitAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLIndexKeyNumberLimit, &IndexKeyNumberLimitAdvisor{})
+}
+
+// IndexKeyNumberLimitAdvisor is the advisor checking for index key number limit.
+type IndexKeyNumberLimitAdvisor struct {
+}
+
+// Check checks for index key number limit.
+func (*IndexKeyNumberLimitAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	if payload.Number > 0 {
+		for _, definition := range getIndexDefinitions(tree, indexKindIndex, indexKindUnique, indexKindPrimaryKey) {
+			if len(definition.columns) > payload.Number {
+				adviceList = append(adviceList, advisor.Advice{
+					Status:  level,
+					Code:    advisor.IndexKeyNumberExceedsLimit,
+					Title:   string(ctx.Rule.Type),
+					Content: fmt.Sprintf("The number of keys of index %q in table %q should be not greater than %d", definition.name, definition.table, payload.Number),
+					Line:    definition.line,
+				})
+			}
+		}
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
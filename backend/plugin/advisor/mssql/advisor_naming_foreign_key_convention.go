@@ -0,0 +1,91 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*NamingFKConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLNamingFKConvention, &NamingFKConventionAdvisor{})
+}
+
+// NamingFKConventionAdvisor is the advisor checking for foreign key naming convention.
+type NamingFKConventionAdvisor struct {
+}
+
+// Check checks for foreign key naming convention.
+func (*NamingFKConventionAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, templateList, maxLength, err := advisor.UnmarshalNamingRulePayloadAsTemplate(advisor.SQLReviewRuleType(ctx.Rule.Type), ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, definition := range getIndexDefinitions(tree, indexKindForeignKey) {
+		metaData := map[string]string{
+			advisor.ReferencingTableNameTemplateToken:  definition.table,
+			advisor.ReferencingColumnNameTemplateToken: strings.Join(definition.columns, "_"),
+			advisor.ReferencedTableNameTemplateToken:   definition.referencedTable,
+			advisor.ReferencedColumnNameTemplateToken:  strings.Join(definition.referencedColumns, "_"),
+		}
+		regex, err := getTemplateRegexp(format, templateList, metaData)
+		if err != nil {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.Internal,
+				Title:   "Internal error for foreign key naming convention rule",
+				Content: fmt.Sprintf("%q meet internal error %q", definition.name, err.Error()),
+				Line:    definition.line,
+			})
+			continue
+		}
+		if !regex.MatchString(definition.name) {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingFKConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("Foreign key in table %q mismatches the naming convention, expect %q but found %q", definition.table, regex, definition.name),
+				Line:    definition.line,
+			})
+		}
+		if maxLength > 0 && len(definition.name) > maxLength {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingFKConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("Foreign key %q in table %q mismatches the naming convention, its length should be within %d characters", definition.name, definition.table, maxLength),
+				Line:    definition.line,
+			})
+		}
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
@@ -0,0 +1,89 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*NamingUKConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLNamingUKConvention, &NamingUKConventionAdvisor{})
+}
+
+// NamingUKConventionAdvisor is the advisor checking for unique key naming convention.
+type NamingUKConventionAdvisor struct {
+}
+
+// Check checks for unique key naming convention.
+func (*NamingUKConventionAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, templateList, maxLength, err := advisor.UnmarshalNamingRulePayloadAsTemplate(advisor.SQLReviewRuleType(ctx.Rule.Type), ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, definition := range getIndexDefinitions(tree, indexKindUnique) {
+		metaData := map[string]string{
+			advisor.ColumnListTemplateToken: strings.Join(definition.columns, "_"),
+			advisor.TableNameTemplateToken:  definition.table,
+		}
+		regex, err := getTemplateRegexp(format, templateList, metaData)
+		if err != nil {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.Internal,
+				Title:   "Internal error for unique key naming convention rule",
+				Content: fmt.Sprintf("%q meet internal error %q", definition.name, err.Error()),
+				Line:    definition.line,
+			})
+			continue
+		}
+		if !regex.MatchString(definition.name) {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingUKConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("Unique key in table %q mismatches the naming convention, expect %q but found %q", definition.table, regex, definition.name),
+				Line:    definition.line,
+			})
+		}
+		if maxLength > 0 && len(definition.name) > maxLength {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingUKConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("Unique key %q in table %q mismatches the naming convention, its length should be within %d characters", definition.name, definition.table, maxLength),
+				Line:    definition.line,
+			})
+		}
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
@@ -0,0 +1,122 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestStatementAffectedRowLimit(t *testing.T) {
+	a := require.New(t)
+	sql.Register("mssql-showplan-stub", &showplanDriver{})
+	db, err := sql.Open("mssql-showplan-stub", "")
+	a.NoError(err)
+	defer db.Close()
+
+	tests := []struct {
+		statement string
+		want      []advisor.Advice
+	}{
+		{
+			statement: "UPDATE Book SET Name = 'a' WHERE Id = 1;",
+			want: []advisor.Advice{
+				{Status: advisor.Success, Code: advisor.Ok, Title: "OK"},
+			},
+		},
+		{
+			statement: "DELETE FROM Book;",
+			want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    advisor.StatementAffectedRowExceedsLimit,
+					Title:   string(advisor.SchemaRuleStatementAffectedRowLimit),
+					Content: "The statement \"DELETE FROM Book\" affected 5000 rows (estimated). The count exceeds 1000.",
+					Line:    1,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		result, err := tsqlparser.ParseTSQL(test.statement)
+		a.NoError(err)
+		adviceList, err := (&StatementAffectedRowLimitAdvisor{}).Check(advisor.Context{
+			AST: result.Tree,
+			Rule: &storepb.SQLReviewRule{
+				Type:    string(advisor.SchemaRuleStatementAffectedRowLimit),
+				Level:   storepb.SQLReviewRuleLevel_WARNING,
+				Payload: `{"number": 1000}`,
+			},
+			Driver:  db,
+			Context: context.Background(),
+		}, test.statement)
+		a.NoError(err)
+		a.Equal(test.want, adviceList, test.statement)
+	}
+}
+
+// showplanDriver is a database/sql driver that answers the statements with SHOWPLAN_XML plans,
+// estimating 1 row for the statements with WHERE and 5000 rows otherwise.
+type showplanDriver struct{}
+
+func (*showplanDriver) Open(string) (driver.Conn, error) {
+	return &showplanConn{}, nil
+}
+
+type showplanConn struct{}
+
+func (*showplanConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (*showplanConn) Close() error {
+	return nil
+}
+
+func (*showplanConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (*showplanConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (*showplanConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	rows := 5000
+	if strings.Contains(query, "WHERE") {
+		rows = 1
+	}
+	return &showplanRows{plan: fmt.Sprintf(`<ShowPlanXML><BatchSequence><Batch><Statements><StmtSimple StatementEstRows="%d"></StmtSimple></Statements></Batch></BatchSequence></ShowPlanXML>`, rows)}, nil
+}
+
+type showplanRows struct {
+	plan string
+	done bool
+}
+
+func (*showplanRows) Columns() []string {
+	return []string{"Microsoft SQL Server 2005 XML Showplan"}
+}
+
+func (*showplanRows) Close() error {
+	return nil
+}
+
+func (r *showplanRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.plan
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDisallowCascadeAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLStatementDisallowCascade, &StatementDisallowCascadeAdvisor{})
+}
+
+// StatementDisallowCascadeAdvisor is the advisor checking for disallow cascading foreign keys.
+type StatementDisallowCascadeAdvisor struct {
+}
+
+// Check checks for disallow cascading foreign keys.
+func (*StatementDisallowCascadeAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &statementDisallowCascadeChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// statementDisallowCascadeChecker is the listener for disallow cascading foreign keys.
+type statementDisallowCascadeChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *statementDisallowCascadeChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterOn_delete is called when production on_delete is entered.
+func (l *statementDisallowCascadeChecker) EnterOn_delete(ctx *parser.On_deleteContext) {
+	if ctx.CASCADE() == nil {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.StatementDisallowCascade,
+		Title:   l.title,
+		Content: "The foreign key with ON DELETE CASCADE is not allowed",
+		Line:    ctx.GetStart().GetLine(),
+	})
+}
+
+// EnterOn_update is called when production on_update is entered.
+func (l *statementDisallowCascadeChecker) EnterOn_update(ctx *parser.On_updateContext) {
+	if ctx.CASCADE() == nil {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.StatementDisallowCascade,
+		Title:   l.title,
+		Content: "The foreign key with ON UPDATE CASCADE is not allowed",
+		Line:    ctx.GetStart().GetLine(),
+	})
+}
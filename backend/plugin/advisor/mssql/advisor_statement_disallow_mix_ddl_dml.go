@@ -0,0 +1,121 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDisallowMixDDLDMLAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLStatementDisallowMixDDLDML, &StatementDisallowMixDDLDMLAdvisor{})
+}
+
+// StatementDisallowMixDDLDMLAdvisor is the advisor checking for disallow mix DDL and DML.
+type StatementDisallowMixDDLDMLAdvisor struct {
+}
+
+// Check checks for disallow mix DDL and DML.
+func (*StatementDisallowMixDDLDMLAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &statementDisallowMixDDLDMLChecker{
+		level:      level,
+		title:      string(ctx.Rule.Type),
+		changeType: ctx.ChangeType,
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// statementDisallowMixDDLDMLChecker is the listener for disallow mix DDL and DML.
+type statementDisallowMixDDLDMLChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level      advisor.Status
+	title      string
+	changeType storepb.PlanCheckRunConfig_ChangeDatabaseType
+
+	hasDDL bool
+	hasDML bool
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *statementDisallowMixDDLDMLChecker) generateAdvice() ([]advisor.Advice, error) {
+	if l.hasDDL && l.hasDML {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.StatementDisallowMixDDLDML,
+			Title:   l.title,
+			Content: "Mixing DDL with DML is not allowed",
+		})
+	}
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterSql_clauses is called when production sql_clauses is entered.
+func (l *statementDisallowMixDDLDMLChecker) EnterSql_clauses(ctx *parser.Sql_clausesContext) {
+	isDDL := ctx.Ddl_clause() != nil
+	// The SELECT statements don't change the data.
+	isDML := ctx.Dml_clause() != nil && ctx.Dml_clause().Select_statement_standalone() == nil
+	text := ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx)
+
+	switch l.changeType {
+	case storepb.PlanCheckRunConfig_DDL, storepb.PlanCheckRunConfig_SDL, storepb.PlanCheckRunConfig_DDL_GHOST:
+		if isDML {
+			l.adviceList = append(l.adviceList, advisor.Advice{
+				Status:  l.level,
+				Code:    advisor.StatementDisallowMixDDLDML,
+				Title:   l.title,
+				Content: fmt.Sprintf("Alter schema can only run DDL, \"%s\" is not DDL", text),
+				Line:    ctx.GetStart().GetLine(),
+			})
+		}
+	case storepb.PlanCheckRunConfig_DML:
+		if isDDL {
+			l.adviceList = append(l.adviceList, advisor.Advice{
+				Status:  l.level,
+				Code:    advisor.StatementDisallowMixDDLDML,
+				Title:   l.title,
+				Content: fmt.Sprintf("Data change can only run DML, \"%s\" is not DML", text),
+				Line:    ctx.GetStart().GetLine(),
+			})
+		}
+	}
+
+	if isDDL {
+		l.hasDDL = true
+	}
+	if isDML {
+		l.hasDML = true
+	}
+}
@@ -0,0 +1,91 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDisallowNoLockHintAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLStatementDisallowNoLockHint, &StatementDisallowNoLockHintAdvisor{})
+}
+
+// StatementDisallowNoLockHintAdvisor is the advisor checking for disallow NOLOCK table hint.
+type StatementDisallowNoLockHintAdvisor struct {
+}
+
+// Check checks for disallow NOLOCK table hint.
+func (*StatementDisallowNoLockHintAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &statementDisallowNoLockHintChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// statementDisallowNoLockHintChecker is the listener for disallow NOLOCK table hint.
+type statementDisallowNoLockHintChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *statementDisallowNoLockHintChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterTable_hint is called when production table_hint is entered.
+func (l *statementDisallowNoLockHintChecker) EnterTable_hint(ctx *parser.Table_hintContext) {
+	var hint antlr.TerminalNode
+	switch {
+	case ctx.NOLOCK() != nil:
+		hint = ctx.NOLOCK()
+	case ctx.READUNCOMMITTED() != nil:
+		hint = ctx.READUNCOMMITTED()
+	default:
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.StatementDisallowNoLockHint,
+		Title:   l.title,
+		Content: fmt.Sprintf("The table hint %s reads uncommitted data and is not allowed", strings.ToUpper(hint.GetText())),
+		Line:    hint.GetSymbol().GetLine(),
+	})
+}
@@ -0,0 +1,84 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDisallowSelectIntoAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLStatementDisallowSelectInto, &StatementDisallowSelectIntoAdvisor{})
+}
+
+// StatementDisallowSelectIntoAdvisor is the advisor checking for disallow SELECT INTO.
+type StatementDisallowSelectIntoAdvisor struct {
+}
+
+// Check checks for disallow SELECT INTO.
+func (*StatementDisallowSelectIntoAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &statementDisallowSelectIntoChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// statementDisallowSelectIntoChecker is the listener for disallow SELECT INTO.
+type statementDisallowSelectIntoChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *statementDisallowSelectIntoChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterQuery_specification is called when production query_specification is entered.
+func (l *statementDisallowSelectIntoChecker) EnterQuery_specification(ctx *parser.Query_specificationContext) {
+	if ctx.INTO() == nil || ctx.GetInto() == nil {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.StatementDisallowSelectInto,
+		Title:   l.title,
+		Content: fmt.Sprintf("SELECT INTO is not allowed but it creates the table %s, please use CREATE TABLE and INSERT INTO ... SELECT instead", ctx.GetInto().GetText()),
+		Line:    ctx.INTO().GetSymbol().GetLine(),
+	})
+}
@@ -0,0 +1,93 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*NoLeadingWildcardLikeAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLNoLeadingWildcardLike, &NoLeadingWildcardLikeAdvisor{})
+}
+
+// NoLeadingWildcardLikeAdvisor is the advisor checking for no leading wildcard LIKE.
+type NoLeadingWildcardLikeAdvisor struct {
+}
+
+// Check checks for no leading wildcard LIKE.
+func (*NoLeadingWildcardLikeAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &noLeadingWildcardLikeChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// noLeadingWildcardLikeChecker is the listener for no leading wildcard LIKE.
+type noLeadingWildcardLikeChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *noLeadingWildcardLikeChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterPredicate is called when production predicate is entered.
+func (l *noLeadingWildcardLikeChecker) EnterPredicate(ctx *parser.PredicateContext) {
+	if ctx.LIKE() == nil || len(ctx.AllExpression()) < 2 {
+		return
+	}
+	pattern := ctx.Expression(1).GetText()
+	// The unicode string literal, e.g. N'%abc'.
+	if strings.HasPrefix(pattern, "N'") || strings.HasPrefix(pattern, "n'") {
+		pattern = pattern[1:]
+	}
+	if !strings.HasPrefix(pattern, "'%") {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.StatementLeadingWildcardLike,
+		Title:   l.title,
+		Content: fmt.Sprintf("\"%s\" uses leading wildcard LIKE", ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx)),
+		Line:    ctx.LIKE().GetSymbol().GetLine(),
+	})
+}
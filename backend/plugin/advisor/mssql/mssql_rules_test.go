@@ -25,6 +25,19 @@ func TestMSSQLRules(t *testing.T) {
 		advisor.SchemaRuleSchemaBackwardCompatibility,
 		advisor.SchemaRuleRequiredColumn,
 		advisor.SchemaRuleColumnTypeDisallowList,
+		advisor.SchemaRuleIDXNaming,
+		advisor.SchemaRuleUKNaming,
+		advisor.SchemaRulePKNaming,
+		advisor.SchemaRuleFKNaming,
+		advisor.SchemaRuleIndexKeyNumberLimit,
+		advisor.SchemaRuleStatementDisallowMixDDLDML,
+		advisor.SchemaRuleStatementAffectedRowLimit,
+		advisor.SchemaRuleStatementInsertMustSpecifyColumn,
+		advisor.SchemaRuleStatementNoLeadingWildcardLike,
+		advisor.SchemaRuleColumnRequireDefault,
+		advisor.SchemaRuleStatementDisallowSelectInto,
+		advisor.SchemaRuleStatementDisallowNoLockHint,
+		advisor.SchemaRuleStatementDisallowCascade,
 	}
 
 	for _, rule := range snowflakeRules {
@@ -0,0 +1,271 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+)
+
+// indexKind is the kind of the index definition.
+type indexKind int
+
+const (
+	indexKindIndex indexKind = iota
+	indexKindUnique
+	indexKindPrimaryKey
+	indexKindForeignKey
+)
+
+// indexDefinition is the index or the key constraint defined in the statements.
+type indexDefinition struct {
+	kind indexKind
+	// name is the original name of the index, empty if the name is generated by SQL Server.
+	name string
+	// table is the original table name without the schema and database.
+	table   string
+	columns []string
+	// referencedTable and referencedColumns are only set for the foreign keys.
+	referencedTable   string
+	referencedColumns []string
+	line              int
+}
+
+// indexDefinitionListener collects the index definitions in CREATE TABLE, ALTER TABLE ADD and CREATE INDEX statements.
+type indexDefinitionListener struct {
+	*parser.BaseTSqlParserListener
+
+	// currentTable is the original name of the table in CREATE TABLE or ALTER TABLE ADD.
+	currentTable string
+
+	definitions []*indexDefinition
+}
+
+func (l *indexDefinitionListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.currentTable = getOriginalTableName(ctx.Table_name())
+}
+
+func (l *indexDefinitionListener) ExitCreate_table(*parser.Create_tableContext) {
+	l.currentTable = ""
+}
+
+func (l *indexDefinitionListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if ctx.ADD() == nil {
+		return
+	}
+	if ctx.Column_def_table_constraints() != nil {
+		l.currentTable = getOriginalTableName(ctx.Table_name(0))
+		return
+	}
+	// ALTER TABLE ... WITH CHECK ADD CONSTRAINT ... FOREIGN KEY (...) REFERENCES ...
+	if ctx.FOREIGN() != nil {
+		l.definitions = append(l.definitions, &indexDefinition{
+			kind:              indexKindForeignKey,
+			name:              getOriginalIdentifier(ctx.GetConstraint()),
+			table:             getOriginalTableName(ctx.Table_name(0)),
+			columns:           getColumnNameList(ctx.GetFk()),
+			referencedTable:   getOriginalTableName(ctx.Table_name(1)),
+			referencedColumns: getColumnNameList(ctx.GetPk()),
+			line:              ctx.FOREIGN().GetSymbol().GetLine(),
+		})
+	}
+}
+
+func (l *indexDefinitionListener) ExitAlter_table(*parser.Alter_tableContext) {
+	l.currentTable = ""
+}
+
+func (l *indexDefinitionListener) EnterTable_constraint(ctx *parser.Table_constraintContext) {
+	if l.currentTable == "" {
+		return
+	}
+	name := getOriginalIdentifier(ctx.GetConstraint())
+	line := ctx.GetStart().GetLine()
+	switch {
+	case ctx.PRIMARY() != nil:
+		l.definitions = append(l.definitions, &indexDefinition{
+			kind:    indexKindPrimaryKey,
+			name:    name,
+			table:   l.currentTable,
+			columns: getColumnNameListWithOrder(ctx.Column_name_list_with_order()),
+			line:    line,
+		})
+	case ctx.UNIQUE() != nil:
+		l.definitions = append(l.definitions, &indexDefinition{
+			kind:    indexKindUnique,
+			name:    name,
+			table:   l.currentTable,
+			columns: getColumnNameListWithOrder(ctx.Column_name_list_with_order()),
+			line:    line,
+		})
+	case ctx.FOREIGN() != nil && ctx.Foreign_key_options() != nil:
+		options := ctx.Foreign_key_options()
+		l.definitions = append(l.definitions, &indexDefinition{
+			kind:              indexKindForeignKey,
+			name:              name,
+			table:             l.currentTable,
+			columns:           getColumnNameList(ctx.GetFk()),
+			referencedTable:   getOriginalTableName(options.Table_name()),
+			referencedColumns: getColumnNameList(options.GetPk()),
+			line:              line,
+		})
+	}
+}
+
+func (l *indexDefinitionListener) EnterColumn_definition(ctx *parser.Column_definitionContext) {
+	if l.currentTable == "" {
+		return
+	}
+	column := getOriginalIdentifier(ctx.Id_())
+	for _, element := range ctx.AllColumn_definition_element() {
+		constraint := element.Column_constraint()
+		if constraint == nil {
+			continue
+		}
+		name := getOriginalIdentifier(constraint.GetConstraint())
+		line := constraint.GetStart().GetLine()
+		switch {
+		case constraint.PRIMARY() != nil:
+			l.definitions = append(l.definitions, &indexDefinition{
+				kind:    indexKindPrimaryKey,
+				name:    name,
+				table:   l.currentTable,
+				columns: []string{column},
+				line:    line,
+			})
+		case constraint.UNIQUE() != nil:
+			l.definitions = append(l.definitions, &indexDefinition{
+				kind:    indexKindUnique,
+				name:    name,
+				table:   l.currentTable,
+				columns: []string{column},
+				line:    line,
+			})
+		case constraint.Foreign_key_options() != nil:
+			options := constraint.Foreign_key_options()
+			l.definitions = append(l.definitions, &indexDefinition{
+				kind:              indexKindForeignKey,
+				name:              name,
+				table:             l.currentTable,
+				columns:           []string{column},
+				referencedTable:   getOriginalTableName(options.Table_name()),
+				referencedColumns: getColumnNameList(options.GetPk()),
+				line:              line,
+			})
+		}
+	}
+	if index := ctx.Column_index(); index != nil {
+		l.definitions = append(l.definitions, &indexDefinition{
+			kind:    indexKindIndex,
+			name:    getOriginalIdentifier(index.GetIndex_name()),
+			table:   l.currentTable,
+			columns: []string{column},
+			line:    index.GetStart().GetLine(),
+		})
+	}
+}
+
+func (l *indexDefinitionListener) EnterTable_indices(ctx *parser.Table_indicesContext) {
+	if l.currentTable == "" {
+		return
+	}
+	kind := indexKindIndex
+	if ctx.UNIQUE() != nil {
+		kind = indexKindUnique
+	}
+	columns := getColumnNameListWithOrder(ctx.Column_name_list_with_order())
+	if ctx.Column_name_list() != nil {
+		// The nonclustered columnstore index.
+		columns = getColumnNameList(ctx.Column_name_list())
+	}
+	l.definitions = append(l.definitions, &indexDefinition{
+		kind:    kind,
+		name:    getOriginalIdentifier(ctx.Id_(0)),
+		table:   l.currentTable,
+		columns: columns,
+		line:    ctx.GetStart().GetLine(),
+	})
+}
+
+func (l *indexDefinitionListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	kind := indexKindIndex
+	if ctx.UNIQUE() != nil {
+		kind = indexKindUnique
+	}
+	l.definitions = append(l.definitions, &indexDefinition{
+		kind:    kind,
+		name:    getOriginalIdentifier(ctx.Id_(0)),
+		table:   getOriginalTableName(ctx.Table_name()),
+		columns: getColumnNameListWithOrder(ctx.Column_name_list_with_order()),
+		line:    ctx.GetStart().GetLine(),
+	})
+}
+
+// getIndexDefinitions returns the definitions of the given kinds in the tree.
+func getIndexDefinitions(tree antlr.Tree, kinds ...indexKind) []*indexDefinition {
+	listener := &indexDefinitionListener{}
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	var result []*indexDefinition
+	for _, definition := range listener.definitions {
+		for _, kind := range kinds {
+			if definition.kind == kind {
+				result = append(result, definition)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// getTemplateRegexp formats the template as regex.
+func getTemplateRegexp(template string, templateList []string, tokens map[string]string) (*regexp.Regexp, error) {
+	for _, key := range templateList {
+		if token, ok := tokens[key]; ok {
+			template = strings.ReplaceAll(template, key, token)
+		}
+	}
+
+	return regexp.Compile(template)
+}
+
+func getOriginalIdentifier(ctx parser.IId_Context) string {
+	if ctx == nil {
+		return ""
+	}
+	original, _ := tsqlparser.NormalizeTSQLIdentifier(ctx)
+	return original
+}
+
+func getOriginalTableName(ctx parser.ITable_nameContext) string {
+	if ctx == nil {
+		return ""
+	}
+	return getOriginalIdentifier(ctx.GetTable())
+}
+
+func getColumnNameList(ctx parser.IColumn_name_listContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	var result []string
+	for _, id := range ctx.AllId_() {
+		result = append(result, getOriginalIdentifier(id))
+	}
+	return result
+}
+
+func getColumnNameListWithOrder(ctx parser.IColumn_name_list_with_orderContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	var result []string
+	for _, id := range ctx.AllId_() {
+		result = append(result, getOriginalIdentifier(id))
+	}
+	return result
+}
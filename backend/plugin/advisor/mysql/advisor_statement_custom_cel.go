@@ -0,0 +1,233 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	mysql "github.com/bytebase/mysql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	mysqlparser "github.com/bytebase/bytebase/backend/plugin/parser/mysql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementCustomCELAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MYSQL, advisor.MySQLStatementCustomCEL, &StatementCustomCELAdvisor{})
+	advisor.Register(storepb.Engine_MARIADB, advisor.MySQLStatementCustomCEL, &StatementCustomCELAdvisor{})
+	advisor.Register(storepb.Engine_OCEANBASE, advisor.MySQLStatementCustomCEL, &StatementCustomCELAdvisor{})
+}
+
+// StatementCustomCELAdvisor is the advisor checking for the user-defined rule expressed in CEL.
+type StatementCustomCELAdvisor struct {
+}
+
+// Check checks for the user-defined rule expressed in CEL.
+func (*StatementCustomCELAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, ok := ctx.AST.([]*mysqlparser.ParseResult)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to mysql parse result")
+	}
+
+	evaluator, err := advisor.NewCustomCELRuleEvaluator(ctx.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var views []*advisor.StatementView
+	for _, stmt := range stmtList {
+		checker := &statementCustomCELChecker{
+			view: &advisor.StatementView{
+				Type: mysqlparser.GetStatementType(stmt),
+			},
+			tableSet:  make(map[string]bool),
+			columnSet: make(map[string]bool),
+			objectSet: make(map[string]bool),
+		}
+		antlr.ParseTreeWalkerDefault.Walk(checker, stmt.Tree)
+		checker.view.Line += stmt.BaseLine
+		if checker.view.Type == "UNKNOWN" && checker.isSelect {
+			checker.view.Type = "SELECT"
+		}
+		if ctx.Driver != nil && evaluator.NeedAffectedRows() {
+			checker.view.AffectedRows, checker.view.AffectedRowsErr = getStatementAffectedRows(ctx, stmt)
+		}
+		views = append(views, checker.view)
+	}
+
+	return evaluator.Check(views)
+}
+
+type statementCustomCELChecker struct {
+	*mysql.BaseMySQLParserListener
+
+	view      *advisor.StatementView
+	isSelect  bool
+	tableSet  map[string]bool
+	columnSet map[string]bool
+	objectSet map[string]bool
+}
+
+// EnterQuery is called when production query is entered.
+func (checker *statementCustomCELChecker) EnterQuery(ctx *mysql.QueryContext) {
+	checker.view.Text = ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx)
+	checker.view.Line = ctx.GetStart().GetLine()
+}
+
+// EnterSelectStatement is called when production selectStatement is entered.
+func (checker *statementCustomCELChecker) EnterSelectStatement(ctx *mysql.SelectStatementContext) {
+	if mysqlparser.IsTopMySQLRule(&ctx.BaseParserRuleContext) {
+		checker.isSelect = true
+	}
+}
+
+// EnterWhereClause is called when production whereClause is entered.
+func (checker *statementCustomCELChecker) EnterWhereClause(_ *mysql.WhereClauseContext) {
+	checker.view.HasWhere = true
+}
+
+// EnterTableRef is called when production tableRef is entered.
+func (checker *statementCustomCELChecker) EnterTableRef(ctx *mysql.TableRefContext) {
+	_, table := mysqlparser.NormalizeMySQLTableRef(ctx)
+	checker.addTable(table)
+}
+
+// EnterTableName is called when production tableName is entered.
+func (checker *statementCustomCELChecker) EnterTableName(ctx *mysql.TableNameContext) {
+	_, table := mysqlparser.NormalizeMySQLTableName(ctx)
+	checker.addTable(table)
+}
+
+// EnterColumnRef is called when production columnRef is entered.
+func (checker *statementCustomCELChecker) EnterColumnRef(ctx *mysql.ColumnRefContext) {
+	if ctx.FieldIdentifier() == nil {
+		return
+	}
+	_, _, column := mysqlparser.NormalizeMySQLFieldIdentifier(ctx.FieldIdentifier())
+	checker.addColumn(column)
+}
+
+// EnterColumnDefinition is called when production columnDefinition is entered.
+func (checker *statementCustomCELChecker) EnterColumnDefinition(ctx *mysql.ColumnDefinitionContext) {
+	if ctx.ColumnName() == nil {
+		return
+	}
+	_, _, column := mysqlparser.NormalizeMySQLColumnName(ctx.ColumnName())
+	checker.addColumn(column)
+}
+
+// EnterColumnInternalRef is called when production columnInternalRef is entered.
+func (checker *statementCustomCELChecker) EnterColumnInternalRef(ctx *mysql.ColumnInternalRefContext) {
+	if ctx.Identifier() == nil {
+		return
+	}
+	checker.addColumn(mysqlparser.NormalizeMySQLIdentifier(ctx.Identifier()))
+}
+
+// EnterIndexName is called when production indexName is entered.
+func (checker *statementCustomCELChecker) EnterIndexName(ctx *mysql.IndexNameContext) {
+	if ctx.Identifier() == nil {
+		return
+	}
+	checker.addObject(mysqlparser.NormalizeMySQLIdentifier(ctx.Identifier()))
+}
+
+// EnterIndexRef is called when production indexRef is entered.
+func (checker *statementCustomCELChecker) EnterIndexRef(ctx *mysql.IndexRefContext) {
+	if ctx.FieldIdentifier() == nil {
+		return
+	}
+	_, _, index := mysqlparser.NormalizeMySQLFieldIdentifier(ctx.FieldIdentifier())
+	checker.addObject(index)
+}
+
+// EnterViewName is called when production viewName is entered.
+func (checker *statementCustomCELChecker) EnterViewName(ctx *mysql.ViewNameContext) {
+	_, view := mysqlparser.NormalizeMySQLViewName(ctx)
+	checker.addObject(view)
+}
+
+// EnterViewRef is called when production viewRef is entered.
+func (checker *statementCustomCELChecker) EnterViewRef(ctx *mysql.ViewRefContext) {
+	_, view := mysqlparser.NormalizeMySQLViewRef(ctx)
+	checker.addObject(view)
+}
+
+// EnterTriggerName is called when production triggerName is entered.
+func (checker *statementCustomCELChecker) EnterTriggerName(ctx *mysql.TriggerNameContext) {
+	_, trigger := mysqlparser.NormalizeMySQLTriggerName(ctx)
+	checker.addObject(trigger)
+}
+
+// EnterFunctionName is called when production functionName is entered.
+func (checker *statementCustomCELChecker) EnterFunctionName(ctx *mysql.FunctionNameContext) {
+	_, function := mysqlparser.NormalizeMySQLFunctionName(ctx)
+	checker.addObject(function)
+}
+
+// EnterProcedureName is called when production procedureName is entered.
+func (checker *statementCustomCELChecker) EnterProcedureName(ctx *mysql.ProcedureNameContext) {
+	_, procedure := mysqlparser.NormalizeMySQLProcedureName(ctx)
+	checker.addObject(procedure)
+}
+
+// EnterEventName is called when production eventName is entered.
+func (checker *statementCustomCELChecker) EnterEventName(ctx *mysql.EventNameContext) {
+	_, event := mysqlparser.NormalizeMySQLEventName(ctx)
+	checker.addObject(event)
+}
+
+// ExitQuery is called when production query is exited.
+func (checker *statementCustomCELChecker) ExitQuery(_ *mysql.QueryContext) {
+	// The tables are also the objects for the DDL statements.
+	switch checker.view.Type {
+	case "UNKNOWN", "SELECT", "INSERT", "UPDATE", "DELETE":
+	default:
+		for _, table := range checker.view.Tables {
+			checker.addObject(table)
+		}
+	}
+}
+
+func (checker *statementCustomCELChecker) addTable(table string) {
+	if table == "" || checker.tableSet[table] {
+		return
+	}
+	checker.tableSet[table] = true
+	checker.view.Tables = append(checker.view.Tables, table)
+}
+
+func (checker *statementCustomCELChecker) addColumn(column string) {
+	if column == "" || checker.columnSet[column] {
+		return
+	}
+	checker.columnSet[column] = true
+	checker.view.Columns = append(checker.view.Columns, column)
+}
+
+func (checker *statementCustomCELChecker) addObject(object string) {
+	if object == "" || checker.objectSet[object] {
+		return
+	}
+	checker.objectSet[object] = true
+	checker.view.ObjectNames = append(checker.view.ObjectNames, object)
+}
+
+// getStatementAffectedRows estimates the affected rows of the statement by the MySQL parser,
+// which runs EXPLAIN for the DML and looks up the table statistics for the DDL.
+func getStatementAffectedRows(ctx advisor.Context, stmt *mysqlparser.ParseResult) (int64, error) {
+	getAffectedRowsByQuery := func(queryCtx context.Context, statement string) (int64, error) {
+		res, err := advisor.Query(queryCtx, ctx.Driver, fmt.Sprintf("EXPLAIN %s", statement))
+		if err != nil {
+			return 0, err
+		}
+		return getRows(res)
+	}
+	return mysqlparser.GetAffectedRows(ctx.Context, stmt, getAffectedRowsByQuery, advisor.NewGetTableDataSizeFunc(ctx.DBSchema, ""))
+}
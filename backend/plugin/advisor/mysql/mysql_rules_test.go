@@ -53,6 +53,8 @@ func TestMySQLRules(t *testing.T) {
 		advisor.SchemaRuleStatementAffectedRowLimit,
 		// advisor.SchemaRuleStatementDMLDryRun dry run the dml.
 		advisor.SchemaRuleStatementDMLDryRun,
+		// advisor.SchemaRuleStatementCustomCEL is the user-defined rule expressed in CEL.
+		advisor.SchemaRuleStatementCustomCEL,
 		// advisor.SchemaRuleStatementNoEqualNull disallow the equal null.
 		advisor.SchemaRuleStatementWhereNoEqualNull,
 		// advisor.SchemaRuleStatementMaximumLimitValue enforce the maximum limit value.
@@ -3,6 +3,7 @@ package pg
 // Framework code is generated by the generator.
 
 import (
+	parser "github.com/bytebase/postgresql-parser"
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
@@ -35,8 +36,9 @@ func (*IndexCreateConcurrentlyAdvisor) Check(ctx advisor.Context, _ string) ([]a
 		return nil, err
 	}
 	checker := &indexCreateConcurrentlyChecker{
-		level: level,
-		title: string(ctx.Rule.Type),
+		level:      level,
+		title:      string(ctx.Rule.Type),
+		statements: ctx.Statements,
 	}
 
 	for _, stmt := range stmtList {
@@ -58,6 +60,7 @@ type indexCreateConcurrentlyChecker struct {
 	adviceList []advisor.Advice
 	level      advisor.Status
 	title      string
+	statements string
 }
 
 // Visit implements ast.Visitor interface.
@@ -70,9 +73,22 @@ func (checker *indexCreateConcurrentlyChecker) Visit(in ast.Node) ast.Visitor {
 				Title:   checker.title,
 				Content: "Creating indexes will block writes on the table, unless use CONCURRENTLY",
 				Line:    in.LastLine(),
+				Fix:     checker.getFix(in),
 			})
 		}
 	}
 
 	return checker
 }
+
+// getFix returns the fix adding CONCURRENTLY after CREATE [UNIQUE] INDEX.
+func (checker *indexCreateConcurrentlyChecker) getFix(in ast.Node) *advisor.Fix {
+	text := in.Text()
+	for _, token := range tokenizeStatement(text) {
+		if token.tokenType == parser.PostgreSQLLexerINDEX {
+			newText := " " + matchKeywordCase("CONCURRENTLY", token.text)
+			return advisor.NewFix("Create the index concurrently", checker.statements, text, in.LastLine(), token.end, token.end, newText)
+		}
+	}
+	return nil
+}
@@ -4,10 +4,14 @@ package pg
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
+	parser "github.com/bytebase/postgresql-parser"
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -37,8 +41,10 @@ func (*InsertMustSpecifyColumnAdvisor) Check(ctx advisor.Context, _ string) ([]a
 		return nil, err
 	}
 	checker := &insertMustSpecifyColumnChecker{
-		level: level,
-		title: string(ctx.Rule.Type),
+		level:      level,
+		title:      string(ctx.Rule.Type),
+		statements: ctx.Statements,
+		catalog:    ctx.Catalog,
 	}
 
 	for _, stmt := range stmtList {
@@ -62,6 +68,8 @@ type insertMustSpecifyColumnChecker struct {
 	level      advisor.Status
 	title      string
 	text       string
+	statements string
+	catalog    *catalog.Finder
 }
 
 // Visit implements ast.Visitor interface.
@@ -73,8 +81,59 @@ func (checker *insertMustSpecifyColumnChecker) Visit(in ast.Node) ast.Visitor {
 			Title:   checker.title,
 			Content: fmt.Sprintf("The INSERT statement must specify columns but \"%s\" does not", checker.text),
 			Line:    node.LastLine(),
+			Fix:     checker.getFix(node),
 		})
 	}
 
 	return checker
 }
+
+// getFix returns the fix adding all the columns of the table after the table name, e.g. INSERT INTO t (a, b) VALUES (1, 2).
+func (checker *insertMustSpecifyColumnChecker) getFix(node *ast.InsertStmt) *advisor.Fix {
+	if node.Table == nil {
+		return nil
+	}
+	find := &catalog.TableFind{
+		SchemaName: normalizeSchemaName(node.Table.Schema),
+		TableName:  node.Table.Name,
+	}
+	table := checker.catalog.Origin.FindTable(find)
+	if table == nil {
+		// The table is created in the same statements.
+		table = checker.catalog.Final.FindTable(find)
+	}
+	if table == nil {
+		return nil
+	}
+	columnList := table.ListColumns()
+	slices.SortFunc(columnList, func(a, b *catalog.ColumnState) int {
+		return a.Position() - b.Position()
+	})
+	var columns []string
+	for _, column := range columnList {
+		columns = append(columns, quoteIdentifier(column.Name()))
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	text := node.Text()
+	tokens := tokenizeStatement(text)
+	for i, token := range tokens {
+		if token.tokenType != parser.PostgreSQLLexerINTO || i+1 >= len(tokens) {
+			continue
+		}
+		// Skip the qualified table name and the alias.
+		end := i + 1
+		for end+2 < len(tokens) && tokens[end+1].tokenType == parser.PostgreSQLLexerDOT {
+			end += 2
+		}
+		if end+2 < len(tokens) && tokens[end+1].tokenType == parser.PostgreSQLLexerAS {
+			end += 2
+		}
+		offset := tokens[end].end
+		newText := fmt.Sprintf(" (%s)", strings.Join(columns, ", "))
+		return advisor.NewFix("Specify the columns", checker.statements, text, node.LastLine(), offset, offset, newText)
+	}
+	return nil
+}
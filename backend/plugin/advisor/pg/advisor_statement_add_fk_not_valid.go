@@ -3,6 +3,7 @@ package pg
 // Framework code is generated by the generator.
 
 import (
+	parser "github.com/bytebase/postgresql-parser"
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
@@ -35,12 +36,17 @@ func (*StatementAddFKNotValidAdvisor) Check(ctx advisor.Context, _ string) ([]ad
 		return nil, err
 	}
 	checker := &statementAddFKNotValidChecker{
-		level: level,
-		title: string(ctx.Rule.Type),
+		level:      level,
+		title:      string(ctx.Rule.Type),
+		statements: ctx.Statements,
 	}
 
 	for _, stmt := range stmtList {
 		checker.line = stmt.LastLine()
+		checker.text = stmt.Text()
+		// NOT VALID at the end of the statement applies to the last alter item only.
+		alterTable, ok := stmt.(*ast.AlterTableStmt)
+		checker.fixable = ok && len(alterTable.AlterItemList) == 1
 		ast.Walk(checker, stmt)
 	}
 
@@ -60,6 +66,9 @@ type statementAddFKNotValidChecker struct {
 	level      advisor.Status
 	title      string
 	line       int
+	statements string
+	text       string
+	fixable    bool
 }
 
 // Visit implements ast.Visitor interface.
@@ -72,9 +81,26 @@ func (checker *statementAddFKNotValidChecker) Visit(in ast.Node) ast.Visitor {
 				Title:   checker.title,
 				Content: "Adding foreign keys with validation will block reads and writes. You can add check foreign keys not valid and then validate separately",
 				Line:    checker.line,
+				Fix:     checker.getFix(),
 			})
 		}
 	}
 
 	return checker
 }
+
+// getFix returns the fix adding NOT VALID at the end of the statement.
+func (checker *statementAddFKNotValidChecker) getFix() *advisor.Fix {
+	if !checker.fixable {
+		return nil
+	}
+	tokens := tokenizeStatement(checker.text)
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if tokens[i].tokenType == parser.PostgreSQLLexerSEMI {
+			continue
+		}
+		newText := " " + matchKeywordCase("NOT VALID", tokens[0].text)
+		return advisor.NewFix("Add the foreign key NOT VALID", checker.statements, checker.text, checker.line, tokens[i].end, tokens[i].end, newText)
+	}
+	return nil
+}
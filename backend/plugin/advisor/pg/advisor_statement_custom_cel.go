@@ -0,0 +1,234 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementCustomCELAdvisor)(nil)
+	_ ast.Visitor     = (*statementCustomCELChecker)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_POSTGRES, advisor.PostgreSQLStatementCustomCEL, &StatementCustomCELAdvisor{})
+}
+
+// StatementCustomCELAdvisor is the advisor checking for the user-defined rule expressed in CEL.
+type StatementCustomCELAdvisor struct {
+}
+
+// Check checks for the user-defined rule expressed in CEL.
+func (*StatementCustomCELAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, ok := ctx.AST.([]ast.Node)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Node")
+	}
+
+	evaluator, err := advisor.NewCustomCELRuleEvaluator(ctx.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var views []*advisor.StatementView
+	for _, stmt := range stmtList {
+		checker := &statementCustomCELChecker{
+			view: &advisor.StatementView{
+				Type: getStatementType(stmt),
+				Text: advisor.NormalizeStatement(stmt.Text()),
+				Line: stmt.LastLine(),
+			},
+			tableSet:  make(map[string]bool),
+			columnSet: make(map[string]bool),
+			objectSet: make(map[string]bool),
+		}
+		ast.Walk(checker, stmt)
+		if ctx.Driver != nil && evaluator.NeedAffectedRows() {
+			checker.view.AffectedRows, checker.view.AffectedRowsErr = getStatementAffectedRows(ctx, stmt)
+		}
+		views = append(views, checker.view)
+	}
+
+	return evaluator.Check(views)
+}
+
+type statementCustomCELChecker struct {
+	view      *advisor.StatementView
+	tableSet  map[string]bool
+	columnSet map[string]bool
+	objectSet map[string]bool
+}
+
+// Visit implements ast.Visitor interface.
+func (checker *statementCustomCELChecker) Visit(in ast.Node) ast.Visitor {
+	switch node := in.(type) {
+	case *ast.TableDef:
+		checker.addTable(node.Name)
+		if !isDMLStatementType(checker.view.Type) {
+			checker.addObject(node.Name)
+		}
+	case *ast.ColumnDef:
+		checker.addColumn(node.ColumnName)
+	case *ast.ColumnNameDef:
+		checker.addColumn(node.ColumnName)
+	case *ast.DropColumnStmt:
+		checker.addColumn(node.ColumnName)
+	case *ast.RenameColumnStmt:
+		checker.addColumn(node.ColumnName)
+		checker.addColumn(node.NewName)
+	case *ast.IndexDef:
+		checker.addObject(node.Name)
+	case *ast.DropIndexStmt:
+		for _, index := range node.IndexList {
+			checker.addObject(index.Name)
+		}
+	case *ast.RenameIndexStmt:
+		checker.addObject(node.IndexName)
+		checker.addObject(node.NewName)
+	case *ast.RenameTableStmt:
+		checker.addObject(node.NewName)
+	case *ast.CreateSchemaStmt:
+		checker.addObject(node.Name)
+	case *ast.DropSchemaStmt:
+		for _, schema := range node.SchemaList {
+			checker.addObject(schema)
+		}
+	case *ast.CreateDatabaseStmt:
+		checker.addObject(node.Name)
+	case *ast.DropDatabaseStmt:
+		checker.addObject(node.DatabaseName)
+	case *ast.UpdateStmt:
+		checker.view.HasWhere = checker.view.HasWhere || node.WhereClause != nil
+	case *ast.DeleteStmt:
+		checker.view.HasWhere = checker.view.HasWhere || node.WhereClause != nil
+	case *ast.SelectStmt:
+		checker.view.HasWhere = checker.view.HasWhere || node.WhereClause != nil
+	}
+	return checker
+}
+
+func (checker *statementCustomCELChecker) addTable(table string) {
+	if table == "" || checker.tableSet[table] {
+		return
+	}
+	checker.tableSet[table] = true
+	checker.view.Tables = append(checker.view.Tables, table)
+}
+
+func (checker *statementCustomCELChecker) addColumn(column string) {
+	if column == "" || checker.columnSet[column] {
+		return
+	}
+	checker.columnSet[column] = true
+	checker.view.Columns = append(checker.view.Columns, column)
+}
+
+func (checker *statementCustomCELChecker) addObject(object string) {
+	if object == "" || checker.objectSet[object] {
+		return
+	}
+	checker.objectSet[object] = true
+	checker.view.ObjectNames = append(checker.view.ObjectNames, object)
+}
+
+func isDMLStatementType(tp string) bool {
+	switch tp {
+	case "SELECT", "INSERT", "UPDATE", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
+func getStatementType(node ast.Node) string {
+	switch node := node.(type) {
+	case *ast.SelectStmt:
+		return "SELECT"
+	case *ast.InsertStmt:
+		return "INSERT"
+	case *ast.UpdateStmt:
+		return "UPDATE"
+	case *ast.DeleteStmt:
+		return "DELETE"
+	case *ast.CreateIndexStmt:
+		return "CREATE_INDEX"
+	case *ast.CreateTableStmt:
+		if node.Name.Type == ast.TableTypeView {
+			return "CREATE_VIEW"
+		}
+		return "CREATE_TABLE"
+	case *ast.CreateSequenceStmt:
+		return "CREATE_SEQUENCE"
+	case *ast.CreateDatabaseStmt:
+		return "CREATE_DATABASE"
+	case *ast.CreateSchemaStmt:
+		return "CREATE_SCHEMA"
+	case *ast.CreateFunctionStmt:
+		return "CREATE_FUNCTION"
+	case *ast.CreateTriggerStmt:
+		return "CREATE_TRIGGER"
+	case *ast.CreateTypeStmt:
+		return "CREATE_TYPE"
+	case *ast.CreateExtensionStmt:
+		return "CREATE_EXTENSION"
+	case *ast.DropDatabaseStmt:
+		return "DROP_DATABASE"
+	case *ast.DropExtensionStmt:
+		return "DROP_EXTENSION"
+	case *ast.DropFunctionStmt:
+		return "DROP_FUNCTION"
+	case *ast.DropIndexStmt:
+		return "DROP_INDEX"
+	case *ast.DropSchemaStmt:
+		return "DROP_SCHEMA"
+	case *ast.DropSequenceStmt:
+		return "DROP_SEQUENCE"
+	case *ast.DropTableStmt:
+		return "DROP_TABLE"
+	case *ast.DropTriggerStmt:
+		return "DROP_TRIGGER"
+	case *ast.DropTypeStmt:
+		return "DROP_TYPE"
+	case *ast.AlterSequenceStmt:
+		return "ALTER_SEQUENCE"
+	case *ast.AlterTableStmt:
+		if node.Table.Type == ast.TableTypeView {
+			return "ALTER_VIEW"
+		}
+		return "ALTER_TABLE"
+	case *ast.AlterTypeStmt:
+		return "ALTER_TYPE"
+	case *ast.RenameIndexStmt:
+		return "RENAME_INDEX"
+	case *ast.RenameSchemaStmt:
+		return "RENAME_SCHEMA"
+	case *ast.CommentStmt:
+		return "COMMENT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// getStatementAffectedRows estimates the affected rows of the statement by the PostgreSQL parser,
+// which runs EXPLAIN for the DML and looks up the table statistics for the DDL.
+func getStatementAffectedRows(ctx advisor.Context, node ast.Node) (int64, error) {
+	parseResult, err := pgparser.ParsePostgreSQL(node.Text())
+	if err != nil {
+		return 0, err
+	}
+	getAffectedRowsByQuery := func(queryCtx context.Context, statement string) (int64, error) {
+		res, err := advisor.Query(queryCtx, ctx.Driver, fmt.Sprintf("EXPLAIN %s", statement))
+		if err != nil {
+			return 0, err
+		}
+		return getAffectedRows(res)
+	}
+	return pgparser.GetAffectedRows(ctx.Context, parseResult, getAffectedRowsByQuery, advisor.NewGetTableDataSizeFunc(ctx.DBSchema, "public"))
+}
@@ -66,11 +66,13 @@ func (*StatementPriorBackupCheckAdvisor) Check(ctx advisor.Context, _ string) ([
 		}
 	}
 
-	if !databaseExists(ctx.Context, ctx.Driver, extractDatabaseName(ctx.PreUpdateBackupDetail.Database)) {
+	// The backup tables are saved into the schema named after the backup database in the same database.
+	schemaName := extractDatabaseName(ctx.PreUpdateBackupDetail.Database)
+	if !schemaExists(ctx.Context, ctx.Driver, schemaName) && !canCreateSchema(ctx.Context, ctx.Driver) {
 		adviceList = append(adviceList, advisor.Advice{
 			Status:  level,
 			Title:   title,
-			Content: fmt.Sprintf("Need database %q to do prior backup but it does not exist", ctx.PreUpdateBackupDetail.Database),
+			Content: fmt.Sprintf("Need schema %q to do prior backup but it does not exist and cannot be created", schemaName),
 			Code:    advisor.DatabaseNotExists,
 			Line:    0,
 		})
@@ -93,13 +95,24 @@ func extractDatabaseName(databaseUID string) string {
 	return segments[len(segments)-1]
 }
 
-func databaseExists(ctx context.Context, driver *sql.DB, database string) bool {
+func schemaExists(ctx context.Context, driver *sql.DB, schema string) bool {
 	if driver == nil {
 		return false
 	}
 	var count int
-	if err := driver.QueryRowContext(ctx, "SELECT COUNT(*) FROM pg_database WHERE datname = $1", database).Scan(&count); err != nil {
+	if err := driver.QueryRowContext(ctx, "SELECT COUNT(*) FROM pg_namespace WHERE nspname = $1", schema).Scan(&count); err != nil {
 		return false
 	}
 	return count > 0
 }
+
+func canCreateSchema(ctx context.Context, driver *sql.DB) bool {
+	if driver == nil {
+		return false
+	}
+	var canCreate bool
+	if err := driver.QueryRowContext(ctx, "SELECT has_database_privilege(current_database(), 'CREATE')").Scan(&canCreate); err != nil {
+		return false
+	}
+	return canCreate
+}
@@ -53,6 +53,7 @@ func TestPostgreSQLRules(t *testing.T) {
 		advisor.SchemaRuleStatementCreateSpecifySchema,
 		advisor.SchemaRuleStatementCheckSetRoleVariable,
 		advisor.SchemaRuleStatementMaximumLimitValue,
+		advisor.SchemaRuleStatementCustomCEL,
 	}
 
 	for _, rule := range pgRules {
@@ -3,6 +3,11 @@ package pg
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/postgresql-parser"
 
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 )
@@ -46,3 +51,59 @@ func normalizeTableName(table *ast.TableDef, defaultSchema string) string {
 	}
 	return fmt.Sprintf("%q.%q", schema, table.Name)
 }
+
+// statementToken is the token of the statement text with the byte offsets, used to locate the fix.
+type statementToken struct {
+	tokenType int
+	text      string
+	start     int
+	end       int
+}
+
+// tokenizeStatement returns the tokens of the statement text on the default channel, excluding the EOF.
+func tokenizeStatement(text string) []statementToken {
+	lexer := parser.NewPostgreSQLLexer(antlr.NewInputStream(text))
+	lexer.RemoveErrorListeners()
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	stream.Fill()
+	// The token offsets are in runes.
+	var byteOffsets []int
+	for i := range text {
+		byteOffsets = append(byteOffsets, i)
+	}
+	byteOffsets = append(byteOffsets, len(text))
+	var tokens []statementToken
+	for _, token := range stream.GetAllTokens() {
+		if token.GetTokenType() == antlr.TokenEOF || token.GetChannel() != antlr.TokenDefaultChannel {
+			continue
+		}
+		if token.GetStart() < 0 || token.GetStop()+1 >= len(byteOffsets) {
+			continue
+		}
+		tokens = append(tokens, statementToken{
+			tokenType: token.GetTokenType(),
+			text:      token.GetText(),
+			start:     byteOffsets[token.GetStart()],
+			end:       byteOffsets[token.GetStop()+1],
+		})
+	}
+	return tokens
+}
+
+// matchKeywordCase returns the keyword in the case of the reference token, e.g. "concurrently" for "index".
+func matchKeywordCase(keyword string, reference string) string {
+	if reference == strings.ToLower(reference) {
+		return strings.ToLower(keyword)
+	}
+	return strings.ToUpper(keyword)
+}
+
+var simpleIdentifierRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+
+// quoteIdentifier quotes the identifier if it is not a simple lower case identifier.
+func quoteIdentifier(name string) string {
+	if simpleIdentifierRegexp.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
@@ -0,0 +1,202 @@
+package advisor
+
+import (
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// SARIFVersion is the SARIF version of the SQL review report.
+	SARIFVersion = "2.1.0"
+	// SARIFSchema is the JSON schema of the SARIF version.
+	SARIFSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	sarifToolName           = "Bytebase SQL Review"
+	sarifToolInformationURI = "https://www.bytebase.com/docs/sql-review/review-rules"
+)
+
+// SARIFLog is the top-level object of the SARIF report.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+type SARIFLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*SARIFRun `json:"runs"`
+}
+
+// SARIFRun is a single run of the SQL review.
+type SARIFRun struct {
+	Tool    *SARIFTool     `json:"tool"`
+	Results []*SARIFResult `json:"results"`
+}
+
+// SARIFTool is the tool producing the results.
+type SARIFTool struct {
+	Driver *SARIFToolComponent `json:"driver"`
+}
+
+// SARIFToolComponent describes the tool and its rules.
+type SARIFToolComponent struct {
+	Name           string                      `json:"name"`
+	InformationURI string                      `json:"informationUri,omitempty"`
+	Rules          []*SARIFReportingDescriptor `json:"rules"`
+}
+
+// SARIFReportingDescriptor is the metadata of a rule.
+type SARIFReportingDescriptor struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name,omitempty"`
+	ShortDescription     *SARIFMessage       `json:"shortDescription,omitempty"`
+	HelpURI              string              `json:"helpUri,omitempty"`
+	DefaultConfiguration *SARIFConfiguration `json:"defaultConfiguration,omitempty"`
+}
+
+// SARIFConfiguration is the default configuration of a rule.
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFResult is a single advice.
+type SARIFResult struct {
+	RuleID     string           `json:"ruleId"`
+	RuleIndex  int              `json:"ruleIndex"`
+	Level      string           `json:"level"`
+	Message    *SARIFMessage    `json:"message"`
+	Locations  []*SARIFLocation `json:"locations,omitempty"`
+	Properties map[string]any   `json:"properties,omitempty"`
+}
+
+// SARIFMessage is the text message.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation is the location of a result.
+type SARIFLocation struct {
+	PhysicalLocation *SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is the file and region of a result.
+type SARIFPhysicalLocation struct {
+	ArtifactLocation *SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion           `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is the file of a result.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is the region of a result. The lines and columns are one-based.
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// FileAdvice is the advice list of a file.
+type FileAdvice struct {
+	// Path is the file path relative to the repository root, or any URI identifying the statements.
+	Path       string
+	Engine     storepb.Engine
+	AdviceList []Advice
+}
+
+// ConvertToSARIF converts the advice list of the files to the SARIF report.
+// The successful advices are skipped.
+func ConvertToSARIF(fileAdviceList []*FileAdvice) *SARIFLog {
+	driver := &SARIFToolComponent{
+		Name:           sarifToolName,
+		InformationURI: sarifToolInformationURI,
+		Rules:          []*SARIFReportingDescriptor{},
+	}
+	ruleIndex := make(map[string]int)
+	results := []*SARIFResult{}
+
+	for _, file := range fileAdviceList {
+		for _, advice := range file.AdviceList {
+			if advice.Status == Success {
+				continue
+			}
+			ruleID := advice.Title
+			index, ok := ruleIndex[ruleID]
+			if !ok {
+				index = len(driver.Rules)
+				ruleIndex[ruleID] = index
+				driver.Rules = append(driver.Rules, newSARIFReportingDescriptor(file.Engine, advice))
+			}
+
+			message := advice.Content
+			if message == "" {
+				message = advice.Title
+			}
+			result := &SARIFResult{
+				RuleID:    ruleID,
+				RuleIndex: index,
+				Level:     convertStatusToSARIFLevel(advice.Status),
+				Message:   &SARIFMessage{Text: message},
+				Locations: []*SARIFLocation{
+					{
+						PhysicalLocation: &SARIFPhysicalLocation{
+							ArtifactLocation: &SARIFArtifactLocation{URI: file.Path},
+							Region:           convertToSARIFRegion(advice),
+						},
+					},
+				},
+				Properties: map[string]any{
+					"code": advice.Code.Int(),
+				},
+			}
+			results = append(results, result)
+		}
+	}
+
+	return &SARIFLog{
+		Schema:  SARIFSchema,
+		Version: SARIFVersion,
+		Runs: []*SARIFRun{
+			{
+				Tool:    &SARIFTool{Driver: driver},
+				Results: results,
+			},
+		},
+	}
+}
+
+// newSARIFReportingDescriptor builds the rule metadata from the advice.
+// The title of the advice is the SQL review rule type for the rule advisors,
+// and we look up the advisor type registered for the engine as the rule name.
+func newSARIFReportingDescriptor(engine storepb.Engine, advice Advice) *SARIFReportingDescriptor {
+	descriptor := &SARIFReportingDescriptor{
+		ID:               advice.Title,
+		ShortDescription: &SARIFMessage{Text: advice.Title},
+		DefaultConfiguration: &SARIFConfiguration{
+			Level: convertStatusToSARIFLevel(advice.Status),
+		},
+	}
+	if advisorType, err := getAdvisorTypeByRule(SQLReviewRuleType(advice.Title), engine); err == nil {
+		descriptor.Name = string(advisorType)
+		descriptor.HelpURI = sarifToolInformationURI + "#" + advice.Title
+	}
+	return descriptor
+}
+
+func convertStatusToSARIFLevel(status Status) string {
+	switch status {
+	case Error:
+		return "error"
+	case Warn:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// convertToSARIFRegion converts the advice position to the SARIF region.
+// The advice line is one-based and the column is zero-based, while both are one-based in SARIF.
+func convertToSARIFRegion(advice Advice) *SARIFRegion {
+	if advice.Line <= 0 {
+		return nil
+	}
+	return &SARIFRegion{
+		StartLine:   advice.Line,
+		StartColumn: advice.Column + 1,
+	}
+}
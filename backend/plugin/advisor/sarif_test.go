@@ -0,0 +1,63 @@
+package advisor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestConvertToSARIF(t *testing.T) {
+	a := require.New(t)
+
+	log := ConvertToSARIF([]*FileAdvice{
+		{
+			Path:   "migrations/1.0_create_table.sql",
+			Engine: storepb.Engine_MYSQL,
+			AdviceList: []Advice{
+				{Status: Warn, Code: StatementNoWhere, Title: string(SchemaRuleStatementRequireWhere), Content: "\"DELETE FROM t\" requires WHERE clause", Line: 3, Column: 4},
+				{Status: Error, Code: StatementSyntaxError, Title: SyntaxErrorTitle, Content: "Syntax error at line 1"},
+			},
+		},
+		{
+			Path:       "migrations/1.1_ok.sql",
+			Engine:     storepb.Engine_MYSQL,
+			AdviceList: []Advice{{Status: Success, Code: Ok, Title: "OK"}},
+		},
+		{
+			Path:   "migrations/1.2_update.sql",
+			Engine: storepb.Engine_MYSQL,
+			AdviceList: []Advice{
+				{Status: Error, Code: StatementNoWhere, Title: string(SchemaRuleStatementRequireWhere), Content: "\"UPDATE t SET a = 1\" requires WHERE clause", Line: 1},
+			},
+		},
+	})
+
+	a.Equal(SARIFVersion, log.Version)
+	a.Len(log.Runs, 1)
+	run := log.Runs[0]
+	a.Len(run.Tool.Driver.Rules, 2)
+
+	rule := run.Tool.Driver.Rules[0]
+	a.Equal(string(SchemaRuleStatementRequireWhere), rule.ID)
+	a.Equal(string(MySQLWhereRequirement), rule.Name)
+	a.Equal("warning", rule.DefaultConfiguration.Level)
+	a.NotEmpty(rule.HelpURI)
+
+	syntaxRule := run.Tool.Driver.Rules[1]
+	a.Equal(SyntaxErrorTitle, syntaxRule.ID)
+	a.Empty(syntaxRule.Name)
+
+	a.Len(run.Results, 3)
+	a.Equal(&SARIFRegion{StartLine: 3, StartColumn: 5}, run.Results[0].Locations[0].PhysicalLocation.Region)
+	a.Nil(run.Results[1].Locations[0].PhysicalLocation.Region)
+	a.Equal(1, run.Results[1].RuleIndex)
+	a.Equal(0, run.Results[2].RuleIndex)
+	a.Equal("error", run.Results[2].Level)
+	a.Equal("migrations/1.2_update.sql", run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+	_, err := json.Marshal(log)
+	a.NoError(err)
+}
@@ -90,6 +90,8 @@ const (
 	SchemaRuleStatementAffectedRowLimit SQLReviewRuleType = "statement.affected-row-limit"
 	// SchemaRuleStatementDMLDryRun dry run the dml.
 	SchemaRuleStatementDMLDryRun SQLReviewRuleType = "statement.dml-dry-run"
+	// SchemaRuleStatementCustomCEL is the user-defined rule whose condition is a CEL expression.
+	SchemaRuleStatementCustomCEL SQLReviewRuleType = "statement.custom-cel"
 	// SchemaRuleStatementDisallowAddColumnWithDefault disallow to add column with DEFAULT.
 	SchemaRuleStatementDisallowAddColumnWithDefault = "statement.disallow-add-column-with-default"
 	// SchemaRuleStatementAddCheckNotValid require add check constraints not valid.
@@ -131,6 +133,10 @@ const (
 	SchemaRuleStatementPriorBackupCheck = "statement.prior-backup-check"
 	// SchemaRuleStatementNonTransactional checks for non-transactional statements.
 	SchemaRuleStatementNonTransactional = "statement.non-transactional"
+	// SchemaRuleStatementDisallowSelectInto disallow creating tables by SELECT INTO.
+	SchemaRuleStatementDisallowSelectInto SQLReviewRuleType = "statement.disallow-select-into"
+	// SchemaRuleStatementDisallowNoLockHint disallow the NOLOCK and READUNCOMMITTED table hints.
+	SchemaRuleStatementDisallowNoLockHint SQLReviewRuleType = "statement.disallow-nolock"
 
 	// SchemaRuleTableRequirePK require the table to have a primary key.
 	SchemaRuleTableRequirePK SQLReviewRuleType = "table.require-pk"
@@ -318,6 +324,25 @@ type NamingCaseRulePayload struct {
 	Upper bool `json:"upper"`
 }
 
+// CustomCELRulePayload is the payload for the user-defined rules expressed in CEL.
+type CustomCELRulePayload struct {
+	// Rules are the user-defined rules, which are identified by their unique names.
+	Rules []*CustomCELRule `json:"rules"`
+}
+
+// CustomCELRule is the user-defined rule expressed in CEL.
+type CustomCELRule struct {
+	// Name is the unique name of the rule.
+	Name string `json:"name"`
+	// Title is shown as the title of the advice. The name is used if it's empty.
+	Title string `json:"title"`
+	// Expression is the CEL expression evaluated against each statement.
+	// The advice is reported if the expression returns true.
+	Expression string `json:"expression"`
+	// Message is the optional content of the advice.
+	Message string `json:"message"`
+}
+
 // UnmarshalNamingRulePayloadAsRegexp will unmarshal payload to NamingRulePayload and compile it as regular expression.
 func UnmarshalNamingRulePayloadAsRegexp(payload string) (*regexp.Regexp, int, error) {
 	var nr NamingRulePayload
@@ -464,6 +489,31 @@ func UnmarshalNamingCaseRulePayload(payload string) (*NamingCaseRulePayload, err
 	return &ncr, nil
 }
 
+// UnmarshalCustomCELRulePayload will unmarshal payload to CustomCELRulePayload.
+func UnmarshalCustomCELRulePayload(payload string) (*CustomCELRulePayload, error) {
+	var ccr CustomCELRulePayload
+	if err := json.Unmarshal([]byte(payload), &ccr); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal custom CEL rule payload %q", payload)
+	}
+	if len(ccr.Rules) == 0 {
+		return nil, errors.Errorf("custom CEL rule payload %q has no rules", payload)
+	}
+	nameSet := make(map[string]bool)
+	for _, rule := range ccr.Rules {
+		if rule.Name == "" {
+			return nil, errors.Errorf("the name of custom CEL rule in payload %q is empty", payload)
+		}
+		if nameSet[rule.Name] {
+			return nil, errors.Errorf("duplicate custom CEL rule name %q", rule.Name)
+		}
+		nameSet[rule.Name] = true
+		if rule.Expression == "" {
+			return nil, errors.Errorf("the expression of custom CEL rule %q is empty", rule.Name)
+		}
+	}
+	return &ccr, nil
+}
+
 // SQLReviewCheckContext is the context for SQL review check.
 type SQLReviewCheckContext struct {
 	Charset               string
@@ -772,7 +822,8 @@ func SQLReviewCheck(statements string, ruleList []*storepb.SQLReviewRule, checkC
 
 	finder := checkContext.Catalog.GetFinder()
 	switch checkContext.DbType {
-	case storepb.Engine_TIDB, storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_POSTGRES, storepb.Engine_OCEANBASE:
+	case storepb.Engine_TIDB, storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_POSTGRES, storepb.Engine_OCEANBASE,
+		storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_MSSQL:
 		if err := finder.WalkThrough(statements); err != nil {
 			return convertWalkThroughErrorToAdvice(checkContext, err)
 		}
@@ -1113,6 +1164,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return PostgreSQLNoLeadingWildcardLike, nil
 		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
 			return OracleNoLeadingWildcardLike, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLNoLeadingWildcardLike, nil
 		}
 	case SchemaRuleStatementNoSelectAll:
 		switch engine {
@@ -1157,10 +1210,15 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLNamingIndexConvention, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLNamingIndexConvention, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLNamingIndexConvention, nil
 		}
 	case SchemaRulePKNaming:
-		if engine == storepb.Engine_POSTGRES {
+		switch engine {
+		case storepb.Engine_POSTGRES:
 			return PostgreSQLNamingPKConvention, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLNamingPKConvention, nil
 		}
 	case SchemaRuleUKNaming:
 		switch engine {
@@ -1168,6 +1226,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLNamingUKConvention, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLNamingUKConvention, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLNamingUKConvention, nil
 		}
 	case SchemaRuleFKNaming:
 		switch engine {
@@ -1175,6 +1235,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLNamingFKConvention, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLNamingFKConvention, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLNamingFKConvention, nil
 		}
 	case SchemaRuleColumnNaming:
 		switch engine {
@@ -1337,6 +1399,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return PostgreSQLRequireColumnDefault, nil
 		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
 			return OracleRequireColumnDefault, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLRequireColumnDefault, nil
 		}
 	case SchemaRuleAddNotNullColumnRequireDefault:
 		if engine == storepb.Engine_ORACLE {
@@ -1444,6 +1508,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return PostgreSQLIndexKeyNumberLimit, nil
 		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
 			return OracleIndexKeyNumberLimit, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLIndexKeyNumberLimit, nil
 		}
 	case SchemaRuleIndexTotalNumberLimit:
 		switch engine {
@@ -1453,8 +1519,11 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return PostgreSQLIndexTotalNumberLimit, nil
 		}
 	case SchemaRuleStatementDisallowCascade:
-		if engine == storepb.Engine_POSTGRES {
+		switch engine {
+		case storepb.Engine_POSTGRES:
 			return PostgreSQLStatementDisallowCascade, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLStatementDisallowCascade, nil
 		}
 	case SchemaRuleStatementDisallowCommit:
 		switch engine {
@@ -1477,6 +1546,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLStatementDisallowMixDDLDML, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLStatementDisallowMixDDLDML, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLStatementDisallowMixDDLDML, nil
 		}
 	case SchemaRuleStatementPriorBackupCheck:
 		switch engine {
@@ -1539,6 +1610,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return PostgreSQLInsertMustSpecifyColumn, nil
 		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
 			return OracleInsertMustSpecifyColumn, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLInsertMustSpecifyColumn, nil
 		}
 	case SchemaRuleStatementInsertDisallowOrderByRand:
 		switch engine {
@@ -1570,6 +1643,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLStatementAffectedRowLimit, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLStatementAffectedRowLimit, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLStatementAffectedRowLimit, nil
 		}
 	case SchemaRuleStatementDMLDryRun:
 		switch engine {
@@ -1578,6 +1653,13 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLStatementDMLDryRun, nil
 		}
+	case SchemaRuleStatementCustomCEL:
+		switch engine {
+		case storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
+			return MySQLStatementCustomCEL, nil
+		case storepb.Engine_POSTGRES:
+			return PostgreSQLStatementCustomCEL, nil
+		}
 	case SchemaRuleStatementDisallowAddColumnWithDefault:
 		if engine == storepb.Engine_POSTGRES {
 			return PostgreSQLDisallowAddColumnWithDefault, nil
@@ -1674,6 +1756,14 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 		if engine == storepb.Engine_POSTGRES {
 			return PostgreSQLNonTransactional, nil
 		}
+	case SchemaRuleStatementDisallowSelectInto:
+		if engine == storepb.Engine_MSSQL {
+			return MSSQLStatementDisallowSelectInto, nil
+		}
+	case SchemaRuleStatementDisallowNoLockHint:
+		if engine == storepb.Engine_MSSQL {
+			return MSSQLStatementDisallowNoLockHint, nil
+		}
 	}
 	return Fake, errors.Errorf("unknown SQL review rule type %v for %v", ruleType, engine)
 }
@@ -364,6 +364,8 @@ func SetDefaultSQLReviewRulePayload(ruleTp SQLReviewRuleType, dbType storepb.Eng
 		SchemaRuleStatementDisallowMixDDLDML,
 		SchemaRuleStatementPriorBackupCheck,
 		SchemaRuleStatementJoinStrictColumnAttrs,
+		SchemaRuleStatementDisallowSelectInto,
+		SchemaRuleStatementDisallowNoLockHint,
 		SchemaRuleTableDisallowSetCharset:
 	case SchemaRuleTableDropNamingConvention:
 		payload, err = json.Marshal(NamingRulePayload{
@@ -412,6 +414,21 @@ func SetDefaultSQLReviewRulePayload(ruleTp SQLReviewRuleType, dbType storepb.Eng
 		payload, err = json.Marshal(NumberTypeRulePayload{
 			Number: 5,
 		})
+	case SchemaRuleStatementCustomCEL:
+		payload, err = json.Marshal(CustomCELRulePayload{
+			Rules: []*CustomCELRule{
+				{
+					Name:       "no-unfiltered-dml",
+					Title:      "Disallow unfiltered UPDATE/DELETE",
+					Expression: `statement.type in ["UPDATE", "DELETE"] && !statement.has_where`,
+				},
+				{
+					Name:       "no-secret-column",
+					Expression: `"secret" in statement.columns`,
+					Message:    "The secret column must not be touched",
+				},
+			},
+		})
 	case SchemaRuleStatementMaximumJoinTableCount:
 		payload, err = json.Marshal(NumberTypeRulePayload{
 			Number: 2,
@@ -205,3 +205,68 @@ func TestGetOrderedColumns(t *testing.T) {
 		a.Equal(tt.wantColumnIndexMap, gotMap)
 	}
 }
+
+func TestParseRoleName(t *testing.T) {
+	tests := []struct {
+		id       string
+		database string
+		name     string
+	}{
+		{id: "app.readWrite", database: "app", name: "readWrite"},
+		{id: "app.role.with.dots", database: "app", name: "role.with.dots"},
+		{id: "reporter", database: "admin", name: "reporter"},
+	}
+
+	for _, tc := range tests {
+		database, name := parseRoleName(tc.id)
+		require.Equal(t, tc.database, database, tc.id)
+		require.Equal(t, tc.name, name, tc.id)
+	}
+}
+
+func TestParseRoleAttribute(t *testing.T) {
+	a := require.New(t)
+
+	attribute := `{"privileges":[{"resource":{"db":"app","collection":""},"actions":["find","insert"]}],"roles":[{"role":"read","db":"app"}],"users":["app.alice"]}`
+	got, err := parseRoleAttribute(&attribute)
+	a.NoError(err)
+	a.Len(got.Privileges, 1)
+	a.Equal("app", *got.Privileges[0].Resource.DB)
+	a.Equal("", *got.Privileges[0].Resource.Collection)
+	a.Nil(got.Privileges[0].Resource.Cluster)
+	a.Equal([]string{"find", "insert"}, got.Privileges[0].Actions)
+	a.Equal([]Role{{RoleName: "read", DB: "app"}}, got.Roles)
+	a.Equal(&[]string{"app.alice"}, got.Users)
+
+	got, err = parseRoleAttribute(nil)
+	a.NoError(err)
+	a.NotNil(got.Privileges)
+	a.NotNil(got.Roles)
+	a.Nil(got.Users)
+
+	// The users are left unchanged if omitted, but revoked from all if empty.
+	privilegesOnly := `{"privileges":[{"resource":{"db":"app","collection":""},"actions":["find"]}]}`
+	got, err = parseRoleAttribute(&privilegesOnly)
+	a.NoError(err)
+	a.Nil(got.Users)
+	noUsers := `{"users":[]}`
+	got, err = parseRoleAttribute(&noUsers)
+	a.NoError(err)
+	a.Equal(&[]string{}, got.Users)
+
+	invalid := `{"privileges":[{"resource":{"cluster":true},"actions":[]}]}`
+	_, err = parseRoleAttribute(&invalid)
+	a.Error(err)
+}
+
+func TestDiffRoleUsers(t *testing.T) {
+	a := require.New(t)
+
+	grantUsers, revokeUsers := diffRoleUsers([]string{"app.alice", "app.bob"}, []string{"app.bob", "app.carol"})
+	a.Equal([]string{"app.carol"}, grantUsers)
+	a.Equal([]string{"app.alice"}, revokeUsers)
+
+	grantUsers, revokeUsers = diffRoleUsers([]string{"app.alice"}, []string{})
+	a.Empty(grantUsers)
+	a.Equal([]string{"app.alice"}, revokeUsers)
+}
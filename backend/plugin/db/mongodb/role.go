@@ -3,11 +3,14 @@ package mongodb
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 
+	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -15,31 +18,315 @@ import (
 const (
 	// bytebaseDefaultDatabase is the default database name for bytebase.
 	bytebaseDefaultDatabase = "bytebase"
+	// adminDatabase is the database of the roles and users without the database specified.
+	adminDatabase = "admin"
 )
 
-// CreateRole creates the role.
-func (*Driver) CreateRole(_ context.Context, _ *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("create role for MongoDB is not implemented yet")
+// RoleAttribute is the attribute of the MongoDB user-defined role, which is encoded as JSON in DatabaseRoleMessage.Attribute.
+type RoleAttribute struct {
+	// Privileges are the privileges of the role.
+	Privileges []RolePrivilege `json:"privileges" bson:"privileges"`
+	// Roles are the roles the role inherits from.
+	Roles []Role `json:"roles" bson:"roles"`
+	// Users are the users granted the role, in the form of "<db>.<user>".
+	// It is nil if omitted, then the users granted the role are left unchanged on update.
+	Users *[]string `json:"users,omitempty" bson:"-"`
 }
 
-// UpdateRole updates the role.
-func (*Driver) UpdateRole(_ context.Context, _ string, _ *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("update role for MongoDB is not implemented yet")
+// RolePrivilege is the privilege of the role.
+type RolePrivilege struct {
+	Resource RolePrivilegeResource `json:"resource" bson:"resource"`
+	Actions  []string              `json:"actions" bson:"actions"`
 }
 
-// FindRole finds the role by name.
-func (*Driver) FindRole(_ context.Context, _ string) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("find role for MongoDB is not implemented yet")
+// RolePrivilegeResource is the resource of the privilege, which is either a database or collection, the cluster, or any resource.
+type RolePrivilegeResource struct {
+	DB          *string `json:"db,omitempty" bson:"db,omitempty"`
+	Collection  *string `json:"collection,omitempty" bson:"collection,omitempty"`
+	Cluster     *bool   `json:"cluster,omitempty" bson:"cluster,omitempty"`
+	AnyResource *bool   `json:"anyResource,omitempty" bson:"anyResource,omitempty"`
 }
 
-// ListRole lists the role.
-func (*Driver) ListRole(_ context.Context) ([]*db.DatabaseRoleMessage, error) {
-	return nil, errors.Errorf("list role for MongoDB is not implemented yet")
+// RolesInfo is the subset of the mongodb command result of "rolesInfo".
+type RolesInfo struct {
+	Roles []RoleInfo `bson:"roles"`
 }
 
-// DeleteRole deletes the role by name.
-func (*Driver) DeleteRole(_ context.Context, _ string) error {
-	return errors.Errorf("delete role for MongoDB is not implemented yet")
+// RoleInfo is the subset of the `roles` field in the `RolesInfo`.
+type RoleInfo struct {
+	RoleName   string          `bson:"role"`
+	DB         string          `bson:"db"`
+	IsBuiltin  bool            `bson:"isBuiltin"`
+	Privileges []RolePrivilege `bson:"privileges"`
+	Roles      []Role          `bson:"roles"`
+}
+
+// CreateRole creates the user-defined role and grants it to the users.
+// The role name is in the form of "<db>.<role>", and the role is created in the admin database if the database is omitted.
+func (driver *Driver) CreateRole(ctx context.Context, upsert *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
+	databaseName, roleName := parseRoleName(upsert.Name)
+	attribute, err := parseRoleAttribute(upsert.Attribute)
+	if err != nil {
+		return nil, err
+	}
+
+	command := bson.D{
+		{Key: "createRole", Value: roleName},
+		{Key: "privileges", Value: attribute.Privileges},
+		{Key: "roles", Value: attribute.Roles},
+	}
+	if err := driver.client.Database(databaseName).RunCommand(ctx, command).Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to create role %q", upsert.Name)
+	}
+	if attribute.Users != nil {
+		if err := driver.grantRoleToUsers(ctx, databaseName, roleName, *attribute.Users); err != nil {
+			return nil, err
+		}
+	}
+
+	return driver.FindRole(ctx, upsert.Name)
+}
+
+// UpdateRole updates the privileges and inherited roles of the user-defined role, and the users granted the role if specified.
+func (driver *Driver) UpdateRole(ctx context.Context, roleName string, upsert *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
+	oldDatabaseName, oldRoleName := parseRoleName(roleName)
+	databaseName, newRoleName := parseRoleName(upsert.Name)
+	if oldDatabaseName != databaseName || oldRoleName != newRoleName {
+		return nil, common.Errorf(common.Invalid, "rename role %q to %q is not supported for MongoDB", roleName, upsert.Name)
+	}
+	if upsert.Attribute == nil {
+		return driver.FindRole(ctx, upsert.Name)
+	}
+	attribute, err := parseRoleAttribute(upsert.Attribute)
+	if err != nil {
+		return nil, err
+	}
+
+	command := bson.D{
+		{Key: "updateRole", Value: newRoleName},
+		{Key: "privileges", Value: attribute.Privileges},
+		{Key: "roles", Value: attribute.Roles},
+	}
+	if err := driver.client.Database(databaseName).RunCommand(ctx, command).Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to update role %q", upsert.Name)
+	}
+
+	if attribute.Users == nil {
+		return driver.FindRole(ctx, upsert.Name)
+	}
+	grantedUsers, err := driver.getRoleUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grantUsers, revokeUsers := diffRoleUsers(grantedUsers[getRoleID(databaseName, newRoleName)], *attribute.Users)
+	if err := driver.grantRoleToUsers(ctx, databaseName, newRoleName, grantUsers); err != nil {
+		return nil, err
+	}
+	if err := driver.revokeRoleFromUsers(ctx, databaseName, newRoleName, revokeUsers); err != nil {
+		return nil, err
+	}
+
+	return driver.FindRole(ctx, upsert.Name)
+}
+
+// FindRole finds the user-defined role by name.
+func (driver *Driver) FindRole(ctx context.Context, roleName string) (*db.DatabaseRoleMessage, error) {
+	databaseName, name := parseRoleName(roleName)
+	command := bson.D{
+		{Key: "rolesInfo", Value: bson.D{{Key: "role", Value: name}, {Key: "db", Value: databaseName}}},
+		{Key: "showPrivileges", Value: true},
+	}
+	var commandResult RolesInfo
+	if err := driver.client.Database(databaseName).RunCommand(ctx, command).Decode(&commandResult); err != nil {
+		return nil, errors.Wrapf(err, "failed to get role %q", roleName)
+	}
+	if len(commandResult.Roles) == 0 || commandResult.Roles[0].IsBuiltin {
+		return nil, common.Errorf(common.NotFound, "cannot find the role %s", roleName)
+	}
+
+	grantedUsers, err := driver.getRoleUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertToDatabaseRoleMessage(&commandResult.Roles[0], grantedUsers)
+}
+
+// ListRole lists the user-defined roles in all databases.
+func (driver *Driver) ListRole(ctx context.Context) ([]*db.DatabaseRoleMessage, error) {
+	databaseNames, err := driver.client.ListDatabaseNames(ctx, bson.M{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list database names")
+	}
+	grantedUsers, err := driver.getRoleUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*db.DatabaseRoleMessage
+	for _, databaseName := range databaseNames {
+		command := bson.D{
+			{Key: "rolesInfo", Value: 1},
+			{Key: "showPrivileges", Value: true},
+			{Key: "showBuiltinRoles", Value: false},
+		}
+		var commandResult RolesInfo
+		if err := driver.client.Database(databaseName).RunCommand(ctx, command).Decode(&commandResult); err != nil {
+			return nil, errors.Wrapf(err, "failed to list roles in database %q", databaseName)
+		}
+		for i := range commandResult.Roles {
+			if commandResult.Roles[i].IsBuiltin {
+				continue
+			}
+			role, err := convertToDatabaseRoleMessage(&commandResult.Roles[i], grantedUsers)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, role)
+		}
+	}
+	return result, nil
+}
+
+// DeleteRole deletes the user-defined role by name, which revokes it from the users as well.
+func (driver *Driver) DeleteRole(ctx context.Context, roleName string) error {
+	databaseName, name := parseRoleName(roleName)
+	command := bson.D{{Key: "dropRole", Value: name}}
+	if err := driver.client.Database(databaseName).RunCommand(ctx, command).Err(); err != nil {
+		return errors.Wrapf(err, "failed to drop role %q", roleName)
+	}
+	return nil
+}
+
+func (driver *Driver) grantRoleToUsers(ctx context.Context, databaseName, roleName string, users []string) error {
+	for _, user := range users {
+		userDatabaseName, userName := parseRoleName(user)
+		command := bson.D{
+			{Key: "grantRolesToUser", Value: userName},
+			{Key: "roles", Value: []Role{{RoleName: roleName, DB: databaseName}}},
+		}
+		if err := driver.client.Database(userDatabaseName).RunCommand(ctx, command).Err(); err != nil {
+			return errors.Wrapf(err, "failed to grant role %q to user %q", getRoleID(databaseName, roleName), user)
+		}
+	}
+	return nil
+}
+
+func (driver *Driver) revokeRoleFromUsers(ctx context.Context, databaseName, roleName string, users []string) error {
+	for _, user := range users {
+		userDatabaseName, userName := parseRoleName(user)
+		command := bson.D{
+			{Key: "revokeRolesFromUser", Value: userName},
+			{Key: "roles", Value: []Role{{RoleName: roleName, DB: databaseName}}},
+		}
+		if err := driver.client.Database(userDatabaseName).RunCommand(ctx, command).Err(); err != nil {
+			return errors.Wrapf(err, "failed to revoke role %q from user %q", getRoleID(databaseName, roleName), user)
+		}
+	}
+	return nil
+}
+
+// getRoleUsers returns the map from the role id to the ids of the users granted the role.
+func (driver *Driver) getRoleUsers(ctx context.Context) (map[string][]string, error) {
+	command := bson.D{{Key: "usersInfo", Value: bson.D{{Key: "forAllDBs", Value: true}}}}
+	var commandResult UsersInfo
+	if err := driver.client.Database(bytebaseDefaultDatabase).RunCommand(ctx, command).Decode(&commandResult); err != nil {
+		return nil, errors.Wrap(err, "cannot run usersInfo command")
+	}
+	result := make(map[string][]string)
+	for _, user := range commandResult.Users {
+		for _, role := range user.Roles {
+			id := getRoleID(role.DB, role.RoleName)
+			result[id] = append(result[id], user.ID)
+		}
+	}
+	return result, nil
+}
+
+func convertToDatabaseRoleMessage(role *RoleInfo, grantedUsers map[string][]string) (*db.DatabaseRoleMessage, error) {
+	id := getRoleID(role.DB, role.RoleName)
+	attribute := RoleAttribute{
+		Privileges: role.Privileges,
+		Roles:      role.Roles,
+	}
+	users := grantedUsers[id]
+	if users == nil {
+		users = []string{}
+	}
+	attribute.Users = &users
+	if attribute.Privileges == nil {
+		attribute.Privileges = []RolePrivilege{}
+	}
+	if attribute.Roles == nil {
+		attribute.Roles = []Role{}
+	}
+	bs, err := json.Marshal(attribute)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal role attribute")
+	}
+	attributeString := string(bs)
+	return &db.DatabaseRoleMessage{
+		Name:      id,
+		Attribute: &attributeString,
+	}, nil
+}
+
+// parseRoleAttribute parses the JSON encoded role attribute.
+func parseRoleAttribute(attribute *string) (*RoleAttribute, error) {
+	result := &RoleAttribute{}
+	if attribute != nil && strings.TrimSpace(*attribute) != "" {
+		if err := json.Unmarshal([]byte(*attribute), result); err != nil {
+			return nil, common.Wrapf(err, common.Invalid, "invalid role attribute %q", *attribute)
+		}
+	}
+	// The privileges and roles fields are required by createRole and updateRole.
+	if result.Privileges == nil {
+		result.Privileges = []RolePrivilege{}
+	}
+	if result.Roles == nil {
+		result.Roles = []Role{}
+	}
+	for _, privilege := range result.Privileges {
+		if len(privilege.Actions) == 0 {
+			return nil, common.Errorf(common.Invalid, "privilege actions must not be empty")
+		}
+	}
+	return result, nil
+}
+
+// diffRoleUsers returns the users to grant and revoke the role, to change the users granted the role from old to new.
+func diffRoleUsers(oldUsers, newUsers []string) ([]string, []string) {
+	oldUserMap := make(map[string]bool)
+	for _, user := range oldUsers {
+		oldUserMap[user] = true
+	}
+	newUserMap := make(map[string]bool)
+	var grantUsers, revokeUsers []string
+	for _, user := range newUsers {
+		newUserMap[user] = true
+		if !oldUserMap[user] {
+			grantUsers = append(grantUsers, user)
+		}
+	}
+	for _, user := range oldUsers {
+		if !newUserMap[user] {
+			revokeUsers = append(revokeUsers, user)
+		}
+	}
+	return grantUsers, revokeUsers
+}
+
+// parseRoleName parses the role or user id in the form of "<db>.<name>", the database defaults to admin.
+// The database name cannot contain dots, so the first dot separates the database and the name.
+func parseRoleName(id string) (string, string) {
+	databaseName, name, ok := strings.Cut(id, ".")
+	if !ok {
+		return adminDatabase, id
+	}
+	return databaseName, name
+}
+
+func getRoleID(databaseName, name string) string {
+	return fmt.Sprintf("%s.%s", databaseName, name)
 }
 
 // getUserList returns the list of users.
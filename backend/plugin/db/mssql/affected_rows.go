@@ -0,0 +1,86 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+)
+
+// GetAffectedRowsByShowplan returns the estimated rows of the DML statement by SHOWPLAN_XML without executing it.
+func GetAffectedRowsByShowplan(ctx context.Context, sqlDB *sql.DB, statement string) (int64, error) {
+	// SET SHOWPLAN_XML is session scoped, so we need a dedicated connection.
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	// SET SHOWPLAN_XML must be the only statement in the batch.
+	if _, err := conn.ExecContext(ctx, "SET SHOWPLAN_XML ON"); err != nil {
+		discardConn(conn)
+		return 0, err
+	}
+	defer func() {
+		// Turn off the showplan even if ctx is canceled, otherwise the connection returns plans instead of executing the statements.
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), "SET SHOWPLAN_XML OFF"); err != nil {
+			slog.Warn("failed to turn off showplan xml, discard the connection", log.BBError(err))
+			discardConn(conn)
+		}
+	}()
+
+	var plan string
+	if err := conn.QueryRowContext(ctx, statement).Scan(&plan); err != nil {
+		return 0, err
+	}
+	return getAffectedRowsFromShowplan(plan)
+}
+
+// discardConn closes the underlying connection instead of returning it to the pool, as its session state is unknown.
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+}
+
+func getAffectedRowsFromShowplan(plan string) (int64, error) {
+	// <ShowPlanXML ...><BatchSequence><Batch><Statements>
+	//   <StmtSimple StatementText="DELETE FROM t" StatementType="DELETE" StatementEstRows="3" ...>
+	decoder := xml.NewDecoder(strings.NewReader(plan))
+	// The plan may declare utf-16 encoding, but the driver has already decoded it into a Go string.
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse showplan xml")
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "StmtSimple" {
+			continue
+		}
+		for _, attr := range element.Attr {
+			if attr.Name.Local != "StatementEstRows" {
+				continue
+			}
+			rows, err := strconv.ParseFloat(attr.Value, 64)
+			if err != nil {
+				return 0, errors.Errorf("failed to get number from %q", attr.Value)
+			}
+			return int64(rows), nil
+		}
+	}
+	return 0, errors.Errorf("failed to extract StatementEstRows from showplan xml")
+}
@@ -0,0 +1,21 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAffectedRowsFromShowplan(t *testing.T) {
+	a := require.New(t)
+
+	rows, err := getAffectedRowsFromShowplan(`<?xml version="1.0" encoding="utf-16"?>
+<ShowPlanXML xmlns="http://schemas.microsoft.com/sqlserver/2004/07/showplan" Version="1.564"><BatchSequence><Batch><Statements>
+<StmtSimple StatementText="DELETE FROM t1 WHERE c1 = 1" StatementId="1" StatementType="DELETE" StatementEstRows="12.5"></StmtSimple>
+</Statements></Batch></BatchSequence></ShowPlanXML>`)
+	a.NoError(err)
+	a.Equal(int64(12), rows)
+
+	_, err = getAffectedRowsFromShowplan(`<ShowPlanXML></ShowPlanXML>`)
+	a.Error(err)
+}
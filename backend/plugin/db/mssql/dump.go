@@ -2,11 +2,863 @@ package mssql
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
-// Dump dumps the database.
-func (*Driver) Dump(_ context.Context, _ io.Writer, _ bool) (string, error) {
-	// TODO(d): implement it.
+// Dump dumps the schema of the database, including schemas, user-defined types, tables with their constraints,
+// indexes, views, functions, procedures and triggers. The data is not dumped.
+// The CREATE statements of the programmable objects must be the only statement in the batch, so each of them is followed by GO.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, _ bool) (string, error) {
+	// go-mssqldb does not support read-only transactions.
+	txn, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	if err := dumpTxn(ctx, txn, out); err != nil {
+		return "", errors.Wrapf(err, "failed to dump database %q", driver.databaseName)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
 	return "", nil
 }
+
+func dumpTxn(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	var defaultCollation sql.NullString
+	if err := txn.QueryRowContext(ctx, "SELECT CAST(DATABASEPROPERTYEX(DB_NAME(), 'Collation') AS NVARCHAR(128))").Scan(&defaultCollation); err != nil {
+		return errors.Wrapf(err, "failed to get database collation")
+	}
+
+	slog.Debug("dump schemas")
+	schemas, err := dumpSchemas(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump schemas")
+	}
+	for _, schema := range schemas {
+		if _, err := fmt.Fprintf(out, "CREATE SCHEMA %s;\nGO\n\n", quoteIdentifier(schema)); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("dump columns")
+	columnMap, err := dumpColumns(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump columns")
+	}
+
+	slog.Debug("dump types")
+	types, err := dumpTypes(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump types")
+	}
+	for _, tp := range types {
+		tp.columns = columnMap[tp.tableObjectID]
+		if err := tp.assembleStatement(out, defaultCollation.String); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("dump tables")
+	tables, err := dumpTables(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump tables")
+	}
+	keyConstraintMap, err := dumpKeyConstraints(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump primary key and unique constraints")
+	}
+	checkConstraintMap, err := dumpCheckConstraints(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump check constraints")
+	}
+	foreignKeyMap, err := dumpForeignKeys(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump foreign keys")
+	}
+	indexMap, err := dumpIndexes(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump indexes")
+	}
+	for _, table := range tables {
+		table.columns = columnMap[table.objectID]
+		table.keyConstraints = keyConstraintMap[table.objectID]
+		table.checkConstraints = checkConstraintMap[table.objectID]
+		table.foreignKeys = foreignKeyMap[table.objectID]
+		table.indexes = indexMap[table.objectID]
+	}
+	tables, deferredForeignKeys := sortTablesByDependency(tables)
+	for _, table := range tables {
+		if err := table.assembleStatement(out, defaultCollation.String); err != nil {
+			return err
+		}
+	}
+	for _, foreignKey := range deferredForeignKeys {
+		if _, err := fmt.Fprintf(out, "ALTER TABLE %s ADD %s;\n\n", foreignKey.table, foreignKey.definition()); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("dump modules")
+	modules, err := dumpModules(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump views, functions, procedures and triggers")
+	}
+	for _, module := range modules {
+		if _, err := fmt.Fprintf(out, "%s\nGO\n\n", strings.TrimSpace(module.definition)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpSchemas(ctx context.Context, txn *sql.Tx) ([]string, error) {
+	// The schema ids of the fixed database roles start from 16384, and the ones below 5 are dbo, guest, INFORMATION_SCHEMA and sys.
+	query := `
+		SELECT name FROM sys.schemas
+		WHERE schema_id >= 5 AND schema_id < 16384
+		ORDER BY name;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+type columnSchema struct {
+	name               string
+	typeName           string
+	typeSchema         string
+	isUserDefined      bool
+	maxLength          int
+	precision          int
+	scale              int
+	nullable           bool
+	collation          sql.NullString
+	isIdentity         bool
+	seed               sql.NullString
+	increment          sql.NullString
+	computed           sql.NullString
+	isPersisted        bool
+	defaultName        sql.NullString
+	defaultValue       sql.NullString
+	defaultSystemNamed bool
+}
+
+func dumpColumns(ctx context.Context, txn *sql.Tx) (map[int][]*columnSchema, error) {
+	query := `
+		SELECT
+			c.object_id,
+			c.name,
+			tp.name,
+			ts.name,
+			tp.is_user_defined,
+			c.max_length,
+			c.precision,
+			c.scale,
+			c.is_nullable,
+			c.collation_name,
+			c.is_identity,
+			CAST(ic.seed_value AS NVARCHAR(64)),
+			CAST(ic.increment_value AS NVARCHAR(64)),
+			cc.definition,
+			ISNULL(cc.is_persisted, 0),
+			dc.name,
+			dc.definition,
+			ISNULL(dc.is_system_named, 0)
+		FROM sys.columns c
+		INNER JOIN sys.types tp ON c.user_type_id = tp.user_type_id
+		INNER JOIN sys.schemas ts ON tp.schema_id = ts.schema_id
+		LEFT JOIN sys.identity_columns ic ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		LEFT JOIN sys.computed_columns cc ON c.object_id = cc.object_id AND c.column_id = cc.column_id
+		LEFT JOIN sys.default_constraints dc ON c.object_id = dc.parent_object_id AND c.column_id = dc.parent_column_id
+		WHERE c.object_id IN (SELECT object_id FROM sys.tables WHERE is_ms_shipped = 0)
+			OR c.object_id IN (SELECT type_table_object_id FROM sys.table_types)
+		ORDER BY c.object_id, c.column_id;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columnMap := make(map[int][]*columnSchema)
+	for rows.Next() {
+		var objectID int
+		column := &columnSchema{}
+		if err := rows.Scan(
+			&objectID,
+			&column.name,
+			&column.typeName,
+			&column.typeSchema,
+			&column.isUserDefined,
+			&column.maxLength,
+			&column.precision,
+			&column.scale,
+			&column.nullable,
+			&column.collation,
+			&column.isIdentity,
+			&column.seed,
+			&column.increment,
+			&column.computed,
+			&column.isPersisted,
+			&column.defaultName,
+			&column.defaultValue,
+			&column.defaultSystemNamed,
+		); err != nil {
+			return nil, err
+		}
+		columnMap[objectID] = append(columnMap[objectID], column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columnMap, nil
+}
+
+func (c *columnSchema) assemble(defaultCollation string) string {
+	var buf strings.Builder
+	_, _ = buf.WriteString(quoteIdentifier(c.name))
+	if c.computed.Valid {
+		_, _ = fmt.Fprintf(&buf, " AS %s", c.computed.String)
+		if c.isPersisted {
+			_, _ = buf.WriteString(" PERSISTED")
+		}
+		return buf.String()
+	}
+	_, _ = fmt.Fprintf(&buf, " %s", formatColumnType(c.typeSchema, c.typeName, c.isUserDefined, c.maxLength, c.precision, c.scale))
+	if c.collation.Valid && c.collation.String != defaultCollation && !c.isUserDefined {
+		_, _ = fmt.Fprintf(&buf, " COLLATE %s", c.collation.String)
+	}
+	if c.isIdentity {
+		_, _ = fmt.Fprintf(&buf, " IDENTITY(%s,%s)", c.seed.String, c.increment.String)
+	}
+	if c.defaultValue.Valid {
+		if !c.defaultSystemNamed {
+			_, _ = fmt.Fprintf(&buf, " CONSTRAINT %s", quoteIdentifier(c.defaultName.String))
+		}
+		_, _ = fmt.Fprintf(&buf, " DEFAULT %s", c.defaultValue.String)
+	}
+	if c.nullable {
+		_, _ = buf.WriteString(" NULL")
+	} else {
+		_, _ = buf.WriteString(" NOT NULL")
+	}
+	return buf.String()
+}
+
+// formatColumnType formats the column type with the length, precision and scale in the catalog.
+func formatColumnType(schema, name string, isUserDefined bool, maxLength, precision, scale int) string {
+	if isUserDefined {
+		return fmt.Sprintf("%s.%s", quoteIdentifier(schema), quoteIdentifier(name))
+	}
+	switch strings.ToLower(name) {
+	case "varchar", "char", "varbinary", "binary":
+		if maxLength == -1 {
+			return fmt.Sprintf("%s(max)", name)
+		}
+		return fmt.Sprintf("%s(%d)", name, maxLength)
+	case "nvarchar", "nchar":
+		if maxLength == -1 {
+			return fmt.Sprintf("%s(max)", name)
+		}
+		// The max_length is in bytes, and each character takes 2 bytes.
+		return fmt.Sprintf("%s(%d)", name, maxLength/2)
+	case "decimal", "numeric":
+		return fmt.Sprintf("%s(%d,%d)", name, precision, scale)
+	case "datetime2", "datetimeoffset", "time":
+		return fmt.Sprintf("%s(%d)", name, scale)
+	default:
+		return name
+	}
+}
+
+type typeSchema struct {
+	schema        string
+	name          string
+	baseType      string
+	maxLength     int
+	precision     int
+	scale         int
+	nullable      bool
+	isTableType   bool
+	tableObjectID int
+	columns       []*columnSchema
+}
+
+func dumpTypes(ctx context.Context, txn *sql.Tx) ([]*typeSchema, error) {
+	query := `
+		SELECT
+			s.name,
+			t.name,
+			bt.name,
+			t.max_length,
+			t.precision,
+			t.scale,
+			t.is_nullable,
+			t.is_table_type,
+			ISNULL(tt.type_table_object_id, 0)
+		FROM sys.types t
+		INNER JOIN sys.schemas s ON t.schema_id = s.schema_id
+		LEFT JOIN sys.types bt ON t.system_type_id = bt.user_type_id
+		LEFT JOIN sys.table_types tt ON t.user_type_id = tt.user_type_id
+		WHERE t.is_user_defined = 1 AND t.is_assembly_type = 0
+		ORDER BY s.name, t.name;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []*typeSchema
+	for rows.Next() {
+		tp := &typeSchema{}
+		var baseType sql.NullString
+		if err := rows.Scan(&tp.schema, &tp.name, &baseType, &tp.maxLength, &tp.precision, &tp.scale, &tp.nullable, &tp.isTableType, &tp.tableObjectID); err != nil {
+			return nil, err
+		}
+		tp.baseType = baseType.String
+		types = append(types, tp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+func (t *typeSchema) assembleStatement(out io.Writer, defaultCollation string) error {
+	name := fmt.Sprintf("%s.%s", quoteIdentifier(t.schema), quoteIdentifier(t.name))
+	if !t.isTableType {
+		nullable := "NULL"
+		if !t.nullable {
+			nullable = "NOT NULL"
+		}
+		_, err := fmt.Fprintf(out, "CREATE TYPE %s FROM %s %s;\n\n", name, formatColumnType("", t.baseType, false, t.maxLength, t.precision, t.scale), nullable)
+		return err
+	}
+	var items []string
+	for _, column := range t.columns {
+		items = append(items, column.assemble(defaultCollation))
+	}
+	_, err := fmt.Fprintf(out, "CREATE TYPE %s AS TABLE (\n    %s\n);\n\n", name, strings.Join(items, ",\n    "))
+	return err
+}
+
+type tableSchema struct {
+	objectID         int
+	schema           string
+	name             string
+	columns          []*columnSchema
+	keyConstraints   []*keyConstraintSchema
+	checkConstraints []*checkConstraintSchema
+	foreignKeys      []*foreignKeySchema
+	indexes          []*indexSchema
+}
+
+func (t *tableSchema) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(t.schema), quoteIdentifier(t.name))
+}
+
+func dumpTables(ctx context.Context, txn *sql.Tx) ([]*tableSchema, error) {
+	query := `
+		SELECT t.object_id, s.name, t.name
+		FROM sys.tables t
+		INNER JOIN sys.schemas s ON t.schema_id = s.schema_id
+		WHERE t.is_ms_shipped = 0
+		ORDER BY s.name, t.name;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []*tableSchema
+	for rows.Next() {
+		table := &tableSchema{}
+		if err := rows.Scan(&table.objectID, &table.schema, &table.name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func (t *tableSchema) assembleStatement(out io.Writer, defaultCollation string) error {
+	var items []string
+	for _, column := range t.columns {
+		items = append(items, column.assemble(defaultCollation))
+	}
+	for _, constraint := range t.keyConstraints {
+		items = append(items, constraint.definition())
+	}
+	for _, constraint := range t.checkConstraints {
+		items = append(items, fmt.Sprintf("CONSTRAINT %s CHECK %s", quoteIdentifier(constraint.name), constraint.definition))
+	}
+	for _, foreignKey := range t.foreignKeys {
+		if foreignKey.deferred {
+			continue
+		}
+		items = append(items, foreignKey.definition())
+	}
+	if _, err := fmt.Fprintf(out, "CREATE TABLE %s (\n    %s\n);\n\n", t.fullName(), strings.Join(items, ",\n    ")); err != nil {
+		return err
+	}
+	for _, index := range t.indexes {
+		if _, err := fmt.Fprintf(out, "%s;\n\n", index.definition(t.fullName())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type indexColumn struct {
+	name       string
+	descending bool
+}
+
+func (c *indexColumn) String() string {
+	if c.descending {
+		return fmt.Sprintf("%s DESC", quoteIdentifier(c.name))
+	}
+	return fmt.Sprintf("%s ASC", quoteIdentifier(c.name))
+}
+
+type keyConstraintSchema struct {
+	name      string
+	isPrimary bool
+	// indexType is CLUSTERED or NONCLUSTERED.
+	indexType string
+	columns   []*indexColumn
+}
+
+func (c *keyConstraintSchema) definition() string {
+	tp := "UNIQUE"
+	if c.isPrimary {
+		tp = "PRIMARY KEY"
+	}
+	var columns []string
+	for _, column := range c.columns {
+		columns = append(columns, column.String())
+	}
+	return fmt.Sprintf("CONSTRAINT %s %s %s (%s)", quoteIdentifier(c.name), tp, c.indexType, strings.Join(columns, ", "))
+}
+
+func dumpKeyConstraints(ctx context.Context, txn *sql.Tx) (map[int][]*keyConstraintSchema, error) {
+	query := `
+		SELECT
+			kc.parent_object_id,
+			kc.name,
+			kc.type,
+			i.type_desc,
+			c.name,
+			ic.is_descending_key
+		FROM sys.key_constraints kc
+		INNER JOIN sys.indexes i ON kc.parent_object_id = i.object_id AND kc.unique_index_id = i.index_id
+		INNER JOIN sys.index_columns ic ON i.object_id = ic.object_id AND i.index_id = ic.index_id
+		INNER JOIN sys.columns c ON ic.object_id = c.object_id AND ic.column_id = c.column_id
+		WHERE kc.is_ms_shipped = 0
+		ORDER BY kc.parent_object_id, kc.type, kc.name, ic.key_ordinal;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	constraintMap := make(map[int][]*keyConstraintSchema)
+	for rows.Next() {
+		var objectID int
+		var name, tp, indexType string
+		column := &indexColumn{}
+		if err := rows.Scan(&objectID, &name, &tp, &indexType, &column.name, &column.descending); err != nil {
+			return nil, err
+		}
+		constraints := constraintMap[objectID]
+		if len(constraints) == 0 || constraints[len(constraints)-1].name != name {
+			constraints = append(constraints, &keyConstraintSchema{
+				name:      name,
+				isPrimary: strings.TrimSpace(tp) == "PK",
+				indexType: indexType,
+			})
+			constraintMap[objectID] = constraints
+		}
+		constraint := constraints[len(constraints)-1]
+		constraint.columns = append(constraint.columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return constraintMap, nil
+}
+
+type checkConstraintSchema struct {
+	name       string
+	definition string
+}
+
+func dumpCheckConstraints(ctx context.Context, txn *sql.Tx) (map[int][]*checkConstraintSchema, error) {
+	query := `
+		SELECT parent_object_id, name, definition
+		FROM sys.check_constraints
+		WHERE is_ms_shipped = 0
+		ORDER BY parent_object_id, name;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	constraintMap := make(map[int][]*checkConstraintSchema)
+	for rows.Next() {
+		var objectID int
+		constraint := &checkConstraintSchema{}
+		if err := rows.Scan(&objectID, &constraint.name, &constraint.definition); err != nil {
+			return nil, err
+		}
+		constraintMap[objectID] = append(constraintMap[objectID], constraint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return constraintMap, nil
+}
+
+type foreignKeySchema struct {
+	name               string
+	table              string
+	referencedObjectID int
+	referencedTable    string
+	columns            []string
+	referencedColumns  []string
+	onDelete           string
+	onUpdate           string
+	// deferred is true if the foreign key is added after all tables are created to break the reference cycle.
+	deferred bool
+}
+
+func (fk *foreignKeySchema) definition() string {
+	var columns, referencedColumns []string
+	for _, column := range fk.columns {
+		columns = append(columns, quoteIdentifier(column))
+	}
+	for _, column := range fk.referencedColumns {
+		referencedColumns = append(referencedColumns, quoteIdentifier(column))
+	}
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)", quoteIdentifier(fk.name), strings.Join(columns, ", "), fk.referencedTable, strings.Join(referencedColumns, ", "))
+	if fk.onDelete != "NO_ACTION" {
+		_, _ = fmt.Fprintf(&buf, " ON DELETE %s", strings.ReplaceAll(fk.onDelete, "_", " "))
+	}
+	if fk.onUpdate != "NO_ACTION" {
+		_, _ = fmt.Fprintf(&buf, " ON UPDATE %s", strings.ReplaceAll(fk.onUpdate, "_", " "))
+	}
+	return buf.String()
+}
+
+func dumpForeignKeys(ctx context.Context, txn *sql.Tx) (map[int][]*foreignKeySchema, error) {
+	query := `
+		SELECT
+			fk.parent_object_id,
+			fk.name,
+			ps.name,
+			pt.name,
+			fk.referenced_object_id,
+			rs.name,
+			rt.name,
+			pc.name,
+			rc.name,
+			fk.delete_referential_action_desc,
+			fk.update_referential_action_desc
+		FROM sys.foreign_keys fk
+		INNER JOIN sys.foreign_key_columns fkc ON fk.object_id = fkc.constraint_object_id
+		INNER JOIN sys.tables pt ON fk.parent_object_id = pt.object_id
+		INNER JOIN sys.schemas ps ON pt.schema_id = ps.schema_id
+		INNER JOIN sys.tables rt ON fk.referenced_object_id = rt.object_id
+		INNER JOIN sys.schemas rs ON rt.schema_id = rs.schema_id
+		INNER JOIN sys.columns pc ON fkc.parent_object_id = pc.object_id AND fkc.parent_column_id = pc.column_id
+		INNER JOIN sys.columns rc ON fkc.referenced_object_id = rc.object_id AND fkc.referenced_column_id = rc.column_id
+		WHERE fk.is_ms_shipped = 0
+		ORDER BY fk.parent_object_id, fk.name, fkc.constraint_column_id;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	foreignKeyMap := make(map[int][]*foreignKeySchema)
+	for rows.Next() {
+		var objectID, referencedObjectID int
+		var name, schema, table, referencedSchema, referencedTable, column, referencedColumn, onDelete, onUpdate string
+		if err := rows.Scan(&objectID, &name, &schema, &table, &referencedObjectID, &referencedSchema, &referencedTable, &column, &referencedColumn, &onDelete, &onUpdate); err != nil {
+			return nil, err
+		}
+		foreignKeys := foreignKeyMap[objectID]
+		if len(foreignKeys) == 0 || foreignKeys[len(foreignKeys)-1].name != name {
+			foreignKeys = append(foreignKeys, &foreignKeySchema{
+				name:               name,
+				table:              fmt.Sprintf("%s.%s", quoteIdentifier(schema), quoteIdentifier(table)),
+				referencedObjectID: referencedObjectID,
+				referencedTable:    fmt.Sprintf("%s.%s", quoteIdentifier(referencedSchema), quoteIdentifier(referencedTable)),
+				onDelete:           onDelete,
+				onUpdate:           onUpdate,
+			})
+			foreignKeyMap[objectID] = foreignKeys
+		}
+		foreignKey := foreignKeys[len(foreignKeys)-1]
+		foreignKey.columns = append(foreignKey.columns, column)
+		foreignKey.referencedColumns = append(foreignKey.referencedColumns, referencedColumn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return foreignKeyMap, nil
+}
+
+// sortTablesByDependency sorts the tables so that the referenced tables are created before the referencing ones.
+// The foreign keys in the reference cycles are returned to be added after all tables are created.
+func sortTablesByDependency(tables []*tableSchema) ([]*tableSchema, []*foreignKeySchema) {
+	tableMap := make(map[int]*tableSchema)
+	for _, table := range tables {
+		tableMap[table.objectID] = table
+	}
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[int]int)
+	var result []*tableSchema
+	var deferred []*foreignKeySchema
+	var visit func(table *tableSchema)
+	visit = func(table *tableSchema) {
+		state[table.objectID] = visiting
+		for _, foreignKey := range table.foreignKeys {
+			if foreignKey.referencedObjectID == table.objectID {
+				// Self-referencing foreign keys can be created along with the table.
+				continue
+			}
+			referenced, ok := tableMap[foreignKey.referencedObjectID]
+			if !ok {
+				continue
+			}
+			switch state[referenced.objectID] {
+			case visiting:
+				foreignKey.deferred = true
+				deferred = append(deferred, foreignKey)
+			case visited:
+			default:
+				visit(referenced)
+			}
+		}
+		state[table.objectID] = visited
+		result = append(result, table)
+	}
+	for _, table := range tables {
+		if state[table.objectID] == 0 {
+			visit(table)
+		}
+	}
+	return result, deferred
+}
+
+type indexSchema struct {
+	name      string
+	unique    bool
+	indexType string
+	filter    sql.NullString
+	columns   []*indexColumn
+	includes  []string
+}
+
+func (i *indexSchema) definition(table string) string {
+	var buf strings.Builder
+	_, _ = buf.WriteString("CREATE ")
+	if i.unique {
+		_, _ = buf.WriteString("UNIQUE ")
+	}
+	var columns []string
+	for _, column := range i.columns {
+		columns = append(columns, column.String())
+	}
+	_, _ = fmt.Fprintf(&buf, "%s INDEX %s ON %s (%s)", i.indexType, quoteIdentifier(i.name), table, strings.Join(columns, ", "))
+	if len(i.includes) > 0 {
+		var includes []string
+		for _, column := range i.includes {
+			includes = append(includes, quoteIdentifier(column))
+		}
+		_, _ = fmt.Fprintf(&buf, " INCLUDE (%s)", strings.Join(includes, ", "))
+	}
+	if i.filter.Valid {
+		_, _ = fmt.Fprintf(&buf, " WHERE %s", i.filter.String)
+	}
+	return buf.String()
+}
+
+func dumpIndexes(ctx context.Context, txn *sql.Tx) (map[int][]*indexSchema, error) {
+	// Only the rowstore indexes not backing the constraints are dumped here.
+	query := `
+		SELECT
+			i.object_id,
+			i.name,
+			i.is_unique,
+			i.type_desc,
+			i.filter_definition,
+			c.name,
+			ic.is_descending_key,
+			ic.is_included_column
+		FROM sys.indexes i
+		INNER JOIN sys.tables t ON i.object_id = t.object_id
+		INNER JOIN sys.index_columns ic ON i.object_id = ic.object_id AND i.index_id = ic.index_id
+		INNER JOIN sys.columns c ON ic.object_id = c.object_id AND ic.column_id = c.column_id
+		WHERE t.is_ms_shipped = 0 AND i.is_primary_key = 0 AND i.is_unique_constraint = 0 AND i.is_hypothetical = 0 AND i.type IN (1, 2)
+		ORDER BY i.object_id, i.index_id, ic.is_included_column, ic.key_ordinal, ic.index_column_id;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexMap := make(map[int][]*indexSchema)
+	for rows.Next() {
+		var objectID int
+		var name, indexType, column string
+		var unique, descending, included bool
+		var filter sql.NullString
+		if err := rows.Scan(&objectID, &name, &unique, &indexType, &filter, &column, &descending, &included); err != nil {
+			return nil, err
+		}
+		indexes := indexMap[objectID]
+		if len(indexes) == 0 || indexes[len(indexes)-1].name != name {
+			indexes = append(indexes, &indexSchema{
+				name:      name,
+				unique:    unique,
+				indexType: indexType,
+				filter:    filter,
+			})
+			indexMap[objectID] = indexes
+		}
+		index := indexes[len(indexes)-1]
+		if included {
+			index.includes = append(index.includes, column)
+		} else {
+			index.columns = append(index.columns, &indexColumn{name: column, descending: descending})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return indexMap, nil
+}
+
+type moduleSchema struct {
+	objectID   int
+	definition string
+	references []int
+}
+
+// dumpModules dumps the views, functions, procedures and triggers in the dependency order.
+func dumpModules(ctx context.Context, txn *sql.Tx) ([]*moduleSchema, error) {
+	query := `
+		SELECT o.object_id, m.definition
+		FROM sys.sql_modules m
+		INNER JOIN sys.objects o ON m.object_id = o.object_id
+		WHERE o.is_ms_shipped = 0 AND o.type IN ('V', 'FN', 'IF', 'TF', 'P', 'TR') AND m.definition IS NOT NULL
+		ORDER BY o.object_id;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var modules []*moduleSchema
+	moduleMap := make(map[int]*moduleSchema)
+	for rows.Next() {
+		module := &moduleSchema{}
+		if err := rows.Scan(&module.objectID, &module.definition); err != nil {
+			return nil, err
+		}
+		modules = append(modules, module)
+		moduleMap[module.objectID] = module
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	dependencyQuery := `
+		SELECT DISTINCT referencing_id, referenced_id
+		FROM sys.sql_expression_dependencies
+		WHERE referenced_id IS NOT NULL;`
+	dependencyRows, err := txn.QueryContext(ctx, dependencyQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer dependencyRows.Close()
+	for dependencyRows.Next() {
+		var referencing, referenced int
+		if err := dependencyRows.Scan(&referencing, &referenced); err != nil {
+			return nil, err
+		}
+		if module, ok := moduleMap[referencing]; ok {
+			module.references = append(module.references, referenced)
+		}
+	}
+	if err := dependencyRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sortModulesByDependency(modules), nil
+}
+
+// sortModulesByDependency sorts the modules so that the referenced modules are created before the referencing ones.
+func sortModulesByDependency(modules []*moduleSchema) []*moduleSchema {
+	moduleMap := make(map[int]*moduleSchema)
+	for _, module := range modules {
+		moduleMap[module.objectID] = module
+	}
+	visited := make(map[int]bool)
+	var result []*moduleSchema
+	var visit func(module *moduleSchema)
+	visit = func(module *moduleSchema) {
+		visited[module.objectID] = true
+		references := append([]int{}, module.references...)
+		sort.Ints(references)
+		for _, reference := range references {
+			if referenced, ok := moduleMap[reference]; ok && !visited[reference] {
+				visit(referenced)
+			}
+		}
+		result = append(result, module)
+	}
+	for _, module := range modules {
+		if !visited[module.objectID] {
+			visit(module)
+		}
+	}
+	return result
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf("[%s]", strings.ReplaceAll(s, "]", "]]"))
+}
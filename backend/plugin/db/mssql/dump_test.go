@@ -0,0 +1,220 @@
+package mssql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatColumnType(t *testing.T) {
+	tests := []struct {
+		schema        string
+		name          string
+		isUserDefined bool
+		maxLength     int
+		precision     int
+		scale         int
+		want          string
+	}{
+		{name: "int", maxLength: 4, precision: 10, want: "int"},
+		{name: "varchar", maxLength: 20, want: "varchar(20)"},
+		{name: "varchar", maxLength: -1, want: "varchar(max)"},
+		{name: "nvarchar", maxLength: 40, want: "nvarchar(20)"},
+		{name: "decimal", maxLength: 9, precision: 10, scale: 2, want: "decimal(10,2)"},
+		{name: "datetime2", maxLength: 8, precision: 27, scale: 7, want: "datetime2(7)"},
+		{schema: "dbo", name: "phone", isUserDefined: true, maxLength: 20, want: "[dbo].[phone]"},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got := formatColumnType(test.schema, test.name, test.isUserDefined, test.maxLength, test.precision, test.scale)
+		a.Equal(test.want, got)
+	}
+}
+
+func TestAssembleTableStatements(t *testing.T) {
+	a := require.New(t)
+	orders := &tableSchema{
+		objectID: 1,
+		schema:   "dbo",
+		name:     "orders",
+		columns: []*columnSchema{
+			{name: "id", typeName: "int", nullable: false, isIdentity: true, seed: sqlString("1"), increment: sqlString("1")},
+			{name: "customer_id", typeName: "int", nullable: true},
+		},
+		keyConstraints: []*keyConstraintSchema{
+			{name: "pk_orders", isPrimary: true, indexType: "CLUSTERED", columns: []*indexColumn{{name: "id"}}},
+		},
+		foreignKeys: []*foreignKeySchema{
+			{name: "fk_orders_customers", table: "[dbo].[orders]", referencedObjectID: 2, referencedTable: "[dbo].[customers]", columns: []string{"customer_id"}, referencedColumns: []string{"id"}, onDelete: "CASCADE", onUpdate: "NO_ACTION"},
+		},
+		indexes: []*indexSchema{
+			{name: "ix_orders_customer_id", indexType: "NONCLUSTERED", columns: []*indexColumn{{name: "customer_id", descending: true}}, includes: []string{"id"}},
+		},
+	}
+	customers := &tableSchema{
+		objectID: 2,
+		schema:   "dbo",
+		name:     "customers",
+		columns: []*columnSchema{
+			{name: "id", typeName: "int", nullable: false},
+			{name: "name", typeName: "nvarchar", maxLength: 100, nullable: false, collation: sqlString("Latin1_General_BIN"), defaultName: sqlString("df_name"), defaultValue: sqlString("(N'')")},
+			{name: "best_order_id", typeName: "int", nullable: true},
+		},
+		foreignKeys: []*foreignKeySchema{
+			{name: "fk_customers_orders", table: "[dbo].[customers]", referencedObjectID: 1, referencedTable: "[dbo].[orders]", columns: []string{"best_order_id"}, referencedColumns: []string{"id"}, onDelete: "NO_ACTION", onUpdate: "NO_ACTION"},
+		},
+	}
+
+	tables, deferred := sortTablesByDependency([]*tableSchema{orders, customers})
+	a.Equal([]*tableSchema{customers, orders}, tables)
+	a.Len(deferred, 1)
+	a.Equal("fk_customers_orders", deferred[0].name)
+
+	var buf bytes.Buffer
+	for _, table := range tables {
+		a.NoError(table.assembleStatement(&buf, "SQL_Latin1_General_CP1_CI_AS"))
+	}
+	want := `CREATE TABLE [dbo].[customers] (
+    [id] int NOT NULL,
+    [name] nvarchar(50) COLLATE Latin1_General_BIN CONSTRAINT [df_name] DEFAULT (N'') NOT NULL,
+    [best_order_id] int NULL
+);
+
+CREATE TABLE [dbo].[orders] (
+    [id] int IDENTITY(1,1) NOT NULL,
+    [customer_id] int NULL,
+    CONSTRAINT [pk_orders] PRIMARY KEY CLUSTERED ([id] ASC),
+    CONSTRAINT [fk_orders_customers] FOREIGN KEY ([customer_id]) REFERENCES [dbo].[customers] ([id]) ON DELETE CASCADE
+);
+
+CREATE NONCLUSTERED INDEX [ix_orders_customer_id] ON [dbo].[orders] ([customer_id] DESC) INCLUDE ([id]);
+
+`
+	a.Equal(want, buf.String())
+	a.Equal("CONSTRAINT [fk_customers_orders] FOREIGN KEY ([best_order_id]) REFERENCES [dbo].[orders] ([id])", deferred[0].definition())
+}
+
+func TestSortModulesByDependency(t *testing.T) {
+	a := require.New(t)
+	modules := []*moduleSchema{
+		{objectID: 1, references: []int{3}},
+		{objectID: 2},
+		{objectID: 3, references: []int{2, 100}},
+	}
+	var got []int
+	for _, module := range sortModulesByDependency(modules) {
+		got = append(got, module.objectID)
+	}
+	a.Equal([]int{2, 3, 1}, got)
+}
+
+func TestDump(t *testing.T) {
+	a := require.New(t)
+	sql.Register("mssql-dump-stub", &stubDriver{
+		results: map[string][][]driver.Value{
+			"DATABASEPROPERTYEX": {{"SQL_Latin1_General_CP1_CI_AS"}},
+			"FROM sys.schemas":   {{"sales"}},
+			"FROM sys.sql_modules": {
+				{int64(1), "CREATE VIEW [sales].[v] AS SELECT 1 AS [a]\n"},
+			},
+		},
+	})
+	db, err := sql.Open("mssql-dump-stub", "")
+	a.NoError(err)
+	defer db.Close()
+
+	var buf bytes.Buffer
+	d := &Driver{db: db, databaseName: "db"}
+	_, err = d.Dump(context.Background(), &buf, false)
+	a.NoError(err)
+	a.Equal("CREATE SCHEMA [sales];\nGO\n\nCREATE VIEW [sales].[v] AS SELECT 1 AS [a]\nGO\n\n", buf.String())
+}
+
+// stubDriver is a database/sql driver that answers the dump queries with canned rows.
+// Like go-mssqldb, it rejects read-only transactions.
+type stubDriver struct {
+	// results maps a query substring to the rows returned by the query.
+	results map[string][][]driver.Value
+}
+
+func (d *stubDriver) Open(string) (driver.Conn, error) {
+	return &stubConn{results: d.results}, nil
+}
+
+type stubConn struct {
+	results map[string][][]driver.Value
+}
+
+func (*stubConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (*stubConn) Close() error {
+	return nil
+}
+
+func (*stubConn) Begin() (driver.Tx, error) {
+	return &stubTx{}, nil
+}
+
+func (*stubConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if opts.ReadOnly {
+		return nil, errors.New("read-only transactions are not supported")
+	}
+	return &stubTx{}, nil
+}
+
+func (c *stubConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	for key, values := range c.results {
+		if strings.Contains(query, key) {
+			return &stubRows{values: values}, nil
+		}
+	}
+	return &stubRows{}, nil
+}
+
+type stubTx struct{}
+
+func (*stubTx) Commit() error {
+	return nil
+}
+
+func (*stubTx) Rollback() error {
+	return nil
+}
+
+type stubRows struct {
+	values [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string {
+	if len(r.values) == 0 {
+		return nil
+	}
+	return make([]string, len(r.values[0]))
+}
+
+func (*stubRows) Close() error {
+	return nil
+}
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func sqlString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: true}
+}
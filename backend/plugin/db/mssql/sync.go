@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
@@ -77,6 +79,10 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get views from database %q", driver.databaseName)
 	}
+	triggerMap, err := getTriggers(txn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get triggers from database %q", driver.databaseName)
+	}
 
 	if err := txn.Commit(); err != nil {
 		return nil, err
@@ -87,9 +93,10 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 	}
 	for _, schemaName := range schemaNames {
 		databaseMetadata.Schemas = append(databaseMetadata.Schemas, &storepb.SchemaMetadata{
-			Name:   schemaName,
-			Tables: tableMap[schemaName],
-			Views:  viewMap[schemaName],
+			Name:     schemaName,
+			Tables:   tableMap[schemaName],
+			Views:    viewMap[schemaName],
+			Triggers: triggerMap[schemaName],
 		})
 	}
 	return databaseMetadata, nil
@@ -325,12 +332,171 @@ func getViews(txn *sql.Tx) (map[string][]*storepb.ViewMetadata, error) {
 	return viewMap, nil
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// getTriggers gets all DML triggers of a database.
+func getTriggers(txn *sql.Tx) (map[string][]*storepb.TriggerMetadata, error) {
+	triggerMap := make(map[string][]*storepb.TriggerMetadata)
+
+	query := `
+		SELECT
+			SCHEMA_NAME(o.schema_id) AS schema_name,
+			OBJECT_NAME(t.parent_id) AS table_name,
+			t.name AS trigger_name,
+			t.is_instead_of_trigger,
+			STUFF((
+				SELECT ' OR ' + te.type_desc
+				FROM sys.trigger_events te
+				WHERE te.object_id = t.object_id
+				ORDER BY te.type
+				FOR XML PATH('')
+			), 1, 4, '') AS trigger_event,
+			m.definition
+		FROM sys.triggers t
+		INNER JOIN sys.objects o ON t.object_id = o.object_id
+		INNER JOIN sys.sql_modules m ON t.object_id = m.object_id
+		WHERE t.parent_class = 1
+		ORDER BY schema_name, table_name, trigger_name;`
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		trigger := &storepb.TriggerMetadata{}
+		var schemaName string
+		var isInsteadOf bool
+		var event, definition sql.NullString
+		if err := rows.Scan(&schemaName, &trigger.TableName, &trigger.Name, &isInsteadOf, &event, &definition); err != nil {
+			return nil, err
+		}
+		trigger.Timing = "AFTER"
+		if isInsteadOf {
+			trigger.Timing = "INSTEAD OF"
+		}
+		if event.Valid {
+			trigger.Event = event.String
+		}
+		// The definition is NULL for encrypted triggers.
+		if definition.Valid {
+			trigger.Definition = definition.String
+		}
+		triggerMap[schemaName] = append(triggerMap[schemaName], trigger)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return triggerMap, nil
+}
+
+// listQueryStoreDatabases lists the user databases with Query Store enabled.
+const listQueryStoreDatabases = "SELECT name FROM master.sys.databases WHERE is_query_store_on = 1 AND name NOT IN ('master', 'model', 'msdb', 'tempdb', 'rdscore')"
+
+// listSlowQuery lists the slow queries from the Query Store of the database, %[1]s is the quoted database name.
+// The runtime statistics are aggregated by the intervals, so summing up the intervals of the day gives the statistics of the day.
+// The statements are grouped by query_hash which ignores the literals.
+// The elapsed time is in microseconds.
+const listSlowQuery = `
+	SELECT
+		MAX(qt.query_sql_text),
+		SUM(rs.count_executions),
+		SUM(rs.avg_duration * rs.count_executions),
+		MAX(rs.max_duration),
+		SUM(rs.avg_rowcount * rs.count_executions),
+		MAX(rs.max_rowcount),
+		MAX(rs.last_execution_time)
+	FROM %[1]s.sys.query_store_runtime_stats rs
+		JOIN %[1]s.sys.query_store_runtime_stats_interval rsi ON rsi.runtime_stats_interval_id = rs.runtime_stats_interval_id
+		JOIN %[1]s.sys.query_store_plan p ON p.plan_id = rs.plan_id
+		JOIN %[1]s.sys.query_store_query q ON q.query_id = p.query_id
+		JOIN %[1]s.sys.query_store_query_text qt ON qt.query_text_id = q.query_text_id
+	WHERE rsi.start_time >= @p1 AND rsi.start_time < @p2
+	GROUP BY q.query_hash
+	HAVING MAX(rs.max_duration) >= 1000000`
+
+// SyncSlowQuery syncs the slow query from the Query Store of the databases.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	databases, err := driver.listQueryStoreDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for _, database := range databases {
+		statistics, err := driver.getSlowQueryStatistics(ctx, database, logDateTs)
+		if err != nil {
+			return nil, err
+		}
+		if len(statistics.Items) > 0 {
+			result[database] = statistics
+		}
+	}
+	return result, nil
+}
+
+func (driver *Driver) getSlowQueryStatistics(ctx context.Context, database string, logDateTs time.Time) (*storepb.SlowQueryStatistics, error) {
+	query := fmt.Sprintf(listSlowQuery, quoteIdentifier(database))
+	rows, err := driver.db.QueryContext(ctx, query, logDateTs, logDateTs.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	statistics := &storepb.SlowQueryStatistics{}
+	for rows.Next() {
+		var sqlText string
+		var count, maxElapsedTime, maxRows int64
+		var totalElapsedTime, totalRows float64
+		var latestTime time.Time
+		if err := rows.Scan(&sqlText, &count, &totalElapsedTime, &maxElapsedTime, &totalRows, &maxRows, &latestTime); err != nil {
+			return nil, err
+		}
+		// Different hashes may have the same fingerprint, e.g. the statements differing in the literal types.
+		util.AddSlowQueryStatisticsItem(statistics, &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   util.GetSQLFingerprint(sqlText),
+			Count:            int32(count),
+			LatestLogTime:    timestamppb.New(latestTime),
+			TotalQueryTime:   durationpb.New(time.Duration(totalElapsedTime) * time.Microsecond),
+			MaximumQueryTime: durationpb.New(time.Duration(maxElapsedTime) * time.Microsecond),
+			TotalRowsSent:    int32(totalRows),
+			MaximumRowsSent:  int32(maxRows),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return statistics, nil
+}
+
+func (driver *Driver) listQueryStoreDatabases(ctx context.Context) ([]string, error) {
+	rows, err := driver.db.QueryContext(ctx, listQueryStoreDatabases)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, listQueryStoreDatabases)
+	}
+	defer rows.Close()
+
+	var databases []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		databases = append(databases, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, listQueryStoreDatabases)
+	}
+	return databases, nil
 }
 
 // CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+// The slow queries are read from Query Store, so it must be enabled on at least one database.
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	databases, err := driver.listQueryStoreDatabases(ctx)
+	if err != nil {
+		return err
+	}
+	if len(databases) == 0 {
+		return errors.New("Query Store is not enabled on any database, please enable it by ALTER DATABASE ... SET QUERY_STORE = ON")
+	}
+	return nil
 }
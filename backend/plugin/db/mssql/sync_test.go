@@ -0,0 +1,43 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncSlowQuery(t *testing.T) {
+	a := require.New(t)
+	latest := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sql.Register("mssql-slow-query-stub", &stubDriver{
+		results: map[string][][]driver.Value{
+			"is_query_store_on": {{"db1"}},
+			"FROM [db1].sys.query_store_runtime_stats rs": {
+				{"SELECT * FROM t WHERE id = 1", int64(2), float64(3000000), int64(2000000), float64(2), int64(1), latest.Add(-time.Hour)},
+				{"SELECT * FROM t WHERE id = 'a'", int64(1), float64(4000000), int64(4000000), float64(3), int64(3), latest},
+			},
+		},
+	})
+	db, err := sql.Open("mssql-slow-query-stub", "")
+	a.NoError(err)
+	defer db.Close()
+
+	d := &Driver{db: db}
+	a.NoError(d.CheckSlowQueryLogEnabled(context.Background()))
+	result, err := d.SyncSlowQuery(context.Background(), latest.Truncate(24*time.Hour))
+	a.NoError(err)
+	a.Len(result, 1)
+	a.Len(result["db1"].Items, 1)
+	item := result["db1"].Items[0]
+	a.Equal("SELECT * FROM t WHERE id = ?", item.SqlFingerprint)
+	a.Equal(int32(3), item.Count)
+	a.Equal(7*time.Second, item.TotalQueryTime.AsDuration())
+	a.Equal(4*time.Second, item.MaximumQueryTime.AsDuration())
+	a.Equal(int32(5), item.TotalRowsSent)
+	a.Equal(int32(3), item.MaximumRowsSent)
+	a.True(item.LatestLogTime.AsTime().Equal(latest))
+}
@@ -360,6 +360,15 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 	schemaMetadata.Functions = functions
 	schemaMetadata.Procedures = procedures
 
+	// TiDB doesn't support triggers.
+	if driver.GetType() != storepb.Engine_TIDB {
+		triggers, err := driver.syncTriggers(ctx, driver.databaseName)
+		if err != nil {
+			return nil, err
+		}
+		schemaMetadata.Triggers = triggers
+	}
+
 	// Query table info.
 	tableQuery := `
 		SELECT
@@ -522,6 +531,49 @@ func (driver *Driver) syncRoutines(ctx context.Context, databaseName string) ([]
 	return functions, procedures, nil
 }
 
+func (driver *Driver) syncTriggers(ctx context.Context, databaseName string) ([]*storepb.TriggerMetadata, error) {
+	triggersQuery := `
+		SELECT
+			TRIGGER_NAME,
+			EVENT_OBJECT_TABLE,
+			EVENT_MANIPULATION,
+			ACTION_TIMING,
+			ACTION_STATEMENT
+		FROM
+			INFORMATION_SCHEMA.TRIGGERS
+		WHERE TRIGGER_SCHEMA = ?
+		ORDER BY EVENT_OBJECT_TABLE, ACTION_TIMING, EVENT_MANIPULATION, ACTION_ORDER, TRIGGER_NAME;
+	`
+	triggerRows, err := driver.db.QueryContext(ctx, triggersQuery, databaseName)
+	if err != nil {
+		// Oceanbase starts to support trigger since 4.0.
+		if driver.GetType() == storepb.Engine_OCEANBASE {
+			return nil, nil
+		}
+		return nil, util.FormatErrorWithQuery(err, triggersQuery)
+	}
+	defer triggerRows.Close()
+	var triggers []*storepb.TriggerMetadata
+	for triggerRows.Next() {
+		trigger := &storepb.TriggerMetadata{}
+		if err := triggerRows.Scan(
+			&trigger.Name,
+			&trigger.TableName,
+			&trigger.Event,
+			&trigger.Timing,
+			&trigger.Definition,
+		); err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, trigger)
+	}
+	if err := triggerRows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, triggersQuery)
+	}
+
+	return triggers, nil
+}
+
 func (driver *Driver) getCreateFunctionStmt(ctx context.Context, databaseName, functionName string) (string, error) {
 	query := fmt.Sprintf("SHOW CREATE FUNCTION `%s`.`%s`", databaseName, functionName)
 	rows, err := driver.db.QueryContext(ctx, query)
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
@@ -90,6 +92,10 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get views from database %q", driver.databaseName)
 	}
+	triggerMap, err := getTriggers(txn, driver.databaseName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get triggers from database %q", driver.databaseName)
+	}
 
 	if err := txn.Commit(); err != nil {
 		return nil, err
@@ -100,9 +106,10 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 		ServiceName: driver.serviceName,
 	}
 	databaseMetadata.Schemas = append(databaseMetadata.Schemas, &storepb.SchemaMetadata{
-		Name:   driver.databaseName,
-		Tables: tableMap[driver.databaseName],
-		Views:  viewMap[driver.databaseName],
+		Name:     driver.databaseName,
+		Tables:   tableMap[driver.databaseName],
+		Views:    viewMap[driver.databaseName],
+		Triggers: triggerMap[driver.databaseName],
 	})
 	return databaseMetadata, nil
 }
@@ -441,12 +448,189 @@ func getViews(txn *sql.Tx, schemaName string) (map[string][]*storepb.ViewMetadat
 	return viewMap, nil
 }
 
+// getTriggers gets all triggers of a database.
+func getTriggers(txn *sql.Tx, schemaName string) (map[string][]*storepb.TriggerMetadata, error) {
+	triggerMap := make(map[string][]*storepb.TriggerMetadata)
+
+	query := `
+		SELECT OWNER, TRIGGER_NAME, TABLE_NAME, TRIGGERING_EVENT, TRIGGER_TYPE, DESCRIPTION, TRIGGER_BODY
+		FROM sys.all_triggers
+		WHERE OWNER = :1
+		ORDER BY TRIGGER_NAME
+	`
+
+	slog.Debug("running get trigger query")
+	rows, err := txn.Query(query, schemaName)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		trigger := &storepb.TriggerMetadata{}
+		var schemaName, triggerType, description, body string
+		var tableName sql.NullString
+		if err := rows.Scan(&schemaName, &trigger.Name, &tableName, &trigger.Event, &triggerType, &description, &body); err != nil {
+			return nil, err
+		}
+		if tableName.Valid {
+			trigger.TableName = tableName.String
+		}
+		trigger.Timing = getTriggerTiming(triggerType)
+		trigger.Definition = fmt.Sprintf("CREATE OR REPLACE TRIGGER %s\n%s", strings.TrimSpace(description), strings.TrimSpace(body))
+		triggerMap[schemaName] = append(triggerMap[schemaName], trigger)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, errors.Wrapf(err, "failed to close rows")
+	}
+
+	return triggerMap, nil
+}
+
+// getTriggerTiming extracts the timing from ALL_TRIGGERS.TRIGGER_TYPE, such as "BEFORE EACH ROW" or "INSTEAD OF".
+func getTriggerTiming(triggerType string) string {
+	triggerType = strings.ToUpper(strings.TrimSpace(triggerType))
+	for _, timing := range []string{"BEFORE", "AFTER", "INSTEAD OF", "COMPOUND"} {
+		if strings.HasPrefix(triggerType, timing) {
+			return timing
+		}
+	}
+	return triggerType
+}
+
+// slowQueryThreshold is the minimum elapsed time per execution of a slow query, in microseconds.
+const slowQueryThreshold = 1000000
+
+var (
+	// listAWRSlowQuery lists the slow queries from the AWR snapshots, which are the deltas within the snapshot interval.
+	// The statements are grouped by FORCE_MATCHING_SIGNATURE which ignores the literals, or SQL_ID if the signature is zero.
+	listAWRSlowQuery = fmt.Sprintf(`
+	SELECT
+		s.parsing_schema_name,
+		MAX(DBMS_LOB.SUBSTR(t.sql_text, 1000, 1)),
+		SUM(s.executions_delta),
+		SUM(s.elapsed_time_delta),
+		MAX(s.elapsed_time_delta / s.executions_delta),
+		SUM(s.rows_processed_delta),
+		MAX(s.rows_processed_delta / s.executions_delta),
+		MAX(CAST(sn.end_interval_time AS DATE))
+	FROM dba_hist_sqlstat s
+		JOIN dba_hist_snapshot sn ON sn.snap_id = s.snap_id AND sn.dbid = s.dbid AND sn.instance_number = s.instance_number
+		JOIN dba_hist_sqltext t ON t.sql_id = s.sql_id AND t.dbid = s.dbid
+	WHERE s.executions_delta > 0
+		AND sn.end_interval_time >= :1 AND sn.end_interval_time < :2
+		AND s.parsing_schema_name NOT IN (%s)
+	GROUP BY s.parsing_schema_name, DECODE(s.force_matching_signature, 0, s.sql_id, TO_CHAR(s.force_matching_signature))
+	HAVING MAX(s.elapsed_time_delta / s.executions_delta) >= %d`, systemSchema, slowQueryThreshold)
+	// listSQLAreaSlowQuery lists the slow queries from the cursors in the shared pool,
+	// the statistics are accumulated since the cursors are loaded, so the syncer stores the deltas between the syncs.
+	listSQLAreaSlowQuery = fmt.Sprintf(`
+	SELECT
+		parsing_schema_name,
+		MAX(sql_text),
+		SUM(executions),
+		SUM(elapsed_time),
+		MAX(elapsed_time / executions),
+		SUM(rows_processed),
+		MAX(rows_processed / executions),
+		MAX(last_active_time)
+	FROM v$sql
+	WHERE executions > 0
+		AND last_active_time >= :1 AND last_active_time < :2
+		AND parsing_schema_name NOT IN (%s)
+	GROUP BY parsing_schema_name, DECODE(force_matching_signature, 0, sql_id, TO_CHAR(force_matching_signature))
+	HAVING MAX(elapsed_time / executions) >= %d`, systemSchema, slowQueryThreshold)
+)
+
 // SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// It reads the AWR snapshots if the Diagnostics Pack is enabled, otherwise it reads V$SQL.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	awrEnabled, err := driver.isAWREnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := listSQLAreaSlowQuery
+	if awrEnabled {
+		query = listAWRSlowQuery
+	}
+
+	rows, err := driver.db.QueryContext(ctx, query, logDateTs, logDateTs.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for rows.Next() {
+		var schemaName, sqlText string
+		var count, totalRows int64
+		var totalElapsedTime, maxElapsedTime, maxRows float64
+		var latestTime time.Time
+		if err := rows.Scan(&schemaName, &sqlText, &count, &totalElapsedTime, &maxElapsedTime, &totalRows, &maxRows, &latestTime); err != nil {
+			return nil, err
+		}
+		item := &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   util.GetSQLFingerprint(sqlText),
+			Count:            int32(count),
+			LatestLogTime:    timestamppb.New(latestTime),
+			TotalQueryTime:   durationpb.New(time.Duration(totalElapsedTime) * time.Microsecond),
+			MaximumQueryTime: durationpb.New(time.Duration(maxElapsedTime) * time.Microsecond),
+			TotalRowsSent:    int32(totalRows),
+			MaximumRowsSent:  int32(maxRows),
+		}
+		statistics, exists := result[schemaName]
+		if !exists {
+			statistics = &storepb.SlowQueryStatistics{}
+			result[schemaName] = statistics
+		}
+		// The cursors not sharing the signature may have the same fingerprint, e.g. the statements differing in the literal types.
+		util.AddSlowQueryStatisticsItem(statistics, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return result, nil
+}
+
+// IsSlowQueryCumulative returns true if the slow query statistics are read from V$SQL,
+// which accumulates them since the cursors are loaded rather than by date.
+func (driver *Driver) IsSlowQueryCumulative(ctx context.Context) (bool, error) {
+	awrEnabled, err := driver.isAWREnabled(ctx)
+	if err != nil {
+		return false, err
+	}
+	return !awrEnabled, nil
+}
+
+// isAWREnabled returns true if the Diagnostics Pack is enabled, which is required to query the AWR views.
+func (driver *Driver) isAWREnabled(ctx context.Context) (bool, error) {
+	var value string
+	query := "SELECT value FROM v$parameter WHERE name = 'control_management_pack_access'"
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&value); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, util.FormatErrorWithQuery(err, query)
+	}
+	return strings.Contains(strings.ToUpper(value), "DIAGNOSTIC"), nil
 }
 
 // CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+// Oracle always collects the statistics of the statements, so we only check whether we have the privilege to read them.
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	awrEnabled, err := driver.isAWREnabled(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check the Diagnostics Pack, please grant SELECT_CATALOG_ROLE to the user")
+	}
+	query := "SELECT COUNT(*) FROM v$sql WHERE ROWNUM <= 1"
+	if awrEnabled {
+		query = "SELECT COUNT(*) FROM dba_hist_snapshot WHERE ROWNUM <= 1"
+	}
+	var count int64
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return errors.Wrapf(util.FormatErrorWithQuery(err, query), "please grant SELECT_CATALOG_ROLE to the user")
+	}
+	return nil
 }
@@ -475,6 +475,12 @@ func (driver *Driver) Execute(ctx context.Context, statement string, opts db.Exe
 			currentIndex += len(chunk)
 		}
 
+		if opts.EndTransactionFunc != nil {
+			if err := opts.EndTransactionFunc(tx); err != nil {
+				return 0, errors.Wrapf(err, "failed to execute end transaction function")
+			}
+		}
+
 		if err := tx.Commit(); err != nil {
 			return 0, err
 		}
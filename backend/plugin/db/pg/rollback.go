@@ -0,0 +1,390 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
+)
+
+const (
+	// rollbackDecodingPlugin is the output plugin used to decode the WAL, it is shipped with PostgreSQL.
+	rollbackDecodingPlugin = "test_decoding"
+	// unchangedToastDatum is the placeholder printed by test_decoding for the unchanged TOASTed values.
+	unchangedToastDatum = "unchanged-toast-datum"
+	noTupleData         = "(no-tuple-data)"
+)
+
+// typesWithoutEquality are the types that don't have the equality operator, we compare them in text.
+var typesWithoutEquality = map[string]bool{
+	"json":    true,
+	"xml":     true,
+	"point":   true,
+	"line":    true,
+	"lseg":    true,
+	"box":     true,
+	"path":    true,
+	"polygon": true,
+	"circle":  true,
+}
+
+// GetRollbackSlotName returns the name of the logical replication slot for the rollback SQL generation of the task.
+func GetRollbackSlotName(taskID int) string {
+	return fmt.Sprintf("bytebase_rollback_task_%d", taskID)
+}
+
+// CreateRollbackSlot creates the logical replication slot to decode the changes of the following transactions.
+// It returns the LSN from which the slot starts decoding.
+// It requires wal_level to be logical and the REPLICATION privilege.
+func CreateRollbackSlot(ctx context.Context, conn *sql.Conn, slotName string) (string, error) {
+	// Drop the slot left by the previous run of the same task.
+	if err := DropRollbackSlot(ctx, conn, slotName); err != nil {
+		return "", err
+	}
+	var name, lsn string
+	query := "SELECT slot_name, lsn FROM pg_create_logical_replication_slot($1, $2)"
+	if err := conn.QueryRowContext(ctx, query, slotName, rollbackDecodingPlugin).Scan(&name, &lsn); err != nil {
+		return "", util.FormatErrorWithQuery(err, query)
+	}
+	return lsn, nil
+}
+
+// execer is implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// DropRollbackSlot drops the logical replication slot if it exists.
+func DropRollbackSlot(ctx context.Context, conn execer, slotName string) error {
+	query := "SELECT pg_drop_replication_slot(slot_name) FROM pg_replication_slots WHERE slot_name = $1"
+	if _, err := conn.ExecContext(ctx, query, slotName); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	return nil
+}
+
+// GetCurrentLSN returns the current WAL write location.
+func GetCurrentLSN(ctx context.Context, conn *sql.Conn) (string, error) {
+	var lsn string
+	query := "SELECT pg_current_wal_lsn()"
+	if err := conn.QueryRowContext(ctx, query).Scan(&lsn); err != nil {
+		return "", util.FormatErrorWithQuery(err, query)
+	}
+	return lsn, nil
+}
+
+// GetCurrentTransactionID returns the ID of the current transaction, which is the same as the xid in the logical decoding output.
+func GetCurrentTransactionID(ctx context.Context, tx *sql.Tx) (string, error) {
+	var txID string
+	// txid_current() returns the 64-bit transaction ID with the epoch, while the logical decoding uses the 32-bit xid.
+	query := "SELECT (txid_current() % 4294967296)::text"
+	if err := tx.QueryRowContext(ctx, query).Scan(&txID); err != nil {
+		return "", util.FormatErrorWithQuery(err, query)
+	}
+	return txID, nil
+}
+
+// GenerateRollbackSQL generates the rollback SQL statements from the logical replication slot.
+// slotName is the slot created before executing the transaction.
+// lsnEnd is the WAL location obtained after committing the transaction, we only peek the changes up to it.
+// transactionID is used to filter the changes of the target transaction.
+// The slot is not consumed, the caller should drop it after generating the rollback SQL statements.
+func (driver *Driver) GenerateRollbackSQL(ctx context.Context, slotName, lsnEnd, transactionID string) (string, error) {
+	query := `SELECT xid::text, data FROM pg_logical_slot_peek_changes($1, $2::pg_lsn, NULL, 'include-xids', '1', 'skip-empty-xacts', '1')`
+	rows, err := driver.db.QueryContext(ctx, query, slotName, lsnEnd)
+	if err != nil {
+		return "", util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var changes []*logicalChange
+	size := 0
+	for rows.Next() {
+		var xid, data string
+		if err := rows.Scan(&xid, &data); err != nil {
+			return "", err
+		}
+		if xid != transactionID {
+			continue
+		}
+		size += len(data)
+		if size > common.MaxBinlogSizeLimit {
+			return "", errors.Errorf("abort because read more than %dMB from the logical replication slot", common.MaxBinlogSizeLimit/1024/1024)
+		}
+		change, err := parseLogicalChange(data)
+		if err != nil {
+			return "", err
+		}
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", util.FormatErrorWithQuery(err, query)
+	}
+
+	if err := driver.checkReplicaIdentityFull(ctx, changes); err != nil {
+		return "", err
+	}
+	return getRollbackSQL(changes)
+}
+
+// checkReplicaIdentityFull checks the tables with UPDATE and DELETE changes use REPLICA IDENTITY FULL,
+// otherwise the old rows are not logged completely.
+func (driver *Driver) checkReplicaIdentityFull(ctx context.Context, changes []*logicalChange) error {
+	checked := make(map[string]bool)
+	for _, change := range changes {
+		if change.action == "INSERT" {
+			continue
+		}
+		key := fmt.Sprintf("%q.%q", change.schema, change.table)
+		if checked[key] {
+			continue
+		}
+		checked[key] = true
+
+		var replicaIdentity string
+		query := `
+		SELECT c.relreplident
+		FROM pg_catalog.pg_class c JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2`
+		if err := driver.db.QueryRowContext(ctx, query, change.schema, change.table).Scan(&replicaIdentity); err != nil {
+			return util.FormatErrorWithQuery(err, query)
+		}
+		if replicaIdentity != "f" {
+			return errors.Errorf("table %s must be altered to REPLICA IDENTITY FULL to generate the rollback SQL statements", key)
+		}
+	}
+	return nil
+}
+
+// logicalColumn is a column value in the test_decoding output, such as `name[character varying]:'bytebase'`.
+type logicalColumn struct {
+	name  string
+	tp    string
+	value string
+}
+
+// logicalChange is a row change in the test_decoding output.
+type logicalChange struct {
+	schema string
+	table  string
+	// action is one of INSERT, UPDATE and DELETE.
+	action   string
+	oldTuple []*logicalColumn
+	newTuple []*logicalColumn
+}
+
+// parseLogicalChange parses a line of the test_decoding output, such as
+//
+//	table public.t: UPDATE: old-key: id[integer]:1 name[text]:'a' new-tuple: id[integer]:1 name[text]:'b'
+//
+// It returns nil for BEGIN, COMMIT and the other messages.
+func parseLogicalChange(data string) (*logicalChange, error) {
+	if !strings.HasPrefix(data, "table ") {
+		return nil, nil
+	}
+	s := data[len("table "):]
+	schema, s, err := parseLogicalIdentifier(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse schema name in %q", data)
+	}
+	if !strings.HasPrefix(s, ".") {
+		return nil, errors.Errorf("expect table name in %q", data)
+	}
+	table, s, err := parseLogicalIdentifier(s[1:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse table name in %q", data)
+	}
+	if !strings.HasPrefix(s, ": ") {
+		return nil, errors.Errorf("expect action in %q", data)
+	}
+	s = s[len(": "):]
+	action, s, found := strings.Cut(s, ":")
+	if !found {
+		return nil, errors.Errorf("expect action in %q", data)
+	}
+	change := &logicalChange{
+		schema: schema,
+		table:  table,
+		action: action,
+	}
+	s = strings.TrimPrefix(s, " ")
+
+	switch action {
+	case "INSERT":
+		if change.newTuple, _, err = parseLogicalTuple(s); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q", data)
+		}
+	case "DELETE":
+		if s == noTupleData {
+			return change, nil
+		}
+		if change.oldTuple, _, err = parseLogicalTuple(s); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q", data)
+		}
+	case "UPDATE":
+		if strings.HasPrefix(s, "old-key: ") {
+			var rest string
+			if change.oldTuple, rest, err = parseLogicalTuple(s[len("old-key: "):]); err != nil {
+				return nil, errors.Wrapf(err, "failed to parse %q", data)
+			}
+			s = strings.TrimPrefix(rest, "new-tuple: ")
+		}
+		if change.newTuple, _, err = parseLogicalTuple(s); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q", data)
+		}
+	default:
+		// TRUNCATE and the other messages cannot be rolled back by row changes.
+		return nil, errors.Errorf("unsupported change %q", data)
+	}
+	return change, nil
+}
+
+// parseLogicalIdentifier parses a quoted or unquoted identifier at the beginning of s and returns the rest.
+func parseLogicalIdentifier(s string) (string, string, error) {
+	if !strings.HasPrefix(s, `"`) {
+		end := strings.IndexAny(s, ".:[")
+		if end <= 0 {
+			return "", "", errors.Errorf("invalid identifier %q", s)
+		}
+		return s[:end], s[end:], nil
+	}
+	var buf strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != '"' {
+			_ = buf.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '"' {
+			_ = buf.WriteByte('"')
+			i++
+			continue
+		}
+		return buf.String(), s[i+1:], nil
+	}
+	return "", "", errors.Errorf("unterminated identifier %q", s)
+}
+
+// parseLogicalTuple parses the columns until the end of s or the "new-tuple:" marker, and returns the rest.
+func parseLogicalTuple(s string) ([]*logicalColumn, string, error) {
+	var columns []*logicalColumn
+	for {
+		s = strings.TrimLeft(s, " ")
+		if s == "" || strings.HasPrefix(s, "new-tuple:") {
+			return columns, s, nil
+		}
+		name, rest, err := parseLogicalIdentifier(s)
+		if err != nil {
+			return nil, "", err
+		}
+		if !strings.HasPrefix(rest, "[") {
+			return nil, "", errors.Errorf("expect column type in %q", s)
+		}
+		// The type name may contain brackets for the array types, such as `text[]`.
+		end := strings.Index(rest, "]:")
+		if end < 0 {
+			return nil, "", errors.Errorf("expect column value in %q", s)
+		}
+		column := &logicalColumn{
+			name: name,
+			tp:   rest[1:end],
+		}
+		rest = rest[end+len("]:"):]
+		if strings.HasPrefix(rest, "'") {
+			i := 1
+			for ; i < len(rest); i++ {
+				if rest[i] != '\'' {
+					continue
+				}
+				if i+1 < len(rest) && rest[i+1] == '\'' {
+					i++
+					continue
+				}
+				break
+			}
+			if i >= len(rest) {
+				return nil, "", errors.Errorf("unterminated value in %q", s)
+			}
+			column.value, s = rest[:i+1], rest[i+1:]
+		} else {
+			end := strings.IndexByte(rest, ' ')
+			if end < 0 {
+				end = len(rest)
+			}
+			column.value, s = rest[:end], rest[end:]
+		}
+		columns = append(columns, column)
+	}
+}
+
+// getRollbackSQL generates the rollback SQL statements for the changes in the reversed order.
+func getRollbackSQL(changes []*logicalChange) (string, error) {
+	var sqlList []string
+	for i := len(changes) - 1; i >= 0; i-- {
+		sql, err := changes[i].getRollbackSQL()
+		if err != nil {
+			return "", err
+		}
+		sqlList = append(sqlList, sql)
+	}
+	return strings.Join(sqlList, "\n"), nil
+}
+
+func (c *logicalChange) getRollbackSQL() (string, error) {
+	table := fmt.Sprintf(`"%s"."%s"`, escapeIdentifier(c.schema), escapeIdentifier(c.table))
+	switch c.action {
+	case "INSERT":
+		return fmt.Sprintf("DELETE FROM %s WHERE %s;", table, getWhereCondition(c.newTuple)), nil
+	case "DELETE":
+		if len(c.oldTuple) == 0 {
+			return "", errors.Errorf("the old row of DELETE on table %s is not logged, please use REPLICA IDENTITY FULL", table)
+		}
+		var columns, values []string
+		for _, column := range c.oldTuple {
+			columns = append(columns, fmt.Sprintf(`"%s"`, escapeIdentifier(column.name)))
+			values = append(values, column.value)
+		}
+		return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", table, strings.Join(columns, ", "), strings.Join(values, ", ")), nil
+	case "UPDATE":
+		if len(c.oldTuple) == 0 {
+			return "", errors.Errorf("the old row of UPDATE on table %s is not logged, please use REPLICA IDENTITY FULL", table)
+		}
+		var sets []string
+		for _, column := range c.oldTuple {
+			sets = append(sets, fmt.Sprintf(`"%s" = %s`, escapeIdentifier(column.name), column.value))
+		}
+		return fmt.Sprintf("UPDATE %s SET %s WHERE %s;", table, strings.Join(sets, ", "), getWhereCondition(c.newTuple)), nil
+	default:
+		return "", errors.Errorf("unsupported action %q", c.action)
+	}
+}
+
+func getWhereCondition(columns []*logicalColumn) string {
+	var conditions []string
+	for _, column := range columns {
+		// The unchanged TOASTed values are not logged in the new row.
+		if column.value == unchangedToastDatum {
+			continue
+		}
+		name := fmt.Sprintf(`"%s"`, escapeIdentifier(column.name))
+		switch {
+		case column.value == "null":
+			conditions = append(conditions, fmt.Sprintf("%s IS NULL", name))
+		case typesWithoutEquality[column.tp]:
+			conditions = append(conditions, fmt.Sprintf("%s::text = %s::text", name, column.value))
+		default:
+			conditions = append(conditions, fmt.Sprintf("%s = %s", name, column.value))
+		}
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+func escapeIdentifier(s string) string {
+	return strings.ReplaceAll(s, `"`, `""`)
+}
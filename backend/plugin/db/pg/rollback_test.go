@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRollbackSQL(t *testing.T) {
+	tests := []struct {
+		name        string
+		changes     []string
+		rollbackSQL string
+		err         bool
+	}{
+		{
+			name:        "empty",
+			changes:     []string{"BEGIN 529", "COMMIT 529"},
+			rollbackSQL: "",
+		},
+		{
+			name: "INSERT",
+			changes: []string{
+				"BEGIN 529",
+				"table public.t: INSERT: id[integer]:1 name[text]:'alice' score[numeric]:null",
+				"table public.t: INSERT: id[integer]:2 name[text]:'bob''s' score[numeric]:1.5",
+				"COMMIT 529",
+			},
+			rollbackSQL: `DELETE FROM "public"."t" WHERE "id" = 2 AND "name" = 'bob''s' AND "score" = 1.5;
+DELETE FROM "public"."t" WHERE "id" = 1 AND "name" = 'alice' AND "score" IS NULL;`,
+		},
+		{
+			name: "UPDATE",
+			changes: []string{
+				`table "My Schema"."My ""Table""": UPDATE: old-key: id[integer]:1 "Tags"[text[]]:'{a,b}' doc[json]:'{"a": 1}' new-tuple: id[integer]:1 "Tags"[text[]]:'{c}' doc[json]:unchanged-toast-datum`,
+			},
+			rollbackSQL: `UPDATE "My Schema"."My ""Table""" SET "id" = 1, "Tags" = '{a,b}', "doc" = '{"a": 1}' WHERE "id" = 1 AND "Tags" = '{c}';`,
+		},
+		{
+			name: "DELETE",
+			changes: []string{
+				"table public.t: DELETE: id[integer]:1 name[character varying]:'alice bob' created_at[timestamp without time zone]:'2024-01-01 00:00:00' p[point]:'(1,2)'",
+			},
+			rollbackSQL: `INSERT INTO "public"."t" ("id", "name", "created_at", "p") VALUES (1, 'alice bob', '2024-01-01 00:00:00', '(1,2)');`,
+		},
+		{
+			name: "DELETE without old row",
+			changes: []string{
+				"table public.t: DELETE: (no-tuple-data)",
+			},
+			err: true,
+		},
+		{
+			name: "UPDATE without old row",
+			changes: []string{
+				"table public.t: UPDATE: id[integer]:1 name[text]:'bob'",
+			},
+			err: true,
+		},
+		{
+			name: "TRUNCATE",
+			changes: []string{
+				"table public.t: TRUNCATE: (no-flags)",
+			},
+			err: true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		var changes []*logicalChange
+		var err error
+		for _, data := range test.changes {
+			var change *logicalChange
+			change, err = parseLogicalChange(data)
+			if err != nil {
+				break
+			}
+			if change != nil {
+				changes = append(changes, change)
+			}
+		}
+		var rollbackSQL string
+		if err == nil {
+			rollbackSQL, err = getRollbackSQL(changes)
+		}
+		if test.err {
+			a.Error(err, test.name)
+			continue
+		}
+		a.NoError(err, test.name)
+		a.Equal(test.rollbackSQL, rollbackSQL, test.name)
+	}
+}
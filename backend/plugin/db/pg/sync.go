@@ -110,6 +110,25 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get functions from database %q", driver.databaseName)
 	}
+	triggerMap, err := getTriggers(txn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get triggers from database %q", driver.databaseName)
+	}
+	var sequenceMap map[string][]*storepb.SequenceMetadata
+	if isAtLeastPG10 {
+		sequenceMap, err = getSequences(txn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get sequences from database %q", driver.databaseName)
+		}
+	}
+	enumTypeMap, err := getEnumTypes(txn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get enum types from database %q", driver.databaseName)
+	}
+	compositeTypeMap, err := getCompositeTypes(txn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get composite types from database %q", driver.databaseName)
+	}
 
 	extensions, err := getExtensions(txn)
 	if err != nil {
@@ -126,6 +145,10 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 		var views []*storepb.ViewMetadata
 		var materializedViews []*storepb.MaterializedViewMetadata
 		var functions []*storepb.FunctionMetadata
+		var triggers []*storepb.TriggerMetadata
+		var sequences []*storepb.SequenceMetadata
+		var enumTypes []*storepb.EnumTypeMetadata
+		var compositeTypes []*storepb.CompositeTypeMetadata
 		var exists bool
 		if tables, exists = tableMap[schemaName]; !exists {
 			tables = []*storepb.TableMetadata{}
@@ -147,6 +170,18 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 		if functions, exists = functionMap[schemaName]; !exists {
 			functions = []*storepb.FunctionMetadata{}
 		}
+		if triggers, exists = triggerMap[schemaName]; !exists {
+			triggers = []*storepb.TriggerMetadata{}
+		}
+		if sequences, exists = sequenceMap[schemaName]; !exists {
+			sequences = []*storepb.SequenceMetadata{}
+		}
+		if enumTypes, exists = enumTypeMap[schemaName]; !exists {
+			enumTypes = []*storepb.EnumTypeMetadata{}
+		}
+		if compositeTypes, exists = compositeTypeMap[schemaName]; !exists {
+			compositeTypes = []*storepb.CompositeTypeMetadata{}
+		}
 		databaseMetadata.Schemas = append(databaseMetadata.Schemas, &storepb.SchemaMetadata{
 			Name:              schemaName,
 			Tables:            tables,
@@ -154,6 +189,10 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 			Views:             views,
 			Functions:         functions,
 			MaterializedViews: materializedViews,
+			Triggers:          triggers,
+			Sequences:         sequences,
+			EnumTypes:         enumTypes,
+			CompositeTypes:    compositeTypes,
 		})
 	}
 	databaseMetadata.Extensions = extensions
@@ -235,6 +274,46 @@ func getForeignKeys(txn *sql.Tx) (map[db.TableKey][]*storepb.ForeignKeyMetadata,
 	return foreignKeysMap, nil
 }
 
+var listCheckConstraintQuery = `
+SELECT
+	n.nspname AS table_schema,
+	c.relname AS table_name,
+	con.conname AS constraint_name,
+	pg_get_constraintdef(con.oid, true) AS constraint_def
+FROM
+	pg_constraint con
+	JOIN pg_class c ON c.oid = con.conrelid
+	JOIN pg_namespace n ON n.oid = c.relnamespace` + fmt.Sprintf(`
+WHERE
+	n.nspname NOT IN(%s)
+	AND con.contype = 'c'
+ORDER BY table_schema, table_name, constraint_name;`, pgparser.SystemSchemaWhereClause)
+
+func getCheckConstraints(txn *sql.Tx) (map[db.TableKey][]*storepb.CheckConstraintMetadata, error) {
+	checkConstraintsMap := make(map[db.TableKey][]*storepb.CheckConstraintMetadata)
+	rows, err := txn.Query(listCheckConstraintQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		checkConstraint := &storepb.CheckConstraintMetadata{}
+		var schemaName, tableName, definition string
+		if err := rows.Scan(&schemaName, &tableName, &checkConstraint.Name, &definition); err != nil {
+			return nil, err
+		}
+		// The definition is in the form of "CHECK (expression)".
+		checkConstraint.Expression = strings.TrimPrefix(definition, "CHECK ")
+		key := db.TableKey{Schema: schemaName, Table: tableName}
+		checkConstraintsMap[key] = append(checkConstraintsMap[key], checkConstraint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return checkConstraintsMap, nil
+}
+
 func convertForeignKeyMatchType(in string) string {
 	switch in {
 	case "f":
@@ -374,6 +453,10 @@ func getTables(txn *sql.Tx, isAtLeastPG10 bool) (map[string][]*storepb.TableMeta
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to get foreign keys")
 	}
+	checkConstraintsMap, err := getCheckConstraints(txn)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get check constraints")
+	}
 	foreignTablesMap, err := getForeignTables(txn, columnMap)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to get foreign tables")
@@ -404,6 +487,7 @@ func getTables(txn *sql.Tx, isAtLeastPG10 bool) (map[string][]*storepb.TableMeta
 		table.Columns = columnMap[key]
 		table.Indexes = indexMap[key]
 		table.ForeignKeys = foreignKeysMap[key]
+		table.CheckConstraints = checkConstraintsMap[key]
 
 		tableMap[schemaName] = append(tableMap[schemaName], table)
 	}
@@ -884,6 +968,224 @@ func getFunctions(txn *sql.Tx) (map[string][]*storepb.FunctionMetadata, error) {
 	return functionMap, nil
 }
 
+var listTriggerQuery = `
+SELECT n.nspname, c.relname, t.tgname, t.tgtype, pg_get_triggerdef(t.oid, true)
+FROM pg_trigger t
+JOIN pg_class c ON c.oid = t.tgrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace` + fmt.Sprintf(`
+WHERE NOT t.tgisinternal AND n.nspname NOT IN (%s)
+ORDER BY n.nspname, c.relname, t.tgname;`, pgparser.SystemSchemaWhereClause)
+
+// getTriggers gets all triggers of a database.
+func getTriggers(txn *sql.Tx) (map[string][]*storepb.TriggerMetadata, error) {
+	triggerMap := make(map[string][]*storepb.TriggerMetadata)
+
+	rows, err := txn.Query(listTriggerQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		trigger := &storepb.TriggerMetadata{}
+		var schemaName string
+		var tgType int
+		if err := rows.Scan(&schemaName, &trigger.TableName, &trigger.Name, &tgType, &trigger.Definition); err != nil {
+			return nil, err
+		}
+		trigger.Timing, trigger.Event = getTriggerTimingAndEvent(tgType)
+
+		triggerMap[schemaName] = append(triggerMap[schemaName], trigger)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return triggerMap, nil
+}
+
+// getTriggerTimingAndEvent decodes the pg_trigger.tgtype bitmask.
+// https://github.com/postgres/postgres/blob/master/src/include/catalog/pg_trigger.h
+func getTriggerTimingAndEvent(tgType int) (string, string) {
+	const (
+		triggerTypeBefore   = 1 << 1
+		triggerTypeInsert   = 1 << 2
+		triggerTypeDelete   = 1 << 3
+		triggerTypeUpdate   = 1 << 4
+		triggerTypeTruncate = 1 << 5
+		triggerTypeInstead  = 1 << 6
+	)
+
+	timing := "AFTER"
+	switch {
+	case tgType&triggerTypeInstead != 0:
+		timing = "INSTEAD OF"
+	case tgType&triggerTypeBefore != 0:
+		timing = "BEFORE"
+	}
+
+	var events []string
+	if tgType&triggerTypeInsert != 0 {
+		events = append(events, "INSERT")
+	}
+	if tgType&triggerTypeUpdate != 0 {
+		events = append(events, "UPDATE")
+	}
+	if tgType&triggerTypeDelete != 0 {
+		events = append(events, "DELETE")
+	}
+	if tgType&triggerTypeTruncate != 0 {
+		events = append(events, "TRUNCATE")
+	}
+	return timing, strings.Join(events, " OR ")
+}
+
+// The pg_sequences view is available since PostgreSQL 10.
+// The sequences implicitly created for the identity columns are excluded, as they are defined by the columns.
+var listSequenceQuery = `
+SELECT
+	seq.schemaname,
+	seq.sequencename,
+	seq.data_type::text,
+	seq.start_value::text,
+	seq.min_value::text,
+	seq.max_value::text,
+	seq.increment_by::text,
+	seq.cycle,
+	seq.cache_size::text,
+	COALESCE(owner_table.relname, ''),
+	COALESCE(owner_column.attname, '')
+FROM pg_sequences seq
+JOIN pg_namespace n ON n.nspname = seq.schemaname
+JOIN pg_class c ON c.relname = seq.sequencename AND c.relnamespace = n.oid
+LEFT JOIN pg_depend d ON d.objid = c.oid AND d.classid = 'pg_class'::regclass AND d.refclassid = 'pg_class'::regclass AND d.deptype = 'a'
+LEFT JOIN pg_class owner_table ON owner_table.oid = d.refobjid
+LEFT JOIN pg_attribute owner_column ON owner_column.attrelid = d.refobjid AND owner_column.attnum = d.refobjsubid` + fmt.Sprintf(`
+WHERE seq.schemaname NOT IN (%s)
+	AND NOT EXISTS (SELECT 1 FROM pg_depend WHERE objid = c.oid AND classid = 'pg_class'::regclass AND deptype = 'i')
+ORDER BY seq.schemaname, seq.sequencename;`, pgparser.SystemSchemaWhereClause)
+
+// getSequences gets all sequences of a database.
+func getSequences(txn *sql.Tx) (map[string][]*storepb.SequenceMetadata, error) {
+	sequenceMap := make(map[string][]*storepb.SequenceMetadata)
+
+	rows, err := txn.Query(listSequenceQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		sequence := &storepb.SequenceMetadata{}
+		var schemaName string
+		if err := rows.Scan(
+			&schemaName,
+			&sequence.Name,
+			&sequence.DataType,
+			&sequence.Start,
+			&sequence.MinValue,
+			&sequence.MaxValue,
+			&sequence.Increment,
+			&sequence.Cycle,
+			&sequence.CacheSize,
+			&sequence.OwnerTable,
+			&sequence.OwnerColumn,
+		); err != nil {
+			return nil, err
+		}
+		sequenceMap[schemaName] = append(sequenceMap[schemaName], sequence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sequenceMap, nil
+}
+
+var listEnumTypeQuery = `
+SELECT n.nspname, t.typname, e.enumlabel, COALESCE(obj_description(t.oid, 'pg_type'), '')
+FROM pg_type t
+JOIN pg_enum e ON e.enumtypid = t.oid
+JOIN pg_namespace n ON n.oid = t.typnamespace` + fmt.Sprintf(`
+WHERE n.nspname NOT IN (%s)
+ORDER BY n.nspname, t.typname, e.enumsortorder;`, pgparser.SystemSchemaWhereClause)
+
+// getEnumTypes gets all user-defined enum types of a database.
+func getEnumTypes(txn *sql.Tx) (map[string][]*storepb.EnumTypeMetadata, error) {
+	enumTypeMap := make(map[string][]*storepb.EnumTypeMetadata)
+
+	rows, err := txn.Query(listEnumTypeQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var current *storepb.EnumTypeMetadata
+	var currentSchema string
+	for rows.Next() {
+		var schemaName, typeName, label, comment string
+		if err := rows.Scan(&schemaName, &typeName, &label, &comment); err != nil {
+			return nil, err
+		}
+		// Each row is a label, and the labels of the same type are adjacent.
+		if current == nil || currentSchema != schemaName || current.Name != typeName {
+			current = &storepb.EnumTypeMetadata{
+				Name:    typeName,
+				Comment: comment,
+			}
+			currentSchema = schemaName
+			enumTypeMap[schemaName] = append(enumTypeMap[schemaName], current)
+		}
+		current.Values = append(current.Values, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return enumTypeMap, nil
+}
+
+var listCompositeTypeQuery = `
+SELECT n.nspname, t.typname, a.attname, format_type(a.atttypid, a.atttypmod), COALESCE(obj_description(t.oid, 'pg_type'), '')
+FROM pg_type t
+JOIN pg_class c ON c.oid = t.typrelid
+JOIN pg_namespace n ON n.oid = t.typnamespace
+JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped` + fmt.Sprintf(`
+WHERE t.typtype = 'c' AND c.relkind = 'c' AND n.nspname NOT IN (%s)
+ORDER BY n.nspname, t.typname, a.attnum;`, pgparser.SystemSchemaWhereClause)
+
+// getCompositeTypes gets all user-defined composite types of a database.
+func getCompositeTypes(txn *sql.Tx) (map[string][]*storepb.CompositeTypeMetadata, error) {
+	compositeTypeMap := make(map[string][]*storepb.CompositeTypeMetadata)
+
+	rows, err := txn.Query(listCompositeTypeQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var current *storepb.CompositeTypeMetadata
+	var currentSchema string
+	for rows.Next() {
+		var schemaName, typeName, comment string
+		attribute := &storepb.CompositeTypeAttribute{}
+		if err := rows.Scan(&schemaName, &typeName, &attribute.Name, &attribute.Type, &comment); err != nil {
+			return nil, err
+		}
+		// Each row is an attribute, and the attributes of the same type are adjacent.
+		if current == nil || currentSchema != schemaName || current.Name != typeName {
+			current = &storepb.CompositeTypeMetadata{
+				Name:    typeName,
+				Comment: comment,
+			}
+			currentSchema = schemaName
+			compositeTypeMap[schemaName] = append(compositeTypeMap[schemaName], current)
+		}
+		current.Attributes = append(current.Attributes, attribute)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return compositeTypeMap, nil
+}
+
 var statPluginVersion = semver.MustParse("1.8.0")
 
 // SyncSlowQuery syncs the slow query.
@@ -42,3 +42,42 @@ func TestIsAtLeastPG10(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestGetTriggerTimingAndEvent(t *testing.T) {
+	tests := []struct {
+		tgType int
+		timing string
+		event  string
+	}{
+		{
+			// BEFORE INSERT FOR EACH ROW.
+			tgType: 7,
+			timing: "BEFORE",
+			event:  "INSERT",
+		},
+		{
+			// AFTER INSERT OR UPDATE OR DELETE FOR EACH STATEMENT.
+			tgType: 28,
+			timing: "AFTER",
+			event:  "INSERT OR UPDATE OR DELETE",
+		},
+		{
+			// INSTEAD OF UPDATE FOR EACH ROW.
+			tgType: 81,
+			timing: "INSTEAD OF",
+			event:  "UPDATE",
+		},
+		{
+			// BEFORE TRUNCATE FOR EACH STATEMENT.
+			tgType: 34,
+			timing: "BEFORE",
+			event:  "TRUNCATE",
+		},
+	}
+
+	for _, test := range tests {
+		timing, event := getTriggerTimingAndEvent(test.tgType)
+		require.Equal(t, test.timing, timing)
+		require.Equal(t, test.event, event)
+	}
+}
@@ -2,35 +2,257 @@ package redis
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
 
+	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 )
 
 // Role
+// The roles are the ACL users of Redis 6+, and the role attribute is the ACL rules of the user, e.g. "on ~app:* +@read".
 
-// CreateRole creates the role.
-func (*Driver) CreateRole(context.Context, *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.New("redis: not supported")
+// CreateRole creates the ACL user.
+func (d *Driver) CreateRole(ctx context.Context, upsert *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
+	if _, err := d.getACLRules(ctx, upsert.Name); err == nil {
+		return nil, common.Errorf(common.Conflict, "role %s already exists", upsert.Name)
+	} else if common.ErrorCode(err) != common.NotFound {
+		return nil, err
+	}
+
+	rules := splitACLRules(stringValue(upsert.Attribute))
+	if upsert.Password != nil {
+		rules = append(rules, fmt.Sprintf(">%s", *upsert.Password))
+	}
+	if err := d.setACLUser(ctx, upsert.Name, rules); err != nil {
+		return nil, err
+	}
+	return d.FindRole(ctx, upsert.Name)
+}
+
+// UpdateRole updates the ACL user.
+// The ACL rules are replaced by the attribute if specified, and the passwords are kept unless a new one is specified.
+// Redis cannot rename users, so renaming copies the user to the new name and then deletes the old one.
+func (d *Driver) UpdateRole(ctx context.Context, roleName string, upsert *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
+	oldRules, err := d.getACLRules(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	name := roleName
+	if upsert.Name != "" && upsert.Name != roleName {
+		if _, err := d.getACLRules(ctx, upsert.Name); err == nil {
+			return nil, common.Errorf(common.Conflict, "role %s already exists", upsert.Name)
+		} else if common.ErrorCode(err) != common.NotFound {
+			return nil, err
+		}
+		// Redis cannot rename users, so we copy the rules including the password hashes to the new user.
+		if err := d.setACLUser(ctx, upsert.Name, append([]string{"reset"}, splitACLRules(oldRules)...)); err != nil {
+			return nil, err
+		}
+		// The rename is not atomic, so we roll back the copy if the old user cannot be deleted.
+		if err := d.deleteACLUser(ctx, roleName); err != nil {
+			if rollbackErr := d.deleteACLUser(ctx, upsert.Name); rollbackErr != nil {
+				return nil, errors.Wrapf(err, "failed to roll back the copied role %s: %v", upsert.Name, rollbackErr)
+			}
+			return nil, err
+		}
+		name = upsert.Name
+	}
+
+	var rules []string
+	if upsert.Attribute != nil {
+		rules = append(rules, "reset")
+		rules = append(rules, splitACLRules(*upsert.Attribute)...)
+		if upsert.Password == nil {
+			rules = append(rules, getACLPasswordRules(oldRules)...)
+		}
+	}
+	if upsert.Password != nil {
+		rules = append(rules, "resetpass", fmt.Sprintf(">%s", *upsert.Password))
+	}
+	if len(rules) > 0 {
+		if err := d.setACLUser(ctx, name, rules); err != nil {
+			return nil, err
+		}
+	}
+	return d.FindRole(ctx, name)
+}
+
+// FindRole finds the ACL user by name.
+func (d *Driver) FindRole(ctx context.Context, roleName string) (*db.DatabaseRoleMessage, error) {
+	rules, err := d.getACLRules(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	rules = stripACLPasswordRules(rules)
+	return &db.DatabaseRoleMessage{
+		Name:      roleName,
+		Attribute: &rules,
+	}, nil
+}
+
+// ListRole lists the ACL users.
+func (d *Driver) ListRole(ctx context.Context) ([]*db.DatabaseRoleMessage, error) {
+	users, err := d.listACLUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []*db.DatabaseRoleMessage
+	for _, user := range users {
+		rules := stripACLPasswordRules(user.rules)
+		result = append(result, &db.DatabaseRoleMessage{
+			Name:      user.name,
+			Attribute: &rules,
+		})
+	}
+	return result, nil
+}
+
+// DeleteRole deletes the ACL user by name.
+func (d *Driver) DeleteRole(ctx context.Context, roleName string) error {
+	if _, err := d.getACLRules(ctx, roleName); err != nil {
+		return err
+	}
+	return d.deleteACLUser(ctx, roleName)
+}
+
+type aclUser struct {
+	name  string
+	rules string
+}
+
+// getACLRules returns the ACL rules of the user.
+func (d *Driver) getACLRules(ctx context.Context, name string) (string, error) {
+	users, err := d.listACLUsers(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, user := range users {
+		if user.name == name {
+			return user.rules, nil
+		}
+	}
+	return "", common.Errorf(common.NotFound, "cannot find the role %s", name)
+}
+
+func (d *Driver) listACLUsers(ctx context.Context) ([]aclUser, error) {
+	lines, err := d.rdb.Do(ctx, "ACL", "LIST").StringSlice()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list ACL users")
+	}
+	var users []aclUser
+	for _, line := range lines {
+		user, err := parseACLListLine(line)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+	return users, nil
+}
+
+func (d *Driver) setACLUser(ctx context.Context, name string, rules []string) error {
+	args := []any{"ACL", "SETUSER", name}
+	for _, rule := range rules {
+		args = append(args, rule)
+	}
+	if err := d.doOnAllNodes(ctx, args...); err != nil {
+		return errors.Wrapf(err, "failed to set ACL user %q", name)
+	}
+	return nil
+}
+
+func (d *Driver) deleteACLUser(ctx context.Context, name string) error {
+	if err := d.doOnAllNodes(ctx, "ACL", "DELUSER", name); err != nil {
+		return errors.Wrapf(err, "failed to delete ACL user %q", name)
+	}
+	return nil
+}
+
+// doOnAllNodes runs the command on all master nodes in the cluster mode, because the ACL rules are not propagated in the cluster.
+func (d *Driver) doOnAllNodes(ctx context.Context, args ...any) error {
+	if cluster, ok := d.rdb.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return client.Do(ctx, args...).Err()
+		})
+	}
+	return d.rdb.Do(ctx, args...).Err()
+}
+
+// parseACLListLine parses the line of ACL LIST in the form of "user <name> <rules>".
+func parseACLListLine(line string) (*aclUser, error) {
+	fields := strings.SplitN(line, " ", 3)
+	if len(fields) < 2 || fields[0] != "user" {
+		return nil, errors.Errorf("invalid ACL LIST line %q", line)
+	}
+	user := &aclUser{name: fields[1]}
+	if len(fields) == 3 {
+		user.rules = fields[2]
+	}
+	return user, nil
+}
+
+// getACLPasswordRules returns the password rules, i.e. the password hashes or nopass, in the ACL rules.
+func getACLPasswordRules(rules string) []string {
+	var result []string
+	for _, rule := range splitACLRules(rules) {
+		if strings.HasPrefix(rule, "#") || rule == "nopass" {
+			result = append(result, rule)
+		}
+	}
+	return result
 }
 
-// UpdateRole updates the role.
-func (*Driver) UpdateRole(context.Context, string, *db.DatabaseRoleUpsertMessage) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.New("redis: not supported")
+// stripACLPasswordRules removes the password hashes and the plaintext passwords from the ACL rules, so they are not exposed as the role attribute.
+func stripACLPasswordRules(rules string) string {
+	var result []string
+	for _, rule := range splitACLRules(rules) {
+		if isACLPasswordRule(rule) {
+			continue
+		}
+		result = append(result, rule)
+	}
+	return strings.Join(result, " ")
 }
 
-// FindRole finds the role by name.
-func (*Driver) FindRole(context.Context, string) (*db.DatabaseRoleMessage, error) {
-	return nil, errors.New("redis: not supported")
+// isACLPasswordRule reports whether the rule adds or removes a password, i.e. ">password", "<password", "#hash" or "!hash".
+func isACLPasswordRule(rule string) bool {
+	return strings.HasPrefix(rule, ">") || strings.HasPrefix(rule, "<") || strings.HasPrefix(rule, "#") || strings.HasPrefix(rule, "!")
 }
 
-// ListRole lists the role.
-func (*Driver) ListRole(context.Context) ([]*db.DatabaseRoleMessage, error) {
-	return nil, errors.New("redis: not supported")
+// splitACLRules splits the ACL rules by spaces, but keeps the selectors in parentheses introduced in Redis 7 as a whole.
+func splitACLRules(rules string) []string {
+	var result []string
+	var current strings.Builder
+	depth := 0
+	for _, r := range rules {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case (r == ' ' || r == '\t' || r == '\n') && depth == 0:
+			if current.Len() > 0 {
+				result = append(result, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		result = append(result, current.String())
+	}
+	return result
 }
 
-// DeleteRole deletes the role by name.
-func (*Driver) DeleteRole(context.Context, string) error {
-	return errors.New("redis: not supported")
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitACLRules(t *testing.T) {
+	tests := []struct {
+		rules string
+		want  []string
+	}{
+		{rules: "", want: nil},
+		{rules: "on nopass ~* &* +@all", want: []string{"on", "nopass", "~*", "&*", "+@all"}},
+		{rules: "on  #5e88 ~app:*   resetchannels -@all +get (~other:* +set %R~log:*)", want: []string{"on", "#5e88", "~app:*", "resetchannels", "-@all", "+get", "(~other:* +set %R~log:*)"}},
+	}
+
+	for _, tc := range tests {
+		require.Equal(t, tc.want, splitACLRules(tc.rules), tc.rules)
+	}
+}
+
+func TestParseACLListLine(t *testing.T) {
+	a := require.New(t)
+
+	user, err := parseACLListLine("user alice on #5e88 ~app:* resetchannels -@all +get")
+	a.NoError(err)
+	a.Equal("alice", user.name)
+	a.Equal("on #5e88 ~app:* resetchannels -@all +get", user.rules)
+	a.Equal([]string{"#5e88"}, getACLPasswordRules(user.rules))
+
+	_, err = parseACLListLine("alice on")
+	a.Error(err)
+}
+
+func TestStripACLPasswordRules(t *testing.T) {
+	tests := []struct {
+		rules string
+		want  string
+	}{
+		{rules: "", want: ""},
+		{rules: "on nopass ~* &* +@all", want: "on nopass ~* &* +@all"},
+		{rules: "on #5e88 #a1b2 ~app:* resetchannels -@all +get", want: "on ~app:* resetchannels -@all +get"},
+		{rules: "on >secret <old !5e88 ~app:* (~other:* +set)", want: "on ~app:* (~other:* +set)"},
+	}
+
+	for _, tc := range tests {
+		require.Equal(t, tc.want, stripACLPasswordRules(tc.rules), tc.rules)
+	}
+}
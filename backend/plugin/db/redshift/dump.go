@@ -3,10 +3,177 @@ package redshift
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"io"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
-// Dump dumps the database to the writer. But not implemented yet.
-func (*Driver) Dump(context.Context, io.Writer, bool) (string, error) {
+// Dump dumps the schema of the database, including schemas, tables and views. The data is not dumped.
+// The table DDLs are generated by SHOW TABLE, and the datashare database is skipped because its objects are read-only.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, _ bool) (string, error) {
+	if driver.datashare {
+		return "", nil
+	}
+	txn, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	if err := dumpTxn(ctx, txn, out); err != nil {
+		return "", errors.Wrapf(err, "failed to dump database %q", driver.databaseName)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
 	return "", nil
 }
+
+func dumpTxn(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	schemas, err := dumpSchemas(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump schemas")
+	}
+	for _, schema := range schemas {
+		if _, err := fmt.Fprintf(out, "CREATE SCHEMA IF NOT EXISTS %s;\n\n", quoteIdentifier(schema)); err != nil {
+			return err
+		}
+	}
+
+	tables, err := dumpTables(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump tables")
+	}
+	for _, table := range tables {
+		if _, err := fmt.Fprintf(out, "%s\n\n", table); err != nil {
+			return err
+		}
+	}
+
+	views, err := dumpViews(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump views")
+	}
+	for _, view := range views {
+		if _, err := fmt.Fprintf(out, "%s\n\n", view); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpSchemas(ctx context.Context, txn *sql.Tx) ([]string, error) {
+	query := `
+	SELECT nspname
+	FROM pg_catalog.pg_namespace
+	WHERE LEFT(nspname, 3) <> 'pg_' AND nspname NOT IN ('information_schema', 'public', 'catalog_history')
+	ORDER BY nspname;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+func dumpTables(ctx context.Context, txn *sql.Tx) ([]string, error) {
+	query := `
+	SELECT schemaname, tablename
+	FROM pg_catalog.pg_tables
+	WHERE LEFT(schemaname, 3) <> 'pg_' AND schemaname NOT IN ('information_schema', 'catalog_history')
+	ORDER BY schemaname, tablename;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var tableNames []string
+	for rows.Next() {
+		var schema, table string
+		if err := rows.Scan(&schema, &table); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tableNames = append(tableNames, fmt.Sprintf("%s.%s", quoteIdentifier(schema), quoteIdentifier(table)))
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	var tables []string
+	for _, tableName := range tableNames {
+		var ddl string
+		if err := txn.QueryRowContext(ctx, fmt.Sprintf("SHOW TABLE %s;", tableName)).Scan(&ddl); err != nil {
+			return nil, errors.Wrapf(err, "failed to show table %s", tableName)
+		}
+		tables = append(tables, ensureSemicolon(ddl))
+	}
+	return tables, nil
+}
+
+// dumpViews dumps the views in the creation order, so that the referenced views are created first.
+func dumpViews(ctx context.Context, txn *sql.Tx) ([]string, error) {
+	query := `
+	SELECT pns.nspname, pc.relname, pg_get_viewdef(pc.oid)
+	FROM pg_catalog.pg_class AS pc
+	JOIN pg_catalog.pg_namespace AS pns ON pns.oid = pc.relnamespace
+	WHERE pc.relkind = 'v' AND LEFT(pns.nspname, 3) <> 'pg_' AND pns.nspname NOT IN ('information_schema', 'catalog_history')
+	ORDER BY pc.oid;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []string
+	for rows.Next() {
+		var schema, name string
+		var definition sql.NullString
+		if err := rows.Scan(&schema, &name, &definition); err != nil {
+			return nil, err
+		}
+		if !definition.Valid {
+			return nil, errors.Errorf("schema %q view %q has empty definition; please check whether proper privileges have been granted to Bytebase", schema, name)
+		}
+		def := strings.TrimSpace(definition.String)
+		// The definition of the late-binding view is the whole CREATE VIEW statement with the WITH NO SCHEMA BINDING clause.
+		if strings.HasPrefix(strings.ToLower(def), "create") {
+			views = append(views, ensureSemicolon(def))
+			continue
+		}
+		views = append(views, ensureSemicolon(fmt.Sprintf("CREATE VIEW %s.%s AS %s", quoteIdentifier(schema), quoteIdentifier(name), def)))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+func ensureSemicolon(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, ";") {
+		return s
+	}
+	return s + ";"
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
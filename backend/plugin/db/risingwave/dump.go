@@ -2,11 +2,106 @@ package risingwave
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// listObjectQuery lists the definitions of the tables, sources, views, materialized views, indexes and sinks.
+// The object ids are allocated from the same sequence, so ordering by id yields the creation order.
+// The sources associated with the tables are created along with the tables, so they are excluded.
+var listObjectQuery = `
+WITH all_objects AS (
+  SELECT id, schema_id, definition FROM rw_tables
+  UNION ALL
+  SELECT id, schema_id, definition FROM rw_sources AS src
+  WHERE NOT EXISTS (
+    SELECT 1 FROM rw_tables AS T WHERE T.schema_id = src.schema_id AND T.name = src.name
+  )
+  UNION ALL
+  SELECT id, schema_id, definition FROM rw_views
+  UNION ALL
+  SELECT id, schema_id, definition FROM rw_materialized_views
+  UNION ALL
+  SELECT id, schema_id, definition FROM rw_indexes
+  UNION ALL
+  SELECT id, schema_id, definition FROM rw_sinks
 )
+SELECT
+  S.name AS schema_name,
+  all_objects.definition
+FROM
+  all_objects
+  JOIN rw_schemas S ON all_objects.schema_id = S.id
+  AND S.name NOT IN ` + fmt.Sprintf("(%s)", systemSchemas) + `
+ORDER BY all_objects.id;`
+
+// Dump dumps the schema of the database. The data is not dumped.
+// RisingWave doesn't support pg_dump, so the statements are assembled from the definitions in rw_catalog.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, _ bool) (string, error) {
+	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	if err := dumpTxn(ctx, txn, out); err != nil {
+		return "", errors.Wrapf(err, "failed to dump database %q", driver.databaseName)
+	}
 
-// Dump dumps the database.
-// TODO: RisingWave doesn't support pg_dump yet.
-func (*Driver) Dump(_ context.Context, _ io.Writer, _ bool) (string, error) {
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
 	return "", nil
 }
+
+func dumpTxn(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	schemas, err := getSchemas(txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get schemas")
+	}
+	for _, schema := range schemas {
+		if schema == "public" {
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "CREATE SCHEMA IF NOT EXISTS \"%s\";\n\n", schema); err != nil {
+			return err
+		}
+	}
+
+	rows, err := txn.QueryContext(ctx, listObjectQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// The definitions in rw_catalog are not qualified by the schema name, so we switch the search path accordingly.
+	currentSchema := ""
+	for rows.Next() {
+		var schema string
+		var definition sql.NullString
+		if err := rows.Scan(&schema, &definition); err != nil {
+			return err
+		}
+		if !definition.Valid {
+			continue
+		}
+		if schema != currentSchema {
+			if _, err := fmt.Fprintf(out, "SET search_path TO \"%s\";\n\n", schema); err != nil {
+				return err
+			}
+			currentSchema = schema
+		}
+		statement := strings.TrimSpace(definition.String)
+		if !strings.HasSuffix(statement, ";") {
+			statement += ";"
+		}
+		if _, err := fmt.Fprintf(out, "%s\n\n", statement); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
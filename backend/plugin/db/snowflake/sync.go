@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/plugin/db"
@@ -572,12 +574,73 @@ func (driver *Driver) getTableSchema(ctx context.Context, database string) (map[
 	return tableMap, viewMap, nil
 }
 
+// listSlowQuery lists the slow queries from the query history of the account.
+// The queries are grouped by the database and QUERY_PARAMETERIZED_HASH which ignores the literals.
+// The elapsed time is in milliseconds.
+var listSlowQuery = `
+	SELECT
+		DATABASE_NAME,
+		ANY_VALUE(QUERY_TEXT),
+		COUNT(*),
+		SUM(TOTAL_ELAPSED_TIME),
+		MAX(TOTAL_ELAPSED_TIME),
+		SUM(COALESCE(ROWS_PRODUCED, 0)),
+		MAX(COALESCE(ROWS_PRODUCED, 0)),
+		MAX(START_TIME)
+	FROM SNOWFLAKE.ACCOUNT_USAGE.QUERY_HISTORY
+	WHERE START_TIME >= TO_TIMESTAMP_TZ(?) AND START_TIME < TO_TIMESTAMP_TZ(?)
+		AND DATABASE_NAME IS NOT NULL
+		AND DATABASE_NAME <> 'SNOWFLAKE'
+		AND EXECUTION_STATUS = 'SUCCESS'
+		AND TOTAL_ELAPSED_TIME >= 1000
+	GROUP BY DATABASE_NAME, COALESCE(QUERY_PARAMETERIZED_HASH, QUERY_HASH)`
+
 // SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	rows, err := driver.db.QueryContext(ctx, listSlowQuery, logDateTs.Format(time.RFC3339), logDateTs.AddDate(0, 0, 1).Format(time.RFC3339))
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, listSlowQuery)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for rows.Next() {
+		var databaseName, sqlText string
+		var count, totalElapsedTime, maxElapsedTime, totalRows, maxRows int64
+		var latestTime time.Time
+		if err := rows.Scan(&databaseName, &sqlText, &count, &totalElapsedTime, &maxElapsedTime, &totalRows, &maxRows, &latestTime); err != nil {
+			return nil, err
+		}
+		item := &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   util.GetSQLFingerprint(sqlText),
+			Count:            int32(count),
+			LatestLogTime:    timestamppb.New(latestTime),
+			TotalQueryTime:   durationpb.New(time.Duration(totalElapsedTime) * time.Millisecond),
+			MaximumQueryTime: durationpb.New(time.Duration(maxElapsedTime) * time.Millisecond),
+			TotalRowsSent:    int32(totalRows),
+			MaximumRowsSent:  int32(maxRows),
+		}
+		statistics, exists := result[databaseName]
+		if !exists {
+			statistics = &storepb.SlowQueryStatistics{}
+			result[databaseName] = statistics
+		}
+		// The queries not sharing the parameterized hash may have the same fingerprint, e.g. the queries differing in the literal types.
+		util.AddSlowQueryStatisticsItem(statistics, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, listSlowQuery)
+	}
+	return result, nil
 }
 
 // CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+// Snowflake always records the query history, so we only check whether we have the privilege to read it.
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	query := "SELECT COUNT(*) FROM (SELECT 1 FROM SNOWFLAKE.ACCOUNT_USAGE.QUERY_HISTORY LIMIT 1)"
+	var count int64
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return errors.Wrapf(util.FormatErrorWithQuery(err, query), "please grant IMPORTED PRIVILEGES on the SNOWFLAKE database to the role")
+	}
+	return nil
 }
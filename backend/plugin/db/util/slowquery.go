@@ -0,0 +1,109 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// GetSQLFingerprint replaces the string and numeric literals with "?" and collapses the whitespaces,
+// so the statements differing only in literals have the same fingerprint.
+// It is used for the engines without a fingerprint in the slow query statistics.
+func GetSQLFingerprint(statement string) string {
+	var buf strings.Builder
+	runes := []rune(strings.TrimSpace(statement))
+	space := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case r == '\'' || ((r == 'N' || r == 'n') && i+1 < len(runes) && runes[i+1] == '\'' && !isIdentifierRune(runes, i-1)):
+			// The string literal, the quote is escaped by doubling it.
+			if r != '\'' {
+				i++
+			}
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			r = '?'
+		case unicode.IsDigit(r) && !isIdentifierRune(runes, i-1) && (i == 0 || runes[i-1] != ':'):
+			// The numeric literal, but not the positional bind variable such as ":1" in Oracle.
+			for i+1 < len(runes) && (isIdentifierRune(runes, i+1) || runes[i+1] == '.') {
+				i++
+			}
+			r = '?'
+		case r == '"' || r == '[' || r == '`':
+			// The quoted identifier is kept as is.
+			end := r
+			if r == '[' {
+				end = ']'
+			}
+			if space && buf.Len() > 0 {
+				buf.WriteRune(' ')
+			}
+			space = false
+			buf.WriteRune(r)
+			for i++; i < len(runes); i++ {
+				buf.WriteRune(runes[i])
+				if runes[i] == end {
+					break
+				}
+			}
+			continue
+		}
+		if space && buf.Len() > 0 {
+			buf.WriteRune(' ')
+		}
+		space = false
+		buf.WriteRune(r)
+	}
+	fingerprint := buf.String()
+	if len(fingerprint) > db.SlowQueryMaxLen {
+		fingerprint, _ = common.TruncateString(fingerprint, db.SlowQueryMaxLen)
+	}
+	return fingerprint
+}
+
+func isIdentifierRune(runes []rune, i int) bool {
+	if i < 0 || i >= len(runes) {
+		return false
+	}
+	r := runes[i]
+	return r == '_' || r == '$' || r == '@' || r == '#' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// AddSlowQueryStatisticsItem adds the item to the statistics, merging it with the item of the same fingerprint.
+func AddSlowQueryStatisticsItem(statistics *storepb.SlowQueryStatistics, item *storepb.SlowQueryStatisticsItem) {
+	for _, existing := range statistics.Items {
+		if existing.SqlFingerprint != item.SqlFingerprint {
+			continue
+		}
+		existing.Count += item.Count
+		existing.TotalQueryTime = durationpb.New(existing.TotalQueryTime.AsDuration() + item.TotalQueryTime.AsDuration())
+		if existing.MaximumQueryTime.AsDuration() < item.MaximumQueryTime.AsDuration() {
+			existing.MaximumQueryTime = item.MaximumQueryTime
+		}
+		existing.TotalRowsSent += item.TotalRowsSent
+		if existing.MaximumRowsSent < item.MaximumRowsSent {
+			existing.MaximumRowsSent = item.MaximumRowsSent
+		}
+		if existing.LatestLogTime.AsTime().Before(item.LatestLogTime.AsTime()) {
+			existing.LatestLogTime = item.LatestLogTime
+		}
+		return
+	}
+	statistics.Items = append(statistics.Items, item)
+}
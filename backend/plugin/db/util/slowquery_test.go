@@ -0,0 +1,77 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestGetSQLFingerprint(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      string
+	}{
+		{
+			statement: "SELECT * FROM t WHERE id = 1",
+			want:      "SELECT * FROM t WHERE id = ?",
+		},
+		{
+			statement: "SELECT *\n  FROM t\tWHERE name = 'it''s' AND score > 3.5 AND t2 = N'x'",
+			want:      "SELECT * FROM t WHERE name = ? AND score > ? AND t2 = ?",
+		},
+		{
+			statement: `SELECT "col 1", [col 2] FROM t1 WHERE c = @p1 AND d IN (1, 2, 0x1F)`,
+			want:      `SELECT "col 1", [col 2] FROM t1 WHERE c = @p1 AND d IN (?, ?, ?)`,
+		},
+		{
+			statement: "select  :1  from dual where name = 'N'",
+			want:      "select :1 from dual where name = ?",
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, GetSQLFingerprint(test.statement), test.statement)
+	}
+}
+
+func TestAddSlowQueryStatisticsItem(t *testing.T) {
+	a := require.New(t)
+	now := time.Now()
+	statistics := &storepb.SlowQueryStatistics{}
+	AddSlowQueryStatisticsItem(statistics, &storepb.SlowQueryStatisticsItem{
+		SqlFingerprint:   "SELECT ?",
+		Count:            2,
+		LatestLogTime:    timestamppb.New(now),
+		TotalQueryTime:   durationpb.New(2 * time.Second),
+		MaximumQueryTime: durationpb.New(time.Second),
+		TotalRowsSent:    2,
+		MaximumRowsSent:  1,
+	})
+	AddSlowQueryStatisticsItem(statistics, &storepb.SlowQueryStatisticsItem{
+		SqlFingerprint:   "SELECT ?",
+		Count:            1,
+		LatestLogTime:    timestamppb.New(now.Add(time.Hour)),
+		TotalQueryTime:   durationpb.New(3 * time.Second),
+		MaximumQueryTime: durationpb.New(3 * time.Second),
+		TotalRowsSent:    5,
+		MaximumRowsSent:  5,
+	})
+	AddSlowQueryStatisticsItem(statistics, &storepb.SlowQueryStatisticsItem{
+		SqlFingerprint: "SELECT * FROM t",
+		Count:          1,
+	})
+
+	a.Len(statistics.Items, 2)
+	item := statistics.Items[0]
+	a.Equal(int32(3), item.Count)
+	a.Equal(5*time.Second, item.TotalQueryTime.AsDuration())
+	a.Equal(3*time.Second, item.MaximumQueryTime.AsDuration())
+	a.Equal(int32(7), item.TotalRowsSent)
+	a.Equal(int32(5), item.MaximumRowsSent)
+	a.True(item.LatestLogTime.AsTime().Equal(now.Add(time.Hour)))
+}
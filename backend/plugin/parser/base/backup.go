@@ -4,5 +4,10 @@ type BackupStatement struct {
 	Statement string
 	TableName string
 
+	// SourceSchema and SourceTableName identify the table the backup is taken from.
+	// They are only set by engines supporting restore.
+	SourceSchema    string
+	SourceTableName string
+
 	OriginalLine int
 }
@@ -0,0 +1,497 @@
+package base
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// KeywordCase is the case of the keywords in the formatted statement.
+type KeywordCase string
+
+const (
+	// KeywordCaseUpper converts the keywords to upper case.
+	KeywordCaseUpper KeywordCase = "upper"
+	// KeywordCaseLower converts the keywords to lower case.
+	KeywordCaseLower KeywordCase = "lower"
+	// KeywordCasePreserve keeps the keywords as they are.
+	KeywordCasePreserve KeywordCase = "preserve"
+)
+
+// FormatOptions is the options to format the statement.
+type FormatOptions struct {
+	// KeywordCase is the case of the keywords, defaults to upper case.
+	KeywordCase KeywordCase
+	// Indent is the string of one indentation level, defaults to two spaces.
+	Indent string
+}
+
+// FormatToken is the token of the statement to format.
+type FormatToken struct {
+	Text string
+	// Keyword is true if the token is a keyword of the engine.
+	Keyword bool
+	// Hidden is true if the token is on the hidden channel, i.e. whitespaces and comments.
+	Hidden bool
+}
+
+// NewFormatTokens lexes the statement with the engine lexer.
+// A token is a keyword if its symbolic name is the text in upper case, with the optional suffix, e.g. "_P" for PostgreSQL and "_SYMBOL" for MySQL.
+// The tokens whose indexes are in identifiers are not keywords, e.g. the non-reserved keywords used as the column names.
+// The whitespaces skipped by the lexer are added back as the hidden tokens.
+func NewFormatTokens(statement string, lexer antlr.Lexer, identifiers map[int]bool, keywordSuffixes ...string) []FormatToken {
+	input := []rune(statement)
+	symbolicNames := lexer.GetSymbolicNames()
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	stream.Fill()
+	var tokens []FormatToken
+	last := 0
+	for _, token := range stream.GetAllTokens() {
+		if token.GetTokenType() == antlr.TokenEOF {
+			break
+		}
+		if start := token.GetStart(); start > last && start <= len(input) {
+			tokens = append(tokens, FormatToken{Text: string(input[last:start]), Hidden: true})
+		}
+		last = token.GetStop() + 1
+
+		text := token.GetText()
+		keyword := false
+		if token.GetTokenType() > 0 && token.GetTokenType() < len(symbolicNames) && !identifiers[token.GetTokenIndex()] {
+			name := symbolicNames[token.GetTokenType()]
+			upper := strings.ToUpper(text)
+			if name == upper {
+				keyword = true
+			}
+			for _, suffix := range keywordSuffixes {
+				if name == upper+suffix {
+					keyword = true
+				}
+			}
+		}
+		tokens = append(tokens, FormatToken{
+			Text:    text,
+			Keyword: keyword,
+			Hidden:  token.GetChannel() != antlr.TokenDefaultChannel,
+		})
+	}
+	return tokens
+}
+
+// GetIdentifierTokenIndexes returns the indexes of the tokens in the identifier rules of the tree.
+func GetIdentifierTokenIndexes(tree antlr.Tree, identifierRules ...int) map[int]bool {
+	result := make(map[int]bool)
+	var walk func(node antlr.Tree)
+	walk = func(node antlr.Tree) {
+		ctx, ok := node.(antlr.ParserRuleContext)
+		if !ok {
+			return
+		}
+		if slices.Contains(identifierRules, ctx.GetRuleIndex()) {
+			if ctx.GetStart() != nil && ctx.GetStop() != nil {
+				for i := ctx.GetStart().GetTokenIndex(); i <= ctx.GetStop().GetTokenIndex(); i++ {
+					result[i] = true
+				}
+			}
+			return
+		}
+		for _, child := range ctx.GetChildren() {
+			walk(child)
+		}
+	}
+	if tree != nil {
+		walk(tree)
+	}
+	return result
+}
+
+// clauseKeywords are the keywords starting a new line in the DML statements.
+var clauseKeywords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "GROUP": true, "ORDER": true, "HAVING": true, "LIMIT": true,
+	"OFFSET": true, "FETCH": true, "UNION": true, "EXCEPT": true, "INTERSECT": true, "MINUS": true, "VALUES": true,
+	"SET": true, "RETURNING": true, "INSERT": true, "UPDATE": true, "DELETE": true, "JOIN": true, "LEFT": true,
+	"RIGHT": true, "INNER": true, "FULL": true, "CROSS": true, "NATURAL": true, "WINDOW": true, "QUALIFY": true,
+	"OUTPUT": true, "USING": true, "WHEN": true,
+}
+
+// noBreakAfterKeywords are the keywords followed by the clause keywords in the same clause, e.g. LEFT JOIN and DELETE FROM.
+var noBreakAfterKeywords = map[string]bool{
+	"LEFT": true, "RIGHT": true, "INNER": true, "FULL": true, "OUTER": true, "CROSS": true, "NATURAL": true,
+	"DELETE": true, "DO": true, "FOR": true, "KEY": true, "WITHIN": true, "INSERT": true, "REPLACE": true,
+	"MERGE": true, "THEN": true, "NOT": true, "DISTINCT": true, "IS": true,
+}
+
+// listClauseKeywords are the clauses whose items separated by commas are put in separate lines.
+var listClauseKeywords = map[string]bool{
+	"SELECT": true, "SET": true, "VALUES": true, "GROUP": true, "ORDER": true, "RETURNING": true, "WITH": true,
+}
+
+// dmlKeywords are the keywords starting the DML statements.
+var dmlKeywords = map[string]bool{
+	"SELECT": true, "INSERT": true, "UPDATE": true, "DELETE": true, "WITH": true, "MERGE": true, "REPLACE": true,
+}
+
+type formatFrame struct {
+	// indent is the indentation level of the clauses in the frame.
+	indent int
+	// block is true if the items in the parentheses are put in separate lines.
+	block bool
+	// dml is true if the clauses in the frame start new lines.
+	dml bool
+	// clause is the current clause keyword in the frame.
+	clause string
+	// between is true after BETWEEN and before the matching AND.
+	between bool
+	// closeIndent is the indentation level of the closing parenthesis of the block.
+	closeIndent int
+}
+
+type formatter struct {
+	options FormatOptions
+	buf     strings.Builder
+	frames  []*formatFrame
+
+	// pendingNewlines and pendingIndent is the line break before the next token.
+	pendingNewlines int
+	pendingIndent   int
+	// lineStart is true if nothing is written in the current line.
+	lineStart bool
+	// lineIndent is the indentation level of the current line.
+	lineIndent int
+	// lineComment is true if the current line ends with a line comment, so the next token must start a new line.
+	lineComment bool
+	// prev is the last written token, excluding the comments.
+	prev *FormatToken
+	// statementStart is true if no token of the current statement is written.
+	statementStart bool
+}
+
+// FormatTokens formats the tokens of the statements.
+// The DML clauses start new lines with the items separated by commas in separate lines, and the subqueries are indented.
+// Other statements are kept in one line except the definitions in parentheses, e.g. the columns in CREATE TABLE.
+func FormatTokens(tokens []FormatToken, options FormatOptions) string {
+	if options.KeywordCase == "" {
+		options.KeywordCase = KeywordCaseUpper
+	}
+	if options.Indent == "" {
+		options.Indent = "  "
+	}
+	f := &formatter{
+		options:        options,
+		frames:         []*formatFrame{{}},
+		lineStart:      true,
+		statementStart: true,
+	}
+
+	// spaceBefore and newlineBefore record the original whitespaces before the token.
+	spaceBefore, newlineBefore := false, false
+	for i := range tokens {
+		token := &tokens[i]
+		if token.Hidden && strings.TrimSpace(token.Text) == "" {
+			spaceBefore = true
+			if strings.Contains(token.Text, "\n") {
+				newlineBefore = true
+			}
+			continue
+		}
+		if token.Hidden {
+			// The comments skipped by the lexer might come with the surrounding whitespaces.
+			leading := token.Text[:len(token.Text)-len(strings.TrimLeft(token.Text, " \t\r\n"))]
+			if leading != "" {
+				spaceBefore = true
+				newlineBefore = newlineBefore || strings.Contains(leading, "\n")
+			}
+			f.writeComment(strings.TrimSpace(token.Text), spaceBefore, newlineBefore)
+		} else {
+			f.writeToken(tokens, i, spaceBefore, newlineBefore)
+		}
+		spaceBefore, newlineBefore = false, false
+	}
+
+	result := strings.TrimRight(f.buf.String(), " \t\n")
+	if result == "" {
+		return ""
+	}
+	return result + "\n"
+}
+
+func (f *formatter) frame() *formatFrame {
+	return f.frames[len(f.frames)-1]
+}
+
+// lineBreak requests a line break with the indentation level before the next token.
+func (f *formatter) lineBreak(newlines int, indent int) {
+	if f.buf.Len() == 0 {
+		return
+	}
+	if newlines > f.pendingNewlines {
+		f.pendingNewlines = newlines
+	}
+	f.pendingIndent = indent
+}
+
+func (f *formatter) flush(space bool) {
+	if f.pendingNewlines > 0 {
+		trimmed := strings.TrimRight(f.buf.String(), " \t")
+		f.buf.Reset()
+		_, _ = f.buf.WriteString(trimmed)
+		_, _ = f.buf.WriteString(strings.Repeat("\n", f.pendingNewlines))
+		_, _ = f.buf.WriteString(strings.Repeat(f.options.Indent, f.pendingIndent))
+		f.lineIndent = f.pendingIndent
+		f.pendingNewlines = 0
+		f.lineStart = true
+		f.lineComment = false
+		return
+	}
+	if space && !f.lineStart {
+		_, _ = f.buf.WriteString(" ")
+	}
+}
+
+func (f *formatter) write(text string) {
+	_, _ = f.buf.WriteString(text)
+	f.lineStart = false
+}
+
+func (f *formatter) writeComment(text string, spaceBefore, newlineBefore bool) {
+	lineComment := strings.HasPrefix(text, "--") || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "//")
+	if newlineBefore && f.buf.Len() > 0 {
+		// The comment in its own line.
+		indent := f.pendingIndent
+		if f.pendingNewlines == 0 {
+			indent = f.frame().indent + 1
+			if f.statementStart {
+				indent = 0
+			}
+		}
+		f.lineBreak(1, indent)
+		f.flush(false)
+	} else if f.pendingNewlines == 0 || !strings.Contains(text, "\n") {
+		// The trailing comment stays in the same line, before the pending line break.
+		if spaceBefore && !f.lineStart {
+			_, _ = f.buf.WriteString(" ")
+		}
+	}
+	f.write(text)
+	if lineComment {
+		f.lineComment = true
+		indent := f.pendingIndent
+		if f.pendingNewlines == 0 {
+			indent = f.frame().indent + 1
+			if f.statementStart {
+				indent = 0
+			}
+		}
+		f.lineBreak(1, indent)
+	}
+}
+
+func (f *formatter) writeToken(tokens []FormatToken, i int, spaceBefore, newlineBefore bool) {
+	token := &tokens[i]
+	text := token.Text
+	upper := strings.ToUpper(text)
+	if token.Keyword {
+		switch f.options.KeywordCase {
+		case KeywordCaseUpper:
+			text = upper
+		case KeywordCaseLower:
+			text = strings.ToLower(text)
+		}
+	}
+	frame := f.frame()
+	prevUpper := ""
+	if f.prev != nil && f.prev.Keyword {
+		prevUpper = strings.ToUpper(f.prev.Text)
+	}
+
+	switch {
+	case text == ";" || (token.Keyword && upper == "GO" && newlineBefore):
+		if upper == "GO" {
+			f.lineBreak(1, 0)
+			f.flush(false)
+		}
+		f.flush(false)
+		f.write(text)
+		// Reset the frames for the next statement.
+		f.frames = []*formatFrame{{}}
+		f.lineIndent = 0
+		f.statementStart = true
+		f.prev = nil
+		f.lineBreak(2, 0)
+		return
+	case text == "(":
+		block := false
+		dml := false
+		if next := nextToken(tokens, i); next != nil && next.Keyword && dmlKeywords[strings.ToUpper(next.Text)] {
+			// The subquery.
+			block, dml = true, true
+		} else if frame == f.frames[0] && !frame.dml && frame.clause == "" && f.isDefinitionParenthesis(tokens, i) {
+			// The definitions in CREATE TABLE.
+			block = true
+		}
+		space := spaceBefore || (f.prev != nil && !f.prev.isWord())
+		if f.prev != nil && (f.prev.Text == "(" || f.prev.Text == ".") {
+			space = false
+		}
+		f.flush(space)
+		f.write(text)
+		f.prev = token
+		f.statementStart = false
+		indent := frame.indent + 1
+		if block {
+			// The block is indented relative to the line of the opening parenthesis.
+			indent = f.lineIndent + 1
+		} else if !frame.block && len(f.frames) > 1 {
+			indent = frame.indent
+		}
+		f.frames = append(f.frames, &formatFrame{indent: indent, block: block, dml: dml, closeIndent: f.lineIndent})
+		if block {
+			f.lineBreak(1, indent)
+		}
+		return
+	case text == ")":
+		if len(f.frames) > 1 {
+			f.frames = f.frames[:len(f.frames)-1]
+			if frame.block {
+				f.lineBreak(1, frame.closeIndent)
+			}
+		}
+		f.flush(false)
+		f.write(text)
+		f.prev = token
+		return
+	case text == ",":
+		itemIndent := -1
+		switch {
+		case frame.block && !frame.dml:
+			itemIndent = frame.indent
+		case frame.dml && listClauseKeywords[frame.clause]:
+			itemIndent = frame.indent + 1
+		}
+		if f.lineComment {
+			// The comma would be commented out in the line of the comment, so it starts the next line.
+			if itemIndent >= 0 {
+				f.pendingIndent = itemIndent
+			}
+			f.flush(false)
+		} else {
+			f.pendingNewlines = 0
+		}
+		f.write(text)
+		f.prev = token
+		switch {
+		case itemIndent >= 0:
+			f.lineBreak(1, itemIndent)
+		default:
+			f.lineBreak(0, 0)
+			_, _ = f.buf.WriteString(" ")
+			f.lineStart = true
+		}
+		return
+	}
+
+	if token.Keyword {
+		if f.statementStart {
+			frame.dml = dmlKeywords[upper]
+		} else if prevUpper == "AS" && dmlKeywords[upper] {
+			// CREATE VIEW ... AS SELECT.
+			frame.dml = true
+		}
+		if frame.dml && frame.block || frame.dml && len(f.frames) == 1 {
+			next := nextToken(tokens, i)
+			isFunction := next != nil && next.Text == "("
+			switch {
+			case clauseKeywords[upper] && !noBreakAfterKeywords[prevUpper] && !(isFunction && (upper == "LEFT" || upper == "RIGHT")) && !(upper == "WHEN" && frame.clause != "MERGE"):
+				frame.clause = upper
+				if !f.statementStart {
+					f.lineBreak(1, frame.indent)
+				}
+			case clauseKeywords[upper]:
+				if upper != "WHEN" {
+					frame.clause = upper
+				}
+			case upper == "WITH" && f.statementStart:
+				frame.clause = upper
+			case upper == "MERGE" && f.statementStart:
+				frame.clause = upper
+			case upper == "BETWEEN":
+				frame.between = true
+			case (upper == "AND" || upper == "OR") && (frame.clause == "WHERE" || frame.clause == "HAVING"):
+				if upper == "AND" && frame.between {
+					frame.between = false
+				} else {
+					f.lineBreak(1, frame.indent+1)
+				}
+			}
+		}
+	}
+
+	space := true
+	if f.prev != nil {
+		switch {
+		case f.prev.Text == "(" || f.prev.Text == ".":
+			space = false
+		case text == ".":
+			space = false
+		case !f.prev.isWord() || !token.isWord():
+			// Keep the original spacing around the operators.
+			space = spaceBefore
+		}
+	}
+	f.flush(space)
+	f.write(text)
+	f.prev = token
+	f.statementStart = false
+}
+
+// isDefinitionParenthesis returns true if the parenthesis is the first one in CREATE TABLE, which encloses the definitions.
+func (*formatter) isDefinitionParenthesis(tokens []FormatToken, i int) bool {
+	start := 0
+	for j := i - 1; j >= 0; j-- {
+		if tokens[j].Text == ";" {
+			start = j + 1
+			break
+		}
+	}
+	var keywords []string
+	for j := start; j < i; j++ {
+		switch {
+		case tokens[j].Hidden:
+		case tokens[j].Text == "(":
+			return false
+		case tokens[j].Keyword:
+			keywords = append(keywords, strings.ToUpper(tokens[j].Text))
+		}
+	}
+	if len(keywords) == 0 || keywords[0] != "CREATE" {
+		return false
+	}
+	for _, keyword := range keywords {
+		if keyword == "TABLE" {
+			return true
+		}
+	}
+	return false
+}
+
+// isWord returns true if the token is a keyword, identifier or literal, rather than an operator or punctuation.
+func (t *FormatToken) isWord() bool {
+	if t.Keyword {
+		return true
+	}
+	for _, r := range t.Text {
+		return r == '_' || r == '"' || r == '`' || r == '[' || r == '\'' || r == '@' || r == '$' || r == '#' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return false
+}
+
+func nextToken(tokens []FormatToken, i int) *FormatToken {
+	for j := i + 1; j < len(tokens); j++ {
+		if !tokens[j].Hidden {
+			return &tokens[j]
+		}
+	}
+	return nil
+}
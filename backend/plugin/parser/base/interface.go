@@ -23,6 +23,8 @@ var (
 	spans                   = make(map[storepb.Engine]GetQuerySpanFunc)
 	affectedRows            = make(map[storepb.Engine]GetAffectedRowsFunc)
 	transformDMLToSelect    = make(map[storepb.Engine]TransformDMLToSelectFunc)
+	restoreGenerators       = make(map[storepb.Engine]GenerateRestoreSQLFunc)
+	formatters              = make(map[storepb.Engine]FormatFunc)
 )
 
 type ValidateSQLForEditorFunc func(string) (bool, error)
@@ -42,6 +44,12 @@ type GetAffectedRowsFunc func(ctx context.Context, stmt any, getAffectedRowsByQu
 // TransformDMLToSelectFunc is the interface of transforming DML statements to SELECT statements.
 type TransformDMLToSelectFunc func(statement string, sourceDatabase string, targetDatabase string, tableSuffix string) ([]BackupStatement, error)
 
+// GenerateRestoreSQLFunc is the interface of generating the statement restoring the rows saved by a backup statement.
+type GenerateRestoreSQLFunc func(backup BackupStatement, backupDatabase string, table *storepb.TableMetadata) (string, error)
+
+// FormatFunc is the interface of formatting the statement.
+type FormatFunc func(statement string, options FormatOptions) (string, error)
+
 func RegisterQueryValidator(engine storepb.Engine, f ValidateSQLForEditorFunc) {
 	mux.Lock()
 	defer mux.Unlock()
@@ -253,3 +261,41 @@ func TransformDMLToSelect(engine storepb.Engine, statement string, sourceDatabas
 	}
 	return f(statement, sourceDatabase, targetDatabase, tableSuffix)
 }
+
+// RegisterGenerateRestoreSQL registers the generateRestoreSQL function for the engine.
+func RegisterGenerateRestoreSQL(engine storepb.Engine, f GenerateRestoreSQLFunc) {
+	mux.Lock()
+	defer mux.Unlock()
+	if _, dup := restoreGenerators[engine]; dup {
+		panic(fmt.Sprintf("Register called twice %s", engine))
+	}
+	restoreGenerators[engine] = f
+}
+
+// GenerateRestoreSQL generates the statement restoring the rows saved by the backup statement.
+func GenerateRestoreSQL(engine storepb.Engine, backup BackupStatement, backupDatabase string, table *storepb.TableMetadata) (string, error) {
+	f, ok := restoreGenerators[engine]
+	if !ok {
+		return "", errors.Errorf("engine %s is not supported", engine)
+	}
+	return f(backup, backupDatabase, table)
+}
+
+// RegisterFormatFunc registers the format function for the engine.
+func RegisterFormatFunc(engine storepb.Engine, f FormatFunc) {
+	mux.Lock()
+	defer mux.Unlock()
+	if _, dup := formatters[engine]; dup {
+		panic(fmt.Sprintf("Register called twice %s", engine))
+	}
+	formatters[engine] = f
+}
+
+// Format formats the statement, the statement must be valid.
+func Format(engine storepb.Engine, statement string, options FormatOptions) (string, error) {
+	f, ok := formatters[engine]
+	if !ok {
+		return "", errors.Errorf("engine %s is not supported", engine)
+	}
+	return f(statement, options)
+}
@@ -548,10 +548,11 @@ func (diff *diffNode) diffTrigger(oldSchema, newSchema *schemaDef) error {
 
 		oldTrigger, ok := oldSchema.triggers[triggerName]
 		if ok {
-			if !isTriggerEqual(oldTrigger, trigger) {
-				diff.dropTriggerList = append(diff.dropTriggerList, oldTrigger)
-			}
 			delete(oldSchema.triggers, triggerName)
+			if isTriggerEqual(oldTrigger, trigger) {
+				continue
+			}
+			diff.dropTriggerList = append(diff.dropTriggerList, oldTrigger)
 		}
 		diff.createTriggerList = append(diff.createTriggerList, trigger)
 	}
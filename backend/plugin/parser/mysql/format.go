@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/mysql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterFormatFunc(storepb.Engine_MYSQL, Format)
+	base.RegisterFormatFunc(storepb.Engine_MARIADB, Format)
+	base.RegisterFormatFunc(storepb.Engine_TIDB, Format)
+	base.RegisterFormatFunc(storepb.Engine_OCEANBASE, Format)
+}
+
+// Format formats the statement with the keyword case and indentation options.
+func Format(statement string, options base.FormatOptions) (string, error) {
+	if _, err := ParseMySQL(statement); err != nil {
+		return "", err
+	}
+	return base.FormatTokens(base.NewFormatTokens(statement, parser.NewMySQLLexer(antlr.NewInputStream(statement)), getIdentifierTokenIndexes(statement), "_SYMBOL"), options), nil
+}
+
+// getIdentifierTokenIndexes parses the whole script to find the identifier tokens.
+// ParseMySQL parses the split statements separately, so its token indexes are not the ones of the whole script.
+// The script with the custom delimiters is not parsable, and all the keywords are formatted in this case.
+func getIdentifierTokenIndexes(statement string) map[int]bool {
+	// Each statement requires the trailing semicolon, and the extra one does not change the indexes of the preceding tokens.
+	lexer := parser.NewMySQLLexer(antlr.NewInputStream(statement + "\n;"))
+	lexer.RemoveErrorListeners()
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	p := parser.NewMySQLParser(stream)
+	p.RemoveErrorListeners()
+	errorListener := &base.ParseErrorListener{}
+	p.AddErrorListener(errorListener)
+	p.BuildParseTrees = true
+	tree := p.Script()
+	if errorListener.Err != nil {
+		return nil
+	}
+	return base.GetIdentifierTokenIndexes(tree, parser.MySQLParserRULE_identifier)
+}
@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		statement string
+		options   base.FormatOptions
+		want      string
+	}{
+		{
+			statement: "select id, name from t1 join t2 on t1.id = t2.id where a = 1 and b in (select b from t3) order by id limit 10",
+			want: `SELECT id,
+  name
+FROM t1
+JOIN t2 ON t1.id = t2.id
+WHERE a = 1
+  AND b IN (
+    SELECT b
+    FROM t3
+  )
+ORDER BY id
+LIMIT 10
+`,
+		},
+		{
+			statement: "insert into t (id, name) values (1, 'a'), (2, 'b');",
+			options:   base.FormatOptions{KeywordCase: base.KeywordCaseLower, Indent: "    "},
+			want: `insert into t (id, name)
+values (1, 'a'),
+    (2, 'b');
+`,
+		},
+		{
+			statement: "create table t (id int primary key, status varchar(10) -- the status\n, name text);",
+			want: `CREATE TABLE t (
+  id INT PRIMARY KEY,
+  status VARCHAR(10) -- the status
+  ,
+  name TEXT
+);
+`,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := Format(test.statement, test.options)
+		a.NoError(err)
+		a.Equal(test.want, got)
+	}
+
+	_, err := Format("select from where", base.FormatOptions{})
+	a.Error(err)
+}
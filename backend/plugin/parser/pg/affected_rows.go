@@ -0,0 +1,136 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/postgresql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterGetAffectedRows(storepb.Engine_POSTGRES, GetAffectedRows)
+}
+
+// GetAffectedRows return the rows count affected by the sql.
+// The DML statements are estimated by getAffectedRowsByQuery with EXPLAIN, and the DDL statements by the table statistics.
+func GetAffectedRows(ctx context.Context, stmt any, getAffectedRowsByQuery base.GetAffectedRowsCountByQueryFunc, getTableDataSizeFunc base.GetTableDataSizeFunc) (int64, error) {
+	parseResult, ok := stmt.(*ParseResult)
+	if !ok {
+		return 0, errors.New("failed to convert stmt to postgres parse result")
+	}
+	root, ok := parseResult.Tree.(*parser.RootContext)
+	if !ok {
+		return 0, errors.New("failed to convert to RootContext")
+	}
+	if root.Stmtblock() == nil || root.Stmtblock().Stmtmulti() == nil {
+		return 0, nil
+	}
+
+	var total int64
+	for _, stmt := range root.Stmtblock().Stmtmulti().AllStmt() {
+		affectedRows, err := getStatementAffectedRows(ctx, parseResult.Tokens, stmt, getAffectedRowsByQuery, getTableDataSizeFunc)
+		if err != nil {
+			return 0, err
+		}
+		total += affectedRows
+	}
+	return total, nil
+}
+
+func getStatementAffectedRows(ctx context.Context, tokens antlr.TokenStream, stmt parser.IStmtContext, getAffectedRowsByQuery base.GetAffectedRowsCountByQueryFunc, getTableDataSizeFunc base.GetTableDataSizeFunc) (int64, error) {
+	switch {
+	case stmt.Insertstmt() != nil:
+		if values := getInsertValuesClause(stmt.Insertstmt()); values != nil {
+			return int64(len(values.AllExpr_list())), nil
+		}
+		if stmt.Insertstmt().Insert_rest().DEFAULT() != nil {
+			return 1, nil
+		}
+		return getAffectedRowsByExplain(ctx, tokens, stmt, getAffectedRowsByQuery)
+	case stmt.Updatestmt() != nil, stmt.Deletestmt() != nil:
+		return getAffectedRowsByExplain(ctx, tokens, stmt, getAffectedRowsByQuery)
+	case stmt.Altertablestmt() != nil:
+		alter := stmt.Altertablestmt()
+		if alter.TABLE() == nil || alter.FOREIGN() != nil || alter.Relation_expr() == nil {
+			return 0, nil
+		}
+		return getRelationDataSize(alter.Relation_expr().Qualified_name(), getTableDataSizeFunc)
+	case stmt.Dropstmt() != nil:
+		drop := stmt.Dropstmt()
+		if drop.Object_type_any_name() == nil || drop.Object_type_any_name().TABLE() == nil || drop.Object_type_any_name().FOREIGN() != nil || drop.Any_name_list() == nil {
+			return 0, nil
+		}
+		var total int64
+		for _, name := range drop.Any_name_list().AllAny_name() {
+			schemaName, tableName, err := NormalizePostgreSQLAnyNameAsTableName(name)
+			if err != nil {
+				return 0, err
+			}
+			total += getTableDataSize(schemaName, tableName, getTableDataSizeFunc)
+		}
+		return total, nil
+	case stmt.Truncatestmt() != nil:
+		var total int64
+		for _, relation := range stmt.Truncatestmt().Relation_expr_list().AllRelation_expr() {
+			affectedRows, err := getRelationDataSize(relation.Qualified_name(), getTableDataSizeFunc)
+			if err != nil {
+				return 0, err
+			}
+			total += affectedRows
+		}
+		return total, nil
+	default:
+		return 0, nil
+	}
+}
+
+// getInsertValuesClause returns the VALUES clause if the INSERT statement inserts constant rows only.
+func getInsertValuesClause(ctx parser.IInsertstmtContext) parser.IValues_clauseContext {
+	selectStmt := ctx.Insert_rest().Selectstmt()
+	if selectStmt == nil || selectStmt.Select_no_parens() == nil {
+		return nil
+	}
+	selectNoParens := selectStmt.Select_no_parens()
+	if selectNoParens.With_clause() != nil || selectNoParens.Select_limit() != nil {
+		return nil
+	}
+	intersects := selectNoParens.Select_clause().AllSimple_select_intersect()
+	if len(intersects) != 1 {
+		return nil
+	}
+	primaries := intersects[0].AllSimple_select_pramary()
+	if len(primaries) != 1 {
+		return nil
+	}
+	return primaries[0].Values_clause()
+}
+
+func getAffectedRowsByExplain(ctx context.Context, tokens antlr.TokenStream, stmt parser.IStmtContext, getAffectedRowsByQuery base.GetAffectedRowsCountByQueryFunc) (int64, error) {
+	if getAffectedRowsByQuery == nil {
+		return 0, nil
+	}
+	return getAffectedRowsByQuery(ctx, tokens.GetTextFromRuleContext(stmt))
+}
+
+func getRelationDataSize(ctx parser.IQualified_nameContext, getTableDataSizeFunc base.GetTableDataSizeFunc) (int64, error) {
+	schemaName, tableName, err := NormalizePostgreSQLQualifiedNameAsTableName(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return getTableDataSize(schemaName, tableName, getTableDataSizeFunc), nil
+}
+
+func getTableDataSize(schemaName, tableName string, getTableDataSizeFunc base.GetTableDataSizeFunc) int64 {
+	if getTableDataSizeFunc == nil {
+		return 0
+	}
+	if schemaName == "" {
+		schemaName = defaultSchema
+	}
+	return getTableDataSizeFunc(schemaName, tableName)
+}
@@ -0,0 +1,286 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/postgresql-parser"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterTransformDMLToSelect(storepb.Engine_POSTGRES, TransformDMLToSelect)
+	base.RegisterGenerateRestoreSQL(storepb.Engine_POSTGRES, GenerateRestoreSQL)
+}
+
+const (
+	defaultSchema = "public"
+	// PostgreSQL truncates identifiers longer than NAMEDATALEN-1 bytes.
+	maxTableNameLength = 63
+)
+
+type backupStatementInfo struct {
+	offset int
+	line   int
+	schema string
+	table  string
+	// alias is the name referring to the target table in the original statement.
+	alias string
+
+	// withClause is the text of the WITH clause, or empty.
+	withClause string
+	// relation is the text of the target table reference, without its alias.
+	relation string
+	// relationWithAlias is the text of the target table reference, with its alias.
+	relationWithAlias string
+	// fromList is the text of the UPDATE FROM or DELETE USING list, or empty.
+	fromList string
+	// whereClause is the text of the WHERE clause, or empty.
+	whereClause string
+}
+
+// TransformDMLToSelect transforms the UPDATE and DELETE statements to CREATE TABLE AS SELECT statements
+// saving the affected rows into the tables under the target schema.
+// PostgreSQL cannot write across databases, so the backup tables live in the same database as the source tables.
+func TransformDMLToSelect(statement string, _ string, targetSchema string, tableSuffix string) ([]base.BackupStatement, error) {
+	statementInfoList, err := prepareTransformation(statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prepare transformation")
+	}
+
+	return generateBackupSQL(statementInfoList, targetSchema, tableSuffix)
+}
+
+func generateBackupSQL(statementInfoList []*backupStatementInfo, targetSchema string, tableSuffix string) ([]base.BackupStatement, error) {
+	var result []base.BackupStatement
+	offsetLength := 1
+	if len(statementInfoList) > 1 {
+		offsetLength = getOffsetLength(statementInfoList[len(statementInfoList)-1].offset)
+	}
+	for _, info := range statementInfoList {
+		// Truncate the source table name rather than the suffix, so the backup tables of the same task stay distinct.
+		suffix := fmt.Sprintf("_%0*d%s", offsetLength, info.offset, tableSuffix)
+		table, _ := common.TruncateString(info.table, maxTableNameLength-len(suffix))
+		targetTable := table + suffix
+
+		var buf strings.Builder
+		if _, err := fmt.Fprintf(&buf, "CREATE TABLE %s.%s AS ", QuoteIdentifier(targetSchema), QuoteIdentifier(targetTable)); err != nil {
+			return nil, errors.Wrap(err, "failed to write buffer")
+		}
+		if len(info.withClause) > 0 {
+			if _, err := fmt.Fprintf(&buf, "%s ", info.withClause); err != nil {
+				return nil, errors.Wrap(err, "failed to write buffer")
+			}
+		}
+		if len(info.fromList) == 0 {
+			if _, err := fmt.Fprintf(&buf, "SELECT %s.* FROM %s", QuoteIdentifier(info.alias), info.relationWithAlias); err != nil {
+				return nil, errors.Wrap(err, "failed to write buffer")
+			}
+			if len(info.whereClause) > 0 {
+				if _, err := fmt.Fprintf(&buf, " %s", info.whereClause); err != nil {
+					return nil, errors.Wrap(err, "failed to write buffer")
+				}
+			}
+		} else {
+			// A target row may join with several rows of the FROM/USING list,
+			// so we select the target rows by their physical location to avoid duplicates.
+			if _, err := fmt.Fprintf(
+				&buf,
+				"SELECT * FROM %s WHERE (tableoid, ctid) IN (SELECT %s.tableoid, %s.ctid FROM %s, %s",
+				info.relation,
+				QuoteIdentifier(info.alias),
+				QuoteIdentifier(info.alias),
+				info.relationWithAlias,
+				info.fromList,
+			); err != nil {
+				return nil, errors.Wrap(err, "failed to write buffer")
+			}
+			if len(info.whereClause) > 0 {
+				if _, err := fmt.Fprintf(&buf, " %s", info.whereClause); err != nil {
+					return nil, errors.Wrap(err, "failed to write buffer")
+				}
+			}
+			if _, err := buf.WriteString(")"); err != nil {
+				return nil, errors.Wrap(err, "failed to write buffer")
+			}
+		}
+		if _, err := buf.WriteString(";"); err != nil {
+			return nil, errors.Wrap(err, "failed to write buffer")
+		}
+		result = append(result, base.BackupStatement{
+			Statement:       buf.String(),
+			TableName:       targetTable,
+			SourceSchema:    info.schema,
+			SourceTableName: info.table,
+			OriginalLine:    info.line,
+		})
+	}
+	return result, nil
+}
+
+func prepareTransformation(statement string) ([]*backupStatementInfo, error) {
+	parseResult, err := ParsePostgreSQL(statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse statement")
+	}
+	root, ok := parseResult.Tree.(*parser.RootContext)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to RootContext")
+	}
+	if root.Stmtblock() == nil || root.Stmtblock().Stmtmulti() == nil {
+		return nil, nil
+	}
+
+	var result []*backupStatementInfo
+	for offset, stmt := range root.Stmtblock().Stmtmulti().AllStmt() {
+		var info *backupStatementInfo
+		var err error
+		switch {
+		case stmt.Updatestmt() != nil:
+			ctx := stmt.Updatestmt()
+			var fromList parser.IFrom_listContext
+			if ctx.From_clause() != nil {
+				fromList = ctx.From_clause().From_list()
+			}
+			info, err = extractBackupStatementInfo(parseResult.Tokens, ctx.Opt_with_clause(), ctx.Relation_expr_opt_alias(), fromList, ctx.Where_or_current_clause())
+		case stmt.Deletestmt() != nil:
+			ctx := stmt.Deletestmt()
+			var fromList parser.IFrom_listContext
+			if ctx.Using_clause() != nil {
+				fromList = ctx.Using_clause().From_list()
+			}
+			info, err = extractBackupStatementInfo(parseResult.Tokens, ctx.Opt_with_clause(), ctx.Relation_expr_opt_alias(), fromList, ctx.Where_or_current_clause())
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		info.offset = offset
+		info.line = stmt.GetStart().GetLine()
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+func extractBackupStatementInfo(
+	tokens antlr.TokenStream,
+	withClause parser.IOpt_with_clauseContext,
+	relation parser.IRelation_expr_opt_aliasContext,
+	fromList parser.IFrom_listContext,
+	whereClause parser.IWhere_or_current_clauseContext,
+) (*backupStatementInfo, error) {
+	if whereClause != nil && whereClause.CURRENT_P() != nil {
+		return nil, errors.New("WHERE CURRENT OF cursor is not supported")
+	}
+
+	schema, table, err := NormalizePostgreSQLQualifiedNameAsTableName(relation.Relation_expr().Qualified_name())
+	if err != nil {
+		return nil, err
+	}
+	if schema == "" {
+		schema = defaultSchema
+	}
+	info := &backupStatementInfo{
+		schema:            schema,
+		table:             table,
+		alias:             table,
+		relation:          tokens.GetTextFromRuleContext(relation.Relation_expr()),
+		relationWithAlias: tokens.GetTextFromRuleContext(relation),
+	}
+	if relation.Colid() != nil {
+		info.alias = NormalizePostgreSQLColid(relation.Colid())
+	}
+	if withClause != nil {
+		info.withClause = tokens.GetTextFromRuleContext(withClause)
+	}
+	if fromList != nil {
+		info.fromList = tokens.GetTextFromRuleContext(fromList)
+	}
+	if whereClause != nil {
+		info.whereClause = tokens.GetTextFromRuleContext(whereClause)
+	}
+	return info, nil
+}
+
+// GenerateRestoreSQL generates the statement writing the rows saved in the backup table back to the source table.
+// Rows deleted by the original statement are re-inserted, and rows updated by it are reset to the saved values.
+func GenerateRestoreSQL(backup base.BackupStatement, backupSchema string, table *storepb.TableMetadata) (string, error) {
+	if table == nil {
+		return "", errors.Errorf("table %q.%q not found", backup.SourceSchema, backup.SourceTableName)
+	}
+	var primaryKey []string
+	for _, index := range table.Indexes {
+		if index.Primary {
+			primaryKey = index.Expressions
+			break
+		}
+	}
+	if len(primaryKey) == 0 {
+		return "", errors.Errorf("table %q.%q has no primary key", backup.SourceSchema, backup.SourceTableName)
+	}
+	primaryKeyMap := make(map[string]bool)
+	for _, column := range primaryKey {
+		primaryKeyMap[column] = true
+	}
+
+	var columns, assignments []string
+	for _, column := range table.Columns {
+		columns = append(columns, QuoteIdentifier(column.Name))
+		if !primaryKeyMap[column.Name] {
+			assignments = append(assignments, fmt.Sprintf("%s = EXCLUDED.%s", QuoteIdentifier(column.Name), QuoteIdentifier(column.Name)))
+		}
+	}
+	var conflictColumns []string
+	for _, column := range primaryKey {
+		conflictColumns = append(conflictColumns, QuoteIdentifier(column))
+	}
+
+	var buf strings.Builder
+	columnList := strings.Join(columns, ", ")
+	if _, err := fmt.Fprintf(
+		&buf,
+		"INSERT INTO %s.%s (%s) SELECT %s FROM %s.%s ON CONFLICT (%s) DO ",
+		QuoteIdentifier(backup.SourceSchema),
+		QuoteIdentifier(backup.SourceTableName),
+		columnList,
+		columnList,
+		QuoteIdentifier(backupSchema),
+		QuoteIdentifier(backup.TableName),
+		strings.Join(conflictColumns, ", "),
+	); err != nil {
+		return "", errors.Wrap(err, "failed to write buffer")
+	}
+	if len(assignments) == 0 {
+		if _, err := buf.WriteString("NOTHING;"); err != nil {
+			return "", errors.Wrap(err, "failed to write buffer")
+		}
+	} else {
+		if _, err := fmt.Fprintf(&buf, "UPDATE SET %s;", strings.Join(assignments, ", ")); err != nil {
+			return "", errors.Wrap(err, "failed to write buffer")
+		}
+	}
+	return buf.String(), nil
+}
+
+func getOffsetLength(total int) int {
+	length := 1
+	for {
+		if total < 10 {
+			return length
+		}
+		total /= 10
+		length++
+	}
+}
+
+// QuoteIdentifier quotes the identifier, escaping the double quotes in it.
+func QuoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
@@ -0,0 +1,112 @@
+package pg
+
+import (
+	"io"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+type rollbackCase struct {
+	Input  string
+	Result []base.BackupStatement
+}
+
+func TestBackup(t *testing.T) {
+	tests := []rollbackCase{}
+
+	const (
+		record = false
+	)
+	var (
+		filepath = "test-data/test_backup.yaml"
+	)
+
+	a := require.New(t)
+	yamlFile, err := os.Open(filepath)
+	a.NoError(err)
+
+	byteValue, err := io.ReadAll(yamlFile)
+	a.NoError(yamlFile.Close())
+	a.NoError(err)
+	a.NoError(yaml.Unmarshal(byteValue, &tests))
+
+	for i, t := range tests {
+		result, err := TransformDMLToSelect(t.Input, "db", "backupSchema", "_rollback")
+		a.NoError(err)
+		sort.Slice(result, func(i, j int) bool {
+			if result[i].TableName == result[j].TableName {
+				return result[i].Statement < result[j].Statement
+			}
+			return result[i].TableName < result[j].TableName
+		})
+
+		if record {
+			tests[i].Result = result
+		} else {
+			a.Equal(t.Result, result, t.Input)
+		}
+	}
+	if record {
+		byteValue, err := yaml.Marshal(tests)
+		a.NoError(err)
+		err = os.WriteFile(filepath, byteValue, 0644)
+		a.NoError(err)
+	}
+}
+
+func TestBackupUnsupported(t *testing.T) {
+	a := require.New(t)
+	_, err := TransformDMLToSelect("DELETE FROM t WHERE CURRENT OF c;", "db", "backupSchema", "_rollback")
+	a.Error(err)
+}
+
+func TestGenerateRestoreSQL(t *testing.T) {
+	tests := []struct {
+		table *storepb.TableMetadata
+		want  string
+		err   bool
+	}{
+		{
+			table: &storepb.TableMetadata{
+				Name:    "t",
+				Columns: []*storepb.ColumnMetadata{{Name: "id"}, {Name: "name"}, {Name: "Score"}},
+				Indexes: []*storepb.IndexMetadata{{Name: "t_pkey", Expressions: []string{"id"}, Primary: true}},
+			},
+			want: `INSERT INTO "public"."t" ("id", "name", "Score") SELECT "id", "name", "Score" FROM "backupSchema"."t_0_rollback" ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "Score" = EXCLUDED."Score";`,
+		},
+		{
+			table: &storepb.TableMetadata{
+				Name:    "t",
+				Columns: []*storepb.ColumnMetadata{{Name: "a"}, {Name: "b"}},
+				Indexes: []*storepb.IndexMetadata{{Name: "t_pkey", Expressions: []string{"a", "b"}, Primary: true}},
+			},
+			want: `INSERT INTO "public"."t" ("a", "b") SELECT "a", "b" FROM "backupSchema"."t_0_rollback" ON CONFLICT ("a", "b") DO NOTHING;`,
+		},
+		{
+			table: &storepb.TableMetadata{
+				Name:    "t",
+				Columns: []*storepb.ColumnMetadata{{Name: "a"}},
+			},
+			err: true,
+		},
+	}
+
+	a := require.New(t)
+	backup := base.BackupStatement{TableName: "t_0_rollback", SourceSchema: "public", SourceTableName: "t"}
+	for _, test := range tests {
+		got, err := GenerateRestoreSQL(backup, "backupSchema", test.table)
+		if test.err {
+			a.Error(err)
+			continue
+		}
+		a.NoError(err)
+		a.Equal(test.want, got)
+	}
+}
@@ -0,0 +1,31 @@
+package pg
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/postgresql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterFormatFunc(storepb.Engine_POSTGRES, Format)
+	base.RegisterFormatFunc(storepb.Engine_REDSHIFT, Format)
+	base.RegisterFormatFunc(storepb.Engine_RISINGWAVE, Format)
+}
+
+// Format formats the statement with the keyword case and indentation options.
+func Format(statement string, options base.FormatOptions) (string, error) {
+	result, err := ParsePostgreSQL(statement)
+	if err != nil {
+		return "", err
+	}
+	identifiers := base.GetIdentifierTokenIndexes(
+		result.Tree,
+		parser.PostgreSQLParserRULE_colid,
+		parser.PostgreSQLParserRULE_type_function_name,
+		parser.PostgreSQLParserRULE_collabel,
+		parser.PostgreSQLParserRULE_identifier,
+	)
+	return base.FormatTokens(base.NewFormatTokens(statement, parser.NewPostgreSQLLexer(antlr.NewInputStream(statement)), identifiers, "_P"), options), nil
+}
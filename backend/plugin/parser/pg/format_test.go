@@ -0,0 +1,66 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		statement string
+		options   base.FormatOptions
+		want      string
+	}{
+		{
+			statement: "select a, name from t1 left join t2 on t1.id = t2.id where a between 1 and 3 or b in (select b from t3) order by a",
+			want: `SELECT a,
+  name
+FROM t1
+LEFT JOIN t2 ON t1.id = t2.id
+WHERE a BETWEEN 1 AND 3
+  OR b IN (
+    SELECT b
+    FROM t3
+  )
+ORDER BY a
+`,
+		},
+		{
+			statement: "CREATE TABLE t (id INT PRIMARY KEY, name TEXT NOT NULL);\n-- comment\nUPDATE t SET name = 'a' WHERE id = 1;",
+			options:   base.FormatOptions{KeywordCase: base.KeywordCaseLower, Indent: "\t"},
+			want:      "create table t (\n\tid int primary key,\n\tname TEXT not null\n);\n\n-- comment\nupdate t\nset name = 'a'\nwhere id = 1;\n",
+		},
+		{
+			// The comma after the line comment must not be commented out.
+			statement: "select a -- first\n, b -- second\nfrom t",
+			want: `SELECT a -- first
+  ,
+  b -- second
+FROM t
+`,
+		},
+		{
+			statement: "create table t (\n  id int, -- the id\n  name text -- the name\n  , age int\n);",
+			want: `CREATE TABLE t (
+  id INT, -- the id
+  name text -- the name
+  ,
+  age INT
+);
+`,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := Format(test.statement, test.options)
+		a.NoError(err)
+		a.Equal(test.want, got)
+	}
+
+	_, err := Format("select from where", base.FormatOptions{})
+	a.Error(err)
+}
@@ -0,0 +1,122 @@
+package plsql
+
+import (
+	"context"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/plsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterGetAffectedRows(storepb.Engine_ORACLE, GetAffectedRows)
+}
+
+// GetAffectedRows return the rows count affected by the sql.
+// The DML statements are estimated by getAffectedRowsByQuery with EXPLAIN PLAN, and the DDL statements by the table statistics.
+// The schema name passed to getTableDataSizeFunc is empty if the table is not qualified.
+func GetAffectedRows(ctx context.Context, stmt any, getAffectedRowsByQuery base.GetAffectedRowsCountByQueryFunc, getTableDataSizeFunc base.GetTableDataSizeFunc) (int64, error) {
+	tree, ok := stmt.(antlr.Tree)
+	if !ok {
+		return 0, errors.New("failed to convert stmt to oracle parse tree")
+	}
+	script, ok := tree.(*parser.Sql_scriptContext)
+	if !ok {
+		return 0, errors.New("failed to convert to Sql_scriptContext")
+	}
+
+	var total int64
+	for _, unit := range script.AllUnit_statement() {
+		affectedRows, err := getUnitStatementAffectedRows(ctx, unit, getAffectedRowsByQuery, getTableDataSizeFunc)
+		if err != nil {
+			return 0, err
+		}
+		total += affectedRows
+	}
+	return total, nil
+}
+
+func getUnitStatementAffectedRows(ctx context.Context, unit parser.IUnit_statementContext, getAffectedRowsByQuery base.GetAffectedRowsCountByQueryFunc, getTableDataSizeFunc base.GetTableDataSizeFunc) (int64, error) {
+	switch {
+	case unit.Data_manipulation_language_statements() != nil:
+		dml := unit.Data_manipulation_language_statements()
+		if dml.Insert_statement() != nil {
+			if single := dml.Insert_statement().Single_table_insert(); single != nil && single.Values_clause() != nil {
+				return 1, nil
+			}
+		} else if dml.Update_statement() == nil && dml.Delete_statement() == nil && dml.Merge_statement() == nil {
+			return 0, nil
+		}
+		if getAffectedRowsByQuery == nil {
+			return 0, nil
+		}
+		return getAffectedRowsByQuery(ctx, unit.GetParser().GetTokenStream().GetTextFromRuleContext(dml))
+	case unit.Alter_table() != nil:
+		return getTableDataSize(unit.Alter_table().Tableview_name(), getTableDataSizeFunc), nil
+	case unit.Drop_table() != nil:
+		return getTableDataSize(unit.Drop_table().Tableview_name(), getTableDataSizeFunc), nil
+	case unit.Truncate_table() != nil:
+		return getTableDataSize(unit.Truncate_table().Tableview_name(), getTableDataSizeFunc), nil
+	default:
+		return 0, nil
+	}
+}
+
+func getTableDataSize(ctx parser.ITableview_nameContext, getTableDataSizeFunc base.GetTableDataSizeFunc) int64 {
+	if ctx == nil || getTableDataSizeFunc == nil {
+		return 0
+	}
+	schemaName, tableName := NormalizeTableViewName("", ctx)
+	if tableName == "" {
+		return 0
+	}
+	return getTableDataSizeFunc(schemaName, tableName)
+}
+
+// GetStatementType return the type of statement.
+func GetStatementType(tree antlr.Tree) string {
+	script, ok := tree.(*parser.Sql_scriptContext)
+	if !ok {
+		return "UNKNOWN"
+	}
+	for _, unit := range script.AllUnit_statement() {
+		switch {
+		case unit.Create_table() != nil:
+			return "CREATE_TABLE"
+		case unit.Create_index() != nil:
+			return "CREATE_INDEX"
+		case unit.Create_view() != nil:
+			return "CREATE_VIEW"
+		case unit.Create_sequence() != nil:
+			return "CREATE_SEQUENCE"
+		case unit.Drop_table() != nil:
+			return "DROP_TABLE"
+		case unit.Drop_index() != nil:
+			return "DROP_INDEX"
+		case unit.Drop_sequence() != nil:
+			return "DROP_SEQUENCE"
+		case unit.Alter_table() != nil:
+			return "ALTER_TABLE"
+		case unit.Alter_sequence() != nil:
+			return "ALTER_SEQUENCE"
+		case unit.Truncate_table() != nil:
+			return "TRUNCATE"
+		case unit.Rename_object() != nil:
+			return "RENAME"
+		case unit.Data_manipulation_language_statements() != nil:
+			dml := unit.Data_manipulation_language_statements()
+			switch {
+			case dml.Insert_statement() != nil:
+				return "INSERT"
+			case dml.Update_statement() != nil:
+				return "UPDATE"
+			case dml.Delete_statement() != nil:
+				return "DELETE"
+			}
+		}
+	}
+	return "UNKNOWN"
+}
@@ -0,0 +1,25 @@
+package plsql
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/plsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterFormatFunc(storepb.Engine_ORACLE, Format)
+	base.RegisterFormatFunc(storepb.Engine_DM, Format)
+	base.RegisterFormatFunc(storepb.Engine_OCEANBASE_ORACLE, Format)
+}
+
+// Format formats the statement with the keyword case and indentation options.
+func Format(statement string, options base.FormatOptions) (string, error) {
+	tree, _, err := ParsePLSQL(statement)
+	if err != nil {
+		return "", err
+	}
+	identifiers := base.GetIdentifierTokenIndexes(tree, parser.PlSqlParserRULE_regular_id)
+	return base.FormatTokens(base.NewFormatTokens(statement, parser.NewPlSqlLexer(antlr.NewInputStream(statement)), identifiers), options), nil
+}
@@ -0,0 +1,58 @@
+package plsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		statement string
+		options   base.FormatOptions
+		want      string
+	}{
+		{
+			statement: "select id, name from t1 a left join t2 b on a.id = b.id where a.x = 1 or b.y between 1 and 2 order by id fetch first 10 rows only",
+			want: `SELECT id,
+  name
+FROM t1 a
+LEFT JOIN t2 b ON a.id = b.id
+WHERE a.x = 1
+  OR b.y BETWEEN 1 AND 2
+ORDER BY id
+FETCH FIRST 10 ROWS ONLY
+`,
+		},
+		{
+			statement: "delete from t where id in (select id from t2)",
+			want: `DELETE FROM t
+WHERE id IN (
+  SELECT id
+  FROM t2
+)
+`,
+		},
+		{
+			statement: "create table t (id number(10) -- the id\n, name varchar2(10))",
+			want: `CREATE TABLE t (
+  id NUMBER(10) -- the id
+  ,
+  name VARCHAR2(10)
+)
+`,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := Format(test.statement, test.options)
+		a.NoError(err)
+		a.Equal(test.want, got)
+	}
+
+	_, err := Format("select from where", base.FormatOptions{})
+	a.Error(err)
+}
@@ -0,0 +1,567 @@
+package snowflake
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/snowsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterSchemaDiffFunc(storepb.Engine_SNOWFLAKE, SchemaDiff)
+}
+
+const defaultSchema = "PUBLIC"
+
+// diffNode collects the migration statements by kind, String() emits them in the dependency order:
+// the views, functions and procedures are dropped first and created last,
+// and the foreign keys are dropped before and added after the tables they reference.
+// Snowflake has no indexes, so the clustering keys are diffed instead.
+type diffNode struct {
+	dropModule     []string
+	dropForeignKey []string
+	dropConstraint []string
+	dropColumn     []string
+	dropTable      []string
+	createTable    []string
+	addColumn      []string
+	alterColumn    []string
+	alterCluster   []string
+	addConstraint  []string
+	addForeignKey  []string
+	createModule   []string
+}
+
+func (diff *diffNode) String() (string, error) {
+	var buf strings.Builder
+	for _, list := range [][]string{
+		diff.dropModule,
+		diff.dropForeignKey,
+		diff.dropConstraint,
+		diff.dropColumn,
+		diff.dropTable,
+		diff.createTable,
+		diff.addColumn,
+		diff.alterColumn,
+		diff.alterCluster,
+		diff.addConstraint,
+		diff.addForeignKey,
+		diff.createModule,
+	} {
+		for _, stmt := range list {
+			if _, err := buf.WriteString(stmt); err != nil {
+				return "", err
+			}
+			if _, err := buf.WriteString("\n"); err != nil {
+				return "", err
+			}
+		}
+	}
+	return buf.String(), nil
+}
+
+// SchemaDiff computes the migration DDL from the old schema to the new schema.
+// The objects are matched by the normalized name, and the definitions are compared by text.
+func SchemaDiff(_ base.DiffContext, oldStmt, newStmt string) (string, error) {
+	oldSchemaInfo, err := buildSchemaInfo(oldStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for old statement")
+	}
+	newSchemaInfo, err := buildSchemaInfo(newStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for new statement")
+	}
+
+	diff := &diffNode{}
+
+	for _, oldModule := range sortModules(oldSchemaInfo.moduleMap, true /* reverse */) {
+		newModule, ok := newSchemaInfo.moduleMap[oldModule.id]
+		if ok && newModule.tp == oldModule.tp && getText(newModule.ctx) == getText(oldModule.ctx) {
+			continue
+		}
+		diff.dropModule = append(diff.dropModule, fmt.Sprintf("DROP %s %s%s;", oldModule.tp, quoteName(oldModule.schema, oldModule.name), oldModule.signature))
+	}
+	for _, newModule := range sortModules(newSchemaInfo.moduleMap, false /* reverse */) {
+		oldModule, ok := oldSchemaInfo.moduleMap[newModule.id]
+		if ok && newModule.tp == oldModule.tp && getText(newModule.ctx) == getText(oldModule.ctx) {
+			continue
+		}
+		diff.createModule = append(diff.createModule, getStatementText(newModule.ctx))
+	}
+
+	var createdTables []*tableInfo
+	for _, newTable := range sortTables(newSchemaInfo.tableMap) {
+		oldTable, ok := oldSchemaInfo.tableMap[newTable.id]
+		if !ok {
+			createdTables = append(createdTables, newTable)
+			continue
+		}
+		diff.diffTable(oldTable, newTable)
+	}
+	for _, table := range sortTablesByDependency(createdTables) {
+		diff.createTable = append(diff.createTable, getStatementText(table.createTable))
+	}
+
+	var droppedTables []*tableInfo
+	for _, oldTable := range sortTables(oldSchemaInfo.tableMap) {
+		if _, ok := newSchemaInfo.tableMap[oldTable.id]; !ok {
+			droppedTables = append(droppedTables, oldTable)
+		}
+	}
+	droppedTables = sortTablesByDependency(droppedTables)
+	for i := len(droppedTables) - 1; i >= 0; i-- {
+		table := droppedTables[i]
+		diff.dropTable = append(diff.dropTable, fmt.Sprintf("DROP TABLE %s;", quoteName(table.schema, table.name)))
+	}
+
+	return diff.String()
+}
+
+func (diff *diffNode) diffTable(oldTable, newTable *tableInfo) {
+	tableName := quoteName(newTable.schema, newTable.name)
+
+	var dropColumns []string
+	for _, oldColumn := range oldTable.columns {
+		id := getColumnID(oldColumn)
+		if _, ok := newTable.columnMap[id]; !ok {
+			dropColumns = append(dropColumns, quoteIdentifier(id))
+		}
+	}
+	if len(dropColumns) > 0 {
+		diff.dropColumn = append(diff.dropColumn, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", tableName, strings.Join(dropColumns, ", ")))
+	}
+
+	var addColumns []string
+	for _, newColumn := range newTable.columns {
+		oldColumn, ok := oldTable.columnMap[getColumnID(newColumn)]
+		if !ok {
+			addColumns = append(addColumns, getText(newColumn))
+			continue
+		}
+		if getText(oldColumn) == getText(newColumn) {
+			continue
+		}
+		diff.diffColumn(tableName, oldColumn, newColumn)
+	}
+	if len(addColumns) > 0 {
+		diff.addColumn = append(diff.addColumn, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", tableName, strings.Join(addColumns, ", ")))
+	}
+
+	oldConstraintMap := buildConstraintMap(oldTable)
+	newConstraintMap := buildConstraintMap(newTable)
+	for _, oldConstraint := range oldTable.constraints {
+		id := getConstraintID(oldConstraint)
+		if id == "" {
+			continue
+		}
+		if newConstraint, ok := newConstraintMap[id]; ok && getText(newConstraint) == getText(oldConstraint) {
+			continue
+		}
+		var stmt string
+		if oldConstraint.Id_() != nil {
+			stmt = fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", tableName, quoteIdentifier(id))
+		} else {
+			stmt = fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", tableName)
+		}
+		if oldConstraint.REFERENCES() != nil {
+			diff.dropForeignKey = append(diff.dropForeignKey, stmt)
+		} else {
+			diff.dropConstraint = append(diff.dropConstraint, stmt)
+		}
+	}
+	for _, newConstraint := range newTable.constraints {
+		id := getConstraintID(newConstraint)
+		if id == "" {
+			continue
+		}
+		if oldConstraint, ok := oldConstraintMap[id]; ok && getText(newConstraint) == getText(oldConstraint) {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD %s;", tableName, getText(newConstraint))
+		if newConstraint.REFERENCES() != nil {
+			diff.addForeignKey = append(diff.addForeignKey, stmt)
+		} else {
+			diff.addConstraint = append(diff.addConstraint, stmt)
+		}
+	}
+
+	oldClusterBy, newClusterBy := "", ""
+	if oldTable.createTable.Cluster_by() != nil {
+		oldClusterBy = getText(oldTable.createTable.Cluster_by())
+	}
+	if newTable.createTable.Cluster_by() != nil {
+		newClusterBy = getText(newTable.createTable.Cluster_by())
+	}
+	if oldClusterBy != newClusterBy {
+		if newClusterBy == "" {
+			diff.alterCluster = append(diff.alterCluster, fmt.Sprintf("ALTER TABLE %s DROP CLUSTERING KEY;", tableName))
+		} else {
+			diff.alterCluster = append(diff.alterCluster, fmt.Sprintf("ALTER TABLE %s %s;", tableName, newClusterBy))
+		}
+	}
+}
+
+// diffColumn generates the ALTER COLUMN statements for the data type, nullability, default value and comment.
+// Snowflake only allows a few changes on the existing columns, the other changes are rejected by the server.
+func (diff *diffNode) diffColumn(tableName string, oldColumn, newColumn parser.IFull_col_declContext) {
+	columnName := quoteIdentifier(getColumnID(newColumn))
+	var actions []string
+
+	oldType, newType := getText(oldColumn.Col_decl().Data_type()), getText(newColumn.Col_decl().Data_type())
+	if oldType != newType {
+		actions = append(actions, fmt.Sprintf("SET DATA TYPE %s", newType))
+	}
+
+	oldNotNull, newNotNull := isNotNull(oldColumn), isNotNull(newColumn)
+	if oldNotNull != newNotNull {
+		if newNotNull {
+			actions = append(actions, "SET NOT NULL")
+		} else {
+			actions = append(actions, "DROP NOT NULL")
+		}
+	}
+
+	oldDefault, newDefault := getDefault(oldColumn), getDefault(newColumn)
+	if oldDefault != newDefault {
+		if newDefault == "" {
+			actions = append(actions, "DROP DEFAULT")
+		} else {
+			actions = append(actions, fmt.Sprintf("SET %s", newDefault))
+		}
+	}
+
+	oldComment, newComment := "", ""
+	if oldColumn.String_() != nil {
+		oldComment = getText(oldColumn.String_())
+	}
+	if newColumn.String_() != nil {
+		newComment = getText(newColumn.String_())
+	}
+	if oldComment != newComment {
+		if newComment == "" {
+			actions = append(actions, "UNSET COMMENT")
+		} else {
+			actions = append(actions, fmt.Sprintf("COMMENT %s", newComment))
+		}
+	}
+
+	for _, action := range actions {
+		diff.alterColumn = append(diff.alterColumn, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", tableName, columnName, action))
+	}
+}
+
+func isNotNull(column parser.IFull_col_declContext) bool {
+	for _, item := range column.AllNull_not_null() {
+		if item.NOT() != nil {
+			return true
+		}
+	}
+	for _, constraint := range column.AllInline_constraint() {
+		if constraint.PRIMARY() != nil {
+			return true
+		}
+		if constraint.Null_not_null() != nil && constraint.Null_not_null().NOT() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func getDefault(column parser.IFull_col_declContext) string {
+	for _, item := range column.AllDefault_value() {
+		return getText(item)
+	}
+	return ""
+}
+
+func getColumnID(column parser.IFull_col_declContext) string {
+	return NormalizeSnowSQLObjectNamePart(column.Col_decl().Column_name().Id_())
+}
+
+// getConstraintID returns the constraint name, or "PRIMARY KEY" for the unnamed primary key as a table has at most one.
+// The other unnamed constraints are not diffed.
+func getConstraintID(constraint parser.IOut_of_line_constraintContext) string {
+	if constraint.Id_() != nil {
+		return NormalizeSnowSQLObjectNamePart(constraint.Id_())
+	}
+	if constraint.PRIMARY() != nil {
+		return "PRIMARY KEY"
+	}
+	return ""
+}
+
+func buildConstraintMap(table *tableInfo) map[string]parser.IOut_of_line_constraintContext {
+	constraintMap := make(map[string]parser.IOut_of_line_constraintContext)
+	for _, constraint := range table.constraints {
+		if id := getConstraintID(constraint); id != "" {
+			constraintMap[id] = constraint
+		}
+	}
+	return constraintMap
+}
+
+// sortTablesByDependency sorts the tables so that the referenced tables come before the referencing ones.
+// The tables not depending on each other keep their original order.
+func sortTablesByDependency(tables []*tableInfo) []*tableInfo {
+	tableMap := make(map[string]*tableInfo)
+	for _, table := range tables {
+		tableMap[table.id] = table
+	}
+	visited := make(map[string]bool)
+	var result []*tableInfo
+	var visit func(table *tableInfo)
+	visit = func(table *tableInfo) {
+		if visited[table.id] {
+			return
+		}
+		visited[table.id] = true
+		for _, reference := range table.references {
+			if referenced, ok := tableMap[reference]; ok {
+				visit(referenced)
+			}
+		}
+		result = append(result, table)
+	}
+	for _, table := range tables {
+		visit(table)
+	}
+	return result
+}
+
+func sortTables(m map[string]*tableInfo) []*tableInfo {
+	var tables []*tableInfo
+	for _, table := range m {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].pos < tables[j].pos
+	})
+	return tables
+}
+
+// sortModules sorts the modules in the definition order, which is the dependency order in the dump and SDL files.
+func sortModules(m map[string]*moduleInfo, reverse bool) []*moduleInfo {
+	var modules []*moduleInfo
+	for _, module := range m {
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if reverse {
+			return modules[i].pos > modules[j].pos
+		}
+		return modules[i].pos < modules[j].pos
+	})
+	return modules
+}
+
+// ruleContext is the parser rule context which can access the token stream.
+type ruleContext interface {
+	antlr.ParserRuleContext
+	GetParser() antlr.Parser
+}
+
+func getText(ctx ruleContext) string {
+	return ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx)
+}
+
+// getStatementText returns the text of the statement terminated by the semicolon.
+func getStatementText(ctx ruleContext) string {
+	return strings.TrimRight(getText(ctx), " \t\n;") + ";"
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
+func quoteName(schema, name string) string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(schema), quoteIdentifier(name))
+}
+
+func buildSchemaInfo(statement string) (*schemaInfo, error) {
+	parseResult, err := ParseSnowSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &buildSchemaInfoListener{
+		schemaInfo: &schemaInfo{
+			tableMap:  make(map[string]*tableInfo),
+			moduleMap: make(map[string]*moduleInfo),
+		},
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, parseResult.Tree)
+	if listener.err != nil {
+		return nil, listener.err
+	}
+	return listener.schemaInfo, nil
+}
+
+type buildSchemaInfoListener struct {
+	*parser.BaseSnowflakeParserListener
+
+	schemaInfo *schemaInfo
+	err        error
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *buildSchemaInfoListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+	schema, table := normalizeObjectName(ctx.Object_name())
+	id := getObjectID(schema, table)
+	if _, ok := l.schemaInfo.tableMap[id]; ok {
+		l.err = errors.Errorf("duplicate table %s.%s", schema, table)
+		return
+	}
+	info := &tableInfo{
+		pos:         len(l.schemaInfo.tableMap),
+		id:          id,
+		schema:      schema,
+		name:        table,
+		createTable: ctx,
+		columnMap:   make(map[string]parser.IFull_col_declContext),
+	}
+	for _, item := range ctx.Column_decl_item_list().AllColumn_decl_item() {
+		switch {
+		case item.Full_col_decl() != nil:
+			column := item.Full_col_decl()
+			info.columns = append(info.columns, column)
+			info.columnMap[getColumnID(column)] = column
+			for _, constraint := range column.AllInline_constraint() {
+				if constraint.REFERENCES() != nil {
+					info.references = append(info.references, getReferencedTableID(constraint.Object_name(), schema))
+				}
+			}
+		case item.Out_of_line_constraint() != nil:
+			constraint := item.Out_of_line_constraint()
+			info.constraints = append(info.constraints, constraint)
+			if constraint.REFERENCES() != nil {
+				info.references = append(info.references, getReferencedTableID(constraint.Object_name(), schema))
+			}
+		}
+	}
+	l.schemaInfo.tableMap[id] = info
+}
+
+// EnterCreate_view is called when production create_view is entered.
+func (l *buildSchemaInfoListener) EnterCreate_view(ctx *parser.Create_viewContext) {
+	if l.err != nil {
+		return
+	}
+	schema, name := normalizeObjectName(ctx.Object_name())
+	l.addModule("VIEW", schema, name, "", ctx)
+}
+
+// EnterCreate_materialized_view is called when production create_materialized_view is entered.
+func (l *buildSchemaInfoListener) EnterCreate_materialized_view(ctx *parser.Create_materialized_viewContext) {
+	if l.err != nil {
+		return
+	}
+	schema, name := normalizeObjectName(ctx.Object_name())
+	l.addModule("MATERIALIZED VIEW", schema, name, "", ctx)
+}
+
+// EnterCreate_function is called when production create_function is entered.
+func (l *buildSchemaInfoListener) EnterCreate_function(ctx *parser.Create_functionContext) {
+	if l.err != nil {
+		return
+	}
+	schema, name := normalizeObjectName(ctx.Object_name())
+	l.addModule("FUNCTION", schema, name, getSignature(ctx.AllArg_decl()), ctx)
+}
+
+// EnterCreate_procedure is called when production create_procedure is entered.
+func (l *buildSchemaInfoListener) EnterCreate_procedure(ctx *parser.Create_procedureContext) {
+	if l.err != nil {
+		return
+	}
+	schema, name := normalizeObjectName(ctx.Object_name())
+	l.addModule("PROCEDURE", schema, name, getSignature(ctx.AllArg_decl()), ctx)
+}
+
+func (l *buildSchemaInfoListener) addModule(tp string, schema, name, signature string, ctx ruleContext) {
+	// The functions and procedures are overloaded by the argument types.
+	id := fmt.Sprintf("%s%s", getObjectID(schema, name), signature)
+	if tp == "FUNCTION" || tp == "PROCEDURE" {
+		id = fmt.Sprintf("%s %s", tp, id)
+	}
+	if _, ok := l.schemaInfo.moduleMap[id]; ok {
+		l.err = errors.Errorf("duplicate %s %s.%s%s", strings.ToLower(tp), schema, name, signature)
+		return
+	}
+	l.schemaInfo.moduleMap[id] = &moduleInfo{
+		pos:       len(l.schemaInfo.moduleMap),
+		id:        id,
+		tp:        tp,
+		schema:    schema,
+		name:      name,
+		signature: signature,
+		ctx:       ctx,
+	}
+}
+
+// getSignature returns the argument types used to identify the overloaded functions and procedures, e.g. "(NUMBER, VARCHAR)".
+func getSignature(args []parser.IArg_declContext) string {
+	var types []string
+	for _, arg := range args {
+		types = append(types, strings.ToUpper(getText(arg.Arg_data_type())))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(types, ", "))
+}
+
+func normalizeObjectName(ctx parser.IObject_nameContext) (string, string) {
+	schema := defaultSchema
+	if ctx.GetS() != nil {
+		schema = NormalizeSnowSQLObjectNamePart(ctx.GetS())
+	}
+	return schema, NormalizeSnowSQLObjectNamePart(ctx.GetO())
+}
+
+func getReferencedTableID(ctx parser.IObject_nameContext, currentSchema string) string {
+	schema, table := normalizeObjectName(ctx)
+	if ctx.GetS() == nil {
+		schema = currentSchema
+	}
+	return getObjectID(schema, table)
+}
+
+func getObjectID(schema, name string) string {
+	return fmt.Sprintf("%s.%s", schema, name)
+}
+
+type schemaInfo struct {
+	tableMap  map[string]*tableInfo
+	moduleMap map[string]*moduleInfo
+}
+
+type tableInfo struct {
+	pos         int
+	id          string
+	schema      string
+	name        string
+	createTable parser.ICreate_tableContext
+	columns     []parser.IFull_col_declContext
+	columnMap   map[string]parser.IFull_col_declContext
+	constraints []parser.IOut_of_line_constraintContext
+	// references is the list of the table ids referenced by the foreign keys.
+	references []string
+}
+
+type moduleInfo struct {
+	pos    int
+	id     string
+	tp     string
+	schema string
+	name   string
+	// signature is the argument types of the functions and procedures, or empty.
+	signature string
+	ctx       ruleContext
+}
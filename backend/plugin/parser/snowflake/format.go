@@ -0,0 +1,24 @@
+package snowflake
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterFormatFunc(storepb.Engine_SNOWFLAKE, Format)
+}
+
+// Format formats the statement with the keyword case and indentation options.
+func Format(statement string, options base.FormatOptions) (string, error) {
+	result, err := ParseSnowSQL(statement)
+	if err != nil {
+		return "", err
+	}
+	// The parsed statement ends with an extra semicolon, which does not change the indexes of the preceding tokens.
+	identifiers := base.GetIdentifierTokenIndexes(result.Tree, parser.SnowflakeParserRULE_id_)
+	return base.FormatTokens(base.NewFormatTokens(statement, parser.NewSnowflakeLexer(antlr.NewInputStream(statement)), identifiers), options), nil
+}
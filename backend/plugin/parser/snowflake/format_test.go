@@ -0,0 +1,56 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		statement string
+		options   base.FormatOptions
+		want      string
+	}{
+		{
+			statement: "select id, name from db.s.t1 join t2 on t1.id = t2.id where a = 1 qualify row_number() over (partition by id order by ts) = 1",
+			want: `SELECT id,
+  name
+FROM db.s.t1
+JOIN t2 ON t1.id = t2.id
+WHERE a = 1
+QUALIFY ROW_NUMBER() OVER (PARTITION BY id ORDER BY ts) = 1
+`,
+		},
+		{
+			statement: "update t set name = s.name from s where t.id = s.id and s.ts > current_date();",
+			want: `UPDATE t
+SET name = s.name
+FROM s
+WHERE t.id = s.id
+  AND s.ts > current_date();
+`,
+		},
+		{
+			statement: "create table t (id int -- the id\n, name string);",
+			want: `CREATE TABLE t (
+  id INT -- the id
+  ,
+  name string
+);
+`,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := Format(test.statement, test.options)
+		a.NoError(err)
+		a.Equal(test.want, got)
+	}
+
+	_, err := Format("select from where", base.FormatOptions{})
+	a.Error(err)
+}
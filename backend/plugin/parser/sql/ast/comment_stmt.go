@@ -6,6 +6,7 @@ const (
 	ObjectTypeUndefined ObjectType = iota
 	ObjectTypeTable
 	ObjectTypeColumn
+	ObjectTypeType
 )
 
 // CommentStmt is the struct for comment statement.
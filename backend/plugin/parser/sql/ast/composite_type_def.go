@@ -0,0 +1,19 @@
+package ast
+
+// CompositeTypeDef is the struct for composite types.
+type CompositeTypeDef struct {
+	userDefinedType
+
+	Name          *TypeNameDef
+	AttributeList []*ColumnDef
+}
+
+// EquivalentType implements the DataType interface.
+func (c *CompositeTypeDef) EquivalentType(tp string) bool {
+	return tp == c.Name.Name
+}
+
+// TypeName implements the UserDefinedType interface.
+func (c CompositeTypeDef) TypeName() *TypeNameDef {
+	return c.Name
+}
@@ -691,6 +691,21 @@ func convert(node *pgquery.Node, statement base.SingleSQL) (res ast.Node, err er
 			default:
 				return nil, errors.Errorf("expect to get a list node but got %T", node)
 			}
+		case pgquery.ObjectType_OBJECT_TYPE:
+			commentStmt.Type = ast.ObjectTypeType
+			switch node := in.CommentStmt.Object.Node.(type) {
+			case *pgquery.Node_TypeName:
+				schema, name, err := convertObjectName(node.TypeName.Names)
+				if err != nil {
+					return nil, err
+				}
+				commentStmt.Object = &ast.TypeNameDef{
+					Schema: schema,
+					Name:   name,
+				}
+			default:
+				return nil, errors.Errorf("expect to get a type name node but got %T", node)
+			}
 		}
 
 		return &commentStmt, nil
@@ -795,6 +810,29 @@ func convert(node *pgquery.Node, statement base.SingleSQL) (res ast.Node, err er
 		}
 
 		return &ast.CreateTypeStmt{Type: enumTypeDef}, nil
+	case *pgquery.Node_CompositeTypeStmt:
+		if in.CompositeTypeStmt.Typevar == nil {
+			return nil, NewConvertErrorf("CompositeTypeStmt.Typevar is nil")
+		}
+		compositeTypeDef := &ast.CompositeTypeDef{
+			Name: &ast.TypeNameDef{
+				Schema: in.CompositeTypeStmt.Typevar.Schemaname,
+				Name:   in.CompositeTypeStmt.Typevar.Relname,
+			},
+		}
+		for _, item := range in.CompositeTypeStmt.Coldeflist {
+			columnDef, ok := item.Node.(*pgquery.Node_ColumnDef)
+			if !ok {
+				return nil, NewConvertErrorf("expected ColumnDef but found %t", item.Node)
+			}
+			attribute, err := convertColumnDef(columnDef)
+			if err != nil {
+				return nil, err
+			}
+			compositeTypeDef.AttributeList = append(compositeTypeDef.AttributeList, attribute)
+		}
+
+		return &ast.CreateTypeStmt{Type: compositeTypeDef}, nil
 	case *pgquery.Node_AlterEnumStmt:
 		if in.AlterEnumStmt.OldVal == "" {
 			schema, name, err := convertObjectName(in.AlterEnumStmt.TypeName)
@@ -0,0 +1,157 @@
+package tsql
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/tsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterGetAffectedRows(storepb.Engine_MSSQL, GetAffectedRows)
+}
+
+// GetAffectedRows return the rows count affected by the sql.
+// The DML statements are estimated by getAffectedRowsByQuery with SHOWPLAN_XML, and the DDL statements by the table statistics.
+func GetAffectedRows(ctx context.Context, stmt any, getAffectedRowsByQuery base.GetAffectedRowsCountByQueryFunc, getTableDataSizeFunc base.GetTableDataSizeFunc) (int64, error) {
+	parseResult, ok := stmt.(*ParseResult)
+	if !ok {
+		return 0, errors.New("failed to convert stmt to tsql parse result")
+	}
+	file, ok := parseResult.Tree.(*parser.Tsql_fileContext)
+	if !ok {
+		return 0, errors.New("failed to convert to Tsql_fileContext")
+	}
+
+	var total int64
+	for _, batch := range file.AllBatch() {
+		for _, clauses := range batch.AllSql_clauses() {
+			affectedRows, err := getSQLClausesAffectedRows(ctx, parseResult, clauses, getAffectedRowsByQuery, getTableDataSizeFunc)
+			if err != nil {
+				return 0, err
+			}
+			total += affectedRows
+		}
+	}
+	return total, nil
+}
+
+func getSQLClausesAffectedRows(ctx context.Context, parseResult *ParseResult, clauses parser.ISql_clausesContext, getAffectedRowsByQuery base.GetAffectedRowsCountByQueryFunc, getTableDataSizeFunc base.GetTableDataSizeFunc) (int64, error) {
+	switch {
+	case clauses.Dml_clause() != nil:
+		dml := clauses.Dml_clause()
+		if dml.Select_statement_standalone() != nil {
+			return 0, nil
+		}
+		if dml.Insert_statement() != nil {
+			if values := getInsertTableValueConstructor(dml.Insert_statement()); values != nil {
+				return int64(len(values.GetExps())), nil
+			}
+		}
+		if getAffectedRowsByQuery == nil {
+			return 0, nil
+		}
+		return getAffectedRowsByQuery(ctx, parseResult.Tokens.GetTextFromRuleContext(dml))
+	case clauses.Ddl_clause() != nil:
+		ddl := clauses.Ddl_clause()
+		switch {
+		case ddl.Alter_table() != nil:
+			return getTableDataSize(ddl.Alter_table().Table_name(0), getTableDataSizeFunc), nil
+		case ddl.Drop_table() != nil:
+			var total int64
+			for _, table := range ddl.Drop_table().AllTable_name() {
+				total += getTableDataSize(table, getTableDataSizeFunc)
+			}
+			return total, nil
+		case ddl.Truncate_table() != nil:
+			return getTableDataSize(ddl.Truncate_table().Table_name(), getTableDataSizeFunc), nil
+		}
+	}
+	return 0, nil
+}
+
+// getInsertTableValueConstructor returns the VALUES clause if the INSERT statement inserts constant rows only.
+func getInsertTableValueConstructor(ctx parser.IInsert_statementContext) parser.ITable_value_constructorContext {
+	value := ctx.Insert_statement_value()
+	if value == nil {
+		return nil
+	}
+	if value.Table_value_constructor() != nil {
+		return value.Table_value_constructor()
+	}
+	if value.Derived_table() != nil {
+		return value.Derived_table().Table_value_constructor()
+	}
+	return nil
+}
+
+func getTableDataSize(ctx parser.ITable_nameContext, getTableDataSizeFunc base.GetTableDataSizeFunc) int64 {
+	if ctx == nil || getTableDataSizeFunc == nil {
+		return 0
+	}
+	schemaName := defaultSchema
+	if ctx.GetSchema() != nil {
+		if schema, _ := NormalizeTSQLIdentifier(ctx.GetSchema()); schema != "" {
+			schemaName = schema
+		}
+	}
+	tableName, _ := NormalizeTSQLIdentifier(ctx.GetTable())
+	if tableName == "" {
+		return 0
+	}
+	return getTableDataSizeFunc(schemaName, tableName)
+}
+
+// GetStatementType return the type of statement.
+func GetStatementType(stmt *ParseResult) string {
+	file, ok := stmt.Tree.(*parser.Tsql_fileContext)
+	if !ok {
+		return "UNKNOWN"
+	}
+	for _, batch := range file.AllBatch() {
+		if batch.Batch_level_statement() != nil && batch.Batch_level_statement().Create_view() != nil {
+			return "CREATE_VIEW"
+		}
+		for _, clauses := range batch.AllSql_clauses() {
+			if dml := clauses.Dml_clause(); dml != nil {
+				switch {
+				case dml.Insert_statement() != nil:
+					return "INSERT"
+				case dml.Update_statement() != nil:
+					return "UPDATE"
+				case dml.Delete_statement() != nil:
+					return "DELETE"
+				}
+			}
+			if ddl := clauses.Ddl_clause(); ddl != nil {
+				switch {
+				case ddl.Create_database() != nil:
+					return "CREATE_DATABASE"
+				case ddl.Create_schema() != nil:
+					return "CREATE_SCHEMA"
+				case ddl.Create_table() != nil:
+					return "CREATE_TABLE"
+				case ddl.Create_index() != nil:
+					return "CREATE_INDEX"
+				case ddl.Drop_database() != nil:
+					return "DROP_DATABASE"
+				case ddl.Drop_schema() != nil:
+					return "DROP_SCHEMA"
+				case ddl.Drop_table() != nil:
+					return "DROP_TABLE"
+				case ddl.Drop_index() != nil:
+					return "DROP_INDEX"
+				case ddl.Alter_table() != nil:
+					return "ALTER_TABLE"
+				case ddl.Truncate_table() != nil:
+					return "TRUNCATE"
+				}
+			}
+		}
+	}
+	return "UNKNOWN"
+}
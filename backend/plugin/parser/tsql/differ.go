@@ -0,0 +1,616 @@
+package tsql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/tsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterSchemaDiffFunc(storepb.Engine_MSSQL, SchemaDiff)
+}
+
+// diffNode collects the migration statements by kind, String() emits them in the dependency order:
+// the modules (views, functions and procedures) depending on the tables are dropped first and created last,
+// and the foreign keys are dropped before and added after the tables they reference.
+type diffNode struct {
+	dropModule     []string
+	dropForeignKey []string
+	dropConstraint []string
+	dropIndex      []string
+	dropColumn     []string
+	dropTable      []string
+	createTable    []string
+	addColumn      []string
+	alterColumn    []string
+	addConstraint  []string
+	addForeignKey  []string
+	addIndex       []string
+	createModule   []string
+
+	// unnamedDefaultCount numbers the variables holding the system generated default constraint names,
+	// so they do not collide in the same batch.
+	unnamedDefaultCount int
+}
+
+func (diff *diffNode) String() (string, error) {
+	var buf strings.Builder
+	for _, list := range [][]string{
+		diff.dropModule,
+		diff.dropForeignKey,
+		diff.dropConstraint,
+		diff.dropIndex,
+		diff.dropColumn,
+		diff.dropTable,
+		diff.createTable,
+		diff.addColumn,
+		diff.alterColumn,
+		diff.addConstraint,
+		diff.addForeignKey,
+		diff.addIndex,
+		diff.createModule,
+	} {
+		for _, stmt := range list {
+			if _, err := buf.WriteString(stmt); err != nil {
+				return "", err
+			}
+			if _, err := buf.WriteString("\n"); err != nil {
+				return "", err
+			}
+		}
+	}
+	return buf.String(), nil
+}
+
+// SchemaDiff computes the migration DDL from the old schema to the new schema.
+// The objects are matched by the case-insensitive name, and the definitions are compared by text.
+func SchemaDiff(_ base.DiffContext, oldStmt, newStmt string) (string, error) {
+	oldSchemaInfo, err := buildSchemaInfo(oldStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for old statement")
+	}
+	newSchemaInfo, err := buildSchemaInfo(newStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for new statement")
+	}
+
+	diff := &diffNode{}
+
+	// Modules are compared first because a changed module has to be dropped before the tables change.
+	for _, oldModule := range sortModules(oldSchemaInfo.moduleMap, true /* reverse */) {
+		newModule, ok := newSchemaInfo.moduleMap[oldModule.id]
+		if ok && newModule.tp == oldModule.tp && getText(newModule.ctx) == getText(oldModule.ctx) {
+			continue
+		}
+		diff.dropModule = append(diff.dropModule, fmt.Sprintf("DROP %s %s;", oldModule.tp, quoteName(oldModule.schema, oldModule.name)))
+	}
+	for _, newModule := range sortModules(newSchemaInfo.moduleMap, false /* reverse */) {
+		oldModule, ok := oldSchemaInfo.moduleMap[newModule.id]
+		if ok && newModule.tp == oldModule.tp && getText(newModule.ctx) == getText(oldModule.ctx) {
+			continue
+		}
+		// CREATE VIEW, FUNCTION and PROCEDURE must be the only statement in the batch.
+		diff.createModule = append(diff.createModule, fmt.Sprintf("%s\nGO", strings.TrimRight(getText(newModule.ctx), " \t\n;")))
+	}
+
+	var createdTables []*tableInfo
+	for _, newTable := range sortTables(newSchemaInfo.tableMap) {
+		oldTable, ok := oldSchemaInfo.tableMap[newTable.id]
+		if !ok {
+			createdTables = append(createdTables, newTable)
+			continue
+		}
+		diff.diffTable(oldTable, newTable)
+	}
+	for _, table := range sortTablesByDependency(createdTables) {
+		diff.createTable = append(diff.createTable, getStatementText(table.createTable))
+	}
+
+	var droppedTables []*tableInfo
+	for _, oldTable := range sortTables(oldSchemaInfo.tableMap) {
+		if _, ok := newSchemaInfo.tableMap[oldTable.id]; !ok {
+			droppedTables = append(droppedTables, oldTable)
+		}
+	}
+	droppedTables = sortTablesByDependency(droppedTables)
+	for i := len(droppedTables) - 1; i >= 0; i-- {
+		table := droppedTables[i]
+		// The foreign keys referencing the other dropped tables must be dropped first.
+		for _, constraint := range table.constraints {
+			if constraint.Foreign_key_options() != nil && constraint.GetConstraint() != nil {
+				name, _ := NormalizeTSQLIdentifier(constraint.GetConstraint())
+				diff.dropForeignKey = append(diff.dropForeignKey, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT [%s];", quoteName(table.schema, table.name), name))
+			}
+		}
+		diff.dropTable = append(diff.dropTable, fmt.Sprintf("DROP TABLE %s;", quoteName(table.schema, table.name)))
+	}
+
+	for _, newIndex := range sortIndexes(newSchemaInfo.indexMap) {
+		oldIndex, ok := oldSchemaInfo.indexMap[newIndex.id]
+		if ok {
+			if getText(oldIndex.createIndex) == getText(newIndex.createIndex) {
+				continue
+			}
+			diff.dropIndex = append(diff.dropIndex, fmt.Sprintf("DROP INDEX [%s] ON %s;", oldIndex.name, quoteName(oldIndex.schema, oldIndex.table)))
+		}
+		diff.addIndex = append(diff.addIndex, getStatementText(newIndex.createIndex))
+	}
+	for _, oldIndex := range sortIndexes(oldSchemaInfo.indexMap) {
+		if _, ok := newSchemaInfo.indexMap[oldIndex.id]; ok {
+			continue
+		}
+		if _, ok := newSchemaInfo.tableMap[oldIndex.tableID]; !ok {
+			// The index is dropped along with the table.
+			continue
+		}
+		diff.dropIndex = append(diff.dropIndex, fmt.Sprintf("DROP INDEX [%s] ON %s;", oldIndex.name, quoteName(oldIndex.schema, oldIndex.table)))
+	}
+
+	return diff.String()
+}
+
+func (diff *diffNode) diffTable(oldTable, newTable *tableInfo) {
+	tableName := quoteName(newTable.schema, newTable.name)
+
+	var dropColumns []string
+	for _, oldColumn := range oldTable.columns {
+		id := getColumnID(oldColumn)
+		if _, ok := newTable.columnMap[id]; !ok {
+			name, _ := NormalizeTSQLIdentifier(oldColumn.Id_())
+			dropColumns = append(dropColumns, fmt.Sprintf("[%s]", name))
+		}
+	}
+	if len(dropColumns) > 0 {
+		diff.dropColumn = append(diff.dropColumn, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", tableName, strings.Join(dropColumns, ", ")))
+	}
+
+	var addColumns []string
+	for _, newColumn := range newTable.columns {
+		oldColumn, ok := oldTable.columnMap[getColumnID(newColumn)]
+		if !ok {
+			addColumns = append(addColumns, getText(newColumn))
+			continue
+		}
+		if getText(oldColumn) == getText(newColumn) {
+			continue
+		}
+		diff.diffColumn(tableName, oldColumn, newColumn)
+	}
+	if len(addColumns) > 0 {
+		diff.addColumn = append(diff.addColumn, fmt.Sprintf("ALTER TABLE %s ADD %s;", tableName, strings.Join(addColumns, ", ")))
+	}
+
+	oldConstraintMap := buildConstraintMap(oldTable)
+	newConstraintMap := buildConstraintMap(newTable)
+	// Unnamed constraints get generated names in SQL Server, so we only diff the named ones.
+	for _, oldConstraint := range oldTable.constraints {
+		id := getConstraintID(oldConstraint)
+		if id == "" {
+			continue
+		}
+		if newConstraint, ok := newConstraintMap[id]; ok && getText(newConstraint) == getText(oldConstraint) {
+			continue
+		}
+		name, _ := NormalizeTSQLIdentifier(oldConstraint.GetConstraint())
+		stmt := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT [%s];", tableName, name)
+		if oldConstraint.Foreign_key_options() != nil {
+			diff.dropForeignKey = append(diff.dropForeignKey, stmt)
+		} else {
+			diff.dropConstraint = append(diff.dropConstraint, stmt)
+		}
+	}
+	for _, newConstraint := range newTable.constraints {
+		id := getConstraintID(newConstraint)
+		if id == "" {
+			continue
+		}
+		if oldConstraint, ok := oldConstraintMap[id]; ok && getText(newConstraint) == getText(oldConstraint) {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD %s;", tableName, getText(newConstraint))
+		if newConstraint.Foreign_key_options() != nil {
+			diff.addForeignKey = append(diff.addForeignKey, stmt)
+		} else {
+			diff.addConstraint = append(diff.addConstraint, stmt)
+		}
+	}
+}
+
+// diffColumn generates the ALTER COLUMN statement for the data type, collation and nullability,
+// and re-creates the default constraint if the default value changes.
+// The computed columns cannot be altered, so they are dropped and added again.
+func (diff *diffNode) diffColumn(tableName string, oldColumn, newColumn parser.IColumn_definitionContext) {
+	columnName, _ := NormalizeTSQLIdentifier(newColumn.Id_())
+	if oldColumn.Data_type() == nil || newColumn.Data_type() == nil {
+		diff.dropColumn = append(diff.dropColumn, fmt.Sprintf("ALTER TABLE %s DROP COLUMN [%s];", tableName, columnName))
+		diff.addColumn = append(diff.addColumn, fmt.Sprintf("ALTER TABLE %s ADD %s;", tableName, getText(newColumn)))
+		return
+	}
+
+	oldDefault, newDefault := getColumnDefault(oldColumn), getColumnDefault(newColumn)
+	oldDefaultText, newDefaultText := "", ""
+	if oldDefault != nil {
+		oldDefaultText = getText(oldDefault)
+	}
+	if newDefault != nil {
+		newDefaultText = getText(newDefault)
+	}
+	if oldDefaultText != newDefaultText {
+		if oldDefault != nil {
+			if oldDefault.GetConstraint() != nil {
+				name, _ := NormalizeTSQLIdentifier(oldDefault.GetConstraint())
+				diff.dropConstraint = append(diff.dropConstraint, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT [%s];", tableName, name))
+			} else {
+				diff.dropConstraint = append(diff.dropConstraint, diff.dropUnnamedDefault(tableName, columnName))
+			}
+		}
+		if newDefault != nil {
+			var constraint string
+			if newDefault.GetConstraint() != nil {
+				name, _ := NormalizeTSQLIdentifier(newDefault.GetConstraint())
+				constraint = fmt.Sprintf("CONSTRAINT [%s] ", name)
+			}
+			diff.addConstraint = append(diff.addConstraint, fmt.Sprintf("ALTER TABLE %s ADD %sDEFAULT %s FOR [%s];", tableName, constraint, getText(newDefault.GetConstant_expr()), columnName))
+		}
+	}
+
+	oldDefinition, newDefinition := getAlterColumnDefinition(oldColumn), getAlterColumnDefinition(newColumn)
+	if oldDefinition != newDefinition {
+		diff.alterColumn = append(diff.alterColumn, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN [%s] %s;", tableName, columnName, newDefinition))
+	}
+}
+
+// dropUnnamedDefault drops the default constraint with the system generated name,
+// which is looked up from sys.default_constraints by the table and column.
+func (diff *diffNode) dropUnnamedDefault(tableName, columnName string) string {
+	diff.unnamedDefaultCount++
+	variable := fmt.Sprintf("@default_constraint_%d", diff.unnamedDefaultCount)
+	quotedTableName := strings.ReplaceAll(tableName, "'", "''")
+	return fmt.Sprintf(`DECLARE %[1]s NVARCHAR(258) = (SELECT QUOTENAME([name]) FROM sys.default_constraints WHERE parent_object_id = OBJECT_ID(N'%[2]s') AND parent_column_id = COLUMNPROPERTY(OBJECT_ID(N'%[2]s'), N'%[3]s', 'ColumnId'));
+EXEC(N'ALTER TABLE %[2]s DROP CONSTRAINT ' + %[1]s);`, variable, quotedTableName, strings.ReplaceAll(columnName, "'", "''"))
+}
+
+// getAlterColumnDefinition returns the part of the column definition allowed in ALTER COLUMN.
+func getAlterColumnDefinition(column parser.IColumn_definitionContext) string {
+	parts := []string{getText(column.Data_type())}
+	for _, element := range column.AllColumn_definition_element() {
+		switch {
+		case element.COLLATE() != nil:
+			parts = append(parts, getText(element))
+		case element.Column_constraint() != nil && element.Column_constraint().Null_notnull() != nil:
+			parts = append(parts, getText(element.Column_constraint().Null_notnull()))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func getColumnDefault(column parser.IColumn_definitionContext) parser.IColumn_definition_elementContext {
+	for _, element := range column.AllColumn_definition_element() {
+		if element.DEFAULT() != nil {
+			return element
+		}
+	}
+	return nil
+}
+
+func getColumnID(column parser.IColumn_definitionContext) string {
+	_, lower := NormalizeTSQLIdentifier(column.Id_())
+	return lower
+}
+
+func getConstraintID(constraint parser.ITable_constraintContext) string {
+	if constraint.GetConstraint() == nil {
+		return ""
+	}
+	_, lower := NormalizeTSQLIdentifier(constraint.GetConstraint())
+	return lower
+}
+
+func buildConstraintMap(table *tableInfo) map[string]parser.ITable_constraintContext {
+	constraintMap := make(map[string]parser.ITable_constraintContext)
+	for _, constraint := range table.constraints {
+		if id := getConstraintID(constraint); id != "" {
+			constraintMap[id] = constraint
+		}
+	}
+	return constraintMap
+}
+
+// sortTablesByDependency sorts the tables so that the referenced tables come before the referencing ones.
+// The tables not depending on each other keep their original order.
+func sortTablesByDependency(tables []*tableInfo) []*tableInfo {
+	tableMap := make(map[string]*tableInfo)
+	for _, table := range tables {
+		tableMap[table.id] = table
+	}
+	visited := make(map[string]bool)
+	var result []*tableInfo
+	var visit func(table *tableInfo)
+	visit = func(table *tableInfo) {
+		if visited[table.id] {
+			return
+		}
+		visited[table.id] = true
+		for _, reference := range table.references {
+			if referenced, ok := tableMap[reference]; ok {
+				visit(referenced)
+			}
+		}
+		result = append(result, table)
+	}
+	for _, table := range tables {
+		visit(table)
+	}
+	return result
+}
+
+func sortTables(m map[string]*tableInfo) []*tableInfo {
+	var tables []*tableInfo
+	for _, table := range m {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].pos < tables[j].pos
+	})
+	return tables
+}
+
+func sortIndexes(m map[string]*indexInfo) []*indexInfo {
+	var indexes []*indexInfo
+	for _, index := range m {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].pos < indexes[j].pos
+	})
+	return indexes
+}
+
+// sortModules sorts the modules in the definition order, which is the dependency order in the dump and SDL files.
+func sortModules(m map[string]*moduleInfo, reverse bool) []*moduleInfo {
+	var modules []*moduleInfo
+	for _, module := range m {
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if reverse {
+			return modules[i].pos > modules[j].pos
+		}
+		return modules[i].pos < modules[j].pos
+	})
+	return modules
+}
+
+// ruleContext is the parser rule context which can access the token stream.
+type ruleContext interface {
+	antlr.ParserRuleContext
+	GetParser() antlr.Parser
+}
+
+func getText(ctx ruleContext) string {
+	return ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx)
+}
+
+// getStatementText returns the text of the statement terminated by the semicolon.
+func getStatementText(ctx ruleContext) string {
+	return strings.TrimRight(getText(ctx), " \t\n;") + ";"
+}
+
+func quoteName(schema, name string) string {
+	return fmt.Sprintf("[%s].[%s]", schema, name)
+}
+
+func buildSchemaInfo(statement string) (*schemaInfo, error) {
+	parseResult, err := ParseTSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &buildSchemaInfoListener{
+		schemaInfo: &schemaInfo{
+			tableMap:  make(map[string]*tableInfo),
+			indexMap:  make(map[string]*indexInfo),
+			moduleMap: make(map[string]*moduleInfo),
+		},
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, parseResult.Tree)
+	if listener.err != nil {
+		return nil, listener.err
+	}
+	return listener.schemaInfo, nil
+}
+
+type buildSchemaInfoListener struct {
+	*parser.BaseTSqlParserListener
+
+	schemaInfo *schemaInfo
+	err        error
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *buildSchemaInfoListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+	schema, table := normalizeTableName(ctx.Table_name())
+	id := getObjectID(schema, table)
+	if _, ok := l.schemaInfo.tableMap[id]; ok {
+		l.err = errors.Errorf("duplicate table %s.%s", schema, table)
+		return
+	}
+	info := &tableInfo{
+		pos:         len(l.schemaInfo.tableMap),
+		id:          id,
+		schema:      schema,
+		name:        table,
+		createTable: ctx,
+		columnMap:   make(map[string]parser.IColumn_definitionContext),
+	}
+	for _, item := range ctx.Column_def_table_constraints().AllColumn_def_table_constraint() {
+		switch {
+		case item.Column_definition() != nil:
+			column := item.Column_definition()
+			info.columns = append(info.columns, column)
+			info.columnMap[getColumnID(column)] = column
+			for _, element := range column.AllColumn_definition_element() {
+				if constraint := element.Column_constraint(); constraint != nil && constraint.Foreign_key_options() != nil {
+					info.references = append(info.references, getReferencedTableID(constraint.Foreign_key_options(), schema))
+				}
+			}
+		case item.Table_constraint() != nil:
+			constraint := item.Table_constraint()
+			info.constraints = append(info.constraints, constraint)
+			if constraint.Foreign_key_options() != nil {
+				info.references = append(info.references, getReferencedTableID(constraint.Foreign_key_options(), schema))
+			}
+		}
+	}
+	l.schemaInfo.tableMap[id] = info
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *buildSchemaInfoListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	if l.err != nil {
+		return
+	}
+	schema, table := normalizeTableName(ctx.Table_name())
+	index, lowerIndex := NormalizeTSQLIdentifier(ctx.Id_(0))
+	// The index name is unique in the table.
+	id := fmt.Sprintf("%s.%s", getObjectID(schema, table), lowerIndex)
+	l.schemaInfo.indexMap[id] = &indexInfo{
+		pos:         len(l.schemaInfo.indexMap),
+		id:          id,
+		tableID:     getObjectID(schema, table),
+		schema:      schema,
+		table:       table,
+		name:        index,
+		createIndex: ctx,
+	}
+}
+
+// EnterCreate_view is called when production create_view is entered.
+func (l *buildSchemaInfoListener) EnterCreate_view(ctx *parser.Create_viewContext) {
+	if l.err != nil {
+		return
+	}
+	schema := defaultSchema
+	if ctx.Simple_name().GetSchema() != nil {
+		schema, _ = NormalizeTSQLIdentifier(ctx.Simple_name().GetSchema())
+	}
+	name, _ := NormalizeTSQLIdentifier(ctx.Simple_name().GetName())
+	l.addModule("VIEW", schema, name, ctx)
+}
+
+// EnterCreate_or_alter_function is called when production create_or_alter_function is entered.
+func (l *buildSchemaInfoListener) EnterCreate_or_alter_function(ctx *parser.Create_or_alter_functionContext) {
+	if l.err != nil {
+		return
+	}
+	schema, name := normalizeFuncProcName(ctx.Func_proc_name_schema())
+	l.addModule("FUNCTION", schema, name, ctx)
+}
+
+// EnterCreate_or_alter_procedure is called when production create_or_alter_procedure is entered.
+func (l *buildSchemaInfoListener) EnterCreate_or_alter_procedure(ctx *parser.Create_or_alter_procedureContext) {
+	if l.err != nil {
+		return
+	}
+	schema, name := normalizeFuncProcName(ctx.Func_proc_name_schema())
+	l.addModule("PROCEDURE", schema, name, ctx)
+}
+
+func (l *buildSchemaInfoListener) addModule(tp string, schema, name string, ctx ruleContext) {
+	// Views, functions and procedures share the same namespace in the schema.
+	id := getObjectID(schema, name)
+	if _, ok := l.schemaInfo.moduleMap[id]; ok {
+		l.err = errors.Errorf("duplicate %s %s.%s", strings.ToLower(tp), schema, name)
+		return
+	}
+	l.schemaInfo.moduleMap[id] = &moduleInfo{
+		pos:    len(l.schemaInfo.moduleMap),
+		id:     id,
+		tp:     tp,
+		schema: schema,
+		name:   name,
+		ctx:    ctx,
+	}
+}
+
+func normalizeTableName(ctx parser.ITable_nameContext) (string, string) {
+	schema := defaultSchema
+	if ctx.GetSchema() != nil {
+		if s, _ := NormalizeTSQLIdentifier(ctx.GetSchema()); s != "" {
+			schema = s
+		}
+	}
+	table, _ := NormalizeTSQLIdentifier(ctx.GetTable())
+	return schema, table
+}
+
+func normalizeFuncProcName(ctx parser.IFunc_proc_name_schemaContext) (string, string) {
+	schema := defaultSchema
+	if ctx.GetSchema() != nil {
+		schema, _ = NormalizeTSQLIdentifier(ctx.GetSchema())
+	}
+	name, _ := NormalizeTSQLIdentifier(ctx.GetProcedure())
+	return schema, name
+}
+
+func getReferencedTableID(ctx parser.IForeign_key_optionsContext, currentSchema string) string {
+	schema, table := normalizeTableName(ctx.Table_name())
+	if ctx.Table_name().GetSchema() == nil {
+		schema = currentSchema
+	}
+	return getObjectID(schema, table)
+}
+
+// getObjectID returns the case-insensitive identifier of the schema object, following the default collation.
+func getObjectID(schema, name string) string {
+	return fmt.Sprintf("%s.%s", strings.ToLower(schema), strings.ToLower(name))
+}
+
+type schemaInfo struct {
+	tableMap  map[string]*tableInfo
+	indexMap  map[string]*indexInfo
+	moduleMap map[string]*moduleInfo
+}
+
+type tableInfo struct {
+	pos         int
+	id          string
+	schema      string
+	name        string
+	createTable parser.ICreate_tableContext
+	columns     []parser.IColumn_definitionContext
+	columnMap   map[string]parser.IColumn_definitionContext
+	constraints []parser.ITable_constraintContext
+	// references is the list of the table ids referenced by the foreign keys.
+	references []string
+}
+
+type indexInfo struct {
+	pos         int
+	id          string
+	tableID     string
+	schema      string
+	table       string
+	name        string
+	createIndex parser.ICreate_indexContext
+}
+
+type moduleInfo struct {
+	pos    int
+	id     string
+	tp     string
+	schema string
+	name   string
+	ctx    ruleContext
+}
@@ -0,0 +1,55 @@
+package tsql
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+type differTestData struct {
+	OldSchema string `yaml:"oldSchema"`
+	NewSchema string `yaml:"newSchema"`
+	Diff      string `yaml:"diff"`
+}
+
+func TestTSQLDiffer(t *testing.T) {
+	tests := []differTestData{}
+
+	const (
+		record = false
+	)
+	var (
+		filepath = "test-data/test_differ.yaml"
+	)
+
+	a := require.New(t)
+	yamlFile, err := os.Open(filepath)
+	a.NoError(err)
+
+	byteValue, err := io.ReadAll(yamlFile)
+	a.NoError(yamlFile.Close())
+	a.NoError(err)
+	a.NoError(yaml.Unmarshal(byteValue, &tests))
+
+	for i, test := range tests {
+		diff, err := SchemaDiff(base.DiffContext{}, test.OldSchema, test.NewSchema)
+		a.NoError(err)
+		if record {
+			tests[i].Diff = diff
+		} else {
+			a.Equal(test.Diff, diff, test.OldSchema)
+		}
+	}
+
+	if record {
+		byteValue, err := yaml.Marshal(tests)
+		a.NoError(err)
+		err = os.WriteFile(filepath, byteValue, 0644)
+		a.NoError(err)
+	}
+}
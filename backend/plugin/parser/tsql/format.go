@@ -0,0 +1,24 @@
+package tsql
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterFormatFunc(storepb.Engine_MSSQL, Format)
+}
+
+// Format formats the statement with the keyword case and indentation options.
+func Format(statement string, options base.FormatOptions) (string, error) {
+	result, err := ParseTSQL(statement)
+	if err != nil {
+		return "", err
+	}
+	// The parsed statement ends with an extra semicolon, which does not change the indexes of the preceding tokens.
+	identifiers := base.GetIdentifierTokenIndexes(result.Tree, parser.TSqlParserRULE_id_)
+	return base.FormatTokens(base.NewFormatTokens(statement, parser.NewTSqlLexer(antlr.NewInputStream(statement)), identifiers), options), nil
+}
@@ -0,0 +1,59 @@
+package tsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		statement string
+		options   base.FormatOptions
+		want      string
+	}{
+		{
+			statement: "select top 10 id, [name] from dbo.t1 inner join t2 on t1.id = t2.id where a = 1 order by id",
+			want: `SELECT TOP 10 id,
+  [name]
+FROM dbo.t1
+INNER JOIN t2 ON t1.id = t2.id
+WHERE a = 1
+ORDER BY id
+`,
+		},
+		{
+			statement: "update t set name = N'a' output inserted.id where id = 1;\nGO\nselect 1",
+			want: `UPDATE t
+SET name = N'a'
+OUTPUT INSERTED.id
+WHERE id = 1;
+
+GO
+
+SELECT 1
+`,
+		},
+		{
+			statement: "create table t (id int -- the id\n, name nvarchar(10))",
+			want: `CREATE TABLE t (
+  id int -- the id
+  ,
+  name nvarchar(10)
+)
+`,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := Format(test.statement, test.options)
+		a.NoError(err)
+		a.Equal(test.want, got)
+	}
+
+	_, err := Format("select from where", base.FormatOptions{})
+	a.Error(err)
+}
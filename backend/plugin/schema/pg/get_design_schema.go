@@ -2,6 +2,7 @@ package pg
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -34,6 +35,130 @@ type designSchemaGenerator struct {
 	err                 error
 
 	lastTokenIndex int
+
+	baseline *baselineObjects
+	// keptEnumTypes are the enum types whose statements in the baseline are kept, including the ALTER TYPE statements.
+	keptEnumTypes map[objectKey]bool
+	// newSequences are the sequences not in the baseline, whose OWNED BY statements are generated after the tables.
+	newSequences map[*sequenceState]bool
+}
+
+type objectKey struct {
+	schema string
+	name   string
+}
+
+// baselineObjects are the schemas, types and sequences in the baseline, collected before generating the design schema.
+type baselineObjects struct {
+	*postgres.BasePostgreSQLParserListener
+
+	schemas map[string]bool
+	// enumTypes are the enum types with the values added or renamed by the ALTER TYPE statements.
+	enumTypes      map[objectKey]*enumTypeState
+	compositeTypes map[objectKey]bool
+	sequences      map[objectKey]bool
+	err            error
+}
+
+func (b *baselineObjects) EnterCreateschemastmt(ctx *postgres.CreateschemastmtContext) {
+	if ctx.Colid() != nil {
+		b.schemas[pgparser.NormalizePostgreSQLColid(ctx.Colid())] = true
+	} else if ctx.Optschemaname() != nil && ctx.Optschemaname().Colid() != nil {
+		b.schemas[pgparser.NormalizePostgreSQLColid(ctx.Optschemaname().Colid())] = true
+	}
+}
+
+func (b *baselineObjects) EnterCreateseqstmt(ctx *postgres.CreateseqstmtContext) {
+	if b.err != nil {
+		return
+	}
+	schemaName, sequenceName, err := pgparser.NormalizePostgreSQLQualifiedNameAsTableName(ctx.Qualified_name())
+	if err != nil {
+		b.err = err
+		return
+	}
+	b.sequences[objectKey{schema: normalizeSchemaName(schemaName), name: sequenceName}] = true
+}
+
+func (b *baselineObjects) EnterDefinestmt(ctx *postgres.DefinestmtContext) {
+	if b.err != nil || ctx.TYPE_P() == nil {
+		return
+	}
+	isEnum := ctx.ENUM_P() != nil
+	isComposite := ctx.AS() != nil && ctx.ENUM_P() == nil && ctx.RANGE() == nil
+	if !isEnum && !isComposite {
+		return
+	}
+	schemaName, typeName, err := pgparser.NormalizePostgreSQLAnyNameAsTableName(ctx.Any_name(0))
+	if err != nil {
+		b.err = err
+		return
+	}
+	key := objectKey{schema: normalizeSchemaName(schemaName), name: typeName}
+	if isComposite {
+		b.compositeTypes[key] = true
+		return
+	}
+	enumType := &enumTypeState{name: typeName}
+	if ctx.Opt_enum_val_list() != nil {
+		for _, value := range ctx.Opt_enum_val_list().Enum_val_list().AllSconst() {
+			enumType.values = append(enumType.values, getEnumValue(value))
+		}
+	}
+	b.enumTypes[key] = enumType
+}
+
+func (b *baselineObjects) EnterAlterenumstmt(ctx *postgres.AlterenumstmtContext) {
+	if b.err != nil {
+		return
+	}
+	schemaName, typeName, err := pgparser.NormalizePostgreSQLAnyNameAsTableName(ctx.Any_name())
+	if err != nil {
+		b.err = err
+		return
+	}
+	enumType, exists := b.enumTypes[objectKey{schema: normalizeSchemaName(schemaName), name: typeName}]
+	if !exists {
+		return
+	}
+	values := ctx.AllSconst()
+	if ctx.RENAME() != nil {
+		if len(values) != 2 {
+			return
+		}
+		oldValue, newValue := getEnumValue(values[0]), getEnumValue(values[1])
+		for i, value := range enumType.values {
+			if value == oldValue {
+				enumType.values[i] = newValue
+			}
+		}
+		return
+	}
+	if len(values) == 0 {
+		return
+	}
+	addLabel := &ast.AddEnumLabelStmt{NewLabel: getEnumValue(values[0])}
+	if slices.Contains(enumType.values, addLabel.NewLabel) {
+		// ADD VALUE IF NOT EXISTS.
+		return
+	}
+	if len(values) == 2 {
+		addLabel.NeighborLabel = getEnumValue(values[1])
+		if ctx.BEFORE() != nil {
+			addLabel.Position = ast.PositionTypeBefore
+		} else {
+			addLabel.Position = ast.PositionTypeAfter
+		}
+	}
+	enumType.addValue(addLabel)
+}
+
+func getEnumValue(value postgres.ISconstContext) string {
+	text := value.GetText()
+	if len(text) >= 2 && text[0] == '\'' && text[len(text)-1] == '\'' {
+		text = unescapePostgreSQLString(text[1 : len(text)-1])
+	}
+	return text
 }
 
 // GetDesignSchema returns the schema string for the design schema.
@@ -50,9 +175,34 @@ func GetDesignSchema(_, baselineSchema string, to *storepb.DatabaseSchemaMetadat
 		return "", nil
 	}
 
+	baseline := &baselineObjects{
+		schemas:        map[string]bool{"public": true},
+		enumTypes:      make(map[objectKey]*enumTypeState),
+		compositeTypes: make(map[objectKey]bool),
+		sequences:      make(map[objectKey]bool),
+	}
+	antlr.ParseTreeWalkerDefault.Walk(baseline, parseResult.Tree)
+	if baseline.err != nil {
+		return "", baseline.err
+	}
+
 	listener := &designSchemaGenerator{
 		lastTokenIndex: 0,
 		to:             toState,
+		baseline:       baseline,
+		keptEnumTypes:  make(map[objectKey]bool),
+		newSequences:   make(map[*sequenceState]bool),
+	}
+
+	// The new types and sequences in the existing schemas are created before the tables in the baseline, which may use them.
+	for _, schema := range to.Schemas {
+		schemaState, exists := listener.to.schemas[schema.Name]
+		if !exists || !baseline.schemas[schema.Name] {
+			continue
+		}
+		if err := listener.printNewTypesAndSequences(schema, schemaState); err != nil {
+			return "", err
+		}
 	}
 
 	antlr.ParseTreeWalkerDefault.Walk(listener, parseResult.Tree)
@@ -71,6 +221,12 @@ func GetDesignSchema(_, baselineSchema string, to *storepb.DatabaseSchemaMetadat
 			return "", err
 		}
 	}
+	// The trailing whitespaces of the baseline are not in the token stream, separate the following statements by a new line.
+	if listener.result.Len() > 0 && !strings.HasSuffix(listener.result.String(), "\n") {
+		if _, err := listener.result.WriteString("\n"); err != nil {
+			return "", err
+		}
+	}
 
 	// Follow the order of the input schema.
 	for _, schema := range to.Schemas {
@@ -81,6 +237,10 @@ func GetDesignSchema(_, baselineSchema string, to *storepb.DatabaseSchemaMetadat
 		if err := schemaState.printCreateSchema(&listener.result); err != nil {
 			return "", err
 		}
+		// The types and sequences should be created before the tables using them.
+		if err := listener.printNewTypesAndSequences(schema, schemaState); err != nil {
+			return "", err
+		}
 		// Follow the order of the input table.
 		for _, table := range schema.Tables {
 			tableState, exists := schemaState.tables[table.Name]
@@ -120,11 +280,81 @@ func GetDesignSchema(_, baselineSchema string, to *storepb.DatabaseSchemaMetadat
 				}
 			}
 		}
+		// The owner table of a sequence should be created before the OWNED BY statement.
+		for _, sequence := range schema.Sequences {
+			sequenceState, exists := schemaState.sequences[sequence.Name]
+			if !exists || sequenceState.ownerTable == "" || (sequenceState.ignore && !listener.newSequences[sequenceState]) {
+				continue
+			}
+			if err := sequenceState.ownedByToString(&listener.result, schema.Name); err != nil {
+				return "", err
+			}
+			if _, err := listener.result.WriteString("\n"); err != nil {
+				return "", err
+			}
+		}
 	}
 
 	return listener.result.String(), nil
 }
 
+// printNewTypesAndSequences prints the types and sequences of the schema which are not in the baseline.
+func (g *designSchemaGenerator) printNewTypesAndSequences(schema *storepb.SchemaMetadata, schemaState *schemaState) error {
+	// Follow the order of the input types.
+	for _, enumType := range schema.EnumTypes {
+		enumTypeState, exists := schemaState.enumTypes[enumType.Name]
+		if !exists || enumTypeState.ignore || g.baseline.enumTypes[objectKey{schema: schema.Name, name: enumType.Name}] != nil {
+			continue
+		}
+		enumTypeState.ignore = true
+		if err := enumTypeState.toString(&g.result, schema.Name); err != nil {
+			return err
+		}
+		if enumType.Comment != "" {
+			if err := enumTypeState.commentToString(&g.result, schema.Name); err != nil {
+				return err
+			}
+		}
+		if _, err := g.result.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+	for _, compositeType := range schema.CompositeTypes {
+		compositeTypeState, exists := schemaState.compositeTypes[compositeType.Name]
+		if !exists || compositeTypeState.ignore || g.baseline.compositeTypes[objectKey{schema: schema.Name, name: compositeType.Name}] {
+			continue
+		}
+		compositeTypeState.ignore = true
+		if err := compositeTypeState.toString(&g.result, schema.Name); err != nil {
+			return err
+		}
+		if compositeType.Comment != "" {
+			if err := compositeTypeState.commentToString(&g.result, schema.Name); err != nil {
+				return err
+			}
+		}
+		if _, err := g.result.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+	// Follow the order of the input sequences, the OWNED BY statements are printed after the tables.
+	for _, sequence := range schema.Sequences {
+		sequenceState, exists := schemaState.sequences[sequence.Name]
+		if !exists || sequenceState.ignore || g.baseline.sequences[objectKey{schema: schema.Name, name: sequence.Name}] {
+			continue
+		}
+		sequenceState.ignore = true
+		g.newSequences[sequenceState] = true
+		if err := sequenceState.toString(&g.result, schema.Name); err != nil {
+			return err
+		}
+		if _, err := g.result.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // EnterCreatestmt is called when production createstmt is entered.
 func (g *designSchemaGenerator) EnterCreatestmt(ctx *postgres.CreatestmtContext) {
 	if g.err != nil {
@@ -409,6 +639,32 @@ func (g *designSchemaGenerator) EnterAltertablestmt(ctx *postgres.Altertablestmt
 					return
 				}
 			}
+		case constraint.CHECK() != nil && cmd.Tableconstraint().Name() != nil:
+			nameText := pgparser.NormalizePostgreSQLColid(cmd.Tableconstraint().Name().Colid())
+			check, exists := table.checks[nameText]
+			if !exists {
+				// Skip not found check constraint.
+				return
+			}
+			delete(table.checks, nameText)
+			if _, err := g.result.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+				Start: ctx.GetStart().GetTokenIndex(),
+				Stop:  cmd.Tableconstraint().GetStart().GetTokenIndex() - 1,
+			})); err != nil {
+				g.err = err
+				return
+			}
+			if _, err := g.result.WriteString(getCheckConstraintText(ctx.GetParser().GetTokenStream(), cmd.Tableconstraint(), constraint, check)); err != nil {
+				g.err = err
+				return
+			}
+			if _, err := g.result.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+				Start: cmd.Tableconstraint().GetStop().GetTokenIndex() + 1,
+				Stop:  g.lastTokenIndex - 1,
+			})); err != nil {
+				g.err = err
+				return
+			}
 		default:
 			if _, err := g.result.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
 				Start: ctx.GetStart().GetTokenIndex(),
@@ -454,6 +710,21 @@ func (g *designSchemaGenerator) EnterTableconstraint(ctx *postgres.Tableconstrai
 		return
 	}
 
+	text := ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+		Start: ctx.GetStart().GetTokenIndex(),
+		Stop:  ctx.GetStop().GetTokenIndex(),
+	})
+	if constraint := ctx.Constraintelem(); constraint.CHECK() != nil && ctx.Name() != nil {
+		name := pgparser.NormalizePostgreSQLColid(ctx.Name().Colid())
+		check, exists := g.currentTable.checks[name]
+		if !exists {
+			// Skip not found check constraint.
+			return
+		}
+		delete(g.currentTable.checks, name)
+		text = getCheckConstraintText(ctx.GetParser().GetTokenStream(), ctx, constraint, check)
+	}
+
 	if g.firstElementInTable {
 		g.firstElementInTable = false
 	} else {
@@ -462,15 +733,35 @@ func (g *designSchemaGenerator) EnterTableconstraint(ctx *postgres.Tableconstrai
 			return
 		}
 	}
-	if _, err := g.tableConstraints.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
-		Start: ctx.GetStart().GetTokenIndex(),
-		Stop:  ctx.GetStop().GetTokenIndex(),
-	})); err != nil {
+	if _, err := g.tableConstraints.WriteString(text); err != nil {
 		g.err = err
 		return
 	}
 }
 
+// getCheckConstraintText returns the text of the rule containing the CHECK constraint with the expression in the target schema.
+func getCheckConstraintText(stream antlr.TokenStream, ctx antlr.ParserRuleContext, constraint postgres.IConstraintelemContext, check *checkConstraintState) string {
+	openParen := constraint.OPEN_PAREN().GetSymbol().GetTokenIndex()
+	closeParen := constraint.CLOSE_PAREN().GetSymbol().GetTokenIndex()
+	expression := stream.GetTextFromInterval(antlr.Interval{
+		Start: openParen,
+		Stop:  closeParen,
+	})
+	if equalCheckExpression(expression, check.expression) {
+		return stream.GetTextFromInterval(antlr.Interval{
+			Start: ctx.GetStart().GetTokenIndex(),
+			Stop:  ctx.GetStop().GetTokenIndex(),
+		})
+	}
+	return stream.GetTextFromInterval(antlr.Interval{
+		Start: ctx.GetStart().GetTokenIndex(),
+		Stop:  openParen - 1,
+	}) + check.expression + stream.GetTextFromInterval(antlr.Interval{
+		Start: closeParen + 1,
+		Stop:  ctx.GetStop().GetTokenIndex(),
+	})
+}
+
 func (g *designSchemaGenerator) EnterTablelikeclause(ctx *postgres.TablelikeclauseContext) {
 	if g.err != nil || g.currentTable == nil {
 		return
@@ -531,6 +822,251 @@ func (g *designSchemaGenerator) EnterCreateschemastmt(ctx *postgres.Createschema
 	}
 }
 
+func (g *designSchemaGenerator) EnterCreateseqstmt(ctx *postgres.CreateseqstmtContext) {
+	if g.err != nil {
+		return
+	}
+	if _, err := g.result.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+		Start: g.lastTokenIndex,
+		Stop:  ctx.GetStart().GetTokenIndex() - 1,
+	})); err != nil {
+		g.err = err
+		return
+	}
+
+	g.lastTokenIndex = skipFollowingSemiIndex(ctx.GetParser().GetTokenStream(), ctx.GetStop().GetTokenIndex()+1)
+	endTokenIndex := g.lastTokenIndex - 1
+
+	schemaName, sequenceName, err := pgparser.NormalizePostgreSQLQualifiedNameAsTableName(ctx.Qualified_name())
+	if err != nil {
+		g.err = err
+		return
+	}
+	schemaName = normalizeSchemaName(schemaName)
+	schema, exists := g.to.schemas[schemaName]
+	if !exists {
+		// Skip not found schema.
+		return
+	}
+	sequence, exists := schema.sequences[sequenceName]
+	if !exists {
+		// Skip not found sequence.
+		return
+	}
+	sequence.ignore = true
+
+	text := ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+		Start: ctx.GetStart().GetTokenIndex(),
+		Stop:  endTokenIndex,
+	})
+	list, err := pgrawparser.Parse(pgrawparser.ParseContext{}, text)
+	if err != nil {
+		g.err = err
+		return
+	}
+	if len(list) != 1 {
+		g.err = errors.Errorf("expect one statement but found %d", len(list))
+		return
+	}
+	createSequence, ok := list[0].(*ast.CreateSequenceStmt)
+	if !ok {
+		g.err = errors.Errorf("failed to convert to CreateSequenceStmt")
+		return
+	}
+
+	if equalSequenceOptions(newSequenceStateFromDef(0, &createSequence.SequenceDef), sequence) {
+		if _, err := g.result.WriteString(text); err != nil {
+			g.err = err
+			return
+		}
+		return
+	}
+	var buf strings.Builder
+	if err := sequence.toString(&buf, schemaName); err != nil {
+		g.err = err
+		return
+	}
+	if _, err := g.result.WriteString(strings.TrimSuffix(buf.String(), "\n")); err != nil {
+		g.err = err
+		return
+	}
+}
+
+func (g *designSchemaGenerator) EnterAlterseqstmt(ctx *postgres.AlterseqstmtContext) {
+	if g.err != nil {
+		return
+	}
+	if _, err := g.result.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+		Start: g.lastTokenIndex,
+		Stop:  ctx.GetStart().GetTokenIndex() - 1,
+	})); err != nil {
+		g.err = err
+		return
+	}
+
+	g.lastTokenIndex = skipFollowingSemiIndex(ctx.GetParser().GetTokenStream(), ctx.GetStop().GetTokenIndex()+1)
+	endTokenIndex := g.lastTokenIndex - 1
+
+	schemaName, sequenceName, err := pgparser.NormalizePostgreSQLQualifiedNameAsTableName(ctx.Qualified_name())
+	if err != nil {
+		g.err = err
+		return
+	}
+	schema, exists := g.to.schemas[normalizeSchemaName(schemaName)]
+	if !exists {
+		// Skip not found schema.
+		return
+	}
+	if _, exists := schema.sequences[sequenceName]; !exists {
+		// Skip not found sequence.
+		return
+	}
+
+	if _, err := g.result.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+		Start: ctx.GetStart().GetTokenIndex(),
+		Stop:  endTokenIndex,
+	})); err != nil {
+		g.err = err
+		return
+	}
+}
+
+func (g *designSchemaGenerator) EnterDefinestmt(ctx *postgres.DefinestmtContext) {
+	if g.err != nil {
+		return
+	}
+	isEnum := ctx.TYPE_P() != nil && ctx.ENUM_P() != nil
+	isComposite := ctx.TYPE_P() != nil && ctx.AS() != nil && ctx.ENUM_P() == nil && ctx.RANGE() == nil
+	if !isEnum && !isComposite {
+		// Skip other define statement for now.
+		return
+	}
+
+	if _, err := g.result.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+		Start: g.lastTokenIndex,
+		Stop:  ctx.GetStart().GetTokenIndex() - 1,
+	})); err != nil {
+		g.err = err
+		return
+	}
+
+	g.lastTokenIndex = skipFollowingSemiIndex(ctx.GetParser().GetTokenStream(), ctx.GetStop().GetTokenIndex()+1)
+	endTokenIndex := g.lastTokenIndex - 1
+
+	schemaName, typeName, err := pgparser.NormalizePostgreSQLAnyNameAsTableName(ctx.Any_name(0))
+	if err != nil {
+		g.err = err
+		return
+	}
+	schemaName = normalizeSchemaName(schemaName)
+	schema, exists := g.to.schemas[schemaName]
+	if !exists {
+		// Skip not found schema.
+		return
+	}
+
+	text := ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+		Start: ctx.GetStart().GetTokenIndex(),
+		Stop:  endTokenIndex,
+	})
+	var buf strings.Builder
+	if isEnum {
+		enumType, exists := schema.enumTypes[typeName]
+		if !exists {
+			// Skip not found type.
+			return
+		}
+		enumType.ignore = true
+		// The values are compared after applying the ALTER TYPE statements in the baseline, which are kept along with this statement.
+		key := objectKey{schema: schemaName, name: typeName}
+		if baselineEnumType, exists := g.baseline.enumTypes[key]; exists && equalKeys(baselineEnumType.values, enumType.values) {
+			g.keptEnumTypes[key] = true
+			if _, err := g.result.WriteString(text); err != nil {
+				g.err = err
+			}
+			return
+		}
+		if err := enumType.toString(&buf, schemaName); err != nil {
+			g.err = err
+			return
+		}
+	} else {
+		compositeType, exists := schema.compositeTypes[typeName]
+		if !exists {
+			// Skip not found type.
+			return
+		}
+		compositeType.ignore = true
+		equal := true
+		var attributes []postgres.ITablefuncelementContext
+		if ctx.Opttablefuncelementlist() != nil {
+			attributes = ctx.Opttablefuncelementlist().Tablefuncelementlist().AllTablefuncelement()
+		}
+		if len(attributes) != len(compositeType.attributes) {
+			equal = false
+		}
+		for i := 0; equal && i < len(attributes); i++ {
+			if pgparser.NormalizePostgreSQLColid(attributes[i].Colid()) != compositeType.attributes[i].name {
+				equal = false
+				break
+			}
+			equalType, err := equalType(compositeType.attributes[i].tp, ctx.GetParser().GetTokenStream().GetTextFromRuleContext(attributes[i].Typename()))
+			if err != nil {
+				g.err = err
+				return
+			}
+			equal = equalType
+		}
+		if equal {
+			if _, err := g.result.WriteString(text); err != nil {
+				g.err = err
+			}
+			return
+		}
+		if err := compositeType.toString(&buf, schemaName); err != nil {
+			g.err = err
+			return
+		}
+	}
+	if _, err := g.result.WriteString(strings.TrimSuffix(buf.String(), "\n")); err != nil {
+		g.err = err
+		return
+	}
+}
+
+func (g *designSchemaGenerator) EnterAlterenumstmt(ctx *postgres.AlterenumstmtContext) {
+	if g.err != nil {
+		return
+	}
+	if _, err := g.result.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+		Start: g.lastTokenIndex,
+		Stop:  ctx.GetStart().GetTokenIndex() - 1,
+	})); err != nil {
+		g.err = err
+		return
+	}
+	g.lastTokenIndex = skipFollowingSemiIndex(ctx.GetParser().GetTokenStream(), ctx.GetStop().GetTokenIndex()+1)
+	endTokenIndex := g.lastTokenIndex - 1
+
+	schemaName, typeName, err := pgparser.NormalizePostgreSQLAnyNameAsTableName(ctx.Any_name())
+	if err != nil {
+		g.err = err
+		return
+	}
+	// Skip the statement if the type is dropped, or the CREATE TYPE statement is regenerated with all the values in the target schema.
+	if !g.keptEnumTypes[objectKey{schema: normalizeSchemaName(schemaName), name: typeName}] {
+		return
+	}
+
+	if _, err := g.result.WriteString(ctx.GetParser().GetTokenStream().GetTextFromInterval(antlr.Interval{
+		Start: ctx.GetStart().GetTokenIndex(),
+		Stop:  endTokenIndex,
+	})); err != nil {
+		g.err = err
+		return
+	}
+}
+
 func (g *designSchemaGenerator) EnterCommentstmt(ctx *postgres.CommentstmtContext) {
 	if g.err != nil {
 		return
@@ -3,6 +3,7 @@ package pg
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -73,6 +74,10 @@ func ParseToMetadata(_, schema string) (*storepb.DatabaseSchemaMetadata, error)
 						defaultText := constraint.Expression.Text()
 						columnState.hasDefault = true
 						columnState.defaultValue = &defaultValueExpression{value: defaultText}
+					case ast.ConstraintTypeCheck:
+						if err := table.addCheckConstraint(constraint, stmt.Name.Schema); err != nil {
+							return nil, err
+						}
 					}
 				}
 
@@ -110,6 +115,10 @@ func ParseToMetadata(_, schema string) (*storepb.DatabaseSchemaMetadata, error)
 						referencedTable:   constraint.Foreign.Table.Name,
 						referencedColumns: constraint.Foreign.ColumnList,
 					}
+				case ast.ConstraintTypeCheck:
+					if err := table.addCheckConstraint(constraint, stmt.Name.Schema); err != nil {
+						return nil, err
+					}
 				case ast.ConstraintTypeUnique:
 				}
 			}
@@ -170,6 +179,10 @@ func ParseToMetadata(_, schema string) (*storepb.DatabaseSchemaMetadata, error)
 							referencedTable:   item.Constraint.Foreign.Table.Name,
 							referencedColumns: item.Constraint.Foreign.ColumnList,
 						}
+					case ast.ConstraintTypeCheck:
+						if err := table.addCheckConstraint(item.Constraint, stmt.Table.Schema); err != nil {
+							return nil, err
+						}
 					}
 				}
 			}
@@ -192,6 +205,83 @@ func ParseToMetadata(_, schema string) (*storepb.DatabaseSchemaMetadata, error)
 				keys:       stmt.Index.GetKeyNameList(),
 				definition: stmt.Text(),
 			}
+		case *ast.CreateSequenceStmt:
+			schemaName := normalizeSchemaName(stmt.SequenceDef.SequenceName.Schema)
+			schema, ok := state.schemas[schemaName]
+			if !ok {
+				return nil, errors.Errorf("schema %q not found", schemaName)
+			}
+			sequenceName := stmt.SequenceDef.SequenceName.Name
+			if _, ok := schema.sequences[sequenceName]; ok {
+				return nil, errors.Errorf("sequence %q already exists in schema %q", sequenceName, schemaName)
+			}
+			schema.sequences[sequenceName] = newSequenceStateFromDef(len(schema.sequences), &stmt.SequenceDef)
+		case *ast.AlterSequenceStmt:
+			if stmt.OwnedBy == nil {
+				// Only OWNED BY is supported for now.
+				continue
+			}
+			schema, ok := state.schemas[normalizeSchemaName(stmt.Name.Schema)]
+			if !ok {
+				continue
+			}
+			sequence, ok := schema.sequences[stmt.Name.Name]
+			if !ok {
+				continue
+			}
+			sequence.ownerTable = stmt.OwnedBy.Table.Name
+			sequence.ownerColumn = stmt.OwnedBy.ColumnName
+		case *ast.CreateTypeStmt:
+			typeName := stmt.Type.TypeName()
+			schemaName := normalizeSchemaName(typeName.Schema)
+			schema, ok := state.schemas[schemaName]
+			if !ok {
+				return nil, errors.Errorf("schema %q not found", schemaName)
+			}
+			if _, ok := schema.enumTypes[typeName.Name]; ok {
+				return nil, errors.Errorf("type %q already exists in schema %q", typeName.Name, schemaName)
+			}
+			if _, ok := schema.compositeTypes[typeName.Name]; ok {
+				return nil, errors.Errorf("type %q already exists in schema %q", typeName.Name, schemaName)
+			}
+			switch tp := stmt.Type.(type) {
+			case *ast.EnumTypeDef:
+				schema.enumTypes[typeName.Name] = &enumTypeState{
+					id:     len(schema.enumTypes),
+					name:   typeName.Name,
+					values: tp.LabelList,
+				}
+			case *ast.CompositeTypeDef:
+				compositeType := &compositeTypeState{
+					id:   len(schema.compositeTypes),
+					name: typeName.Name,
+				}
+				for _, attribute := range tp.AttributeList {
+					typeText, err := pgrawparser.Deparse(pgrawparser.DeparseContext{}, attribute.Type)
+					if err != nil {
+						return nil, err
+					}
+					compositeType.attributes = append(compositeType.attributes, &compositeTypeAttributeState{
+						name: attribute.ColumnName,
+						tp:   typeText,
+					})
+				}
+				schema.compositeTypes[typeName.Name] = compositeType
+			}
+		case *ast.AlterTypeStmt:
+			schema, ok := state.schemas[normalizeSchemaName(stmt.Type.Schema)]
+			if !ok {
+				continue
+			}
+			enumType, ok := schema.enumTypes[stmt.Type.Name]
+			if !ok {
+				continue
+			}
+			for _, item := range stmt.AlterItemList {
+				if addLabel, ok := item.(*ast.AddEnumLabelStmt); ok {
+					enumType.addValue(addLabel)
+				}
+			}
 		case *ast.CommentStmt:
 			switch stmt.Type {
 			case ast.ObjectTypeColumn:
@@ -231,6 +321,21 @@ func ParseToMetadata(_, schema string) (*storepb.DatabaseSchemaMetadata, error)
 					continue
 				}
 				table.comment = stmt.Comment
+			case ast.ObjectTypeType:
+				typeName, ok := stmt.Object.(*ast.TypeNameDef)
+				if !ok {
+					return nil, errors.Errorf("failed to convert to TypeNameDef")
+				}
+				schema, ok := state.schemas[normalizeSchemaName(typeName.Schema)]
+				if !ok {
+					// Skip unknown schema for comments.
+					continue
+				}
+				if enumType, ok := schema.enumTypes[typeName.Name]; ok {
+					enumType.comment = stmt.Comment
+				} else if compositeType, ok := schema.compositeTypes[typeName.Name]; ok {
+					compositeType.comment = stmt.Comment
+				}
 			default:
 				// Skip other comment types for now.
 			}
@@ -284,9 +389,12 @@ type schemaState struct {
 	// ignore means CREATE SCHEMA statement for this schema is already in the target schema info.
 	// But we need the schemaState to deal with the other objects.
 	// So we cannot delete the schemaState, instead we set ignore to true.
-	ignore bool
-	name   string
-	tables map[string]*tableState
+	ignore         bool
+	name           string
+	tables         map[string]*tableState
+	sequences      map[string]*sequenceState
+	enumTypes      map[string]*enumTypeState
+	compositeTypes map[string]*compositeTypeState
 }
 
 func (s *schemaState) printCreateSchema(buf *strings.Builder) error {
@@ -301,9 +409,12 @@ func (s *schemaState) printCreateSchema(buf *strings.Builder) error {
 
 func newSchemaState(id int, name string) *schemaState {
 	return &schemaState{
-		id:     id,
-		name:   name,
-		tables: make(map[string]*tableState),
+		id:             id,
+		name:           name,
+		tables:         make(map[string]*tableState),
+		sequences:      make(map[string]*sequenceState),
+		enumTypes:      make(map[string]*enumTypeState),
+		compositeTypes: make(map[string]*compositeTypeState),
 	}
 }
 
@@ -312,6 +423,15 @@ func convertToSchemaState(id int, schema *storepb.SchemaMetadata) *schemaState {
 	for i, table := range schema.Tables {
 		state.tables[table.Name] = convertToTableState(i, table)
 	}
+	for i, sequence := range schema.Sequences {
+		state.sequences[sequence.Name] = convertToSequenceState(i, sequence)
+	}
+	for i, enumType := range schema.EnumTypes {
+		state.enumTypes[enumType.Name] = convertToEnumTypeState(i, enumType)
+	}
+	for i, compositeType := range schema.CompositeTypes {
+		state.compositeTypes[compositeType.Name] = convertToCompositeTypeState(i, compositeType)
+	}
 	return state
 }
 
@@ -327,9 +447,24 @@ func (s *schemaState) convertToSchemaMetadata() *storepb.SchemaMetadata {
 	for _, table := range tableStates {
 		tables = append(tables, table.convertToTableMetadata())
 	}
+	sequences := []*storepb.SequenceMetadata{}
+	for _, sequence := range sortedSequences(s.sequences) {
+		sequences = append(sequences, sequence.convertToSequenceMetadata())
+	}
+	enumTypes := []*storepb.EnumTypeMetadata{}
+	for _, enumType := range sortedEnumTypes(s.enumTypes) {
+		enumTypes = append(enumTypes, enumType.convertToEnumTypeMetadata())
+	}
+	compositeTypes := []*storepb.CompositeTypeMetadata{}
+	for _, compositeType := range sortedCompositeTypes(s.compositeTypes) {
+		compositeTypes = append(compositeTypes, compositeType.convertToCompositeTypeMetadata())
+	}
 	return &storepb.SchemaMetadata{
-		Name:   s.name,
-		Tables: tables,
+		Name:           s.name,
+		Tables:         tables,
+		Sequences:      sequences,
+		EnumTypes:      enumTypes,
+		CompositeTypes: compositeTypes,
 		// Unsupported, for tests only.
 		Views:             []*storepb.ViewMetadata{},
 		Functions:         []*storepb.FunctionMetadata{},
@@ -346,6 +481,7 @@ type tableState struct {
 	columns     map[string]*columnState
 	indexes     map[string]*indexState
 	foreignKeys map[string]*foreignKeyState
+	checks      map[string]*checkConstraintState
 	// ignoreComment means this column is already in the target schema.
 	ignoreComment bool
 	comment       string
@@ -419,6 +555,39 @@ func (t *tableState) toString(buf *strings.Builder, schemaName string) error {
 		}
 	}
 
+	checks := []*checkConstraintState{}
+	for _, check := range t.checks {
+		checks = append(checks, check)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].id < checks[j].id
+	})
+
+	for _, check := range checks {
+		if err := check.toString(buf, schemaName, t.name); err != nil {
+			return err
+		}
+		if _, err := buf.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *tableState) addCheckConstraint(constraint *ast.ConstraintDef, schemaName string) error {
+	if constraint.Name == "" {
+		// Skip unnamed check constraints, we cannot match them with the target schema.
+		return nil
+	}
+	if _, ok := t.checks[constraint.Name]; ok {
+		return errors.Errorf("check constraint %q already exists in table %q.%q", constraint.Name, schemaName, t.name)
+	}
+	t.checks[constraint.Name] = &checkConstraintState{
+		id:         len(t.checks),
+		name:       constraint.Name,
+		expression: fmt.Sprintf("(%s)", constraint.Expression.Text()),
+	}
 	return nil
 }
 
@@ -429,6 +598,7 @@ func newTableState(id int, name string) *tableState {
 		columns:     make(map[string]*columnState),
 		indexes:     make(map[string]*indexState),
 		foreignKeys: make(map[string]*foreignKeyState),
+		checks:      make(map[string]*checkConstraintState),
 	}
 }
 
@@ -443,6 +613,9 @@ func convertToTableState(id int, table *storepb.TableMetadata) *tableState {
 	for i, fk := range table.ForeignKeys {
 		state.foreignKeys[fk.Name] = convertToForeignKeyState(i, fk)
 	}
+	for i, check := range table.CheckConstraints {
+		state.checks[check.Name] = convertToCheckConstraintState(i, check)
+	}
 	state.comment = table.Comment
 	return state
 }
@@ -484,12 +657,25 @@ func (t *tableState) convertToTableMetadata() *storepb.TableMetadata {
 		fks = append(fks, fk.convertToForeignKeyMetadata())
 	}
 
+	checkStates := []*checkConstraintState{}
+	for _, check := range t.checks {
+		checkStates = append(checkStates, check)
+	}
+	sort.Slice(checkStates, func(i, j int) bool {
+		return checkStates[i].id < checkStates[j].id
+	})
+	checks := []*storepb.CheckConstraintMetadata{}
+	for _, check := range checkStates {
+		checks = append(checks, check.convertToCheckConstraintMetadata())
+	}
+
 	return &storepb.TableMetadata{
-		Name:        t.name,
-		Columns:     columns,
-		Indexes:     indexes,
-		ForeignKeys: fks,
-		Comment:     t.comment,
+		Name:             t.name,
+		Columns:          columns,
+		Indexes:          indexes,
+		ForeignKeys:      fks,
+		CheckConstraints: checks,
+		Comment:          t.comment,
 	}
 }
 
@@ -782,3 +968,415 @@ func convertToColumnState(id int, column *storepb.ColumnMetadata) *columnState {
 	}
 	return result
 }
+
+type checkConstraintState struct {
+	id   int
+	name string
+	// expression is the parenthesized boolean expression, such as "(a > 0)".
+	expression string
+}
+
+func (c *checkConstraintState) convertToCheckConstraintMetadata() *storepb.CheckConstraintMetadata {
+	return &storepb.CheckConstraintMetadata{
+		Name:       c.name,
+		Expression: c.expression,
+	}
+}
+
+func convertToCheckConstraintState(id int, check *storepb.CheckConstraintMetadata) *checkConstraintState {
+	return &checkConstraintState{
+		id:         id,
+		name:       check.Name,
+		expression: check.Expression,
+	}
+}
+
+// equalCheckExpression compares two check expressions, ignoring the whitespaces and the redundant outer parentheses.
+func equalCheckExpression(a, b string) bool {
+	return normalizeCheckExpression(a) == normalizeCheckExpression(b)
+}
+
+func normalizeCheckExpression(expression string) string {
+	expression = strings.Join(strings.Fields(expression), "")
+	for len(expression) >= 2 && expression[0] == '(' && expression[len(expression)-1] == ')' {
+		// Make sure the first parenthesis matches the last one, such as "(a > 0) AND (b > 0)" does not.
+		depth := 0
+		matched := true
+		for i := 0; i < len(expression)-1; i++ {
+			switch expression[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			break
+		}
+		expression = expression[1 : len(expression)-1]
+	}
+	return expression
+}
+
+func (c *checkConstraintState) toString(buf *strings.Builder, schemaName, tableName string) error {
+	_, err := buf.WriteString(fmt.Sprintf("ALTER TABLE ONLY \"%s\".\"%s\"\n    ADD CONSTRAINT \"%s\" CHECK %s;\n", schemaName, tableName, c.name, c.expression))
+	return err
+}
+
+type sequenceState struct {
+	// ignore means this sequence is already in the target schema.
+	ignore      bool
+	id          int
+	name        string
+	dataType    string
+	start       string
+	minValue    string
+	maxValue    string
+	increment   string
+	cycle       bool
+	cacheSize   string
+	ownerTable  string
+	ownerColumn string
+}
+
+func newSequenceStateFromDef(id int, def *ast.SequenceDef) *sequenceState {
+	sequence := &sequenceState{
+		id:    id,
+		name:  def.SequenceName.Name,
+		cycle: def.Cycle,
+	}
+	if def.SequenceDataType != nil {
+		switch def.SequenceDataType.Size {
+		case 2:
+			sequence.dataType = "smallint"
+		case 4:
+			sequence.dataType = "integer"
+		case 8:
+			sequence.dataType = "bigint"
+		}
+	}
+	sequence.start = formatInt32(def.StartWith)
+	sequence.minValue = formatInt32(def.MinValue)
+	sequence.maxValue = formatInt32(def.MaxValue)
+	sequence.increment = formatInt32(def.IncrementBy)
+	sequence.cacheSize = formatInt32(def.Cache)
+	if def.OwnedBy != nil {
+		sequence.ownerTable = def.OwnedBy.Table.Name
+		sequence.ownerColumn = def.OwnedBy.ColumnName
+	}
+	return sequence
+}
+
+func formatInt32(v *int32) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(int64(*v), 10)
+}
+
+func convertToSequenceState(id int, sequence *storepb.SequenceMetadata) *sequenceState {
+	return &sequenceState{
+		id:          id,
+		name:        sequence.Name,
+		dataType:    sequence.DataType,
+		start:       sequence.Start,
+		minValue:    sequence.MinValue,
+		maxValue:    sequence.MaxValue,
+		increment:   sequence.Increment,
+		cycle:       sequence.Cycle,
+		cacheSize:   sequence.CacheSize,
+		ownerTable:  sequence.OwnerTable,
+		ownerColumn: sequence.OwnerColumn,
+	}
+}
+
+func (s *sequenceState) convertToSequenceMetadata() *storepb.SequenceMetadata {
+	return &storepb.SequenceMetadata{
+		Name:        s.name,
+		DataType:    s.dataType,
+		Start:       s.start,
+		MinValue:    s.minValue,
+		MaxValue:    s.maxValue,
+		Increment:   s.increment,
+		Cycle:       s.cycle,
+		CacheSize:   s.cacheSize,
+		OwnerTable:  s.ownerTable,
+		OwnerColumn: s.ownerColumn,
+	}
+}
+
+// equalSequenceOptions compares the options of a sequence in the baseline schema with the target one.
+// An option omitted in the baseline schema takes the PostgreSQL default value.
+func equalSequenceOptions(baseline, target *sequenceState) bool {
+	dataType := baseline.dataType
+	if dataType == "" {
+		dataType = "bigint"
+	}
+	increment := baseline.increment
+	if increment == "" {
+		increment = "1"
+	}
+	minValue, maxValue := baseline.minValue, baseline.maxValue
+	if minValue == "" || maxValue == "" {
+		defaultMinValue, defaultMaxValue := getSequenceDefaultRange(dataType, strings.HasPrefix(increment, "-"))
+		if minValue == "" {
+			minValue = defaultMinValue
+		}
+		if maxValue == "" {
+			maxValue = defaultMaxValue
+		}
+	}
+	start := baseline.start
+	if start == "" {
+		start = minValue
+		if strings.HasPrefix(increment, "-") {
+			start = maxValue
+		}
+	}
+	cacheSize := baseline.cacheSize
+	if cacheSize == "" {
+		cacheSize = "1"
+	}
+	return equalOption(dataType, target.dataType) &&
+		equalOption(start, target.start) &&
+		equalOption(minValue, target.minValue) &&
+		equalOption(maxValue, target.maxValue) &&
+		equalOption(increment, target.increment) &&
+		equalOption(cacheSize, target.cacheSize) &&
+		baseline.cycle == target.cycle
+}
+
+// equalOption treats an empty target option as unchanged.
+func equalOption(baseline, target string) bool {
+	return target == "" || baseline == target
+}
+
+// getSequenceDefaultRange returns the default MINVALUE and MAXVALUE of a sequence.
+// https://www.postgresql.org/docs/current/sql-createsequence.html
+func getSequenceDefaultRange(dataType string, descending bool) (string, string) {
+	var typeMin, typeMax string
+	switch dataType {
+	case "smallint":
+		typeMin, typeMax = "-32768", "32767"
+	case "integer":
+		typeMin, typeMax = "-2147483648", "2147483647"
+	default:
+		typeMin, typeMax = "-9223372036854775808", "9223372036854775807"
+	}
+	if descending {
+		return typeMin, "-1"
+	}
+	return "1", typeMax
+}
+
+func (s *sequenceState) toString(buf *strings.Builder, schemaName string) error {
+	if _, err := buf.WriteString(fmt.Sprintf("CREATE SEQUENCE \"%s\".\"%s\"", schemaName, s.name)); err != nil {
+		return err
+	}
+	if s.dataType != "" {
+		if _, err := buf.WriteString(fmt.Sprintf("\n    AS %s", s.dataType)); err != nil {
+			return err
+		}
+	}
+	if s.start != "" {
+		if _, err := buf.WriteString(fmt.Sprintf("\n    START WITH %s", s.start)); err != nil {
+			return err
+		}
+	}
+	if s.increment != "" {
+		if _, err := buf.WriteString(fmt.Sprintf("\n    INCREMENT BY %s", s.increment)); err != nil {
+			return err
+		}
+	}
+	if s.minValue != "" {
+		if _, err := buf.WriteString(fmt.Sprintf("\n    MINVALUE %s", s.minValue)); err != nil {
+			return err
+		}
+	}
+	if s.maxValue != "" {
+		if _, err := buf.WriteString(fmt.Sprintf("\n    MAXVALUE %s", s.maxValue)); err != nil {
+			return err
+		}
+	}
+	if s.cacheSize != "" {
+		if _, err := buf.WriteString(fmt.Sprintf("\n    CACHE %s", s.cacheSize)); err != nil {
+			return err
+		}
+	}
+	if s.cycle {
+		if _, err := buf.WriteString("\n    CYCLE"); err != nil {
+			return err
+		}
+	}
+	_, err := buf.WriteString(";\n")
+	return err
+}
+
+func (s *sequenceState) ownedByToString(buf *strings.Builder, schemaName string) error {
+	if s.ownerTable == "" || s.ownerColumn == "" {
+		return nil
+	}
+	_, err := buf.WriteString(fmt.Sprintf("ALTER SEQUENCE \"%s\".\"%s\" OWNED BY \"%s\".\"%s\".\"%s\";\n", schemaName, s.name, schemaName, s.ownerTable, s.ownerColumn))
+	return err
+}
+
+func sortedSequences(m map[string]*sequenceState) []*sequenceState {
+	var result []*sequenceState
+	for _, sequence := range m {
+		result = append(result, sequence)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+type enumTypeState struct {
+	// ignore means this type is already in the target schema.
+	ignore  bool
+	id      int
+	name    string
+	values  []string
+	comment string
+}
+
+func convertToEnumTypeState(id int, enumType *storepb.EnumTypeMetadata) *enumTypeState {
+	return &enumTypeState{
+		id:      id,
+		name:    enumType.Name,
+		values:  enumType.Values,
+		comment: enumType.Comment,
+	}
+}
+
+func (e *enumTypeState) convertToEnumTypeMetadata() *storepb.EnumTypeMetadata {
+	return &storepb.EnumTypeMetadata{
+		Name:    e.name,
+		Values:  e.values,
+		Comment: e.comment,
+	}
+}
+
+func (e *enumTypeState) addValue(addLabel *ast.AddEnumLabelStmt) {
+	pos := len(e.values)
+	for i, value := range e.values {
+		if value != addLabel.NeighborLabel {
+			continue
+		}
+		switch addLabel.Position {
+		case ast.PositionTypeBefore:
+			pos = i
+		case ast.PositionTypeAfter:
+			pos = i + 1
+		}
+		break
+	}
+	values := append([]string{}, e.values[:pos]...)
+	values = append(values, addLabel.NewLabel)
+	e.values = append(values, e.values[pos:]...)
+}
+
+func (e *enumTypeState) toString(buf *strings.Builder, schemaName string) error {
+	var values []string
+	for _, value := range e.values {
+		values = append(values, fmt.Sprintf("'%s'", escapePostgreSQLString(value)))
+	}
+	_, err := buf.WriteString(fmt.Sprintf("CREATE TYPE \"%s\".\"%s\" AS ENUM (%s);\n", schemaName, e.name, strings.Join(values, ", ")))
+	return err
+}
+
+func (e *enumTypeState) commentToString(buf *strings.Builder, schemaName string) error {
+	_, err := buf.WriteString(fmt.Sprintf("COMMENT ON TYPE \"%s\".\"%s\" IS '%s';\n", schemaName, e.name, escapePostgreSQLString(e.comment)))
+	return err
+}
+
+func sortedEnumTypes(m map[string]*enumTypeState) []*enumTypeState {
+	var result []*enumTypeState
+	for _, enumType := range m {
+		result = append(result, enumType)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+type compositeTypeAttributeState struct {
+	name string
+	tp   string
+}
+
+type compositeTypeState struct {
+	// ignore means this type is already in the target schema.
+	ignore     bool
+	id         int
+	name       string
+	attributes []*compositeTypeAttributeState
+	comment    string
+}
+
+func convertToCompositeTypeState(id int, compositeType *storepb.CompositeTypeMetadata) *compositeTypeState {
+	state := &compositeTypeState{
+		id:      id,
+		name:    compositeType.Name,
+		comment: compositeType.Comment,
+	}
+	for _, attribute := range compositeType.Attributes {
+		state.attributes = append(state.attributes, &compositeTypeAttributeState{
+			name: attribute.Name,
+			tp:   attribute.Type,
+		})
+	}
+	return state
+}
+
+func (c *compositeTypeState) convertToCompositeTypeMetadata() *storepb.CompositeTypeMetadata {
+	result := &storepb.CompositeTypeMetadata{
+		Name:    c.name,
+		Comment: c.comment,
+	}
+	for _, attribute := range c.attributes {
+		result.Attributes = append(result.Attributes, &storepb.CompositeTypeAttribute{
+			Name: attribute.name,
+			Type: attribute.tp,
+		})
+	}
+	return result
+}
+
+func (c *compositeTypeState) toString(buf *strings.Builder, schemaName string) error {
+	var attributes []string
+	for _, attribute := range c.attributes {
+		attributes = append(attributes, fmt.Sprintf("\"%s\" %s", attribute.name, attribute.tp))
+	}
+	_, err := buf.WriteString(fmt.Sprintf("CREATE TYPE \"%s\".\"%s\" AS (\n  %s\n);\n", schemaName, c.name, strings.Join(attributes, ",\n  ")))
+	return err
+}
+
+func (c *compositeTypeState) commentToString(buf *strings.Builder, schemaName string) error {
+	_, err := buf.WriteString(fmt.Sprintf("COMMENT ON TYPE \"%s\".\"%s\" IS '%s';\n", schemaName, c.name, escapePostgreSQLString(c.comment)))
+	return err
+}
+
+func sortedCompositeTypes(m map[string]*compositeTypeState) []*compositeTypeState {
+	var result []*compositeTypeState
+	for _, compositeType := range m {
+		result = append(result, compositeType)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+// normalizeSchemaName returns the public schema for unqualified object names.
+func normalizeSchemaName(schemaName string) string {
+	if schemaName == "" {
+		return "public"
+	}
+	return schemaName
+}
@@ -380,13 +380,29 @@ func (p *Provider) ListPullRequestFile(ctx context.Context, repositoryID, lastMe
 }
 
 type Comment struct {
-	Content     string `json:"content"`
-	CommentType string `json:"commentType"`
+	ID          int64        `json:"id,omitempty"`
+	Content     string       `json:"content"`
+	CommentType string       `json:"commentType,omitempty"`
+	IsDeleted   bool         `json:"isDeleted,omitempty"`
+	Author      *IdentityRef `json:"author,omitempty"`
+}
+
+// IdentityRef is the API message for Azure DevOps identity reference.
+type IdentityRef struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
 }
 
 type PullRequestThread struct {
-	Comments []*Comment `json:"comments"`
-	Status   string     `json:"status"`
+	ID        int64      `json:"id,omitempty"`
+	Comments  []*Comment `json:"comments"`
+	Status    string     `json:"status"`
+	IsDeleted bool       `json:"isDeleted,omitempty"`
+}
+
+// PullRequestThreadList is the API response for listing pull request threads.
+type PullRequestThreadList struct {
+	Value []*PullRequestThread `json:"value"`
 }
 
 // CreatePullRequestComment creates a pull request comment.
@@ -427,6 +443,147 @@ func (p *Provider) CreatePullRequestComment(ctx context.Context, repositoryID, p
 	return nil
 }
 
+// GetCurrentUserID returns the ID of the authenticated user, which is the public alias of the profile.
+func (p *Provider) GetCurrentUserID(ctx context.Context) (string, error) {
+	return p.getAuthenticatedProfilePublicAlias(ctx)
+}
+
+// ListPullRequestComments lists the first comment of each thread in the pull request.
+// By design, we encode the comment ID as <threadID>/<commentID> for Azure DevOps.
+//
+// Docs: https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-request-threads/list?view=azure-devops-rest-7.0&tabs=HTTP
+func (p *Provider) ListPullRequestComments(ctx context.Context, repositoryID, pullRequestID string) ([]*vcs.PullRequestComment, error) {
+	apiURL, err := p.getRepositoryAPIURL(repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	values := &url.Values{}
+	values.Set("api-version", "7.0")
+	url := fmt.Sprintf("%s/pullRequests/%s/threads?%s", apiURL, pullRequestID, values.Encode())
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+	if code != http.StatusOK {
+		return nil, errors.Errorf("failed to list threads, code: %v, body: %s", code, string(body))
+	}
+
+	var threads PullRequestThreadList
+	if err := json.Unmarshal([]byte(body), &threads); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal threads response body, code %v", code)
+	}
+	var comments []*vcs.PullRequestComment
+	for _, thread := range threads.Value {
+		if thread.IsDeleted || len(thread.Comments) == 0 {
+			continue
+		}
+		comment := thread.Comments[0]
+		if comment.IsDeleted {
+			continue
+		}
+		var authorID string
+		if comment.Author != nil {
+			authorID = comment.Author.ID
+		}
+		comments = append(comments, &vcs.PullRequestComment{
+			ID:       fmt.Sprintf("%d/%d", thread.ID, comment.ID),
+			AuthorID: authorID,
+			Content:  comment.Content,
+		})
+	}
+	return comments, nil
+}
+
+// UpdatePullRequestComment updates the comment in the pull request thread.
+//
+// Docs: https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-request-thread-comments/update?view=azure-devops-rest-7.0&tabs=HTTP
+func (p *Provider) UpdatePullRequestComment(ctx context.Context, repositoryID, pullRequestID, commentID, comment string) error {
+	threadID, threadCommentID, ok := strings.Cut(commentID, "/")
+	if !ok {
+		return errors.Errorf("invalid comment ID %q", commentID)
+	}
+	commentUpdatePayload, err := json.Marshal(&Comment{Content: comment})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for updating pull request comment")
+	}
+
+	apiURL, err := p.getRepositoryAPIURL(repositoryID)
+	if err != nil {
+		return err
+	}
+
+	values := &url.Values{}
+	values.Set("api-version", "7.0")
+	url := fmt.Sprintf("%s/pullRequests/%s/threads/%s/comments/%s?%s", apiURL, pullRequestID, threadID, threadCommentID, values.Encode())
+	code, body, err := internal.Patch(ctx, url, p.getAuthorization(), commentUpdatePayload)
+	if err != nil {
+		return errors.Wrapf(err, "PATCH %s", url)
+	}
+	if code != http.StatusOK {
+		return errors.Errorf("failed to update thread comment, code: %v, body: %s", code, string(body))
+	}
+
+	return nil
+}
+
+// PullRequestStatus is the API message for Azure DevOps pull request status.
+type PullRequestStatus struct {
+	State       string                   `json:"state"`
+	Description string                   `json:"description"`
+	Context     PullRequestStatusContext `json:"context"`
+	TargetURL   string                   `json:"targetUrl,omitempty"`
+}
+
+// PullRequestStatusContext is the API message for the context of Azure DevOps pull request status.
+type PullRequestStatusContext struct {
+	Name string `json:"name"`
+}
+
+// SetCommitStatus sets the status of the pull request, which can be required by the branch policy.
+// Azure DevOps evaluates the latest status of the same context, so the commit ID is not used.
+//
+// Docs: https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-request-statuses/create?view=azure-devops-rest-7.0&tabs=HTTP
+func (p *Provider) SetCommitStatus(ctx context.Context, repositoryID, pullRequestID, _ string, status *vcs.CommitStatus) error {
+	pullRequestStatus := &PullRequestStatus{
+		Description: status.Description,
+		Context:     PullRequestStatusContext{Name: status.Name},
+		TargetURL:   status.TargetURL,
+	}
+	switch status.State {
+	case vcs.CommitStatusPending:
+		pullRequestStatus.State = "pending"
+	case vcs.CommitStatusSuccess:
+		pullRequestStatus.State = "succeeded"
+	case vcs.CommitStatusFailure:
+		pullRequestStatus.State = "failed"
+	default:
+		return errors.Errorf("unsupported commit status state %q", status.State)
+	}
+	pullRequestStatusPayload, err := json.Marshal(pullRequestStatus)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for creating pull request status")
+	}
+
+	apiURL, err := p.getRepositoryAPIURL(repositoryID)
+	if err != nil {
+		return err
+	}
+
+	values := &url.Values{}
+	values.Set("api-version", "7.0")
+	url := fmt.Sprintf("%s/pullRequests/%s/statuses?%s", apiURL, pullRequestID, values.Encode())
+	code, body, err := internal.Post(ctx, url, p.getAuthorization(), pullRequestStatusPayload)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+	if code != http.StatusOK {
+		return errors.Errorf("failed to create pull request status, code: %v, body: %s", code, string(body))
+	}
+
+	return nil
+}
+
 // CreateWebhook creates a webhook in the organization, and returns the webhook ID which can be used in PatchWebhook.
 // API Version 7.0 do not specify the OAuth scope for creating webhook explicitly, but it works.
 //
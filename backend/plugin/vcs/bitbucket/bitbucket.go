@@ -59,6 +59,7 @@ func (*Provider) APIURL(instanceURL string) string {
 
 // User represents a Bitbucket Cloud API response for a user.
 type User struct {
+	UUID        string `json:"uuid,omitempty"`
 	DisplayName string `json:"display_name"`
 	Nickname    string `json:"nickname"`
 }
@@ -316,7 +317,16 @@ func (p *Provider) ListPullRequestFile(ctx context.Context, repositoryID, pullRe
 }
 
 type Comment struct {
+	ID      int64          `json:"id,omitempty"`
 	Content CommentContent `json:"content"`
+	Deleted bool           `json:"deleted,omitempty"`
+	User    *User          `json:"user,omitempty"`
+}
+
+// CommentListResponse is the API response for listing pull request comments.
+type CommentListResponse struct {
+	Values []*Comment `json:"values"`
+	Next   string     `json:"next"`
 }
 
 type CommentContent struct {
@@ -351,6 +361,166 @@ func (p *Provider) CreatePullRequestComment(ctx context.Context, repositoryID, p
 	return nil
 }
 
+// GetCurrentUserID returns the UUID of the authenticated user.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-get
+func (p *Provider) GetCurrentUserID(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("%s/user", p.APIURL(p.instanceURL))
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return "", errors.Wrapf(err, "GET %s", url)
+	}
+	if code >= 300 {
+		return "", errors.Errorf("failed to get the authenticated user from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal the authenticated user")
+	}
+	return user.UUID, nil
+}
+
+// ListPullRequestComments lists the comments on the pull request, excluding the deleted comments.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-get
+func (p *Provider) ListPullRequestComments(ctx context.Context, repositoryID, pullRequestID string) ([]*vcs.PullRequestComment, error) {
+	var res []*vcs.PullRequestComment
+	next := fmt.Sprintf("%s/repositories/%s/pullrequests/%s/comments?pagelen=%d", p.APIURL(p.instanceURL), repositoryID, pullRequestID, apiPageSize)
+	for next != "" {
+		var err error
+		var comments []*Comment
+		comments, next, err = p.fetchPaginatedCommentList(ctx, next)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		for _, comment := range comments {
+			if comment.Deleted {
+				continue
+			}
+			var authorID string
+			if comment.User != nil {
+				authorID = comment.User.UUID
+			}
+			res = append(res, &vcs.PullRequestComment{
+				ID:       strconv.FormatInt(comment.ID, 10),
+				AuthorID: authorID,
+				Content:  comment.Content.Raw,
+			})
+		}
+	}
+	return res, nil
+}
+
+// fetchPaginatedCommentList fetches pull request comments in the given page.
+func (p *Provider) fetchPaginatedCommentList(ctx context.Context, url string) (comments []*Comment, next string, err error) {
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, "", common.Errorf(common.NotFound, "failed to list pull request comment from URL %s", url)
+	} else if code >= 300 {
+		return nil, "", errors.Errorf("failed to list pull request comment from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var resp CommentListResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return nil, "", errors.Wrapf(err, "failed to unmarshal pull request comment data from Bitbucket Cloud instance %s", url)
+	}
+	return resp.Values, resp.Next, nil
+}
+
+// UpdatePullRequestComment updates a pull request comment.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-comment-id-put
+func (p *Provider) UpdatePullRequestComment(ctx context.Context, repositoryID, pullRequestID, commentID, comment string) error {
+	commentUpdatePayload, err := json.Marshal(Comment{Content: CommentContent{Raw: comment}})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for updating pull request comment")
+	}
+	url := fmt.Sprintf("%s/repositories/%s/pullrequests/%s/comments/%s", p.APIURL(p.instanceURL), repositoryID, pullRequestID, commentID)
+	code, body, err := internal.Put(ctx, url, p.getAuthorization(), commentUpdatePayload)
+	if err != nil {
+		return errors.Wrapf(err, "PUT %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to update pull request comment through URL %s", url)
+	}
+
+	if code != http.StatusOK {
+		return errors.Errorf("failed to update pull request comment through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
+// CommitStatus is the API message for Bitbucket build status.
+type CommitStatus struct {
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// SetCommitStatus sets the build status of the commit, the status with the same key is overwritten.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commit-statuses/#api-repositories-workspace-repo-slug-commit-commit-statuses-build-post
+func (p *Provider) SetCommitStatus(ctx context.Context, repositoryID, _, commitID string, status *vcs.CommitStatus) error {
+	commitStatus := CommitStatus{
+		Key:         status.Name,
+		Name:        status.Name,
+		URL:         status.TargetURL,
+		Description: status.Description,
+	}
+	switch status.State {
+	case vcs.CommitStatusPending:
+		commitStatus.State = "INPROGRESS"
+	case vcs.CommitStatusSuccess:
+		commitStatus.State = "SUCCESSFUL"
+	case vcs.CommitStatusFailure:
+		commitStatus.State = "FAILED"
+	default:
+		return errors.Errorf("unsupported commit status state %q", status.State)
+	}
+	commitStatusPayload, err := json.Marshal(commitStatus)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for setting commit status")
+	}
+	url := fmt.Sprintf("%s/repositories/%s/commit/%s/statuses/build", p.APIURL(p.instanceURL), repositoryID, commitID)
+	code, body, err := internal.Post(ctx, url, p.getAuthorization(), commitStatusPayload)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to set commit status through URL %s", url)
+	}
+
+	if code != http.StatusOK && code != http.StatusCreated {
+		return errors.Errorf("failed to set commit status through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
 // Link is the API message for link.
 type Link struct {
 	Href string `json:"href"`
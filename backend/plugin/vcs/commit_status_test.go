@@ -0,0 +1,148 @@
+package vcs_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	_ "github.com/bytebase/bytebase/backend/plugin/vcs/azure"
+	_ "github.com/bytebase/bytebase/backend/plugin/vcs/bitbucket"
+	_ "github.com/bytebase/bytebase/backend/plugin/vcs/gitea"
+	_ "github.com/bytebase/bytebase/backend/plugin/vcs/github"
+	_ "github.com/bytebase/bytebase/backend/plugin/vcs/gitlab"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestSetCommitStatus(t *testing.T) {
+	const targetURL = "https://bytebase.example.com/projects/p/plans/1"
+	tests := []struct {
+		vcsType           storepb.VCSType
+		repositoryID      string
+		wantPath          string
+		wantQuery         string
+		wantAuthorization string
+		// wantStates are the provider states of the pending, success and failure statuses.
+		wantStates  [3]string
+		wantPayload func(state string) map[string]any
+		// statusCode is the status code of the provider for the created status.
+		statusCode int
+	}{
+		{
+			vcsType:           storepb.VCSType_GITHUB,
+			repositoryID:      "owner/repo",
+			wantPath:          "/api/v3/repos/owner/repo/statuses/abc123",
+			wantAuthorization: "Bearer token",
+			wantStates:        [3]string{"pending", "success", "failure"},
+			wantPayload: func(state string) map[string]any {
+				return map[string]any{"state": state, "context": "bytebase/plan-check", "description": "Plan checks passed", "target_url": targetURL}
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			vcsType:           storepb.VCSType_GITLAB,
+			repositoryID:      "42",
+			wantPath:          "/api/v4/projects/42/statuses/abc123",
+			wantAuthorization: "Bearer token",
+			wantStates:        [3]string{"running", "success", "failed"},
+			wantPayload: func(state string) map[string]any {
+				return map[string]any{"state": state, "name": "bytebase/plan-check", "description": "Plan checks passed", "target_url": targetURL}
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			vcsType:           storepb.VCSType_BITBUCKET,
+			repositoryID:      "workspace/repo",
+			wantPath:          "/2.0/repositories/workspace/repo/commit/abc123/statuses/build",
+			wantAuthorization: "Basic dG9rZW4=",
+			wantStates:        [3]string{"INPROGRESS", "SUCCESSFUL", "FAILED"},
+			wantPayload: func(state string) map[string]any {
+				return map[string]any{"key": "bytebase/plan-check", "state": state, "name": "bytebase/plan-check", "description": "Plan checks passed", "url": targetURL}
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			vcsType:           storepb.VCSType_AZURE_DEVOPS,
+			repositoryID:      "org/project/repo",
+			wantPath:          "/org/project/_apis/git/repositories/repo/pullRequests/7/statuses",
+			wantQuery:         "api-version=7.0",
+			wantAuthorization: "Basic OnRva2Vu",
+			wantStates:        [3]string{"pending", "succeeded", "failed"},
+			wantPayload: func(state string) map[string]any {
+				return map[string]any{"state": state, "description": "Plan checks passed", "context": map[string]any{"name": "bytebase/plan-check"}, "targetUrl": targetURL}
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			vcsType:           storepb.VCSType_GITEA,
+			repositoryID:      "owner/repo",
+			wantPath:          "/api/v1/repos/owner/repo/statuses/abc123",
+			wantAuthorization: "token token",
+			wantStates:        [3]string{"pending", "success", "failure"},
+			wantPayload: func(state string) map[string]any {
+				return map[string]any{"state": state, "context": "bytebase/plan-check", "description": "Plan checks passed", "target_url": targetURL}
+			},
+			statusCode: http.StatusCreated,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		for i, state := range []vcs.CommitStatusState{vcs.CommitStatusPending, vcs.CommitStatusSuccess, vcs.CommitStatusFailure} {
+			var method, path, query, authorization string
+			var payload map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method, path, query, authorization = r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("Authorization")
+				body, err := io.ReadAll(r.Body)
+				a.NoError(err)
+				a.NoError(json.Unmarshal(body, &payload))
+				w.WriteHeader(test.statusCode)
+			}))
+
+			p := vcs.Get(test.vcsType, vcs.ProviderConfig{InstanceURL: server.URL, AuthToken: "token"})
+			err := p.SetCommitStatus(context.Background(), test.repositoryID, "7", "abc123", &vcs.CommitStatus{
+				State:       state,
+				Name:        "bytebase/plan-check",
+				Description: "Plan checks passed",
+				TargetURL:   targetURL,
+			})
+			server.Close()
+			a.NoError(err, test.vcsType)
+			a.Equal(http.MethodPost, method, test.vcsType)
+			a.Equal(test.wantPath, path, test.vcsType)
+			a.Equal(test.wantQuery, query, test.vcsType)
+			a.Equal(test.wantAuthorization, authorization, test.vcsType)
+			a.Equal(test.wantPayload(test.wantStates[i]), payload, test.vcsType)
+		}
+
+		// The errors from the provider and the unknown states are returned.
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		p := vcs.Get(test.vcsType, vcs.ProviderConfig{InstanceURL: server.URL, AuthToken: "token"})
+		err := p.SetCommitStatus(context.Background(), test.repositoryID, "7", "abc123", &vcs.CommitStatus{State: vcs.CommitStatusSuccess, Name: "bytebase/plan-check"})
+		a.Error(err, test.vcsType)
+		err = p.SetCommitStatus(context.Background(), test.repositoryID, "7", "abc123", &vcs.CommitStatus{State: "unknown", Name: "bytebase/plan-check"})
+		a.Error(err, test.vcsType)
+		server.Close()
+	}
+}
+
+func TestSetCommitStatusGitLabSameState(t *testing.T) {
+	a := require.New(t)
+	// GitLab rejects setting the status to its current state, which is not an error for us.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"Cannot transition status via :run from :running"}`))
+	}))
+	defer server.Close()
+
+	p := vcs.Get(storepb.VCSType_GITLAB, vcs.ProviderConfig{InstanceURL: server.URL, AuthToken: "token"})
+	err := p.SetCommitStatus(context.Background(), "42", "7", "abc123", &vcs.CommitStatus{State: vcs.CommitStatusPending, Name: "bytebase/plan-check"})
+	a.NoError(err)
+}
@@ -0,0 +1,574 @@
+// Package gitea is the plugin for Gitea and Forgejo.
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/plugin/vcs/internal"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// apiPageSize is the default page size when making API requests.
+	//
+	// NOTE: Gitea caps the page size with the MAX_RESPONSE_ITEMS setting, which
+	// is 50 by default.
+	apiPageSize = 50
+)
+
+func init() {
+	vcs.Register(storepb.VCSType_GITEA, newProvider)
+}
+
+var _ vcs.Provider = (*Provider)(nil)
+
+// Provider is a Gitea VCS provider, Forgejo shares the same API.
+type Provider struct {
+	client      *http.Client
+	instanceURL string
+	authToken   string
+}
+
+func newProvider(config vcs.ProviderConfig) vcs.Provider {
+	return &Provider{
+		client:      &http.Client{},
+		instanceURL: config.InstanceURL,
+		authToken:   config.AuthToken,
+	}
+}
+
+// APIURL returns the API URL path of Gitea.
+func (*Provider) APIURL(instanceURL string) string {
+	return fmt.Sprintf("%s/api/v1", strings.TrimSuffix(instanceURL, "/"))
+}
+
+// Repository represents a Gitea API response for a repository.
+type Repository struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	HTMLURL     string `json:"html_url"`
+	Permissions struct {
+		Admin bool `json:"admin"`
+	} `json:"permissions"`
+}
+
+// WebhookInfo represents a Gitea API response for the webhook information.
+type WebhookInfo struct {
+	ID int `json:"id"`
+}
+
+// WebhookConfig represents the Gitea API message for webhook configuration.
+type WebhookConfig struct {
+	// URL is the URL to which the payloads will be delivered.
+	URL string `json:"url"`
+	// ContentType is the media type used to serialize the payloads. Supported
+	// values include "json" and "form".
+	ContentType string `json:"content_type"`
+	// Secret is the secret will be used as the key to generate the HMAC hex digest
+	// value for the X-Gitea-Signature header.
+	Secret string `json:"secret"`
+}
+
+// WebhookCreateOrUpdate represents a Gitea API request for creating a webhook.
+type WebhookCreateOrUpdate struct {
+	// Type is the webhook type, "gitea" is the plain JSON webhook. Forgejo
+	// accepts "gitea" as well as "forgejo".
+	Type string `json:"type"`
+	// Config contains settings for the webhook.
+	Config WebhookConfig `json:"config"`
+	// Events determines what events the hook is triggered for.
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
+
+// FetchRepositoryList fetches all repositories where the authenticated user
+// has admin permissions, which is required to create webhook in the repository.
+//
+// Docs: https://gitea.com/api/swagger#/user/userCurrentListRepos
+func (p *Provider) FetchRepositoryList(ctx context.Context, listAll bool) ([]*vcs.Repository, error) {
+	var giteaRepos []Repository
+	page := 1
+	for {
+		repos, hasNextPage, err := p.fetchPaginatedRepositoryList(ctx, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		giteaRepos = append(giteaRepos, repos...)
+
+		if !hasNextPage || !listAll {
+			break
+		}
+		page++
+	}
+
+	var allRepos []*vcs.Repository
+	for _, r := range giteaRepos {
+		if !r.Permissions.Admin {
+			continue
+		}
+		// The API identifies the repository by the "owner/name", so we use the full name as the ID.
+		allRepos = append(allRepos,
+			&vcs.Repository{
+				ID:       r.FullName,
+				Name:     r.Name,
+				FullPath: r.FullName,
+				WebURL:   r.HTMLURL,
+			},
+		)
+	}
+	return allRepos, nil
+}
+
+// fetchPaginatedRepositoryList fetches repositories where the authenticated
+// user has access to in given page. It returns the paginated results along
+// with a boolean indicating whether the next page exists.
+func (p *Provider) fetchPaginatedRepositoryList(ctx context.Context, page int) (repos []Repository, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/user/repos?page=%d&limit=%d", p.APIURL(p.instanceURL), page, apiPageSize)
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, "failed to fetch repository list from URL %s", url)
+	} else if code >= 300 {
+		return nil, false,
+			errors.Errorf("failed to fetch repository list from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	if err := json.Unmarshal([]byte(body), &repos); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal")
+	}
+	return repos, len(repos) >= apiPageSize, nil
+}
+
+// ReadFileContent reads the content of the given file in the repository.
+//
+// Docs: https://gitea.com/api/swagger#/repository/repoGetRawFile
+func (p *Provider) ReadFileContent(ctx context.Context, repositoryID, filePath string, refInfo vcs.RefInfo) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/raw/%s?ref=%s", p.APIURL(p.instanceURL), repositoryID, escapeFilePath(filePath), url.QueryEscape(refInfo.RefName))
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return "", errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, "failed to read file content from URL %s", url)
+	} else if code >= 300 {
+		return "",
+			errors.Errorf("failed to read file content from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+	return body, nil
+}
+
+// escapeFilePath escapes each segment of the file path, the raw file API
+// takes the file path as the trailing URL path.
+func escapeFilePath(filePath string) string {
+	segments := strings.Split(strings.TrimPrefix(filePath, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// PullRequestFile is the API message for files in Gitea pull request.
+type PullRequestFile struct {
+	FileName string `json:"filename"`
+	// The file status in Gitea PR.
+	// Available values: "added", "deleted", "changed", "renamed", "copied", "unchanged"
+	Status string `json:"status"`
+}
+
+// PullRequest is the API message for Gitea pull request.
+type PullRequest struct {
+	Number  int         `json:"number"`
+	HTMLURL string      `json:"html_url"`
+	Head    EventBranch `json:"head"`
+}
+
+// ListPullRequestFile lists the changed files in the pull request.
+//
+// Docs: https://gitea.com/api/swagger#/repository/repoGetPullRequestFiles
+func (p *Provider) ListPullRequestFile(ctx context.Context, repositoryID, pullRequestID string) ([]*vcs.PullRequestFile, error) {
+	// Unlike GitHub, the changed file does not carry the ref, so we use the
+	// head commit of the pull request.
+	pullRequest, err := p.getPullRequest(ctx, repositoryID, pullRequestID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get pull request")
+	}
+
+	var allPRFiles []PullRequestFile
+	page := 1
+	for {
+		fileList, err := p.listPaginatedPullRequestFile(ctx, repositoryID, pullRequestID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to list pull request file")
+		}
+
+		allPRFiles = append(allPRFiles, fileList...)
+		if len(fileList) < apiPageSize {
+			break
+		}
+		page++
+	}
+
+	var res []*vcs.PullRequestFile
+	for _, file := range allPRFiles {
+		res = append(res, &vcs.PullRequestFile{
+			Path:         file.FileName,
+			LastCommitID: pullRequest.Head.SHA,
+			IsDeleted:    file.Status == "deleted",
+		})
+	}
+	return res, nil
+}
+
+// getPullRequest gets the pull request by the index.
+//
+// Docs: https://gitea.com/api/swagger#/repository/repoGetPullRequest
+func (p *Provider) getPullRequest(ctx context.Context, repositoryID, pullRequestID string) (*PullRequest, error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls/%s", p.APIURL(p.instanceURL), repositoryID, pullRequestID)
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to get pull request from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to get pull request from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	pullRequest := new(PullRequest)
+	if err := json.Unmarshal([]byte(body), pullRequest); err != nil {
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+// listPaginatedPullRequestFile lists the changed files in the pull request with pagination.
+func (p *Provider) listPaginatedPullRequestFile(ctx context.Context, repositoryID, pullRequestID string, page int) ([]PullRequestFile, error) {
+	requestURL := fmt.Sprintf("%s/repos/%s/pulls/%s/files?limit=%d&page=%d", p.APIURL(p.instanceURL), repositoryID, pullRequestID, apiPageSize, page)
+	code, body, err := internal.Get(ctx, requestURL, p.getAuthorization())
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", requestURL)
+	}
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to list pull request file from URL %s", requestURL)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to list pull request file from URL %s, status code: %d, body: %s",
+			requestURL,
+			code,
+			body,
+		)
+	}
+
+	var prFiles []PullRequestFile
+	if err := json.Unmarshal([]byte(body), &prFiles); err != nil {
+		return nil, err
+	}
+	return prFiles, nil
+}
+
+// Comment is the API message for Gitea issue comment.
+type Comment struct {
+	ID   int64  `json:"id,omitempty"`
+	Body string `json:"body"`
+	User *User  `json:"user,omitempty"`
+}
+
+// User is the API message for Gitea user.
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+// GetCurrentUserID returns the ID of the authenticated user.
+//
+// Docs: https://gitea.com/api/swagger#/user/userGetCurrent
+func (p *Provider) GetCurrentUserID(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("%s/user", p.APIURL(p.instanceURL))
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return "", errors.Wrapf(err, "GET %s", url)
+	}
+	if code >= 300 {
+		return "", errors.Errorf("failed to get the authenticated user from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal the authenticated user")
+	}
+	return strconv.FormatInt(user.ID, 10), nil
+}
+
+// CreatePullRequestComment creates a comment on the pull request.
+//
+// Gitea shares the comment API between issues and pull requests.
+// Docs: https://gitea.com/api/swagger#/issue/issueCreateComment
+func (p *Provider) CreatePullRequestComment(ctx context.Context, repositoryID, pullRequestID, comment string) error {
+	commentCreatePayload, err := json.Marshal(Comment{Body: comment})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for creating pull request comment")
+	}
+	url := fmt.Sprintf("%s/repos/%s/issues/%s/comments", p.APIURL(p.instanceURL), repositoryID, pullRequestID)
+	code, body, err := internal.Post(ctx, url, p.getAuthorization(), commentCreatePayload)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to create pull request comment through URL %s", url)
+	}
+
+	// Gitea returns 201 HTTP status codes upon successful issue comment creation,
+	if code != http.StatusCreated {
+		return errors.Errorf("failed to create pull request comment through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
+// ListPullRequestComments lists the comments on the pull request.
+//
+// Docs: https://gitea.com/api/swagger#/issue/issueGetComments
+func (p *Provider) ListPullRequestComments(ctx context.Context, repositoryID, pullRequestID string) ([]*vcs.PullRequestComment, error) {
+	url := fmt.Sprintf("%s/repos/%s/issues/%s/comments", p.APIURL(p.instanceURL), repositoryID, pullRequestID)
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to list pull request comment from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to list pull request comment from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var comments []*Comment
+	if err := json.Unmarshal([]byte(body), &comments); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal pull request comments")
+	}
+	var res []*vcs.PullRequestComment
+	for _, comment := range comments {
+		var authorID string
+		if comment.User != nil {
+			authorID = strconv.FormatInt(comment.User.ID, 10)
+		}
+		res = append(res, &vcs.PullRequestComment{
+			ID:       strconv.FormatInt(comment.ID, 10),
+			AuthorID: authorID,
+			Content:  comment.Body,
+		})
+	}
+	return res, nil
+}
+
+// UpdatePullRequestComment updates the comment on the pull request.
+//
+// Docs: https://gitea.com/api/swagger#/issue/issueEditComment
+func (p *Provider) UpdatePullRequestComment(ctx context.Context, repositoryID, _, commentID, comment string) error {
+	commentPatch, err := json.Marshal(Comment{Body: comment})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for updating pull request comment")
+	}
+	url := fmt.Sprintf("%s/repos/%s/issues/comments/%s", p.APIURL(p.instanceURL), repositoryID, commentID)
+	code, body, err := internal.Patch(ctx, url, p.getAuthorization(), commentPatch)
+	if err != nil {
+		return errors.Wrapf(err, "PATCH %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to update pull request comment through URL %s", url)
+	}
+
+	if code != http.StatusOK {
+		return errors.Errorf("failed to update pull request comment through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
+// CommitStatusCreate is the API message to create the commit status.
+type CommitStatusCreate struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"`
+}
+
+// SetCommitStatus sets the status of the commit, the status with the same context is overwritten.
+//
+// Docs: https://gitea.com/api/swagger#/repository/repoCreateStatus
+func (p *Provider) SetCommitStatus(ctx context.Context, repositoryID, _, commitID string, status *vcs.CommitStatus) error {
+	statusCreate := CommitStatusCreate{
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+		Context:     status.Name,
+	}
+	switch status.State {
+	case vcs.CommitStatusPending:
+		statusCreate.State = "pending"
+	case vcs.CommitStatusSuccess:
+		statusCreate.State = "success"
+	case vcs.CommitStatusFailure:
+		statusCreate.State = "failure"
+	default:
+		return errors.Errorf("unsupported commit status state %q", status.State)
+	}
+	statusCreateBody, err := json.Marshal(statusCreate)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for setting commit status")
+	}
+	url := fmt.Sprintf("%s/repos/%s/statuses/%s", p.APIURL(p.instanceURL), repositoryID, commitID)
+	code, body, err := internal.Post(ctx, url, p.getAuthorization(), statusCreateBody)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to set commit status through URL %s", url)
+	}
+
+	if code != http.StatusCreated {
+		return errors.Errorf("failed to set commit status through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
+// Branch is the API message for Gitea branch.
+type Branch struct {
+	Name   string       `json:"name"`
+	Commit BranchCommit `json:"commit"`
+}
+
+// BranchCommit is the latest commit of the Gitea branch.
+type BranchCommit struct {
+	ID string `json:"id"`
+}
+
+// GetBranch gets the given branch in the repository.
+//
+// Docs: https://gitea.com/api/swagger#/repository/repoGetBranch
+func (p *Provider) GetBranch(ctx context.Context, repositoryID, branchName string) (*vcs.BranchInfo, error) {
+	url := fmt.Sprintf("%s/repos/%s/branches/%s", p.APIURL(p.instanceURL), repositoryID, escapeFilePath(branchName))
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to get branch from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to get branch from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	res := new(Branch)
+	if err := json.Unmarshal([]byte(body), res); err != nil {
+		return nil, err
+	}
+	return &vcs.BranchInfo{
+		Name:         res.Name,
+		LastCommitID: res.Commit.ID,
+	}, nil
+}
+
+// CreateWebhook creates a webhook in the repository with given payload.
+//
+// Docs: https://gitea.com/api/swagger#/repository/repoCreateHook
+func (p *Provider) CreateWebhook(ctx context.Context, repositoryID string, payload []byte) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/hooks", p.APIURL(p.instanceURL), repositoryID)
+	code, body, err := internal.Post(ctx, url, p.getAuthorization(), payload)
+	if err != nil {
+		return "", errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, "failed to create webhook through URL %s", url)
+	}
+
+	// Gitea returns 201 HTTP status codes upon successful webhook creation.
+	if code != http.StatusCreated {
+		return "", errors.Errorf("failed to create webhook through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var webhookInfo WebhookInfo
+	if err = json.Unmarshal([]byte(body), &webhookInfo); err != nil {
+		return "", errors.Wrap(err, "unmarshal body")
+	}
+	return strconv.Itoa(webhookInfo.ID), nil
+}
+
+// DeleteWebhook deletes the webhook from the repository.
+//
+// Docs: https://gitea.com/api/swagger#/repository/repoDeleteHook
+func (p *Provider) DeleteWebhook(ctx context.Context, repositoryID, webhookID string) error {
+	url := fmt.Sprintf("%s/repos/%s/hooks/%s", p.APIURL(p.instanceURL), repositoryID, webhookID)
+	code, body, err := internal.Delete(ctx, url, p.getAuthorization())
+	if err != nil {
+		return errors.Wrapf(err, "DELETE %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil // It is OK if the webhook has already gone
+	} else if code >= 300 {
+		return errors.Errorf("failed to delete webhook through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
+func (p *Provider) getAuthorization() string {
+	return fmt.Sprintf("token %s", p.authToken)
+}
@@ -330,7 +330,39 @@ func (p *Provider) listPaginatedPullRequestFile(ctx context.Context, repositoryI
 }
 
 type Comment struct {
+	ID   int64  `json:"id,omitempty"`
 	Body string `json:"body"`
+	User *User  `json:"user,omitempty"`
+}
+
+// User is the API message for GitHub user.
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+// GetCurrentUserID returns the ID of the authenticated user.
+//
+// Docs: https://docs.github.com/en/rest/users/users?apiVersion=2022-11-28#get-the-authenticated-user
+func (p *Provider) GetCurrentUserID(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("%s/user", p.APIURL(p.instanceURL))
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return "", errors.Wrapf(err, "GET %s", url)
+	}
+	if code >= 300 {
+		return "", errors.Errorf("failed to get the authenticated user from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal the authenticated user")
+	}
+	return strconv.FormatInt(user.ID, 10), nil
 }
 
 // CreatePullRequestComment creates a comment on the pull request.
@@ -366,6 +398,141 @@ func (p *Provider) CreatePullRequestComment(ctx context.Context, repositoryID, p
 	return nil
 }
 
+// ListPullRequestComments lists the issue comments on the pull request.
+//
+// Docs: https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#list-issue-comments
+func (p *Provider) ListPullRequestComments(ctx context.Context, repositoryID, pullRequestID string) ([]*vcs.PullRequestComment, error) {
+	var res []*vcs.PullRequestComment
+	page := 1
+	for {
+		comments, err := p.listPaginatedPullRequestComment(ctx, repositoryID, pullRequestID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list pull request comment")
+		}
+		for _, comment := range comments {
+			var authorID string
+			if comment.User != nil {
+				authorID = strconv.FormatInt(comment.User.ID, 10)
+			}
+			res = append(res, &vcs.PullRequestComment{
+				ID:       strconv.FormatInt(comment.ID, 10),
+				AuthorID: authorID,
+				Content:  comment.Body,
+			})
+		}
+		if len(comments) < apiPageSize {
+			break
+		}
+		page++
+	}
+	return res, nil
+}
+
+// listPaginatedPullRequestComment lists the issue comments on the pull request with pagination.
+func (p *Provider) listPaginatedPullRequestComment(ctx context.Context, repositoryID, pullRequestID string, page int) ([]Comment, error) {
+	requestURL := fmt.Sprintf("%s/repos/%s/issues/%s/comments?per_page=%d&page=%d", p.APIURL(p.instanceURL), repositoryID, pullRequestID, apiPageSize, page)
+	code, body, err := internal.Get(ctx, requestURL, p.getAuthorization())
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", requestURL)
+	}
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to list pull request comment from URL %s", requestURL)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to list pull request comment from URL %s, status code: %d, body: %s",
+			requestURL,
+			code,
+			body,
+		)
+	}
+
+	var comments []Comment
+	if err := json.Unmarshal([]byte(body), &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// UpdatePullRequestComment updates the issue comment on the pull request.
+//
+// Docs: https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#update-an-issue-comment
+func (p *Provider) UpdatePullRequestComment(ctx context.Context, repositoryID, _, commentID, comment string) error {
+	commentPatch, err := json.Marshal(Comment{Body: comment})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for updating pull request comment")
+	}
+	url := fmt.Sprintf("%s/repos/%s/issues/comments/%s", p.APIURL(p.instanceURL), repositoryID, commentID)
+	code, body, err := internal.Patch(ctx, url, p.getAuthorization(), commentPatch)
+	if err != nil {
+		return errors.Wrapf(err, "PATCH %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to update pull request comment through URL %s", url)
+	}
+
+	if code != http.StatusOK {
+		return errors.Errorf("failed to update pull request comment through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
+// CommitStatusCreate is the API message to create the commit status.
+type CommitStatusCreate struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"`
+}
+
+// SetCommitStatus sets the status of the commit, GitHub shows the latest status of the same context.
+// We use the commit statuses rather than the check runs, because only GitHub Apps can create check runs,
+// while the provider authenticates with the personal access token. Branch protection accepts both as required checks.
+//
+// Docs: https://docs.github.com/en/rest/commits/statuses#create-a-commit-status
+func (p *Provider) SetCommitStatus(ctx context.Context, repositoryID, _, commitID string, status *vcs.CommitStatus) error {
+	statusCreate := CommitStatusCreate{
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+		Context:     status.Name,
+	}
+	switch status.State {
+	case vcs.CommitStatusPending:
+		statusCreate.State = "pending"
+	case vcs.CommitStatusSuccess:
+		statusCreate.State = "success"
+	case vcs.CommitStatusFailure:
+		statusCreate.State = "failure"
+	default:
+		return errors.Errorf("unsupported commit status state %q", status.State)
+	}
+	statusCreateBody, err := json.Marshal(statusCreate)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for setting commit status")
+	}
+	url := fmt.Sprintf("%s/repos/%s/statuses/%s", p.APIURL(p.instanceURL), repositoryID, commitID)
+	code, body, err := internal.Post(ctx, url, p.getAuthorization(), statusCreateBody)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to set commit status through URL %s", url)
+	}
+
+	if code != http.StatusCreated {
+		return errors.Errorf("failed to set commit status through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
 // Branch is the API message for GitHub branch.
 type Branch struct {
 	Ref    string          `json:"ref"`
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -312,6 +313,177 @@ func (p *Provider) CreatePullRequestComment(ctx context.Context, repositoryID, p
 	return nil
 }
 
+// MergeRequestNote is the API message for GitLab merge request note.
+type MergeRequestNote struct {
+	ID     int64  `json:"id"`
+	Body   string `json:"body"`
+	System bool   `json:"system"`
+	Author User   `json:"author"`
+}
+
+// User is the API message for GitLab user.
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// GetCurrentUserID returns the ID of the authenticated user.
+//
+// Docs: https://docs.gitlab.com/ee/api/users.html#list-current-user
+func (p *Provider) GetCurrentUserID(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("%s/user", p.APIURL(p.instanceURL))
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return "", errors.Wrapf(err, "GET %s", url)
+	}
+	if code >= 300 {
+		return "", errors.Errorf("failed to get the authenticated user from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal the authenticated user")
+	}
+	return strconv.FormatInt(user.ID, 10), nil
+}
+
+// ListPullRequestComments lists the notes on the merge request, excluding the system notes.
+//
+// Docs: https://docs.gitlab.com/ee/api/notes.html#list-all-merge-request-notes
+func (p *Provider) ListPullRequestComments(ctx context.Context, repositoryID, pullRequestID string) ([]*vcs.PullRequestComment, error) {
+	var res []*vcs.PullRequestComment
+	page := 1
+	for {
+		notes, err := p.listPaginatedMergeRequestNote(ctx, repositoryID, pullRequestID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list merge request note")
+		}
+		for _, note := range notes {
+			if note.System {
+				continue
+			}
+			res = append(res, &vcs.PullRequestComment{
+				ID:       strconv.FormatInt(note.ID, 10),
+				AuthorID: strconv.FormatInt(note.Author.ID, 10),
+				Content:  note.Body,
+			})
+		}
+		if len(notes) < apiPageSize {
+			break
+		}
+		page++
+	}
+	return res, nil
+}
+
+// listPaginatedMergeRequestNote lists the notes on the merge request with pagination.
+func (p *Provider) listPaginatedMergeRequestNote(ctx context.Context, repositoryID, pullRequestID string, page int) ([]MergeRequestNote, error) {
+	url := fmt.Sprintf("%s/projects/%s/merge_requests/%s/notes?page=%d&per_page=%d", p.APIURL(p.instanceURL), repositoryID, pullRequestID, page, apiPageSize)
+	code, body, err := internal.Get(ctx, url, p.getAuthorization())
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to list merge request note from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to list merge request note from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var notes []MergeRequestNote
+	if err := json.Unmarshal([]byte(body), &notes); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal merge request notes")
+	}
+	return notes, nil
+}
+
+// UpdatePullRequestComment updates the note on the merge request.
+//
+// Docs: https://docs.gitlab.com/ee/api/notes.html#modify-existing-merge-request-note
+func (p *Provider) UpdatePullRequestComment(ctx context.Context, repositoryID, pullRequestID, commentID, comment string) error {
+	url := fmt.Sprintf("%s/projects/%s/merge_requests/%s/notes/%s?body=%s", p.APIURL(p.instanceURL), repositoryID, pullRequestID, commentID, url.QueryEscape(comment))
+	code, body, err := internal.Put(ctx, url, p.getAuthorization(), nil)
+	if err != nil {
+		return errors.Wrapf(err, "PUT %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to update merge request note through URL %s", url)
+	}
+
+	if code != http.StatusOK {
+		return errors.Errorf("failed to update merge request note through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
+// CommitStatusCreate is the API message to create the commit status.
+type CommitStatusCreate struct {
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SetCommitStatus sets the status of the commit.
+//
+// Docs: https://docs.gitlab.com/ee/api/commits.html#set-the-pipeline-status-of-a-commit
+func (p *Provider) SetCommitStatus(ctx context.Context, repositoryID, _, commitID string, status *vcs.CommitStatus) error {
+	statusCreate := CommitStatusCreate{
+		Name:        status.Name,
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+	}
+	switch status.State {
+	case vcs.CommitStatusPending:
+		statusCreate.State = "running"
+	case vcs.CommitStatusSuccess:
+		statusCreate.State = "success"
+	case vcs.CommitStatusFailure:
+		statusCreate.State = "failed"
+	default:
+		return errors.Errorf("unsupported commit status state %q", status.State)
+	}
+	statusCreateBody, err := json.Marshal(statusCreate)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request body for setting commit status")
+	}
+	url := fmt.Sprintf("%s/projects/%s/statuses/%s", p.APIURL(p.instanceURL), repositoryID, commitID)
+	code, body, err := internal.Post(ctx, url, p.getAuthorization(), statusCreateBody)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to set commit status through URL %s", url)
+	}
+
+	// GitLab rejects setting the same state again, e.g. "Cannot transition status via :run from :running".
+	if code == http.StatusBadRequest && strings.Contains(body, "Cannot transition status") {
+		return nil
+	}
+
+	if code != http.StatusCreated {
+		return errors.Errorf("failed to set commit status through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
 // Branch is the API message for GitLab branch.
 type Branch struct {
 	Name   string `json:"name"`
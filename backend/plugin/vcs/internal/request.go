@@ -25,6 +25,16 @@ func GetWithHeader(ctx context.Context, url string, authorization string, header
 	return request(ctx, http.MethodGet, url, authorization, header, bytes.NewReader(nil))
 }
 
+// Patch makes a HTTP PATCH request to the given URL.
+func Patch(ctx context.Context, url string, authorization string, body []byte) (code int, respBody string, err error) {
+	return request(ctx, http.MethodPatch, url, authorization, nil, bytes.NewReader(body))
+}
+
+// Put makes a HTTP PUT request to the given URL.
+func Put(ctx context.Context, url string, authorization string, body []byte) (code int, respBody string, err error) {
+	return request(ctx, http.MethodPut, url, authorization, nil, bytes.NewReader(body))
+}
+
 // Delete makes a HTTP DELETE request to the given URL.
 func Delete(ctx context.Context, url string, authorization string) (code int, respBody string, err error) {
 	return request(ctx, http.MethodDelete, url, authorization, nil, bytes.NewReader(nil))
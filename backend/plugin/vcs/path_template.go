@@ -0,0 +1,124 @@
+package vcs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The placeholders supported in the file path template.
+const (
+	// PlaceholderEnvironmentID is the placeholder for the environment resource ID.
+	PlaceholderEnvironmentID = "{{ENV_ID}}"
+	// PlaceholderDatabaseName is the placeholder for the database name.
+	PlaceholderDatabaseName = "{{DB_NAME}}"
+	// PlaceholderVersion is the placeholder for the schema version.
+	PlaceholderVersion = "{{VERSION}}"
+	// PlaceholderType is the placeholder for the change type, one of "ddl", "dml" and "ghost".
+	PlaceholderType = "{{TYPE}}"
+	// PlaceholderDescription is the placeholder for the change description.
+	PlaceholderDescription = "{{DESC}}"
+)
+
+var (
+	placeholderRE = regexp.MustCompile(`{{[^{}]*}}`)
+
+	placeholderPatterns = map[string]string{
+		PlaceholderEnvironmentID: `(?P<env>[^/]+)`,
+		PlaceholderDatabaseName:  `(?P<db>[^/]+)`,
+		PlaceholderVersion:       `(?P<version>[0-9]+)`,
+		PlaceholderType:          `(?P<type>(?i:ddl|dml|ghost))`,
+		PlaceholderDescription:   `(?P<desc>[^/]*)`,
+	}
+)
+
+// FilePathTemplate is the parsed file path template of a VCS connector, for
+// example, "{{ENV_ID}}/{{DB_NAME}}/{{VERSION}}_{{TYPE}}_{{DESC}}.sql".
+// The template is relative to the base directory of the VCS connector.
+type FilePathTemplate struct {
+	template string
+	re       *regexp.Regexp
+}
+
+// FilePathTemplateMatch is the values captured from a file path by the template.
+// The fields absent from the template are empty.
+type FilePathTemplateMatch struct {
+	EnvironmentID string
+	DatabaseName  string
+	Version       string
+	// Type is in lower case.
+	Type        string
+	Description string
+}
+
+// ParseFilePathTemplate parses the file path template.
+func ParseFilePathTemplate(template string) (*FilePathTemplate, error) {
+	if template == "" {
+		return nil, errors.New("file path template is empty")
+	}
+	if strings.HasPrefix(template, "/") {
+		return nil, errors.Errorf("file path template %q should be relative to the base directory", template)
+	}
+
+	var pattern strings.Builder
+	seen := make(map[string]bool)
+	last := 0
+	for _, loc := range placeholderRE.FindAllStringIndex(template, -1) {
+		placeholder := template[loc[0]:loc[1]]
+		p, ok := placeholderPatterns[placeholder]
+		if !ok {
+			return nil, errors.Errorf("unknown placeholder %q in file path template %q", placeholder, template)
+		}
+		if seen[placeholder] {
+			return nil, errors.Errorf("duplicate placeholder %q in file path template %q", placeholder, template)
+		}
+		seen[placeholder] = true
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		pattern.WriteString(p)
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+
+	re, err := regexp.Compile(fmt.Sprintf("^%s$", pattern.String()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid file path template %q", template)
+	}
+	return &FilePathTemplate{template: template, re: re}, nil
+}
+
+// Has returns true if the template contains the placeholder.
+func (t *FilePathTemplate) Has(placeholder string) bool {
+	return strings.Contains(t.template, placeholder)
+}
+
+// AddressDatabase returns true if the template addresses the databases by the environment or the database name.
+func (t *FilePathTemplate) AddressDatabase() bool {
+	return t.Has(PlaceholderEnvironmentID) || t.Has(PlaceholderDatabaseName)
+}
+
+// Match matches the file path relative to the base directory against the template.
+// It returns nil if the file path does not match.
+func (t *FilePathTemplate) Match(path string) *FilePathTemplateMatch {
+	matches := t.re.FindStringSubmatch(path)
+	if matches == nil {
+		return nil
+	}
+	result := &FilePathTemplateMatch{}
+	for i, name := range t.re.SubexpNames() {
+		switch name {
+		case "env":
+			result.EnvironmentID = matches[i]
+		case "db":
+			result.DatabaseName = matches[i]
+		case "version":
+			result.Version = matches[i]
+		case "type":
+			result.Type = strings.ToLower(matches[i])
+		case "desc":
+			result.Description = matches[i]
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package vcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilePathTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  bool
+	}{
+		{template: "{{ENV_ID}}/{{DB_NAME}}/{{VERSION}}_{{TYPE}}_{{DESC}}.sql"},
+		{template: "{{VERSION}}.sql"},
+		{template: "", wantErr: true},
+		{template: "/{{VERSION}}.sql", wantErr: true},
+		{template: "{{DB_NAME}}.sql"},
+		{template: "{{VERSION}}_{{FOO}}.sql", wantErr: true},
+		{template: "{{VERSION}}/{{VERSION}}.sql", wantErr: true},
+	}
+
+	for _, test := range tests {
+		_, err := ParseFilePathTemplate(test.template)
+		if test.wantErr {
+			require.Error(t, err, test.template)
+		} else {
+			require.NoError(t, err, test.template)
+		}
+	}
+}
+
+func TestFilePathTemplateMatch(t *testing.T) {
+	template, err := ParseFilePathTemplate("{{ENV_ID}}/{{DB_NAME}}/{{VERSION}}_{{TYPE}}_{{DESC}}.sql")
+	require.NoError(t, err)
+	require.True(t, template.AddressDatabase())
+
+	tests := []struct {
+		path string
+		want *FilePathTemplateMatch
+	}{
+		{
+			path: "prod/employee/0001_ddl_create_table.sql",
+			want: &FilePathTemplateMatch{
+				EnvironmentID: "prod",
+				DatabaseName:  "employee",
+				Version:       "0001",
+				Type:          "ddl",
+				Description:   "create_table",
+			},
+		},
+		{
+			path: "test/db.1/20240101_DML_.sql",
+			want: &FilePathTemplateMatch{
+				EnvironmentID: "test",
+				DatabaseName:  "db.1",
+				Version:       "20240101",
+				Type:          "dml",
+			},
+		},
+		// Missing the environment directory.
+		{path: "employee/0001_ddl_create_table.sql"},
+		// Unknown change type.
+		{path: "prod/employee/0001_foo_create_table.sql"},
+		// Nested deeper than the template.
+		{path: "prod/employee/v1/0001_ddl_create_table.sql"},
+		{path: "prod/employee/0001_ddl_create_table.txt"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, template.Match(test.path), test.path)
+	}
+}
@@ -62,6 +62,38 @@ type PullRequestFile struct {
 	IsDeleted    bool
 }
 
+// PullRequestComment is the API message for pull request comment.
+type PullRequestComment struct {
+	// ID is the provider specific comment ID used to update the comment.
+	ID string
+	// AuthorID is the provider specific ID of the user creating the comment.
+	AuthorID string
+	Content  string
+}
+
+// CommitStatusState is the state of a commit status.
+type CommitStatusState string
+
+const (
+	// CommitStatusPending means the checks or the rollout are in progress.
+	CommitStatusPending CommitStatusState = "pending"
+	// CommitStatusSuccess means the checks or the rollout succeeded.
+	CommitStatusSuccess CommitStatusState = "success"
+	// CommitStatusFailure means the checks or the rollout failed.
+	CommitStatusFailure CommitStatusState = "failure"
+)
+
+// CommitStatus is the API message for the status of a commit in the pull request.
+// It's mapped to check runs on GitHub, commit statuses on GitLab and Gitea, build statuses on Bitbucket
+// and pull request statuses on Azure DevOps.
+type CommitStatus struct {
+	State CommitStatusState
+	// Name identifies the status among the statuses of the commit, setting the status with the same name overrides the previous one.
+	Name        string
+	Description string
+	TargetURL   string
+}
+
 // BranchInfo is the API message for repository branch.
 type BranchInfo struct {
 	Name         string
@@ -88,6 +120,18 @@ type Provider interface {
 	// CreatePullRequestComment creates a pull request comment.
 	CreatePullRequestComment(ctx context.Context, repositoryID, pullRequestID, comment string) error
 
+	// GetCurrentUserID returns the provider specific ID of the user authenticated by the token, who creates the comments.
+	GetCurrentUserID(ctx context.Context) (string, error)
+
+	// ListPullRequestComments lists the comments of a pull request.
+	ListPullRequestComments(ctx context.Context, repositoryID, pullRequestID string) ([]*PullRequestComment, error)
+
+	// UpdatePullRequestComment updates the content of a pull request comment.
+	UpdatePullRequestComment(ctx context.Context, repositoryID, pullRequestID, commentID, comment string) error
+
+	// SetCommitStatus sets the status of the commit in the pull request.
+	SetCommitStatus(ctx context.Context, repositoryID, pullRequestID, commitID string, status *CommitStatus) error
+
 	// Creates a webhook. Returns the created webhook ID on success.
 	CreateWebhook(ctx context.Context, repositoryID string, payload []byte) (string, error)
 
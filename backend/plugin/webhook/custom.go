@@ -2,13 +2,28 @@ package webhook
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
+	"text/template"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
+const (
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the custom webhook request.
+	// The value is "sha256=" followed by the hex encoded signature of "{timestamp}.{body}".
+	SignatureHeader = "X-Bytebase-Signature"
+	// TimestampHeader is the header of the unix timestamp when the custom webhook request is sent.
+	// The receiver should reject the request with a stale timestamp to prevent replay attacks.
+	TimestampHeader = "X-Bytebase-Timestamp"
+)
+
 // CustomWebhookResponse is the API message for Custom webhook response.
 type CustomWebhookResponse struct {
 	Code    int    `json:"code"`
@@ -51,9 +66,9 @@ func (*CustomReceiver) post(context Context) error {
 		Project:      context.Project,
 	}
 
-	body, err := json.Marshal(&payload)
+	body, err := getCustomWebhookBody(context.BodyTemplate, &payload)
 	if err != nil {
-		return errors.Wrapf(err, "failed to marshal webhook POST request to %s", context.URL)
+		return errors.Wrapf(err, "failed to render webhook POST request to %s", context.URL)
 	}
 	req, err := http.NewRequest("POST",
 		context.URL, bytes.NewBuffer(body))
@@ -62,9 +77,12 @@ func (*CustomReceiver) post(context Context) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{
-		Timeout: timeout,
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(TimestampHeader, timestamp)
+	if context.SigningSecret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(context.SigningSecret, timestamp, body))
 	}
+	client := context.newHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return errors.Wrapf(err, "failed to POST webhook to %s", context.URL)
@@ -76,6 +94,14 @@ func (*CustomReceiver) post(context Context) error {
 	}
 	defer resp.Body.Close()
 
+	// The templated body targets third-party services such as PagerDuty, whose responses don't follow CustomWebhookResponse.
+	if context.BodyTemplate != "" {
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			return errors.Errorf("failed to POST webhook %s, status code: %d, response body: %s", context.URL, resp.StatusCode, b)
+		}
+		return nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return errors.Errorf("failed to POST webhook %s, status code: %d, response body: %s", context.URL, resp.StatusCode, b)
 	}
@@ -91,3 +117,52 @@ func (*CustomReceiver) post(context Context) error {
 
 	return nil
 }
+
+// Sign returns the hex encoded HMAC-SHA256 signature of "{timestamp}.{body}" with the secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(timestamp))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateBodyTemplate validates the body template of the custom webhook by rendering it with an empty request,
+// which has the same shape as the requests of the events without an issue.
+func ValidateBodyTemplate(bodyTemplate string) error {
+	_, err := getCustomWebhookBody(bodyTemplate, &CustomWebhookRequest{})
+	return err
+}
+
+// getCustomWebhookBody renders the request body with the Go template, or marshals the request if the template is empty.
+// The template is executed with the CustomWebhookRequest, and the "json" function encodes a value as JSON.
+// The missing issue and project are rendered as the zero values, so a template such as "{{.Issue.Name}}" works for every event.
+func getCustomWebhookBody(bodyTemplate string, payload *CustomWebhookRequest) ([]byte, error) {
+	if bodyTemplate == "" {
+		return json.Marshal(payload)
+	}
+	data := *payload
+	if data.Issue == nil {
+		data.Issue = &Issue{}
+	}
+	if data.Project == nil {
+		data.Project = &Project{}
+	}
+	tmpl, err := template.New("body").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return string(b), nil
+		},
+	}).Parse(bodyTemplate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid body template")
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to execute body template")
+	}
+	return buf.Bytes(), nil
+}
@@ -2,6 +2,9 @@
 package webhook
 
 import (
+	"bytes"
+	"io"
+	"net/http"
 	"sync"
 	"time"
 
@@ -15,6 +18,8 @@ var (
 	receivers  = make(map[string]Receiver)
 	// Based on the local test, Teams sometimes cannot finish the request in 1 second, so use 3s.
 	timeout = 3 * time.Second
+	// responseBodyLimit is the maximum bytes of the response body kept in the delivery.
+	responseBodyLimit = 1024
 )
 
 // meta is the webhook metadata.
@@ -79,6 +84,23 @@ type Context struct {
 	Project             *Project
 	TaskResult          *TaskResult
 	MentionUsersByPhone []string
+	// SigningSecret is the secret to sign the custom webhook request, it's never persisted with the event.
+	SigningSecret string `json:"-"`
+	// BodyTemplate is the Go template to render the custom webhook request body.
+	BodyTemplate string `json:"-"`
+
+	// delivery records the response of the receiver, it's set by Deliver.
+	delivery *Delivery
+}
+
+// Delivery is the result of a webhook delivery attempt.
+type Delivery struct {
+	// StatusCode is the HTTP status code of the response, it's zero if no response is received.
+	StatusCode int
+	// ResponseBody is the excerpt of the response body.
+	ResponseBody string
+	// Latency is the duration from sending the request to receiving the response.
+	Latency time.Duration
 }
 
 // Receiver is the webhook receiver.
@@ -205,11 +227,62 @@ func register(host string, r Receiver) {
 
 // Post posts the message to webhook.
 func Post(webhookType string, context Context) error {
+	_, err := Deliver(webhookType, context)
+	return err
+}
+
+// Deliver posts the message to webhook and returns the delivery result.
+// The delivery result is returned even if the receiver fails, so that the caller can record the attempt.
+func Deliver(webhookType string, context Context) (*Delivery, error) {
 	receiverMu.RLock()
 	r, ok := receivers[webhookType]
 	receiverMu.RUnlock()
 	if !ok {
-		return errors.Errorf("webhook: no applicable receiver for webhook type: %v", webhookType)
+		return nil, errors.Errorf("webhook: no applicable receiver for webhook type: %v", webhookType)
+	}
+	delivery := &Delivery{}
+	context.delivery = delivery
+	start := time.Now()
+	err := r.post(context)
+	if delivery.Latency == 0 {
+		delivery.Latency = time.Since(start)
+	}
+	return delivery, err
+}
+
+func (c *Context) newHTTPClient() *http.Client {
+	client := &http.Client{
+		Timeout: timeout,
+	}
+	if c.delivery != nil {
+		client.Transport = &recordingTransport{
+			base:     http.DefaultTransport,
+			delivery: c.delivery,
+		}
+	}
+	return client
+}
+
+// recordingTransport records the status code, latency and the response body excerpt into the delivery.
+type recordingTransport struct {
+	base     http.RoundTripper
+	delivery *Delivery
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	t.delivery.Latency = time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+	t.delivery.StatusCode = resp.StatusCode
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
 	}
-	return r.post(context)
+	t.delivery.ResponseBody, _ = common.TruncateString(string(b), responseBodyLimit)
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	return resp, nil
 }
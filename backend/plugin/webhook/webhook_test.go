@@ -2,7 +2,12 @@ package webhook
 
 import (
 	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -122,3 +127,61 @@ func TestContext_getMetaList(t *testing.T) {
 		a.Equal(want, context.getMetaList())
 	})
 }
+
+func TestDeliver(t *testing.T) {
+	a := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(strings.Repeat("x", responseBodyLimit+100)))
+	}))
+	defer server.Close()
+
+	delivery, err := Deliver("bb.plugin.webhook.custom", Context{URL: server.URL})
+	a.Error(err)
+	a.Equal(http.StatusInternalServerError, delivery.StatusCode)
+	a.Equal(responseBodyLimit, len(delivery.ResponseBody))
+	a.Greater(delivery.Latency, time.Duration(0))
+
+	_, err = Deliver("bb.plugin.webhook.unknown", Context{URL: server.URL})
+	a.Error(err)
+}
+
+func TestCustomReceiverSignedTemplatedBody(t *testing.T) {
+	a := require.New(t)
+	var gotBody []byte
+	var gotHeader http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	bodyTemplate := `{"summary": {{json .Title}}, "source": {{json .Project.Name}}}`
+	a.NoError(ValidateBodyTemplate(bodyTemplate))
+	a.Error(ValidateBodyTemplate(`{{.Unknown}}`))
+
+	err := Post("bb.plugin.webhook.custom", Context{
+		URL:           server.URL,
+		Title:         `Issue "1" created`,
+		Project:       &Project{Name: "hr"},
+		SigningSecret: "secret",
+		BodyTemplate:  bodyTemplate,
+	})
+	a.NoError(err)
+	a.Equal(`{"summary": "Issue \"1\" created", "source": "hr"}`, string(gotBody))
+	timestamp := gotHeader.Get(TimestampHeader)
+	a.NotEmpty(timestamp)
+	a.Equal("sha256="+Sign("secret", timestamp, gotBody), gotHeader.Get(SignatureHeader))
+
+	// The events without an issue render the issue fields as the zero values.
+	issueTemplate := `{"issue": {{json .Issue.Name}}}`
+	a.NoError(ValidateBodyTemplate(issueTemplate))
+	err = Post("bb.plugin.webhook.custom", Context{
+		URL:          server.URL,
+		Project:      &Project{Name: "hr"},
+		BodyTemplate: issueTemplate,
+	})
+	a.NoError(err)
+	a.Equal(`{"issue": ""}`, string(gotBody))
+}
@@ -74,9 +74,7 @@ func (*WeComReceiver) post(context Context) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{
-		Timeout: timeout,
-	}
+	client := context.newHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return errors.Wrapf(err, "failed to POST webhook to %s", context.URL)
@@ -8,25 +8,31 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/ghost"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
 // NewGhostSyncExecutor creates a gh-ost sync check executor.
-func NewGhostSyncExecutor(store *store.Store, secret string) Executor {
+func NewGhostSyncExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, secret string) Executor {
 	return &GhostSyncExecutor{
-		store:  store,
-		secret: secret,
+		store:     store,
+		dbFactory: dbFactory,
+		secret:    secret,
 	}
 }
 
 // GhostSyncExecutor is the gh-ost sync check executor.
+// For PostgreSQL, it dry runs the online migration by pgosc instead.
 type GhostSyncExecutor struct {
-	store  *store.Store
-	secret string
+	store     *store.Store
+	dbFactory *dbfactory.DBFactory
+	secret    string
 }
 
 // Run runs the gh-ost sync check executor.
@@ -97,6 +103,10 @@ func (e *GhostSyncExecutor) Run(ctx context.Context, config *storepb.PlanCheckRu
 	// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
 	renderedStatement := utils.RenderStatement(statement, materials)
 
+	if instance.Engine == storepb.Engine_POSTGRES {
+		return e.runPostgresDryRun(ctx, instance, database, renderedStatement, config.GhostFlags)
+	}
+
 	tableName, err := ghost.GetTableNameFromStatement(renderedStatement)
 	if err != nil {
 		return nil, common.Wrapf(err, common.Internal, "failed to parse table name from statement, statement: %v", statement)
@@ -131,3 +141,37 @@ func (e *GhostSyncExecutor) Run(ctx context.Context, config *storepb.PlanCheckRu
 		},
 	}, nil
 }
+
+func (e *GhostSyncExecutor) runPostgresDryRun(ctx context.Context, instance *store.InstanceMessage, database *store.DatabaseMessage, statement string, flags map[string]string) ([]*storepb.PlanCheckRunResult_Result, error) {
+	driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database, db.ConnectionContext{})
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+
+	migrator, err := pgosc.NewMigrator(driver.GetDB(), statement, true, flags)
+	if err == nil {
+		err = migrator.Migrate(ctx)
+	}
+	if err != nil {
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_ERROR,
+				Title:   "Online migration dry run failed",
+				Content: err.Error(),
+				Code:    common.Internal.Int32(),
+				Report:  nil,
+			},
+		}, nil
+	}
+
+	return []*storepb.PlanCheckRunResult_Result{
+		{
+			Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+			Title:   "OK",
+			Content: "Online migration dry run succeeded",
+			Code:    common.Ok.Int32(),
+			Report:  nil,
+		},
+	}, nil
+}
@@ -152,7 +152,9 @@ func (s *Scheduler) markPlanCheckRunDone(ctx context.Context, planCheckRun *stor
 		planCheckRun.UID,
 	); err != nil {
 		slog.Error("failed to mark plan check run failed", log.BBError(err))
+		return
 	}
+	s.syncPullRequest(planCheckRun.PlanUID)
 }
 
 func (s *Scheduler) markPlanCheckRunFailed(ctx context.Context, planCheckRun *store.PlanCheckRunMessage, reason string) {
@@ -166,5 +168,12 @@ func (s *Scheduler) markPlanCheckRunFailed(ctx context.Context, planCheckRun *st
 		planCheckRun.UID,
 	); err != nil {
 		slog.Error("failed to mark plan check run failed", log.BBError(err))
+		return
 	}
+	s.syncPullRequest(planCheckRun.PlanUID)
+}
+
+// syncPullRequest notifies the pull request runner to sync the plan check results to the pull request linked to the plan.
+func (s *Scheduler) syncPullRequest(planUID int64) {
+	s.stateCfg.PullRequestSyncs.Store(planUID, true)
 }
@@ -17,8 +17,11 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	mysqlparser "github.com/bytebase/bytebase/backend/plugin/parser/mysql"
+	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
+	plsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 	pgrawparser "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/store/model"
 	"github.com/bytebase/bytebase/backend/utils"
@@ -134,8 +137,26 @@ func (e *StatementReportExecutor) runForDatabaseTarget(ctx context.Context, conf
 		sqlDB := driver.GetDB()
 
 		return reportForMySQL(ctx, sqlDB, instance.Engine, database.DatabaseName, renderedStatement, dbSchema)
-	case storepb.Engine_ORACLE, storepb.Engine_DM, storepb.Engine_OCEANBASE_ORACLE:
-		return reportForOracle(database.DatabaseName, database.DatabaseName, renderedStatement, dbSchema)
+	case storepb.Engine_ORACLE:
+		driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database, db.ConnectionContext{})
+		if err != nil {
+			return nil, err
+		}
+		defer driver.Close(ctx)
+		sqlDB := driver.GetDB()
+
+		return reportForOracle(ctx, sqlDB, instance.Engine, database.DatabaseName, database.DatabaseName, renderedStatement, dbSchema)
+	case storepb.Engine_DM, storepb.Engine_OCEANBASE_ORACLE:
+		return reportForOracle(ctx, nil, instance.Engine, database.DatabaseName, database.DatabaseName, renderedStatement, dbSchema)
+	case storepb.Engine_MSSQL:
+		driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database, db.ConnectionContext{})
+		if err != nil {
+			return nil, err
+		}
+		defer driver.Close(ctx)
+		sqlDB := driver.GetDB()
+
+		return reportForMSSQL(ctx, sqlDB, database.DatabaseName, renderedStatement, dbSchema)
 	default:
 		return []*storepb.PlanCheckRunResult_Result{
 			{
@@ -284,8 +305,26 @@ func (e *StatementReportExecutor) runForDatabaseGroupTarget(ctx context.Context,
 					sqlDB := driver.GetDB()
 
 					return reportForMySQL(ctx, sqlDB, instance.Engine, database.DatabaseName, renderedStatement, dbSchema)
-				case storepb.Engine_ORACLE, storepb.Engine_DM, storepb.Engine_OCEANBASE_ORACLE:
-					return reportForOracle(database.DatabaseName, database.DatabaseName, renderedStatement, dbSchema)
+				case storepb.Engine_ORACLE:
+					driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database, db.ConnectionContext{})
+					if err != nil {
+						return nil, err
+					}
+					defer driver.Close(ctx)
+					sqlDB := driver.GetDB()
+
+					return reportForOracle(ctx, sqlDB, instance.Engine, database.DatabaseName, database.DatabaseName, renderedStatement, dbSchema)
+				case storepb.Engine_DM, storepb.Engine_OCEANBASE_ORACLE:
+					return reportForOracle(ctx, nil, instance.Engine, database.DatabaseName, database.DatabaseName, renderedStatement, dbSchema)
+				case storepb.Engine_MSSQL:
+					driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database, db.ConnectionContext{})
+					if err != nil {
+						return nil, err
+					}
+					defer driver.Close(ctx)
+					sqlDB := driver.GetDB()
+
+					return reportForMSSQL(ctx, sqlDB, database.DatabaseName, renderedStatement, dbSchema)
 				default:
 					return nil, nil
 				}
@@ -319,7 +358,7 @@ func (e *StatementReportExecutor) runForDatabaseGroupTarget(ctx context.Context,
 	return results, nil
 }
 
-func reportForOracle(databaseName string, schemaName string, statement string, dbMetadata *model.DBSchema) ([]*storepb.PlanCheckRunResult_Result, error) {
+func reportForOracle(ctx context.Context, sqlDB *sql.DB, engine storepb.Engine, databaseName string, schemaName string, statement string, dbMetadata *model.DBSchema) ([]*storepb.PlanCheckRunResult_Result, error) {
 	singleSQLs, err := base.SplitMultiSQL(storepb.Engine_ORACLE, statement)
 	if err != nil {
 		// nolint:nilerr
@@ -339,6 +378,8 @@ func reportForOracle(databaseName string, schemaName string, statement string, d
 	}
 	singleSQLs = base.FilterEmptySQL(singleSQLs)
 
+	sqlTypeSet := map[string]struct{}{}
+	var totalAffectedRows int64
 	var changedResources []base.SchemaResource
 
 	for _, stmt := range singleSQLs {
@@ -351,8 +392,30 @@ func reportForOracle(databaseName string, schemaName string, statement string, d
 		} else {
 			changedResources = append(changedResources, resources...)
 		}
+
+		tree, _, err := plsqlparser.ParsePLSQL(stmt.Text)
+		if err != nil {
+			slog.Error("failed to parse statement", slog.String("statement", stmt.Text), log.BBError(err))
+			continue
+		}
+		sqlTypeSet[plsqlparser.GetStatementType(tree)] = struct{}{}
+
+		// Only Oracle supports EXPLAIN PLAN for the estimation.
+		if engine != storepb.Engine_ORACLE || sqlDB == nil {
+			continue
+		}
+		affectedRows, err := base.GetAffectedRows(ctx, engine, tree, buildGetRowsCountByQueryForOracle(sqlDB), buildGetTableDataSizeFunc(dbMetadata, schemaName))
+		if err != nil {
+			slog.Error("failed to get affected rows for oracle", slog.String("database", databaseName), log.BBError(err))
+		} else {
+			totalAffectedRows += affectedRows
+		}
 	}
 
+	var sqlTypes []string
+	for sqlType := range sqlTypeSet {
+		sqlTypes = append(sqlTypes, sqlType)
+	}
 	return []*storepb.PlanCheckRunResult_Result{
 		{
 			Status: storepb.PlanCheckRunResult_Result_SUCCESS,
@@ -360,8 +423,8 @@ func reportForOracle(databaseName string, schemaName string, statement string, d
 			Title:  "OK",
 			Report: &storepb.PlanCheckRunResult_Result_SqlSummaryReport_{
 				SqlSummaryReport: &storepb.PlanCheckRunResult_Result_SqlSummaryReport{
-					StatementTypes:   nil,
-					AffectedRows:     0,
+					StatementTypes:   sqlTypes,
+					AffectedRows:     int32(totalAffectedRows),
 					ChangedResources: convertToChangedResources(dbMetadata, changedResources),
 				},
 			},
@@ -369,6 +432,68 @@ func reportForOracle(databaseName string, schemaName string, statement string, d
 	}, nil
 }
 
+func reportForMSSQL(ctx context.Context, sqlDB *sql.DB, databaseName string, statement string, dbMetadata *model.DBSchema) ([]*storepb.PlanCheckRunResult_Result, error) {
+	singleSQLs, err := base.SplitMultiSQL(storepb.Engine_MSSQL, statement)
+	if err != nil {
+		// nolint:nilerr
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_ERROR,
+				Title:   "Syntax error",
+				Content: err.Error(),
+				Code:    0,
+				Report: &storepb.PlanCheckRunResult_Result_SqlSummaryReport_{
+					SqlSummaryReport: &storepb.PlanCheckRunResult_Result_SqlSummaryReport{
+						Code: advisor.StatementSyntaxError.Int32(),
+					},
+				},
+			},
+		}, nil
+	}
+	singleSQLs = base.FilterEmptySQL(singleSQLs)
+
+	sqlTypeSet := map[string]struct{}{}
+	var totalAffectedRows int64
+
+	for _, stmt := range singleSQLs {
+		if stmt.Text == "" {
+			continue
+		}
+
+		parseResult, err := tsqlparser.ParseTSQL(stmt.Text)
+		if err != nil {
+			slog.Error("failed to parse statement", slog.String("statement", stmt.Text), log.BBError(err))
+			continue
+		}
+		sqlTypeSet[tsqlparser.GetStatementType(parseResult)] = struct{}{}
+
+		affectedRows, err := base.GetAffectedRows(ctx, storepb.Engine_MSSQL, parseResult, buildGetRowsCountByQueryForMSSQL(sqlDB), buildGetTableDataSizeFunc(dbMetadata, "dbo"))
+		if err != nil {
+			slog.Error("failed to get affected rows for mssql", slog.String("database", databaseName), log.BBError(err))
+		} else {
+			totalAffectedRows += affectedRows
+		}
+	}
+
+	var sqlTypes []string
+	for sqlType := range sqlTypeSet {
+		sqlTypes = append(sqlTypes, sqlType)
+	}
+	return []*storepb.PlanCheckRunResult_Result{
+		{
+			Status: storepb.PlanCheckRunResult_Result_SUCCESS,
+			Code:   common.Ok.Int32(),
+			Title:  "OK",
+			Report: &storepb.PlanCheckRunResult_Result_SqlSummaryReport_{
+				SqlSummaryReport: &storepb.PlanCheckRunResult_Result_SqlSummaryReport{
+					StatementTypes: sqlTypes,
+					AffectedRows:   int32(totalAffectedRows),
+				},
+			},
+		},
+	}, nil
+}
+
 func reportForMySQL(ctx context.Context, sqlDB *sql.DB, engine storepb.Engine, databaseName string, statement string, dbMetadata *model.DBSchema) ([]*storepb.PlanCheckRunResult_Result, error) {
 	singleSQLs, err := base.SplitMultiSQL(engine, statement)
 	if err != nil {
@@ -418,7 +543,7 @@ func reportForMySQL(ctx context.Context, sqlDB *sql.DB, engine storepb.Engine, d
 			changedResources = append(changedResources, resources...)
 		}
 
-		affectedRows, err := base.GetAffectedRows(ctx, engine, stmts[0], buildGetRowsCountByQueryForMySQL(sqlDB, engine), buildGetTableDataSizeFuncForMySQL(dbMetadata))
+		affectedRows, err := base.GetAffectedRows(ctx, engine, stmts[0], buildGetRowsCountByQueryForMySQL(sqlDB, engine), buildGetTableDataSizeFunc(dbMetadata, ""))
 		if err != nil {
 			slog.Error("failed to get affected rows for mysql", slog.String("database", databaseName), log.BBError(err))
 		} else {
@@ -505,7 +630,12 @@ func reportForPostgres(ctx context.Context, sqlDB *sql.DB, database, statement s
 		sqlTypeSet[sqlType] = struct{}{}
 		changedResources = append(changedResources, resources...)
 
-		rowCount, err := getAffectedRowsForPostgres(ctx, sqlDB, dbMetadata.GetMetadata(), stmt)
+		parseResult, err := pgparser.ParsePostgreSQL(stmt.Text())
+		if err != nil {
+			slog.Error("failed to parse statement", slog.String("statement", stmt.Text()), log.BBError(err))
+			continue
+		}
+		rowCount, err := base.GetAffectedRows(ctx, storepb.Engine_POSTGRES, parseResult, buildGetRowsCountByQueryForPostgres(sqlDB), buildGetTableDataSizeFunc(dbMetadata, "public"))
 		if err != nil {
 			slog.Error("failed to get affected rows for postgres", slog.String("database", database), log.BBError(err))
 		} else {
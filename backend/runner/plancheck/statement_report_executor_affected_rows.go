@@ -5,37 +5,18 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 
+	mssqldb "github.com/bytebase/bytebase/backend/plugin/db/mssql"
 	"github.com/bytebase/bytebase/backend/store/model"
 
-	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
-func getTableDataSize(metadata *storepb.DatabaseSchemaMetadata, schemaName, tableName string) int64 {
-	if metadata == nil {
-		return 0
-	}
-	for _, schema := range metadata.Schemas {
-		if schema.Name != schemaName {
-			continue
-		}
-		for _, table := range schema.Tables {
-			if table.Name != tableName {
-				continue
-			}
-			return table.RowCount
-		}
-	}
-	return 0
-}
-
-func buildGetTableDataSizeFuncForMySQL(metadata *model.DBSchema) func(schemaName, tableName string) int64 {
+func buildGetTableDataSizeFunc(metadata *model.DBSchema, defaultSchema string) func(schemaName, tableName string) int64 {
 	return func(schemaName, tableName string) int64 {
 		if metadata == nil {
 			return 0
@@ -44,6 +25,9 @@ func buildGetTableDataSizeFuncForMySQL(metadata *model.DBSchema) func(schemaName
 		if dbMeta == nil {
 			return 0
 		}
+		if schemaName == "" {
+			schemaName = defaultSchema
+		}
 		schemaMeta := dbMeta.GetSchema(schemaName)
 		if schemaMeta == nil {
 			return 0
@@ -69,42 +53,19 @@ func buildGetRowsCountByQueryForMySQL(sqlDB *sql.DB, engine storepb.Engine) func
 	}
 }
 
-func getAffectedRowsForPostgres(ctx context.Context, sqlDB *sql.DB, metadata *storepb.DatabaseSchemaMetadata, node ast.Node) (int64, error) {
-	switch node := node.(type) {
-	case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt:
-		if node, ok := node.(*ast.InsertStmt); ok && len(node.ValueList) > 0 {
-			return int64(len(node.ValueList)), nil
-		}
-		return getAffectedRowsCount(ctx, sqlDB, fmt.Sprintf("EXPLAIN %s", node.Text()), getAffectedRowsCountForPostgres)
-	case *ast.AlterTableStmt:
-		if node.Table.Type == ast.TableTypeBaseTable {
-			schemaName := "public"
-			if node.Table.Schema != "" {
-				schemaName = node.Table.Schema
-			}
-			tableName := node.Table.Name
-
-			return getTableDataSize(metadata, schemaName, tableName), nil
-		}
-		return 0, nil
-	case *ast.DropTableStmt:
-		var total int64
-		for _, table := range node.TableList {
-			schemaName := "public"
-			if table.Schema != "" {
-				schemaName = table.Schema
-			}
-			tableName := table.Name
-
-			total += getTableDataSize(metadata, schemaName, tableName)
-		}
-		return total, nil
-
-	default:
-		return 0, nil
+func buildGetRowsCountByQueryForPostgres(sqlDB *sql.DB) func(ctx context.Context, statement string) (int64, error) {
+	return func(ctx context.Context, statement string) (int64, error) {
+		return getAffectedRowsCount(ctx, sqlDB, fmt.Sprintf("EXPLAIN (FORMAT JSON) %s", statement), getAffectedRowsCountForPostgres)
 	}
 }
 
+// PostgreSQLQueryPlan represents the plan node of PostgreSQL EXPLAIN (FORMAT JSON).
+type PostgreSQLQueryPlan struct {
+	NodeType string                 `json:"Node Type"`
+	PlanRows float64                `json:"Plan Rows"`
+	Plans    []*PostgreSQLQueryPlan `json:"Plans"`
+}
+
 func getAffectedRowsCountForPostgres(res []any) (int64, error) {
 	// the res struct is []any{columnName, columnTable, rowDataList}
 	if len(res) != 3 {
@@ -114,40 +75,74 @@ func getAffectedRowsCountForPostgres(res []any) (int64, error) {
 	if !ok {
 		return 0, errors.Errorf("expected []any but got %t", res[2])
 	}
-	// test-bb=# EXPLAIN INSERT INTO t SELECT * FROM t;
-	// QUERY PLAN
-	// -------------------------------------------------------------
-	//  Insert on t  (cost=0.00..1.03 rows=0 width=0)
-	//    ->  Seq Scan on t t_1  (cost=0.00..1.03 rows=3 width=520)
-	// (2 rows)
-	if len(rowList) < 2 {
+	if len(rowList) < 1 {
 		return 0, errors.Errorf("not found any data")
 	}
-	// We need the row 2.
-	rowTwo, ok := rowList[1].([]any)
+	row, ok := rowList[0].([]any)
 	if !ok {
 		return 0, errors.Errorf("expected []any but got %t", rowList[0])
 	}
-	// PostgreSQL EXPLAIN statement result has one column.
-	if len(rowTwo) != 1 {
-		return 0, errors.Errorf("expected one but got %d", len(rowTwo))
+	// PostgreSQL EXPLAIN (FORMAT JSON) statement result has one column.
+	if len(row) != 1 {
+		return 0, errors.Errorf("expected one but got %d", len(row))
 	}
-	// Get the string value.
-	text, ok := rowTwo[0].(string)
+	planString, ok := row[0].(string)
 	if !ok {
-		return 0, errors.Errorf("expected string but got %t", rowTwo[0])
+		return 0, errors.Errorf("expected string but got %t", row[0])
 	}
+	return getAffectedRowsCountFromPostgresPlan(planString)
+}
 
-	rowsRegexp := regexp.MustCompile("rows=([0-9]+)")
-	matches := rowsRegexp.FindStringSubmatch(text)
-	if len(matches) != 2 {
-		return 0, errors.Errorf("failed to find rows in %q", text)
+func getAffectedRowsCountFromPostgresPlan(planString string) (int64, error) {
+	// test-bb=# EXPLAIN (FORMAT JSON) DELETE FROM t;
+	// [{"Plan": {"Node Type": "ModifyTable", "Operation": "Delete", "Plan Rows": 0, ...,
+	//   "Plans": [{"Node Type": "Seq Scan", "Plan Rows": 3, ...}]}}]
+	var plans []struct {
+		Plan *PostgreSQLQueryPlan `json:"Plan"`
 	}
-	value, err := strconv.ParseInt(matches[1], 10, 64)
-	if err != nil {
-		return 0, errors.Errorf("failed to get integer from %q", matches[1])
+	if err := json.Unmarshal([]byte(planString), &plans); err != nil {
+		return 0, errors.Wrapf(err, "failed to parse query plan from string: %+v", planString)
+	}
+	if len(plans) < 1 || plans[0].Plan == nil {
+		return 0, errors.Errorf("failed to find plan in %q", planString)
+	}
+	plan := plans[0].Plan
+	// The ModifyTable node reports zero rows since PostgreSQL 13, so we use the rows of its input.
+	if plan.NodeType == "ModifyTable" && len(plan.Plans) > 0 {
+		plan = plan.Plans[0]
+	}
+	return int64(plan.PlanRows), nil
+}
+
+// oracleExplainStatementID is the statement id of the plans in PLAN_TABLE explained by Bytebase.
+const oracleExplainStatementID = "bytebase_affected_rows"
+
+func buildGetRowsCountByQueryForOracle(sqlDB *sql.DB) func(ctx context.Context, statement string) (int64, error) {
+	return func(ctx context.Context, statement string) (int64, error) {
+		// EXPLAIN PLAN writes the plan into PLAN_TABLE, so we roll back the transaction to clean it up.
+		tx, err := sqlDB.BeginTx(ctx, &sql.TxOptions{})
+		if err != nil {
+			return 0, err
+		}
+		defer tx.Rollback()
+
+		statement = strings.TrimRight(strings.TrimSpace(statement), ";")
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("EXPLAIN PLAN SET STATEMENT_ID = '%s' FOR %s", oracleExplainStatementID, statement)); err != nil {
+			return 0, err
+		}
+		// The root operation, such as UPDATE STATEMENT, carries the estimated rows of the statement.
+		var cardinality sql.NullInt64
+		if err := tx.QueryRowContext(ctx, "SELECT CARDINALITY FROM PLAN_TABLE WHERE STATEMENT_ID = :1 AND ID = 0", oracleExplainStatementID).Scan(&cardinality); err != nil {
+			return 0, errors.Wrapf(err, "failed to get cardinality from plan table")
+		}
+		return cardinality.Int64, nil
+	}
+}
+
+func buildGetRowsCountByQueryForMSSQL(sqlDB *sql.DB) func(ctx context.Context, statement string) (int64, error) {
+	return func(ctx context.Context, statement string) (int64, error) {
+		return mssqldb.GetAffectedRowsByShowplan(ctx, sqlDB, statement)
 	}
-	return value, nil
 }
 
 type affectedRowsCountExtractor func(res []any) (int64, error)
@@ -9,7 +9,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	mysqlparser "github.com/bytebase/bytebase/backend/plugin/parser/mysql"
+	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
+	plsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
 	"github.com/bytebase/bytebase/backend/store/model"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -47,7 +51,7 @@ func TestGetAffectedRows(t *testing.T) {
 			t.Fatalf("the length of parse result of stmt %v is not one", test.Statement)
 		}
 
-		affectedRows, err := mysqlparser.GetAffectedRows(context.Background(), stmts[0], nil, buildGetTableDataSizeFuncForMySQL(getMetadataForAffectedRowsTest()))
+		affectedRows, err := mysqlparser.GetAffectedRows(context.Background(), stmts[0], nil, buildGetTableDataSizeFunc(getMetadataForAffectedRowsTest(), ""))
 		a.NoError(err)
 
 		if record {
@@ -65,15 +69,91 @@ func TestGetAffectedRows(t *testing.T) {
 	}
 }
 
+func TestGetAffectedRowsByEngine(t *testing.T) {
+	tests := []struct {
+		engine    storepb.Engine
+		schema    string
+		statement string
+		want      int64
+	}{
+		{engine: storepb.Engine_POSTGRES, schema: "public", statement: "INSERT INTO t1 VALUES (1), (2), (3);", want: 3},
+		{engine: storepb.Engine_POSTGRES, schema: "public", statement: "INSERT INTO t1 SELECT * FROM t2;", want: 42},
+		{engine: storepb.Engine_POSTGRES, schema: "public", statement: "UPDATE t1 SET c1 = 1 WHERE c1 = 2;", want: 42},
+		{engine: storepb.Engine_POSTGRES, schema: "public", statement: "DELETE FROM t1;", want: 42},
+		{engine: storepb.Engine_POSTGRES, schema: "public", statement: "ALTER TABLE public.t1 ADD COLUMN c2 INT;", want: 100},
+		{engine: storepb.Engine_POSTGRES, schema: "public", statement: "DROP TABLE t1, t2;", want: 1100},
+		{engine: storepb.Engine_POSTGRES, schema: "public", statement: "TRUNCATE t2;", want: 1000},
+		{engine: storepb.Engine_POSTGRES, schema: "public", statement: "CREATE TABLE t3 (c1 INT);", want: 0},
+		{engine: storepb.Engine_ORACLE, schema: "TESTDB", statement: "INSERT INTO t1 VALUES (1)", want: 1},
+		{engine: storepb.Engine_ORACLE, schema: "TESTDB", statement: "UPDATE t1 SET c1 = 1", want: 42},
+		{engine: storepb.Engine_ORACLE, schema: "TESTDB", statement: "DELETE FROM TESTDB.t1 WHERE c1 = 1", want: 42},
+		{engine: storepb.Engine_ORACLE, schema: "TESTDB", statement: "ALTER TABLE T1 ADD c2 INT", want: 100},
+		{engine: storepb.Engine_ORACLE, schema: "TESTDB", statement: "DROP TABLE T2", want: 1000},
+		{engine: storepb.Engine_ORACLE, schema: "TESTDB", statement: "TRUNCATE TABLE TESTDB.T2", want: 1000},
+		{engine: storepb.Engine_MSSQL, schema: "dbo", statement: "INSERT INTO t1 VALUES (1), (2);", want: 2},
+		{engine: storepb.Engine_MSSQL, schema: "dbo", statement: "UPDATE t1 SET c1 = 1;", want: 42},
+		{engine: storepb.Engine_MSSQL, schema: "dbo", statement: "DELETE FROM t1 WHERE c1 = 1;", want: 42},
+		{engine: storepb.Engine_MSSQL, schema: "dbo", statement: "ALTER TABLE dbo.t1 ADD c2 INT;", want: 100},
+		{engine: storepb.Engine_MSSQL, schema: "dbo", statement: "DROP TABLE t1, [dbo].[t2];", want: 1100},
+		{engine: storepb.Engine_MSSQL, schema: "dbo", statement: "TRUNCATE TABLE t2;", want: 1000},
+	}
+
+	a := require.New(t)
+	getAffectedRowsByQuery := func(context.Context, string) (int64, error) {
+		return 42, nil
+	}
+	for _, test := range tests {
+		var stmt any
+		switch test.engine {
+		case storepb.Engine_POSTGRES:
+			parseResult, err := pgparser.ParsePostgreSQL(test.statement)
+			a.NoError(err)
+			stmt = parseResult
+		case storepb.Engine_ORACLE:
+			tree, _, err := plsqlparser.ParsePLSQL(test.statement)
+			a.NoError(err)
+			stmt = tree
+		case storepb.Engine_MSSQL:
+			parseResult, err := tsqlparser.ParseTSQL(test.statement)
+			a.NoError(err)
+			stmt = parseResult
+		}
+		metadata := getMetadataForAffectedRowsTestWithSchema(test.schema)
+		affectedRows, err := base.GetAffectedRows(context.Background(), test.engine, stmt, getAffectedRowsByQuery, buildGetTableDataSizeFunc(metadata, test.schema))
+		a.NoError(err, test.statement)
+		a.Equal(test.want, affectedRows, test.statement)
+	}
+}
+
+func TestGetAffectedRowsCountFromPlan(t *testing.T) {
+	a := require.New(t)
+
+	rows, err := getAffectedRowsCountFromPostgresPlan(`[{"Plan": {"Node Type": "ModifyTable", "Operation": "Delete", "Plan Rows": 0, "Plans": [{"Node Type": "Seq Scan", "Plan Rows": 1234}]}}]`)
+	a.NoError(err)
+	a.Equal(int64(1234), rows)
+
+	rows, err = getAffectedRowsCountFromPostgresPlan(`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 7}}]`)
+	a.NoError(err)
+	a.Equal(int64(7), rows)
+}
+
 func getMetadataForAffectedRowsTest() *model.DBSchema {
+	return getMetadataForAffectedRowsTestWithSchema("")
+}
+
+func getMetadataForAffectedRowsTestWithSchema(schemaName string) *model.DBSchema {
+	t1, t2 := "t1", "t2"
+	if schemaName == "TESTDB" {
+		t1, t2 = "T1", "T2"
+	}
 	metadata := &storepb.DatabaseSchemaMetadata{
 		Name: "testdb",
 		Schemas: []*storepb.SchemaMetadata{
 			{
-				Name: "",
+				Name: schemaName,
 				Tables: []*storepb.TableMetadata{
 					{
-						Name: "t1",
+						Name: t1,
 						Columns: []*storepb.ColumnMetadata{
 							{
 								Name: "c1",
@@ -82,7 +162,7 @@ func getMetadataForAffectedRowsTest() *model.DBSchema {
 						RowCount: 100,
 					},
 					{
-						Name: "t2",
+						Name: t2,
 						Columns: []*storepb.ColumnMetadata{
 							{
 								Name: "c1",
@@ -0,0 +1,269 @@
+package pullrequest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/runner/utils"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// summaryCommentPrefix identifies the summary comment among the pull request comments.
+	summaryCommentPrefix = "Bytebase Bot:"
+	// maxPullRequestCommentLength is the limit of the pull request comment, GitHub caps the comment at 65536 characters.
+	maxPullRequestCommentLength = 60000
+)
+
+// planCheckRunKey identifies the check of a type of a file against a database.
+type planCheckRunKey struct {
+	runType      store.PlanCheckRunType
+	sheetUID     int32
+	instanceUID  int32
+	databaseName string
+}
+
+// getSummaryComment returns the summary comment of the plan, which contains the link to the issue,
+// the schema changes generated from the SDL files and the SQL review results per file.
+func (r *Runner) getSummaryComment(ctx context.Context, issueURL string, plan *store.PlanMessage, vcsConnector *store.VCSConnectorMessage) (string, error) {
+	rolloutStarted, err := r.isRolloutStarted(ctx, plan)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	if plan.PipelineUID == nil {
+		_, _ = fmt.Fprintf(&buf, "%s the changes in this pull request are planned in a Bytebase issue and will be rolled out after the pull request is merged. Check out the status at %s.\n", summaryCommentPrefix, issueURL)
+	} else {
+		_, _ = fmt.Fprintf(&buf, "%s this pull request has triggered a Bytebase rollout 🚀. Check out the status at %s.\n", summaryCommentPrefix, issueURL)
+	}
+
+	sheetUIDs := getPlanSheetUIDs(plan)
+	// The schema changes are meaningless once the rollout has started, since they are diffed against the current schema.
+	if vcsConnector.Payload.Mode == storepb.VCSConnector_SDL && !rolloutStarted {
+		if err := r.writeSDLDiff(ctx, &buf, plan); err != nil {
+			return "", err
+		}
+	}
+	if err := r.writeSQLReview(ctx, &buf, plan, sheetUIDs); err != nil {
+		return "", err
+	}
+
+	comment, truncated := common.TruncateString(buf.String(), maxPullRequestCommentLength)
+	if truncated {
+		comment += "\n\n... the comment is truncated, check out the details in the Bytebase issue."
+	}
+	return comment, nil
+}
+
+// isRolloutStarted returns true if any task of the plan has been run.
+func (r *Runner) isRolloutStarted(ctx context.Context, plan *store.PlanMessage) (bool, error) {
+	if plan.PipelineUID == nil {
+		return false, nil
+	}
+	tasks, err := r.store.ListTasks(ctx, &api.TaskFind{PipelineID: plan.PipelineUID})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list tasks for pipeline %d", *plan.PipelineUID)
+	}
+	for _, task := range tasks {
+		if task.LatestTaskRunStatus != api.TaskRunNotStarted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// writeSDLDiff writes the schema changes generated from the SDL files for each target database.
+func (r *Runner) writeSDLDiff(ctx context.Context, buf *strings.Builder, plan *store.PlanMessage) error {
+	buf.WriteString("\n### Schema changes\n")
+	for _, step := range plan.Config.GetSteps() {
+		for _, spec := range step.GetSpecs() {
+			config := spec.GetChangeDatabaseConfig()
+			if config == nil || config.Type != storepb.PlanConfig_ChangeDatabaseConfig_MIGRATE_SDL {
+				continue
+			}
+			_, sheetUID, err := common.GetProjectResourceIDSheetUID(config.Sheet)
+			if err != nil {
+				return err
+			}
+			sheet, err := r.store.GetSheet(ctx, &store.FindSheetMessage{UID: &sheetUID})
+			if err != nil {
+				return errors.Wrapf(err, "failed to get sheet %d", sheetUID)
+			}
+			if sheet == nil {
+				return errors.Errorf("sheet %d not found", sheetUID)
+			}
+			_, _ = fmt.Fprintf(buf, "\n**%s** (`%s`)\n", config.Target, sheet.Title)
+			diff, err := r.getSDLDiff(ctx, config.Target, sheetUID)
+			switch {
+			case err != nil:
+				_, _ = fmt.Fprintf(buf, "Failed to generate the schema changes, error: %v\n", err)
+			case strings.TrimSpace(diff) == "":
+				buf.WriteString("No schema change.\n")
+			default:
+				_, _ = fmt.Fprintf(buf, "```sql\n%s\n```\n", strings.TrimSpace(diff))
+			}
+		}
+	}
+	return nil
+}
+
+// getSDLDiff diffs the SDL in the sheet against the latest synced schema of the target database.
+func (r *Runner) getSDLDiff(ctx context.Context, target string, sheetUID int) (string, error) {
+	instanceID, databaseName, err := common.GetInstanceDatabaseID(target)
+	if err != nil {
+		return "", err
+	}
+	database, err := r.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{InstanceID: &instanceID, DatabaseName: &databaseName})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get database %q", databaseName)
+	}
+	if database == nil {
+		return "", errors.Errorf("database %q not found", databaseName)
+	}
+	sdl, err := r.store.GetSheetStatementByID(ctx, sheetUID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get statement of sheet %d", sheetUID)
+	}
+	return utils.ComputeSyncedSchemaDiff(ctx, r.store, database, sdl)
+}
+
+// writeSQLReview writes the latest SQL review results of each file in the plan.
+func (r *Runner) writeSQLReview(ctx context.Context, buf *strings.Builder, plan *store.PlanMessage, sheetUIDs []int) error {
+	planCheckRuns, err := r.store.ListPlanCheckRuns(ctx, &store.FindPlanCheckRunMessage{
+		PlanUID: &plan.UID,
+		Type:    &[]store.PlanCheckRunType{store.PlanCheckDatabaseStatementAdvise},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list plan check runs for plan %d", plan.UID)
+	}
+	runsBySheet := getLatestPlanCheckRunsBySheet(planCheckRuns)
+
+	buf.WriteString("\n### SQL review\n")
+	for _, sheetUID := range sheetUIDs {
+		sheet, err := r.store.GetSheet(ctx, &store.FindSheetMessage{UID: &sheetUID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get sheet %d", sheetUID)
+		}
+		if sheet == nil {
+			return errors.Errorf("sheet %d not found", sheetUID)
+		}
+		_, _ = fmt.Fprintf(buf, "\n**`%s`**\n", sheet.Title)
+		runs := runsBySheet[int32(sheetUID)]
+		if len(runs) == 0 {
+			buf.WriteString("- No SQL review result.\n")
+			continue
+		}
+		for _, run := range runs {
+			database, err := r.formatPlanCheckRunDatabase(ctx, run)
+			if err != nil {
+				return err
+			}
+			writePlanCheckRun(buf, database, run)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) formatPlanCheckRunDatabase(ctx context.Context, run *store.PlanCheckRunMessage) (string, error) {
+	instanceUID := int(run.Config.InstanceUid)
+	instance, err := r.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &instanceUID})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get instance %d", instanceUID)
+	}
+	if instance == nil {
+		return "", errors.Errorf("instance %d not found", instanceUID)
+	}
+	return common.FormatDatabase(instance.ResourceID, run.Config.DatabaseName), nil
+}
+
+// writePlanCheckRun writes the result of the plan check run against the database as a list item.
+func writePlanCheckRun(buf *strings.Builder, database string, run *store.PlanCheckRunMessage) {
+	switch run.Status {
+	case store.PlanCheckRunStatusRunning:
+		_, _ = fmt.Fprintf(buf, "- `%s`: ⏳ Running\n", database)
+	case store.PlanCheckRunStatusCanceled:
+		_, _ = fmt.Fprintf(buf, "- `%s`: Canceled\n", database)
+	case store.PlanCheckRunStatusFailed:
+		_, _ = fmt.Fprintf(buf, "- `%s`: ❌ Failed, error: %s\n", database, run.Result.GetError())
+	case store.PlanCheckRunStatusDone:
+		var results []*storepb.PlanCheckRunResult_Result
+		for _, result := range run.Result.GetResults() {
+			if result.Status != storepb.PlanCheckRunResult_Result_SUCCESS {
+				results = append(results, result)
+			}
+		}
+		if len(results) == 0 {
+			_, _ = fmt.Fprintf(buf, "- `%s`: ✅ Passed\n", database)
+			return
+		}
+		_, _ = fmt.Fprintf(buf, "- `%s`:\n", database)
+		for _, result := range results {
+			icon := "⚠️"
+			if result.Status == storepb.PlanCheckRunResult_Result_ERROR {
+				icon = "❌"
+			}
+			_, _ = fmt.Fprintf(buf, "  - %s %s: %s", icon, result.Title, result.Content)
+			if line := result.GetSqlReviewReport().GetLine(); line > 0 {
+				_, _ = fmt.Fprintf(buf, " (line %d)", line)
+			}
+			buf.WriteString("\n")
+		}
+	}
+}
+
+// getPlanSheetUIDs returns the distinct sheets of the plan in the order of the specs.
+func getPlanSheetUIDs(plan *store.PlanMessage) []int {
+	var sheetUIDs []int
+	seen := make(map[int]bool)
+	for _, step := range plan.Config.GetSteps() {
+		for _, spec := range step.GetSpecs() {
+			config := spec.GetChangeDatabaseConfig()
+			if config == nil {
+				continue
+			}
+			_, sheetUID, err := common.GetProjectResourceIDSheetUID(config.Sheet)
+			if err != nil || seen[sheetUID] {
+				continue
+			}
+			seen[sheetUID] = true
+			sheetUIDs = append(sheetUIDs, sheetUID)
+		}
+	}
+	return sheetUIDs
+}
+
+// getLatestPlanCheckRunsBySheet groups the latest plan check run of each type, file and database by sheet.
+// The plan checks are rerun when the pull request is updated, and the outdated runs are kept.
+func getLatestPlanCheckRunsBySheet(planCheckRuns []*store.PlanCheckRunMessage) map[int32][]*store.PlanCheckRunMessage {
+	latest := make(map[planCheckRunKey]*store.PlanCheckRunMessage)
+	for _, run := range planCheckRuns {
+		key := planCheckRunKey{
+			runType:      run.Type,
+			sheetUID:     run.Config.GetSheetUid(),
+			instanceUID:  run.Config.GetInstanceUid(),
+			databaseName: run.Config.GetDatabaseName(),
+		}
+		if v, ok := latest[key]; !ok || v.UID < run.UID {
+			latest[key] = run
+		}
+	}
+
+	runsBySheet := make(map[int32][]*store.PlanCheckRunMessage)
+	for key, run := range latest {
+		runsBySheet[key.sheetUID] = append(runsBySheet[key.sheetUID], run)
+	}
+	for _, runs := range runsBySheet {
+		sort.Slice(runs, func(i, j int) bool {
+			return runs[i].UID < runs[j].UID
+		})
+	}
+	return runsBySheet
+}
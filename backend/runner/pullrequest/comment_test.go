@@ -0,0 +1,98 @@
+package pullrequest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestGetLatestPlanCheckRunsBySheet(t *testing.T) {
+	a := require.New(t)
+	newRun := func(uid int, runType store.PlanCheckRunType, sheetUID int32, databaseName string) *store.PlanCheckRunMessage {
+		return &store.PlanCheckRunMessage{
+			UID:  uid,
+			Type: runType,
+			Config: &storepb.PlanCheckRunConfig{
+				SheetUid:     sheetUID,
+				InstanceUid:  1,
+				DatabaseName: databaseName,
+			},
+		}
+	}
+
+	runsBySheet := getLatestPlanCheckRunsBySheet([]*store.PlanCheckRunMessage{
+		newRun(1, store.PlanCheckDatabaseStatementAdvise, 10, "db1"),
+		newRun(2, store.PlanCheckDatabaseStatementAdvise, 10, "db2"),
+		// Rerun against db1.
+		newRun(3, store.PlanCheckDatabaseStatementAdvise, 10, "db1"),
+		newRun(4, store.PlanCheckDatabaseStatementAdvise, 20, "db1"),
+		// A different type of check does not override the SQL review.
+		newRun(5, store.PlanCheckDatabaseConnect, 20, "db1"),
+	})
+	a.Len(runsBySheet, 2)
+	a.Len(runsBySheet[10], 2)
+	a.Equal(2, runsBySheet[10][0].UID)
+	a.Equal(3, runsBySheet[10][1].UID)
+	a.Len(runsBySheet[20], 2)
+}
+
+func TestWritePlanCheckRun(t *testing.T) {
+	tests := []struct {
+		run  *store.PlanCheckRunMessage
+		want string
+	}{
+		{
+			run:  &store.PlanCheckRunMessage{Status: store.PlanCheckRunStatusRunning},
+			want: "- `instances/i/databases/d`: ⏳ Running\n",
+		},
+		{
+			run: &store.PlanCheckRunMessage{
+				Status: store.PlanCheckRunStatusFailed,
+				Result: &storepb.PlanCheckRunResult{Error: "connection refused"},
+			},
+			want: "- `instances/i/databases/d`: ❌ Failed, error: connection refused\n",
+		},
+		{
+			run: &store.PlanCheckRunMessage{
+				Status: store.PlanCheckRunStatusDone,
+				Result: &storepb.PlanCheckRunResult{
+					Results: []*storepb.PlanCheckRunResult_Result{
+						{Status: storepb.PlanCheckRunResult_Result_SUCCESS, Title: "OK"},
+					},
+				},
+			},
+			want: "- `instances/i/databases/d`: ✅ Passed\n",
+		},
+		{
+			run: &store.PlanCheckRunMessage{
+				Status: store.PlanCheckRunStatusDone,
+				Result: &storepb.PlanCheckRunResult{
+					Results: []*storepb.PlanCheckRunResult_Result{
+						{
+							Status:  storepb.PlanCheckRunResult_Result_ERROR,
+							Title:   "statement.where.require",
+							Content: "WHERE clause is required",
+							Report: &storepb.PlanCheckRunResult_Result_SqlReviewReport_{
+								SqlReviewReport: &storepb.PlanCheckRunResult_Result_SqlReviewReport{Line: 3},
+							},
+						},
+						{Status: storepb.PlanCheckRunResult_Result_WARNING, Title: "column.no-null", Content: "Column is nullable"},
+					},
+				},
+			},
+			want: "- `instances/i/databases/d`:\n" +
+				"  - ❌ statement.where.require: WHERE clause is required (line 3)\n" +
+				"  - ⚠️ column.no-null: Column is nullable\n",
+		},
+	}
+
+	for _, test := range tests {
+		var buf strings.Builder
+		writePlanCheckRun(&buf, "instances/i/databases/d", test.run)
+		require.Equal(t, test.want, buf.String())
+	}
+}
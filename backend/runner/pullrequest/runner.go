@@ -0,0 +1,178 @@
+// Package pullrequest is the runner syncing the status of the GitOps plans back to their pull requests.
+package pullrequest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/state"
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	// pullRequestRunnerInterval batches the sync requests of a plan, as the plan check runs of a plan finish one by one.
+	pullRequestRunnerInterval = 5 * time.Second
+)
+
+// NewRunner creates a new pull request runner.
+func NewRunner(store *store.Store, stateCfg *state.State) *Runner {
+	return &Runner{
+		store:    store,
+		stateCfg: stateCfg,
+	}
+}
+
+// Runner is the runner updating the commit status and the summary comment of the pull requests linked to the plans.
+type Runner struct {
+	store    *store.Store
+	stateCfg *state.State
+}
+
+// Run runs the runner.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(pullRequestRunnerInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	slog.Debug(fmt.Sprintf("Pull request runner started and will run every %v", pullRequestRunnerInterval))
+	for {
+		select {
+		case <-ticker.C:
+			r.stateCfg.PullRequestSyncs.Range(func(key, _ any) bool {
+				planUID, ok := key.(int64)
+				if !ok {
+					return true
+				}
+				// Delete before syncing, so that the plans changed during the sync are synced again on the next tick.
+				r.stateCfg.PullRequestSyncs.Delete(key)
+				r.syncPlan(ctx, planUID)
+				return true
+			})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) syncPlan(ctx context.Context, planUID int64) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = errors.Errorf("%v", r)
+			}
+			slog.Error("Pull request runner PANIC RECOVER", log.BBError(err), log.BBStack("panic-stack"))
+		}
+	}()
+
+	if err := r.syncPullRequest(ctx, planUID); err != nil {
+		slog.Error("failed to sync pull request", slog.Int64("plan", planUID), log.BBError(err))
+	}
+}
+
+// syncPullRequest sets the commit status of the pull request linked to the plan, and creates or updates its summary comment.
+func (r *Runner) syncPullRequest(ctx context.Context, planUID int64) error {
+	plan, err := r.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planUID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get plan %d", planUID)
+	}
+	if plan == nil {
+		return errors.Errorf("plan %d not found", planUID)
+	}
+	vcsSource := plan.Config.GetVcsSource()
+	if vcsSource == nil {
+		return nil
+	}
+	projectID, vcsConnectorID, err := common.GetProjectVCSConnectorID(vcsSource.VcsConnector)
+	if err != nil {
+		return err
+	}
+	vcsConnector, err := r.store.GetVCSConnector(ctx, &store.FindVCSConnectorMessage{ProjectID: &projectID, ResourceID: &vcsConnectorID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get VCS connector %q", vcsSource.VcsConnector)
+	}
+	if vcsConnector == nil {
+		// The VCS connector has been deleted.
+		return nil
+	}
+	vcsProvider, err := r.store.GetVCSProvider(ctx, &store.FindVCSProviderMessage{ResourceID: &vcsConnector.VCSResourceID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get VCS provider %q", vcsConnector.VCSResourceID)
+	}
+	if vcsProvider == nil {
+		return nil
+	}
+	issue, err := r.store.GetIssueV2(ctx, &store.FindIssueMessage{PlanUID: &plan.UID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get issue for plan %d", plan.UID)
+	}
+	if issue == nil {
+		// GitOps syncs the plan again after creating the issue.
+		return nil
+	}
+	setting, err := r.store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get workspace general setting")
+	}
+
+	issueURL := fmt.Sprintf("%s/%s", setting.ExternalUrl, common.FormatIssue(issue.Project.ResourceID, issue.UID))
+	provider := vcs.Get(vcsProvider.Type, vcs.ProviderConfig{InstanceURL: vcsProvider.InstanceURL, AuthToken: vcsProvider.AccessToken})
+	repositoryID, pullRequestID := vcsConnector.Payload.ExternalId, getPullRequestID(vcsSource.PullRequestUrl)
+
+	// The summary comment is still worth updating if the commit status cannot be set, e.g. the token lacks the permission.
+	if err := r.syncCommitStatus(ctx, provider, repositoryID, pullRequestID, issueURL, plan); err != nil {
+		slog.Error("failed to sync commit status", slog.Int64("plan", plan.UID), log.BBError(err))
+	}
+
+	comment, err := r.getSummaryComment(ctx, issueURL, plan, vcsConnector)
+	if err != nil {
+		return err
+	}
+	return upsertSummaryComment(ctx, provider, repositoryID, pullRequestID, comment)
+}
+
+// upsertSummaryComment updates the existing summary comment of the pull request, or creates one if there is none,
+// so that the pull request keeps a single summary comment no matter how many times it is pushed.
+// The summary comment is the one created by the authenticated user with the summary prefix, so we never overwrite the others' comments quoting it.
+func upsertSummaryComment(ctx context.Context, provider vcs.Provider, repositoryID, pullRequestID, comment string) error {
+	userID, err := provider.GetCurrentUserID(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the current user")
+	}
+	comments, err := provider.ListPullRequestComments(ctx, repositoryID, pullRequestID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list pull request comments")
+	}
+	for _, c := range comments {
+		if c.AuthorID != userID || !strings.HasPrefix(c.Content, summaryCommentPrefix) {
+			continue
+		}
+		if c.Content == comment {
+			return nil
+		}
+		if err := provider.UpdatePullRequestComment(ctx, repositoryID, pullRequestID, c.ID, comment); err != nil {
+			return errors.Wrapf(err, "failed to update pull request comment")
+		}
+		return nil
+	}
+	if err := provider.CreatePullRequestComment(ctx, repositoryID, pullRequestID, comment); err != nil {
+		return errors.Wrapf(err, "failed to create pull request comment")
+	}
+	return nil
+}
+
+func getPullRequestID(url string) string {
+	fields := strings.Split(url, "/")
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
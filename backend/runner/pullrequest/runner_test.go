@@ -0,0 +1,132 @@
+package pullrequest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/tests/fake"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestUpsertSummaryComment(t *testing.T) {
+	tests := []struct {
+		vcsType            storepb.VCSType
+		vcsProviderCreator fake.VCSProviderCreator
+		repository         *vcs.Repository
+		// repositoryID is the repository ID used by the VCS provider and its fake.
+		repositoryID string
+	}{
+		{
+			vcsType:            storepb.VCSType_GITHUB,
+			vcsProviderCreator: fake.NewGitHub,
+			repository:         &vcs.Repository{ID: "1", Name: "repo", FullPath: "owner/repo"},
+			repositoryID:       "owner/repo",
+		},
+		{
+			vcsType:            storepb.VCSType_GITLAB,
+			vcsProviderCreator: fake.NewGitLab,
+			repository:         &vcs.Repository{ID: "42", Name: "repo", FullPath: "owner/repo"},
+			repositoryID:       "42",
+		},
+		{
+			vcsType:            storepb.VCSType_BITBUCKET,
+			vcsProviderCreator: fake.NewBitbucket,
+			repository:         &vcs.Repository{ID: "owner/repo", Name: "repo", FullPath: "owner/repo"},
+			repositoryID:       "owner/repo",
+		},
+		{
+			vcsType:            storepb.VCSType_AZURE_DEVOPS,
+			vcsProviderCreator: fake.NewAzure,
+			repository:         &vcs.Repository{ID: "org/project/repo", Name: "repo", FullPath: "org/project/repo"},
+			repositoryID:       "org/project/repo",
+		},
+		{
+			vcsType:            storepb.VCSType_GITEA,
+			vcsProviderCreator: fake.NewGitea,
+			repository:         &vcs.Repository{ID: "1", Name: "repo", FullPath: "owner/repo"},
+			repositoryID:       "owner/repo",
+		},
+	}
+
+	a := require.New(t)
+	ctx := context.Background()
+	for _, test := range tests {
+		fakeProvider, instanceURL, err := startFakeVCSProvider(test.vcsProviderCreator)
+		a.NoError(err, test.vcsType)
+		a.NoError(fakeProvider.CreateRepository(test.repository), test.vcsType)
+		a.NoError(fakeProvider.AddPullRequest(test.repositoryID, 7, []*vcs.PullRequestFile{{Path: "migration.sql", LastCommitID: "abc123"}}), test.vcsType)
+		// The comment quoting the summary by another user must not be overwritten.
+		otherComment := fmt.Sprintf("%s the plan is stale, please take a look.", summaryCommentPrefix)
+		a.NoError(fakeProvider.AddPullRequestComment(test.repositoryID, 7, otherComment), test.vcsType)
+
+		provider := vcs.Get(test.vcsType, vcs.ProviderConfig{InstanceURL: instanceURL, AuthToken: "token"})
+		userID, err := provider.GetCurrentUserID(ctx)
+		a.NoError(err, test.vcsType)
+
+		firstComment := fmt.Sprintf("%s the plan checks are running.", summaryCommentPrefix)
+		secondComment := fmt.Sprintf("%s the plan checks passed.", summaryCommentPrefix)
+		for _, summary := range []string{firstComment, secondComment, secondComment} {
+			a.NoError(upsertSummaryComment(ctx, provider, test.repositoryID, "7", summary), test.vcsType)
+
+			comments, err := provider.ListPullRequestComments(ctx, test.repositoryID, "7")
+			a.NoError(err, test.vcsType)
+			a.Len(comments, 2, test.vcsType)
+			a.NotEqual(userID, comments[0].AuthorID, test.vcsType)
+			a.Equal(otherComment, comments[0].Content, test.vcsType)
+			a.Equal(userID, comments[1].AuthorID, test.vcsType)
+			a.Equal(summary, comments[1].Content, test.vcsType)
+		}
+
+		// The unknown comment cannot be updated.
+		unknownCommentID := "100"
+		if test.vcsType == storepb.VCSType_AZURE_DEVOPS {
+			unknownCommentID = "100/1"
+		}
+		a.Error(provider.UpdatePullRequestComment(ctx, test.repositoryID, "7", unknownCommentID, secondComment), test.vcsType)
+		a.NoError(fakeProvider.Close(), test.vcsType)
+	}
+}
+
+// startFakeVCSProvider starts the fake VCS provider on a free port, and returns its instance URL.
+func startFakeVCSProvider(vcsProviderCreator fake.VCSProviderCreator) (fake.VCSProvider, string, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return nil, "", err
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	if err := listener.Close(); err != nil {
+		return nil, "", err
+	}
+
+	p := vcsProviderCreator(port)
+	errChan := make(chan error, 1)
+	go func() {
+		if err := p.Run(); err != nil && err != http.ErrServerClosed {
+			errChan <- err
+		}
+	}()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case <-ticker.C:
+			if p.ListenerAddr() != nil {
+				return p, fmt.Sprintf("http://localhost:%d", port), nil
+			}
+		case err := <-errChan:
+			return nil, "", errors.Wrap(err, "failed to run the fake VCS provider")
+		case <-timeout:
+			return nil, "", errors.New("timeout waiting for the fake VCS provider to start")
+		}
+	}
+}
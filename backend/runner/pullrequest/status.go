@@ -0,0 +1,131 @@
+package pullrequest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// commitStatusName is the name of the commit status, which can be required by the branch protection rules.
+	commitStatusName = "Bytebase"
+)
+
+// syncCommitStatus sets the commit status of the last commit of the pull request.
+// It reflects the plan check results before the rollout, so that the branch protection can block merging the pull request
+// with SQL review errors, and reflects the rollout progress after the pull request is merged.
+func (r *Runner) syncCommitStatus(ctx context.Context, provider vcs.Provider, repositoryID, pullRequestID, issueURL string, plan *store.PlanMessage) error {
+	commit := plan.Config.GetVcsSource().GetLastCommit()
+	if commit == "" {
+		return nil
+	}
+
+	var status *vcs.CommitStatus
+	if plan.PipelineUID == nil {
+		planCheckRuns, err := r.store.ListPlanCheckRuns(ctx, &store.FindPlanCheckRunMessage{PlanUID: &plan.UID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to list plan check runs for plan %d", plan.UID)
+		}
+		status = getPlanCheckCommitStatus(planCheckRuns, getPlanSheetUIDs(plan))
+	} else {
+		tasks, err := r.store.ListTasks(ctx, &api.TaskFind{PipelineID: plan.PipelineUID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to list tasks for pipeline %d", *plan.PipelineUID)
+		}
+		s, err := getRolloutCommitStatus(tasks)
+		if err != nil {
+			return err
+		}
+		status = s
+	}
+	status.Name = commitStatusName
+	status.TargetURL = issueURL
+
+	if err := provider.SetCommitStatus(ctx, repositoryID, pullRequestID, commit, status); err != nil {
+		return errors.Wrapf(err, "failed to set commit status of commit %s", commit)
+	}
+	return nil
+}
+
+// getPlanCheckCommitStatus returns the commit status from the latest plan check runs of the files in the plan.
+// The runs of the files no longer in the plan are outdated by the pushes to the pull request.
+func getPlanCheckCommitStatus(planCheckRuns []*store.PlanCheckRunMessage, sheetUIDs []int) *vcs.CommitStatus {
+	runsBySheet := getLatestPlanCheckRunsBySheet(planCheckRuns)
+	var total, running, failed, errorCount, warningCount int
+	for _, sheetUID := range sheetUIDs {
+		for _, run := range runsBySheet[int32(sheetUID)] {
+			total++
+			switch run.Status {
+			case store.PlanCheckRunStatusRunning:
+				running++
+			case store.PlanCheckRunStatusFailed:
+				failed++
+			case store.PlanCheckRunStatusDone:
+				for _, result := range run.Result.GetResults() {
+					switch result.Status {
+					case storepb.PlanCheckRunResult_Result_ERROR:
+						errorCount++
+					case storepb.PlanCheckRunResult_Result_WARNING:
+						warningCount++
+					}
+				}
+			}
+		}
+	}
+
+	switch {
+	case total == 0:
+		return &vcs.CommitStatus{State: vcs.CommitStatusPending, Description: "Waiting for the plan checks"}
+	case running > 0:
+		return &vcs.CommitStatus{State: vcs.CommitStatusPending, Description: fmt.Sprintf("Running %d of %d plan checks", running, total)}
+	case failed > 0:
+		return &vcs.CommitStatus{State: vcs.CommitStatusFailure, Description: fmt.Sprintf("%d of %d plan checks failed to run", failed, total)}
+	case errorCount > 0:
+		return &vcs.CommitStatus{State: vcs.CommitStatusFailure, Description: fmt.Sprintf("Plan checks found %d errors and %d warnings", errorCount, warningCount)}
+	case warningCount > 0:
+		return &vcs.CommitStatus{State: vcs.CommitStatusSuccess, Description: fmt.Sprintf("Plan checks passed with %d warnings", warningCount)}
+	default:
+		return &vcs.CommitStatus{State: vcs.CommitStatusSuccess, Description: "Plan checks passed"}
+	}
+}
+
+// getRolloutCommitStatus returns the commit status from the rollout progress of the tasks.
+func getRolloutCommitStatus(tasks []*store.TaskMessage) (*vcs.CommitStatus, error) {
+	var finished, failed, canceled int
+	for _, task := range tasks {
+		skipped, err := utils.GetTaskSkipped(task)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get skipped of task %d", task.ID)
+		}
+		if skipped {
+			finished++
+			continue
+		}
+		switch task.LatestTaskRunStatus {
+		case api.TaskRunDone:
+			finished++
+		case api.TaskRunFailed:
+			failed++
+		case api.TaskRunCanceled:
+			canceled++
+		}
+	}
+
+	switch {
+	case failed > 0:
+		return &vcs.CommitStatus{State: vcs.CommitStatusFailure, Description: fmt.Sprintf("Rollout failed, %d of %d tasks failed", failed, len(tasks))}, nil
+	case canceled > 0:
+		return &vcs.CommitStatus{State: vcs.CommitStatusFailure, Description: fmt.Sprintf("Rollout canceled, %d of %d tasks canceled", canceled, len(tasks))}, nil
+	case finished == len(tasks):
+		return &vcs.CommitStatus{State: vcs.CommitStatusSuccess, Description: "Rollout completed"}, nil
+	default:
+		return &vcs.CommitStatus{State: vcs.CommitStatusPending, Description: fmt.Sprintf("Rolling out, %d of %d tasks done", finished, len(tasks))}, nil
+	}
+}
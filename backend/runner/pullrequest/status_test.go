@@ -0,0 +1,121 @@
+package pullrequest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestGetPlanCheckCommitStatus(t *testing.T) {
+	newRun := func(uid int, sheetUID int32, status store.PlanCheckRunStatus, results ...storepb.PlanCheckRunResult_Result_Status) *store.PlanCheckRunMessage {
+		run := &store.PlanCheckRunMessage{
+			UID:    uid,
+			Type:   store.PlanCheckDatabaseStatementAdvise,
+			Status: status,
+			Config: &storepb.PlanCheckRunConfig{
+				SheetUid:     sheetUID,
+				InstanceUid:  1,
+				DatabaseName: "db",
+			},
+			Result: &storepb.PlanCheckRunResult{},
+		}
+		for _, result := range results {
+			run.Result.Results = append(run.Result.Results, &storepb.PlanCheckRunResult_Result{Status: result})
+		}
+		return run
+	}
+
+	tests := []struct {
+		runs      []*store.PlanCheckRunMessage
+		sheetUIDs []int
+		want      vcs.CommitStatusState
+	}{
+		{
+			runs:      nil,
+			sheetUIDs: []int{10},
+			want:      vcs.CommitStatusPending,
+		},
+		{
+			runs:      []*store.PlanCheckRunMessage{newRun(1, 10, store.PlanCheckRunStatusRunning)},
+			sheetUIDs: []int{10},
+			want:      vcs.CommitStatusPending,
+		},
+		{
+			runs:      []*store.PlanCheckRunMessage{newRun(1, 10, store.PlanCheckRunStatusFailed)},
+			sheetUIDs: []int{10},
+			want:      vcs.CommitStatusFailure,
+		},
+		{
+			runs:      []*store.PlanCheckRunMessage{newRun(1, 10, store.PlanCheckRunStatusDone, storepb.PlanCheckRunResult_Result_SUCCESS, storepb.PlanCheckRunResult_Result_ERROR)},
+			sheetUIDs: []int{10},
+			want:      vcs.CommitStatusFailure,
+		},
+		{
+			runs:      []*store.PlanCheckRunMessage{newRun(1, 10, store.PlanCheckRunStatusDone, storepb.PlanCheckRunResult_Result_WARNING)},
+			sheetUIDs: []int{10},
+			want:      vcs.CommitStatusSuccess,
+		},
+		{
+			// The rerun passes.
+			runs: []*store.PlanCheckRunMessage{
+				newRun(1, 10, store.PlanCheckRunStatusDone, storepb.PlanCheckRunResult_Result_ERROR),
+				newRun(2, 10, store.PlanCheckRunStatusDone, storepb.PlanCheckRunResult_Result_SUCCESS),
+			},
+			sheetUIDs: []int{10},
+			want:      vcs.CommitStatusSuccess,
+		},
+		{
+			// The file with errors is no longer in the plan.
+			runs: []*store.PlanCheckRunMessage{
+				newRun(1, 10, store.PlanCheckRunStatusDone, storepb.PlanCheckRunResult_Result_ERROR),
+				newRun(2, 20, store.PlanCheckRunStatusDone, storepb.PlanCheckRunResult_Result_SUCCESS),
+			},
+			sheetUIDs: []int{20},
+			want:      vcs.CommitStatusSuccess,
+		},
+	}
+
+	for _, test := range tests {
+		status := getPlanCheckCommitStatus(test.runs, test.sheetUIDs)
+		require.Equal(t, test.want, status.State, status.Description)
+	}
+}
+
+func TestGetRolloutCommitStatus(t *testing.T) {
+	newTask := func(status api.TaskRunStatus, payload string) *store.TaskMessage {
+		return &store.TaskMessage{LatestTaskRunStatus: status, Payload: payload}
+	}
+
+	tests := []struct {
+		tasks []*store.TaskMessage
+		want  vcs.CommitStatusState
+	}{
+		{
+			tasks: []*store.TaskMessage{newTask(api.TaskRunDone, "{}"), newTask(api.TaskRunNotStarted, "{}")},
+			want:  vcs.CommitStatusPending,
+		},
+		{
+			tasks: []*store.TaskMessage{newTask(api.TaskRunDone, "{}"), newTask(api.TaskRunNotStarted, `{"skipped":true}`)},
+			want:  vcs.CommitStatusSuccess,
+		},
+		{
+			tasks: []*store.TaskMessage{newTask(api.TaskRunDone, "{}"), newTask(api.TaskRunFailed, "{}")},
+			want:  vcs.CommitStatusFailure,
+		},
+		{
+			tasks: []*store.TaskMessage{newTask(api.TaskRunCanceled, "{}"), newTask(api.TaskRunRunning, "{}")},
+			want:  vcs.CommitStatusFailure,
+		},
+	}
+
+	for _, test := range tests {
+		status, err := getRolloutCommitStatus(test.tasks)
+		require.NoError(t, err)
+		require.Equal(t, test.want, status.State, status.Description)
+	}
+}
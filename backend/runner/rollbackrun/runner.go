@@ -21,6 +21,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
+	"github.com/bytebase/bytebase/backend/plugin/db/pg"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -48,6 +49,7 @@ func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 	defer wg.Done()
+	r.dropOrphanedPostgresRollbackSlots(ctx)
 	r.retryGenerateRollbackSQL(ctx)
 	for {
 		select {
@@ -90,6 +92,82 @@ func (r *Runner) retryGenerateRollbackSQL(ctx context.Context) {
 	}
 }
 
+// dropOrphanedPostgresRollbackSlots drops the PostgreSQL logical replication slots which no one will consume.
+// The slots are left if Bytebase exits after creating them, e.g. before the transaction ID is persisted.
+// It is called when Bytebase server starts, the slots of the running tasks are recreated when the tasks are rerun.
+func (r *Runner) dropOrphanedPostgresRollbackSlots(ctx context.Context) {
+	taskList, err := r.store.ListTasks(ctx, &api.TaskFind{
+		LatestTaskRunStatusList: &[]api.TaskRunStatus{api.TaskRunDone, api.TaskRunFailed, api.TaskRunCanceled},
+		TypeList:                &[]api.TaskType{api.TaskDatabaseDataUpdate},
+		Payload:                 "task.payload->>'replicationSlot' != '' AND task.payload->>'rollbackSqlStatus'='PENDING'",
+	})
+	if err != nil {
+		slog.Error("Failed to get DML tasks with the logical replication slot", log.BBError(err))
+		return
+	}
+	for _, task := range taskList {
+		payload := &api.TaskDatabaseDataUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			slog.Error("Invalid database data update payload", slog.Int("taskID", task.ID), log.BBError(err))
+			continue
+		}
+		// The slot is consumed by retryGenerateRollbackSQL.
+		if task.LatestTaskRunStatus == api.TaskRunDone && payload.TransactionID != "" {
+			continue
+		}
+		if err := r.dropPostgresRollbackSlot(ctx, task, payload.ReplicationSlot); err != nil {
+			slog.Error("Failed to drop the orphaned logical replication slot", slog.Int("taskID", task.ID), slog.String("slot", payload.ReplicationSlot), log.BBError(err))
+			continue
+		}
+
+		payload.ReplicationSlot = ""
+		if task.LatestTaskRunStatus == api.TaskRunDone {
+			payload.RollbackSQLStatus = api.RollbackSQLStatusFailed
+			payload.RollbackError = "the transaction ID of the migration is lost"
+		}
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			slog.Error("Failed to marshal task payload", slog.Int("taskID", task.ID), log.BBError(err))
+			continue
+		}
+		payloadString := string(payloadBytes)
+		if _, err := r.store.UpdateTaskV2(ctx, &api.TaskPatch{
+			ID:        task.ID,
+			UpdaterID: api.SystemBotID,
+			Payload:   &payloadString,
+		}); err != nil {
+			slog.Error("Failed to patch task with the PostgreSQL payload", slog.Int("taskID", task.ID), log.BBError(err))
+		}
+	}
+}
+
+func (r *Runner) dropPostgresRollbackSlot(ctx context.Context, task *store.TaskMessage, slotName string) error {
+	if task.DatabaseID == nil {
+		return errors.Errorf("task %d has no database", task.ID)
+	}
+	instance, err := r.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find instance")
+	}
+	if instance == nil {
+		return errors.Errorf("instance %d not found", task.InstanceID)
+	}
+	database, err := r.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find database")
+	}
+	if database == nil {
+		return errors.Errorf("database %d not found", *task.DatabaseID)
+	}
+	// The logical replication slot is in the database, we need to connect to the same database.
+	driver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, instance, database, db.ConnectionContext{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get admin database driver")
+	}
+	defer driver.Close(ctx)
+	return pg.DropRollbackSlot(ctx, driver.GetDB(), slotName)
+}
+
 func (r *Runner) generateRollbackSQL(ctx context.Context, task *store.TaskMessage) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -128,7 +206,81 @@ func (r *Runner) generateRollbackSQL(ctx context.Context, task *store.TaskMessag
 		r.generateMySQLRollbackSQL(ctx, task, payload, instance, project)
 	case storepb.Engine_ORACLE:
 		r.generateOracleRollbackSQL(ctx, task, payload, instance, project)
+	case storepb.Engine_POSTGRES:
+		r.generatePostgresRollbackSQL(ctx, task, payload, instance, database, project)
+	}
+}
+
+func (r *Runner) generatePostgresRollbackSQL(ctx context.Context, task *store.TaskMessage, payload *api.TaskDatabaseDataUpdatePayload, instance *store.InstanceMessage, database *store.DatabaseMessage, project *store.ProjectMessage) {
+	var rollbackSQLStatus api.RollbackSQLStatus
+	var rollbackStatement, rollbackError string
+
+	rollbackSQL, err := r.generatePostgresRollbackSQLImpl(ctx, payload, instance, database)
+	if err != nil {
+		slog.Error("Failed to generate rollback SQL statement", log.BBError(err))
+		rollbackSQLStatus = api.RollbackSQLStatusFailed
+		rollbackError = err.Error()
+	} else {
+		rollbackSQLStatus = api.RollbackSQLStatusDone
+		rollbackStatement = rollbackSQL
 	}
+
+	sheet, err := r.store.CreateSheet(ctx, &store.SheetMessage{
+		CreatorID:  api.SystemBotID,
+		ProjectUID: project.UID,
+		Title:      fmt.Sprintf("Sheet for rolling back task %d", task.ID),
+		Statement:  rollbackStatement,
+	})
+	if err != nil {
+		slog.Error("failed to create database creation sheet", log.BBError(err))
+		return
+	}
+	patch := &api.TaskPatch{
+		ID:                task.ID,
+		UpdaterID:         api.SystemBotID,
+		RollbackSQLStatus: &rollbackSQLStatus,
+		RollbackSheetID:   &sheet.UID,
+		RollbackError:     &rollbackError,
+	}
+	if _, err := r.store.UpdateTaskV2(ctx, patch); err != nil {
+		slog.Error("Failed to patch task with the PostgreSQL payload", slog.Int("taskID", task.ID))
+		return
+	}
+	slog.Debug("Rollback SQL generation success", slog.Int("taskID", task.ID))
+}
+
+func (r *Runner) generatePostgresRollbackSQLImpl(ctx context.Context, payload *api.TaskDatabaseDataUpdatePayload, instance *store.InstanceMessage, database *store.DatabaseMessage) (string, error) {
+	if payload.ReplicationSlot == "" {
+		return "", errors.New("missing logical replication slot, please check if wal_level is logical and the user has the REPLICATION privilege")
+	}
+	// The logical replication slot is in the database, we need to connect to the same database.
+	driver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, instance, database, db.ConnectionContext{})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get admin database driver")
+	}
+	defer driver.Close(ctx)
+	// The slot retains the WAL, so we always drop it after the generation.
+	defer func() {
+		if err := pg.DropRollbackSlot(ctx, driver.GetDB(), payload.ReplicationSlot); err != nil {
+			slog.Error("failed to drop the logical replication slot", slog.String("slot", payload.ReplicationSlot), log.BBError(err))
+		}
+	}()
+
+	if payload.TransactionID == "" {
+		return "", errors.New("missing transaction ID, may be there is no data change in the transaction")
+	}
+	if payload.LSNEnd == "" {
+		return "", errors.New("missing the WAL location after executing the transaction")
+	}
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		return "", errors.Errorf("failed to cast driver to pg.Driver")
+	}
+	rollbackSQL, err := pgDriver.GenerateRollbackSQL(ctx, payload.ReplicationSlot, payload.LSNEnd, payload.TransactionID)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to generate rollback SQL statement")
+	}
+	return rollbackSQL, nil
 }
 
 func (r *Runner) generateOracleRollbackSQL(ctx context.Context, task *store.TaskMessage, payload *api.TaskDatabaseDataUpdatePayload, instance *store.InstanceMessage, project *store.ProjectMessage) {
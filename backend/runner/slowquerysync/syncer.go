@@ -119,8 +119,8 @@ func (s *Syncer) syncInstanceSlowQuery(ctx context.Context, instance *store.Inst
 	}
 
 	switch instance.Engine {
-	case storepb.Engine_MYSQL:
-		return s.syncMySQLSlowQuery(ctx, instance)
+	case storepb.Engine_MYSQL, storepb.Engine_ORACLE, storepb.Engine_MSSQL, storepb.Engine_SNOWFLAKE:
+		return s.syncInstanceSlowQueryByDate(ctx, instance)
 	case storepb.Engine_POSTGRES:
 		return s.syncPostgreSQLSlowQuery(ctx, instance, project)
 	default:
@@ -288,7 +288,9 @@ func getLatestLogTime(logMap map[string]*storepb.SlowQueryStatistics) time.Time
 	return time.Time{}
 }
 
-func (s *Syncer) syncMySQLSlowQuery(ctx context.Context, instance *store.InstanceMessage) error {
+// syncInstanceSlowQueryByDate syncs the slow query logs of the instance day by day,
+// for the engines that keep the slow query history with timestamps.
+func (s *Syncer) syncInstanceSlowQueryByDate(ctx context.Context, instance *store.InstanceMessage) error {
 	today := time.Now().UTC().Truncate(24 * time.Hour)
 
 	earliestDate := today.AddDate(0, 0, -retentionCycle)
@@ -315,6 +317,17 @@ func (s *Syncer) syncMySQLSlowQuery(ctx context.Context, instance *store.Instanc
 		return err
 	}
 
+	cumulative := false
+	if d, ok := driver.(cumulativeSlowQueryDriver); ok {
+		if cumulative, err = d.IsSlowQueryCumulative(ctx); err != nil {
+			return err
+		}
+	}
+	if cumulative {
+		// The cumulative statistics cannot be split by date, so they are synced into today only.
+		latestSlowLogDate = &today
+	}
+
 	for date := latestSlowLogDate.Truncate(24 * time.Hour); !date.After(today); date = date.AddDate(0, 0, 1) {
 		logs, err := driver.SyncSlowQuery(ctx, date)
 		if err != nil {
@@ -322,6 +335,14 @@ func (s *Syncer) syncMySQLSlowQuery(ctx context.Context, instance *store.Instanc
 		}
 
 		for dbName, slowLog := range logs {
+			if cumulative {
+				if slowLog, err = s.subtractPreviousSlowLog(ctx, instance, dbName, slowLog, earliestDate, date); err != nil {
+					return err
+				}
+				if len(slowLog.Items) == 0 {
+					continue
+				}
+			}
 			if err := s.store.UpsertSlowLog(ctx, &store.UpsertSlowLogMessage{
 				EnvironmentID: &instance.EnvironmentID,
 				InstanceID:    &instance.ResourceID,
@@ -338,3 +359,74 @@ func (s *Syncer) syncMySQLSlowQuery(ctx context.Context, instance *store.Instanc
 
 	return nil
 }
+
+// cumulativeSlowQueryDriver is implemented by the drivers whose slow query statistics may be accumulated
+// since the statements are cached rather than recorded by date, such as Oracle without AWR.
+type cumulativeSlowQueryDriver interface {
+	IsSlowQueryCumulative(ctx context.Context) (bool, error)
+}
+
+// subtractPreviousSlowLog subtracts the slow logs stored before the date from the cumulative statistics,
+// so the log of the date only contains the deltas since the previous days.
+func (s *Syncer) subtractPreviousSlowLog(ctx context.Context, instance *store.InstanceMessage, databaseName string, statistics *storepb.SlowQueryStatistics, startDate, endDate time.Time) (*storepb.SlowQueryStatistics, error) {
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
+		InstanceID:          &instance.ResourceID,
+		DatabaseName:        &databaseName,
+		IgnoreCaseSensitive: store.IgnoreDatabaseAndTableCaseSensitive(instance),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if database == nil {
+		return statistics, nil
+	}
+	logs, err := s.store.ListSlowQuery(ctx, &store.ListSlowQueryMessage{
+		InstanceUID:  &instance.UID,
+		DatabaseUID:  &database.UID,
+		StartLogDate: &startDate,
+		EndLogDate:   &endDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subtractSlowQueryLog(statistics, logs), nil
+}
+
+func subtractSlowQueryLog(statistics *storepb.SlowQueryStatistics, logs []*v1pb.SlowQueryLog) *storepb.SlowQueryStatistics {
+	previous := make(map[string]*v1pb.SlowQueryStatistics)
+	for _, log := range logs {
+		previous[log.Statistics.SqlFingerprint] = log.Statistics
+	}
+
+	result := &storepb.SlowQueryStatistics{}
+	for _, item := range statistics.Items {
+		value, exists := previous[item.SqlFingerprint]
+		// The statistics are reset if the statements are aged out of the cache, then they are all deltas.
+		if !exists || value.Count > item.Count {
+			result.Items = append(result.Items, item)
+			continue
+		}
+		if value.Count == item.Count {
+			// No execution since the previous days.
+			continue
+		}
+		totalQueryTime := item.TotalQueryTime.AsDuration() - value.AverageQueryTime.AsDuration()*time.Duration(value.Count)
+		if totalQueryTime < 0 {
+			totalQueryTime = 0
+		}
+		totalRowsSent := item.TotalRowsSent - value.AverageRowsSent*value.Count
+		if totalRowsSent < 0 {
+			totalRowsSent = 0
+		}
+		result.Items = append(result.Items, &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   item.SqlFingerprint,
+			Count:            item.Count - value.Count,
+			LatestLogTime:    item.LatestLogTime,
+			TotalQueryTime:   durationpb.New(totalQueryTime),
+			MaximumQueryTime: item.MaximumQueryTime,
+			TotalRowsSent:    totalRowsSent,
+			MaximumRowsSent:  item.MaximumRowsSent,
+		})
+	}
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -24,6 +25,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/store/model"
 )
@@ -73,7 +75,15 @@ func (exec *DataUpdateExecutor) RunOnce(ctx context.Context, driverCtx context.C
 		return true, nil, err
 	}
 	version := model.Version{Version: payload.SchemaVersion}
-	return runMigration(ctx, driverCtx, exec.store, exec.dbFactory, exec.stateCfg, exec.profile, task, taskRunUID, db.Data, statement, version, &payload.SheetID)
+	terminated, result, err = runMigration(ctx, driverCtx, exec.store, exec.dbFactory, exec.stateCfg, exec.profile, task, taskRunUID, db.Data, statement, version, &payload.SheetID)
+	if err != nil {
+		return terminated, result, err
+	}
+	if err := exec.createRestoreSheet(ctx, payload, task); err != nil {
+		// The data is updated, so we don't fail the task.
+		slog.Error("failed to create the restore sheet", slog.Int("task", task.ID), log.BBError(err))
+	}
+	return terminated, result, nil
 }
 
 func (exec *DataUpdateExecutor) backupData(
@@ -107,13 +117,6 @@ func (exec *DataUpdateExecutor) backupData(
 	if err != nil {
 		return err
 	}
-	backupDatabase, err := exec.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{InstanceID: &backupInstanceID, DatabaseName: &backupDatabaseName})
-	if err != nil {
-		return err
-	}
-	if backupDatabase == nil {
-		return errors.Errorf("backup database %q not found", payload.PreUpdateBackupDetail.Database)
-	}
 
 	driver, err := exec.dbFactory.GetAdminDatabaseDriver(driverCtx, instance, database, db.ConnectionContext{})
 	if err != nil {
@@ -121,11 +124,27 @@ func (exec *DataUpdateExecutor) backupData(
 	}
 	defer driver.Close(driverCtx)
 
-	backupDriver, err := exec.dbFactory.GetAdminDatabaseDriver(driverCtx, instance, backupDatabase, db.ConnectionContext{})
-	if err != nil {
-		return err
+	// PostgreSQL cannot write across databases, so the backup tables are saved into
+	// the schema named after the backup database in the same database.
+	backupDatabase, backupDriver := database, driver
+	if instance.Engine == storepb.Engine_POSTGRES {
+		if _, err := driver.Execute(driverCtx, fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s;`, pgparser.QuoteIdentifier(backupDatabaseName)), db.ExecuteOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to create backup schema %q", backupDatabaseName)
+		}
+	} else {
+		backupDatabase, err = exec.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{InstanceID: &backupInstanceID, DatabaseName: &backupDatabaseName})
+		if err != nil {
+			return err
+		}
+		if backupDatabase == nil {
+			return errors.Errorf("backup database %q not found", payload.PreUpdateBackupDetail.Database)
+		}
+		backupDriver, err = exec.dbFactory.GetAdminDatabaseDriver(driverCtx, instance, backupDatabase, db.ConnectionContext{})
+		if err != nil {
+			return err
+		}
+		defer backupDriver.Close(driverCtx)
 	}
-	defer backupDriver.Close(driverCtx)
 
 	suffix := "_" + time.Now().Format("20060102150405")
 	statements, err := base.TransformDMLToSelect(instance.Engine, statement, database.DatabaseName, backupDatabaseName, suffix)
@@ -137,6 +156,7 @@ func (exec *DataUpdateExecutor) backupData(
 		if _, err := driver.Execute(driverCtx, statement.Statement, db.ExecuteOptions{}); err != nil {
 			return err
 		}
+		commentDatabase, commentSchema := backupDatabaseName, ""
 		var originalLine *int32
 		switch instance.Engine {
 		case storepb.Engine_MYSQL, storepb.Engine_TIDB:
@@ -149,6 +169,19 @@ func (exec *DataUpdateExecutor) backupData(
 			}
 			num := int32(statement.OriginalLine)
 			originalLine = &num
+		case storepb.Engine_POSTGRES:
+			if _, err := driver.Execute(driverCtx, fmt.Sprintf(`COMMENT ON TABLE %s.%s IS 'issue %d';`, pgparser.QuoteIdentifier(backupDatabaseName), pgparser.QuoteIdentifier(statement.TableName), issue.UID), db.ExecuteOptions{}); err != nil {
+				return err
+			}
+			payload.PreUpdateBackupDetail.Tables = append(payload.PreUpdateBackupDetail.Tables, &api.PreUpdateBackupTable{
+				Schema:       backupDatabaseName,
+				Table:        statement.TableName,
+				SourceSchema: statement.SourceSchema,
+				SourceTable:  statement.SourceTableName,
+			})
+			commentDatabase, commentSchema = database.DatabaseName, backupDatabaseName
+			num := int32(statement.OriginalLine)
+			originalLine = &num
 		}
 
 		if err := exec.store.CreateIssueComment(ctx, &store.IssueCommentMessage{
@@ -157,10 +190,10 @@ func (exec *DataUpdateExecutor) backupData(
 				Event: &storepb.IssueCommentPayload_TaskPriorBackup_{
 					TaskPriorBackup: &storepb.IssueCommentPayload_TaskPriorBackup{
 						Task:     common.FormatTask(issue.Project.ResourceID, task.PipelineID, task.StageID, task.ID),
-						Database: backupDatabaseName,
+						Database: commentDatabase,
 						Tables: []*storepb.IssueCommentPayload_TaskPriorBackup_Table{
 							{
-								Schema: "",
+								Schema: commentSchema,
 								Table:  statement.TableName,
 							},
 						},
@@ -173,6 +206,23 @@ func (exec *DataUpdateExecutor) backupData(
 		}
 	}
 
+	if len(payload.PreUpdateBackupDetail.Tables) > 0 {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal task payload")
+		}
+		payloadString := string(payloadBytes)
+		if _, err := exec.store.UpdateTaskV2(ctx, &api.TaskPatch{
+			ID:        task.ID,
+			UpdaterID: api.SystemBotID,
+			Payload:   &payloadString,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to patch task %d with the backup tables", task.ID)
+		}
+		// Keep the backup tables when the payload is patched again in the migration.
+		task.Payload = payloadString
+	}
+
 	if err := exec.schemaSyncer.SyncDatabaseSchema(ctx, backupDatabase, true /* force */); err != nil {
 		slog.Error("failed to sync backup database schema",
 			slog.String("database", payload.PreUpdateBackupDetail.Database),
@@ -181,3 +231,76 @@ func (exec *DataUpdateExecutor) backupData(
 	}
 	return nil
 }
+
+// createRestoreSheet creates the statement restoring the backup tables as the rollback sheet of the task.
+// It is skipped if the rollback SQL is generated by other means, e.g. the logical decoding of PostgreSQL.
+func (exec *DataUpdateExecutor) createRestoreSheet(ctx context.Context, payload *api.TaskDatabaseDataUpdatePayload, task *store.TaskMessage) error {
+	if len(payload.PreUpdateBackupDetail.Tables) == 0 || payload.RollbackEnabled {
+		return nil
+	}
+	instance, err := exec.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return err
+	}
+	database, err := exec.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return err
+	}
+	project, err := exec.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &database.ProjectID})
+	if err != nil {
+		return err
+	}
+	dbSchema, err := exec.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return err
+	}
+	if dbSchema == nil {
+		return errors.Errorf("database schema %q not found", database.DatabaseName)
+	}
+
+	var statements []string
+	for _, table := range payload.PreUpdateBackupDetail.Tables {
+		var tableMetadata *storepb.TableMetadata
+		for _, schema := range dbSchema.GetMetadata().GetSchemas() {
+			if schema.GetName() != table.SourceSchema {
+				continue
+			}
+			for _, t := range schema.GetTables() {
+				if t.GetName() == table.SourceTable {
+					tableMetadata = t
+				}
+			}
+		}
+		statement, err := base.GenerateRestoreSQL(instance.Engine, base.BackupStatement{
+			TableName:       table.Table,
+			SourceSchema:    table.SourceSchema,
+			SourceTableName: table.SourceTable,
+		}, table.Schema, tableMetadata)
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate the restore statement for backup table %q", table.Table)
+		}
+		statements = append(statements, statement)
+	}
+
+	sheet, err := exec.store.CreateSheet(ctx, &store.SheetMessage{
+		CreatorID:  api.SystemBotID,
+		ProjectUID: project.UID,
+		Title:      fmt.Sprintf("Sheet for restoring task %d", task.ID),
+		Statement:  strings.Join(statements, "\n"),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the restore sheet")
+	}
+	rollbackEnabled := true
+	rollbackSQLStatus := api.RollbackSQLStatusDone
+	if _, err := exec.store.UpdateTaskV2(ctx, &api.TaskPatch{
+		ID:                task.ID,
+		UpdaterID:         api.SystemBotID,
+		RollbackEnabled:   &rollbackEnabled,
+		RollbackSQLStatus: &rollbackSQLStatus,
+		RollbackSheetID:   &sheet.UID,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to patch task %d with the restore sheet", task.ID)
+	}
+	return nil
+}
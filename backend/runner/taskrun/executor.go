@@ -20,6 +20,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
+	"github.com/bytebase/bytebase/backend/plugin/db/pg"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/store/model"
 	"github.com/bytebase/bytebase/backend/utils"
@@ -220,6 +221,24 @@ func executeMigration(
 		// getSetOracleTransactionIdFunc will update the task payload to set the Oracle transaction id, we need to re-retrieve the task to store to the RollbackGenerate.
 		opts.EndTransactionFunc = getSetOracleTransactionIDFunc(ctx, task, stores)
 	}
+	var rollbackSlot string
+	if task.Type == api.TaskDatabaseDataUpdate && instance.Engine == storepb.Engine_POSTGRES {
+		updatedTask, slotName, err := createPostgresRollbackSlot(ctx, driver, task, stores)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to update the task payload for PostgreSQL rollback SQL")
+		}
+		task = updatedTask
+		if slotName != "" {
+			rollbackSlot = slotName
+			opts.EndTransactionFunc = getSetPostgresTransactionIDFunc(ctx, task, stores)
+		}
+	}
+	defer func() {
+		// The slot retains the WAL, drop it as soon as possible unless the rollback runner takes it over.
+		if rollbackSlot != "" {
+			dropPostgresRollbackSlot(ctx, driver, rollbackSlot)
+		}
+	}()
 
 	if profile.ExecuteDetail && stateCfg != nil {
 		switch task.Type {
@@ -283,9 +302,141 @@ func executeMigration(
 		}
 	}
 
+	if rollbackSlot != "" && stateCfg != nil {
+		conn, err := driver.GetDB().Conn(ctx)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to create connection")
+		}
+		defer conn.Close()
+		updatedTask, err := setMigrationIDAndEndLSN(ctx, conn, task, stores, migrationID)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to update the task payload for PostgreSQL rollback SQL")
+		}
+		// The runner will periodically scan the map to generate rollback SQL asynchronously.
+		// It drops the slot after the generation.
+		stateCfg.RollbackGenerate.Store(task.ID, updatedTask)
+		rollbackSlot = ""
+	}
+
 	return migrationID, schema, nil
 }
 
+// createPostgresRollbackSlot creates the logical replication slot before executing the migration if the rollback is enabled.
+// It returns an empty slot name if the slot cannot be created, e.g. wal_level is not logical.
+func createPostgresRollbackSlot(ctx context.Context, driver db.Driver, task *store.TaskMessage, store *store.Store) (*store.TaskMessage, string, error) {
+	payload := &api.TaskDatabaseDataUpdatePayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return nil, "", errors.Wrap(err, "invalid database data update payload")
+	}
+	if !payload.RollbackEnabled {
+		return task, "", nil
+	}
+
+	conn, err := driver.GetDB().Conn(ctx)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to create connection")
+	}
+	defer conn.Close()
+	slotName := pg.GetRollbackSlotName(task.ID)
+	lsn, err := pg.CreateRollbackSlot(ctx, conn, slotName)
+	if err != nil {
+		slog.Warn("failed to create the logical replication slot", slog.Int("task", task.ID), log.BBError(err))
+		return task, "", nil
+	}
+	payload.ReplicationSlot = slotName
+	payload.LSNStart = lsn
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal task payload")
+	}
+	payloadString := string(payloadBytes)
+	patch := &api.TaskPatch{
+		ID:        task.ID,
+		UpdaterID: api.SystemBotID,
+		Payload:   &payloadString,
+	}
+	updatedTask, err := store.UpdateTaskV2(ctx, patch)
+	if err != nil {
+		dropPostgresRollbackSlot(ctx, driver, slotName)
+		return nil, "", errors.Wrapf(err, "failed to patch task %d with the PostgreSQL replication slot", task.ID)
+	}
+	return updatedTask, slotName, nil
+}
+
+func dropPostgresRollbackSlot(ctx context.Context, driver db.Driver, slotName string) {
+	if err := pg.DropRollbackSlot(ctx, driver.GetDB(), slotName); err != nil {
+		slog.Error("failed to drop the logical replication slot", slog.String("slot", slotName), log.BBError(err))
+	}
+}
+
+func getSetPostgresTransactionIDFunc(ctx context.Context, task *store.TaskMessage, store *store.Store) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		payload := &api.TaskDatabaseDataUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			slog.Error("failed to unmarshal task payload", slog.Int("TaskId", task.ID), log.BBError(err))
+			return nil
+		}
+		txID, err := pg.GetCurrentTransactionID(ctx, tx)
+		if err != nil {
+			slog.Error("failed to get the PostgreSQL transaction id in task", slog.Int("TaskId", task.ID), log.BBError(err))
+			return nil
+		}
+		payload.TransactionID = txID
+		updatedPayload, err := json.Marshal(payload)
+		if err != nil {
+			slog.Error("failed to marshal task payload", slog.Int("TaskId", task.ID), log.BBError(err))
+			return nil
+		}
+		updatedPayloadString := string(updatedPayload)
+		patch := &api.TaskPatch{
+			ID:        task.ID,
+			UpdaterID: api.SystemBotID,
+			Payload:   &updatedPayloadString,
+		}
+		if _, err = store.UpdateTaskV2(ctx, patch); err != nil {
+			slog.Error("failed to update task with new payload", slog.Any("TaskPatch", patch), log.BBError(err))
+			return nil
+		}
+		return nil
+	}
+}
+
+func setMigrationIDAndEndLSN(ctx context.Context, conn *sql.Conn, task *store.TaskMessage, store *store.Store, migrationID string) (*store.TaskMessage, error) {
+	// Re-retrieve the task because the transaction ID is set in the payload by the end transaction function.
+	updatedTask, err := store.GetTaskV2ByID(ctx, task.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get task by id %d", task.ID)
+	}
+	payload := &api.TaskDatabaseDataUpdatePayload{}
+	if err := json.Unmarshal([]byte(updatedTask.Payload), payload); err != nil {
+		return nil, errors.Wrap(err, "invalid database data update payload")
+	}
+
+	payload.MigrationID = migrationID
+	lsn, err := pg.GetCurrentLSN(ctx, conn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the WAL location after executing the migration transaction")
+	}
+	payload.LSNEnd = lsn
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal task payload")
+	}
+	payloadString := string(payloadBytes)
+	patch := &api.TaskPatch{
+		ID:        task.ID,
+		UpdaterID: api.SystemBotID,
+		Payload:   &payloadString,
+	}
+	updatedTask, err = store.UpdateTaskV2(ctx, patch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to patch task %d with the PostgreSQL WAL location", task.ID)
+	}
+	return updatedTask, nil
+}
+
 func getSetOracleTransactionIDFunc(ctx context.Context, task *store.TaskMessage, store *store.Store) func(tx *sql.Tx) error {
 	return func(tx *sql.Tx) error {
 		payload := &api.TaskDatabaseDataUpdatePayload{}
@@ -432,6 +432,7 @@ func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRun
 			)
 			return
 		}
+		s.syncPullRequest(ctx, task)
 		return
 	}
 
@@ -500,6 +501,7 @@ func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRun
 		}
 
 		s.createActivityForTaskRunStatusUpdate(ctx, task, api.TaskRunFailed)
+		s.syncPullRequest(ctx, task)
 		return
 	}
 
@@ -574,6 +576,7 @@ func (s *SchedulerV2) ListenTaskSkippedOrDone(ctx context.Context) {
 				if err != nil {
 					return errors.Wrapf(err, "failed to get task")
 				}
+				s.syncPullRequest(ctx, task)
 				if stageDoneConfirmed[task.StageID] {
 					return nil
 				}
@@ -815,6 +818,19 @@ func (s *SchedulerV2) createActivityForTaskRunStatusUpdate(ctx context.Context,
 	}
 }
 
+// syncPullRequest notifies the pull request runner to sync the rollout progress to the pull request linked to the plan of the task.
+func (s *SchedulerV2) syncPullRequest(ctx context.Context, task *store.TaskMessage) {
+	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{PipelineID: &task.PipelineID})
+	if err != nil {
+		slog.Error("failed to get plan", slog.Int("pipeline", task.PipelineID), log.BBError(err))
+		return
+	}
+	if plan == nil || plan.Config.GetVcsSource() == nil {
+		return
+	}
+	s.stateCfg.PullRequestSyncs.Store(plan.UID, true)
+}
+
 func tasksSkippedOrDone(tasks []*store.TaskMessage) (bool, error) {
 	for _, task := range tasks {
 		skipped, err := utils.GetTaskSkipped(task)
@@ -90,29 +90,33 @@ func (e *SchemaUpdateGhostCutoverExecutor) RunOnce(ctx context.Context, taskCont
 	if err != nil {
 		return true, nil, errors.Wrapf(err, "failed to get sheet statement by id: %d", payload.SheetID)
 	}
-	materials := utils.GetSecretMapFromDatabaseMessage(database)
-	// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
-	renderedStatement := utils.RenderStatement(statement, materials)
-
-	tableName, err := ghost.GetTableNameFromStatement(renderedStatement)
-	if err != nil {
-		return true, nil, common.Wrapf(err, common.Internal, "failed to parse table name from statement, statement: %v", statement)
-	}
-
-	postponeFilename := ghost.GetPostponeFlagFilename(syncTaskID, syncTask.CreatedTs, database.UID, database.DatabaseName, tableName)
-
 	value, ok := e.stateCfg.GhostTaskState.Load(syncTaskID)
 	if !ok {
 		return true, nil, errors.Errorf("failed to get gh-ost state from sync task")
 	}
-	sharedGhost, ok := value.(sharedGhostState)
-	if !ok {
-		return true, nil, errors.Errorf("failed to convert shared gh-ost state")
-	}
 
 	// not using the rendered statement here because we want to avoid leaking the rendered statement
 	version := model.Version{Version: payload.SchemaVersion}
-	terminated, result, err := cutover(ctx, taskContext, e.store, e.dbFactory, e.stateCfg, e.profile, task, taskRunUID, statement, payload.SheetID, version, postponeFilename, sharedGhost.migrationContext, sharedGhost.errCh)
+	var terminated bool
+	var result *storepb.TaskRunResult
+	switch shared := value.(type) {
+	case sharedGhostState:
+		materials := utils.GetSecretMapFromDatabaseMessage(database)
+		// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
+		renderedStatement := utils.RenderStatement(statement, materials)
+
+		tableName, parseErr := ghost.GetTableNameFromStatement(renderedStatement)
+		if parseErr != nil {
+			return true, nil, common.Wrapf(parseErr, common.Internal, "failed to parse table name from statement, statement: %v", statement)
+		}
+
+		postponeFilename := ghost.GetPostponeFlagFilename(syncTaskID, syncTask.CreatedTs, database.UID, database.DatabaseName, tableName)
+		terminated, result, err = cutover(ctx, taskContext, e.store, e.dbFactory, e.stateCfg, e.profile, task, taskRunUID, statement, payload.SheetID, version, postponeFilename, shared.migrationContext, shared.errCh)
+	case sharedPgOSCState:
+		terminated, result, err = cutoverPostgres(ctx, taskContext, e.store, e.dbFactory, e.stateCfg, e.profile, task, taskRunUID, statement, payload.SheetID, version, shared)
+	default:
+		return true, nil, errors.Errorf("failed to convert shared gh-ost state")
+	}
 	if err := e.schemaSyncer.SyncDatabaseSchema(ctx, database, true /* force */); err != nil {
 		slog.Error("failed to sync database schema",
 			slog.String("instanceName", instance.ResourceID),
@@ -170,6 +174,58 @@ func cutover(ctx context.Context, taskContext context.Context, stores *store.Sto
 	return postMigration(ctx, stores, task, mi, migrationID, &sheetID)
 }
 
+// cutoverPostgres runs the cutover of the PostgreSQL online migration.
+// Unlike gh-ost, the cutover is waited in the migration itself, which retries on the lock timeout.
+func cutoverPostgres(ctx context.Context, taskContext context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State, profile config.Profile, task *store.TaskMessage, taskRunUID int, statement string, sheetID int, schemaVersion model.Version, shared sharedPgOSCState) (terminated bool, result *storepb.TaskRunResult, err error) {
+	statement = strings.TrimSpace(statement)
+	instance, err := stores.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		shared.cancel()
+		return true, nil, err
+	}
+	database, err := stores.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		shared.cancel()
+		return true, nil, err
+	}
+	select {
+	case <-ctx.Done():
+		shared.cancel()
+		return true, nil, errors.Errorf("cutover context cancelled")
+	case <-taskContext.Done():
+		shared.cancel()
+		return true, nil, errors.Errorf("cutover context cancelled")
+	default:
+	}
+
+	mi, err := getMigrationInfo(ctx, stores, profile, task, db.Migrate, statement, schemaVersion)
+	if err != nil {
+		shared.cancel()
+		return true, nil, err
+	}
+
+	execFunc := func(_ context.Context, _ string) error {
+		shared.migrator.Cutover()
+		if migrationErr := <-shared.errCh; migrationErr != nil {
+			return errors.Wrapf(migrationErr, "failed to run online migration")
+		}
+		return nil
+	}
+	driver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, database, db.ConnectionContext{})
+	if err != nil {
+		shared.cancel()
+		return true, nil, err
+	}
+	defer driver.Close(ctx)
+	migrationID, _, err := utils.ExecuteMigrationWithFunc(ctx, ctx, stores, stateCfg, taskRunUID, driver, mi, statement, &sheetID, execFunc)
+	if err != nil {
+		shared.cancel()
+		return true, nil, err
+	}
+
+	return postMigration(ctx, stores, task, mi, migrationID, &sheetID)
+}
+
 func waitForCutover(ctx context.Context, taskContext context.Context, migrationContext *base.MigrationContext) bool {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
@@ -14,9 +14,12 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/ghost"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
 	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -24,19 +27,22 @@ import (
 )
 
 // NewSchemaUpdateGhostSyncExecutor creates a schema update (gh-ost) sync task executor.
-func NewSchemaUpdateGhostSyncExecutor(store *store.Store, stateCfg *state.State, secret string) Executor {
+func NewSchemaUpdateGhostSyncExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State, secret string) Executor {
 	return &SchemaUpdateGhostSyncExecutor{
-		store:    store,
-		stateCfg: stateCfg,
-		secret:   secret,
+		store:     store,
+		dbFactory: dbFactory,
+		stateCfg:  stateCfg,
+		secret:    secret,
 	}
 }
 
 // SchemaUpdateGhostSyncExecutor is the schema update (gh-ost) sync task executor.
+// For PostgreSQL, the online migration is run by pgosc instead of gh-ost.
 type SchemaUpdateGhostSyncExecutor struct {
-	store    *store.Store
-	stateCfg *state.State
-	secret   string
+	store     *store.Store
+	dbFactory *dbfactory.DBFactory
+	stateCfg  *state.State
+	secret    string
 }
 
 // RunOnce will run SchemaUpdateGhostSync task once.
@@ -57,6 +63,16 @@ func (exec *SchemaUpdateGhostSyncExecutor) RunOnce(ctx context.Context, taskCont
 		return true, nil, err
 	}
 
+	instance, err := exec.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return true, nil, err
+	}
+	if instance == nil {
+		return true, nil, errors.Errorf("instance %d not found", task.InstanceID)
+	}
+	if instance.Engine == storepb.Engine_POSTGRES {
+		return exec.runPostgresOnlineMigration(ctx, taskContext, task, instance, statement, payload.Flags)
+	}
 	return exec.runGhostMigration(ctx, taskContext, task, statement, payload.Flags)
 }
 
@@ -65,6 +81,12 @@ type sharedGhostState struct {
 	errCh            <-chan error
 }
 
+type sharedPgOSCState struct {
+	migrator *pgosc.Migrator
+	errCh    <-chan error
+	cancel   context.CancelFunc
+}
+
 func (exec *SchemaUpdateGhostSyncExecutor) runGhostMigration(ctx context.Context, taskContext context.Context, task *store.TaskMessage, statement string, flags map[string]string) (terminated bool, result *storepb.TaskRunResult, err error) {
 	syncDone := make(chan struct{})
 	// set buffer size to 1 to unblock the sender because there is no listner if the task is canceled.
@@ -166,3 +188,89 @@ func (exec *SchemaUpdateGhostSyncExecutor) runGhostMigration(ctx context.Context
 		return true, nil, errors.New("task canceled")
 	}
 }
+
+func (exec *SchemaUpdateGhostSyncExecutor) runPostgresOnlineMigration(ctx context.Context, taskContext context.Context, task *store.TaskMessage, instance *store.InstanceMessage, statement string, flags map[string]string) (terminated bool, result *storepb.TaskRunResult, err error) {
+	database, err := exec.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return true, nil, err
+	}
+	if database == nil {
+		return true, nil, errors.Errorf("database not found")
+	}
+
+	materials := utils.GetSecretMapFromDatabaseMessage(database)
+	renderedStatement := utils.RenderStatement(strings.TrimSpace(statement), materials)
+
+	// The driver is closed after the migration finishes in the cutover task.
+	driver, err := exec.dbFactory.GetAdminDatabaseDriver(ctx, instance, database, db.ConnectionContext{})
+	if err != nil {
+		return true, nil, err
+	}
+	migrator, err := pgosc.NewMigrator(driver.GetDB(), renderedStatement, false, flags)
+	if err != nil {
+		driver.Close(ctx)
+		return true, nil, errors.Wrap(err, "failed to init the online migration")
+	}
+
+	syncDone := make(chan struct{})
+	// set buffer size to 1 to unblock the sender because there is no listener if the task is canceled.
+	migrationError := make(chan error, 1)
+	// The migration outlives the sync task run, so it derives from the scheduler context instead of taskContext,
+	// which is canceled once the sync task run returns. It stops on the server shutdown or by migrationCancel.
+	migrationCtx, migrationCancel := context.WithCancel(ctx)
+
+	childCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func(childCtx context.Context) {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		createdTs := time.Now().Unix()
+		for {
+			select {
+			case <-ticker.C:
+				completedUnit := migrator.GetTotalRowsCopied()
+				totalUnit := migrator.GetRowsEstimate()
+				if totalUnit < completedUnit {
+					totalUnit = completedUnit
+				}
+				exec.stateCfg.TaskProgress.Store(task.ID, api.Progress{
+					TotalUnit:     totalUnit,
+					CompletedUnit: completedUnit,
+					CreatedTs:     createdTs,
+					UpdatedTs:     time.Now().Unix(),
+				})
+				if migrator.IsSynced() {
+					close(syncDone)
+					return
+				}
+			case <-childCtx.Done():
+				return
+			}
+		}
+	}(childCtx)
+
+	go func() {
+		defer driver.Close(context.Background())
+		if err := migrator.Migrate(migrationCtx); err != nil {
+			slog.Error("failed to run online migration", log.BBError(err))
+			migrationError <- err
+			return
+		}
+		migrationError <- nil
+	}()
+
+	select {
+	case <-syncDone:
+		exec.stateCfg.GhostTaskState.Store(task.ID, sharedPgOSCState{migrator: migrator, errCh: migrationError, cancel: migrationCancel})
+		return true, &storepb.TaskRunResult{Detail: "sync done"}, nil
+	case err := <-migrationError:
+		migrationCancel()
+		return true, nil, err
+	case <-ctx.Done():
+		migrationCancel()
+		return true, nil, errors.New("task canceled")
+	case <-taskContext.Done():
+		migrationCancel()
+		return true, nil, errors.New("task canceled")
+	}
+}
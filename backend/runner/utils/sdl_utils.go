@@ -33,20 +33,53 @@ func ComputeDatabaseSchemaDiff(ctx context.Context, instance *store.InstanceMess
 	if err != nil {
 		return "", errors.Wrap(err, "dump old schema")
 	}
+	return ComputeSchemaDiff(instance, schema.String(), newSchema)
+}
+
+// ComputeSyncedSchemaDiff computes the diff between the latest synced schema
+// of the database and the given schema. Unlike ComputeDatabaseSchemaDiff, it
+// does not connect to the database.
+func ComputeSyncedSchemaDiff(ctx context.Context, stores *store.Store, database *store.DatabaseMessage, newSchema string) (string, error) {
+	instance, err := stores.GetInstanceV2(ctx, &store.FindInstanceMessage{ResourceID: &database.InstanceID})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get instance %q", database.InstanceID)
+	}
+	if instance == nil {
+		return "", errors.Errorf("instance %q not found", database.InstanceID)
+	}
+	dbSchema, err := stores.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get schema of database %q", database.DatabaseName)
+	}
+	if dbSchema == nil {
+		return "", errors.Errorf("schema of database %q is not synced yet", database.DatabaseName)
+	}
+	return ComputeSchemaDiff(instance, string(dbSchema.GetSchema()), newSchema)
+}
 
+// ComputeSchemaDiff computes the diff between the given old schema dump of the
+// instance and the new schema. It returns an empty string if there is no
+// applicable diff.
+func ComputeSchemaDiff(instance *store.InstanceMessage, oldSchema, newSchema string) (string, error) {
 	var engine storepb.Engine
 	switch instance.Engine {
 	case storepb.Engine_POSTGRES, storepb.Engine_RISINGWAVE:
 		engine = storepb.Engine_POSTGRES
 	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
 		engine = storepb.Engine_MYSQL
+	case storepb.Engine_MSSQL, storepb.Engine_SNOWFLAKE:
+		engine = instance.Engine
 	default:
 		return "", errors.Errorf("unsupported database engine %q", instance.Engine)
 	}
 
-	sdlFormat, err := transform.SchemaTransform(engine, schema.String())
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to transform SDL format")
+	sdlFormat := oldSchema
+	var err error
+	if engine == storepb.Engine_POSTGRES || engine == storepb.Engine_MYSQL {
+		sdlFormat, err = transform.SchemaTransform(engine, sdlFormat)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to transform SDL format")
+		}
 	}
 	diff, err := base.SchemaDiff(engine, base.DiffContext{
 		IgnoreCaseSensitive: store.IgnoreDatabaseAndTableCaseSensitive(instance),
@@ -0,0 +1,200 @@
+// Package webhookdelivery is the runner for delivering the project webhook events.
+package webhookdelivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/state"
+	"github.com/bytebase/bytebase/backend/plugin/webhook"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	webhookDeliveryRunnerInterval = 10 * time.Second
+	// MaxAttempts is the maximum number of attempts of a webhook delivery.
+	MaxAttempts = 8
+	// initialBackoff is the delay before the second attempt, it doubles for each following attempt.
+	initialBackoff = 30 * time.Second
+	maxBackoff     = 1 * time.Hour
+	// retentionPeriod is the period to keep the finished webhook deliveries.
+	retentionPeriod = 30 * 24 * time.Hour
+	// deliveryBatchSize is the maximum number of the pending deliveries handled in one run.
+	deliveryBatchSize = 100
+	// deliveryConcurrency is the maximum number of the deliveries posted concurrently.
+	deliveryConcurrency = 10
+)
+
+// NewRunner creates a new webhook delivery runner.
+func NewRunner(store *store.Store, stateCfg *state.State) *Runner {
+	return &Runner{
+		store:    store,
+		stateCfg: stateCfg,
+	}
+}
+
+// Runner is the runner posting the pending webhook deliveries.
+type Runner struct {
+	store    *store.Store
+	stateCfg *state.State
+}
+
+// Run runs the runner.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(webhookDeliveryRunnerInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	slog.Debug(fmt.Sprintf("Webhook delivery runner started and will run every %v", webhookDeliveryRunnerInterval))
+	for {
+		select {
+		case <-ticker.C:
+			r.runOnce(ctx)
+			r.deleteOutdatedDeliveries(ctx)
+		case <-r.stateCfg.WebhookDeliveryTickleChan:
+			r.runOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) runOnce(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = errors.Errorf("%v", r)
+			}
+			slog.Error("Webhook delivery runner PANIC RECOVER", log.BBError(err), log.BBStack("panic-stack"))
+		}
+	}()
+
+	pending := store.WebhookDeliveryPending
+	now := time.Now()
+	limit := deliveryBatchSize
+	deliveries, err := r.store.ListProjectWebhookDeliveries(ctx, &store.FindProjectWebhookDeliveryMessage{
+		Status:            &pending,
+		NextAttemptBefore: &now,
+		Limit:             &limit,
+	})
+	if err != nil {
+		slog.Error("failed to list pending webhook deliveries", log.BBError(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, deliveryConcurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *store.ProjectWebhookDeliveryMessage) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := r.deliver(ctx, delivery); err != nil {
+				slog.Error("failed to deliver webhook", slog.Int("delivery", delivery.UID), log.BBError(err))
+			}
+		}(delivery)
+	}
+	wg.Wait()
+}
+
+// deleteOutdatedDeliveries deletes the finished deliveries older than the retention period.
+func (r *Runner) deleteOutdatedDeliveries(ctx context.Context) {
+	if err := r.store.DeleteOutdatedProjectWebhookDeliveries(ctx, time.Now().Add(-retentionPeriod)); err != nil {
+		slog.Error("failed to delete outdated webhook deliveries", log.BBError(err))
+	}
+}
+
+// deliver makes an attempt for the delivery and records the result.
+func (r *Runner) deliver(ctx context.Context, delivery *store.ProjectWebhookDeliveryMessage) error {
+	hook, err := r.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{ID: &delivery.ProjectWebhookUID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get project webhook %d", delivery.ProjectWebhookUID)
+	}
+	if hook == nil {
+		return errors.Errorf("project webhook %d not found", delivery.ProjectWebhookUID)
+	}
+	var webhookCtx webhook.Context
+	if err := json.Unmarshal(delivery.Event, &webhookCtx); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal webhook event")
+	}
+	// Always post to the latest URL, so that the redelivery works after the URL is fixed.
+	webhookCtx.URL = hook.URL
+	webhookCtx.SigningSecret = hook.Payload.GetSigningSecret()
+	webhookCtx.BodyTemplate = hook.Payload.GetBodyTemplate()
+
+	attemptTime := time.Now()
+	// Each receiver makes a single request with the webhook HTTP client, which times out after 3 seconds,
+	// so an attempt cannot block the runner for long.
+	result, postErr := webhook.Deliver(hook.Type, webhookCtx)
+	attempt := &storepb.WebhookDeliveryAttempt{
+		Time: timestamppb.New(attemptTime),
+	}
+	if result != nil {
+		attempt.StatusCode = int32(result.StatusCode)
+		attempt.Latency = durationpb.New(result.Latency)
+		attempt.ResponseBody = result.ResponseBody
+	}
+	if postErr != nil {
+		attempt.Error = postErr.Error()
+		// The external webhook endpoint might be invalid which is out of our code control, so we just emit a warning.
+		slog.Warn("Failed to post webhook event",
+			slog.String("webhook type", hook.Type),
+			slog.String("webhook name", hook.Title),
+			slog.String("activity type", webhookCtx.ActivityType),
+			slog.String("title", webhookCtx.Title),
+			slog.Int("attempt", delivery.Attempt+1),
+			log.BBError(postErr))
+	}
+
+	payload := delivery.Payload
+	payload.Attempts = append(payload.Attempts, attempt)
+	attemptCount := delivery.Attempt + 1
+	status := getDeliveryStatus(attemptCount, postErr)
+	nextAttemptTime := delivery.NextAttemptTime
+	if status == store.WebhookDeliveryPending {
+		nextAttemptTime = attemptTime.Add(GetBackoff(attemptCount))
+	}
+	return r.store.UpdateProjectWebhookDelivery(ctx, &store.UpdateProjectWebhookDeliveryMessage{
+		UID:             delivery.UID,
+		Status:          &status,
+		Attempt:         &attemptCount,
+		NextAttemptTime: &nextAttemptTime,
+		Payload:         payload,
+	})
+}
+
+// getDeliveryStatus returns the status of the delivery after the given number of attempts.
+func getDeliveryStatus(attempt int, attemptErr error) store.WebhookDeliveryStatus {
+	if attemptErr == nil {
+		return store.WebhookDeliverySucceeded
+	}
+	if attempt >= MaxAttempts {
+		return store.WebhookDeliveryFailed
+	}
+	return store.WebhookDeliveryPending
+}
+
+// GetBackoff returns the delay before the next attempt after the given number of failed attempts.
+func GetBackoff(attempt int) time.Duration {
+	backoff := initialBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,60 @@
+package webhookdelivery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestGetBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: 1 * time.Minute},
+		{attempt: 3, want: 2 * time.Minute},
+		{attempt: 4, want: 4 * time.Minute},
+		{attempt: 5, want: 8 * time.Minute},
+		{attempt: 6, want: 16 * time.Minute},
+		{attempt: 7, want: 32 * time.Minute},
+		{attempt: 8, want: 1 * time.Hour},
+		{attempt: 20, want: 1 * time.Hour},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, GetBackoff(test.attempt), test.attempt)
+	}
+}
+
+func TestGetDeliveryStatus(t *testing.T) {
+	a := require.New(t)
+	attemptErr := errors.New("connection refused")
+
+	for attempt := 1; attempt <= MaxAttempts; attempt++ {
+		a.Equal(store.WebhookDeliverySucceeded, getDeliveryStatus(attempt, nil), attempt)
+	}
+	for attempt := 1; attempt < MaxAttempts; attempt++ {
+		a.Equal(store.WebhookDeliveryPending, getDeliveryStatus(attempt, attemptErr), attempt)
+	}
+	a.Equal(store.WebhookDeliveryFailed, getDeliveryStatus(MaxAttempts, attemptErr))
+}
+
+func TestRetrySchedule(t *testing.T) {
+	a := require.New(t)
+	attemptErr := errors.New("connection refused")
+
+	// A delivery failing every time is attempted MaxAttempts times, and the retries span the sum of the backoffs.
+	attempt, elapsed := 1, time.Duration(0)
+	for getDeliveryStatus(attempt, attemptErr) == store.WebhookDeliveryPending {
+		elapsed += GetBackoff(attempt)
+		attempt++
+	}
+	a.Equal(MaxAttempts, attempt)
+	a.Equal(63*time.Minute+30*time.Second, elapsed)
+}
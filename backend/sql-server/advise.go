@@ -43,8 +43,19 @@ type sqlCheckRequestBody struct {
 	DatabaseType string `json:"databaseType"`
 	TemplateID   string `json:"templateId"`
 	Override     string `json:"override"`
+	Format       string `json:"format"`
+	FilePath     string `json:"filePath"`
 }
 
+const (
+	// adviseFormatJSON returns the advice list in JSON.
+	adviseFormatJSON = "json"
+	// adviseFormatSARIF returns the SARIF report.
+	adviseFormatSARIF = "sarif"
+
+	defaultAdviseFilePath = "statement.sql"
+)
+
 func (s *Server) registerAdvisorRoutes(g *echo.Group) {
 	g.POST("/advise", s.sqlCheckController)
 }
@@ -59,6 +70,8 @@ func (s *Server) registerAdvisorRoutes(g *echo.Group) {
 // @Param  databaseType  body  string  true   "The database type."  Enums(MYSQL, POSTGRES, TIDB, OCEANBASE, SNOWFLAKE, MSSQL)
 // @Param  templateId    body  string  false  "The SQL check template id. Required if the config is not specified." Enums(bb.sql-review.prod, bb.sql-review.dev)
 // @Param  override      body  string  false  "The SQL check config override string in YAML format. Check https://github.com/bytebase/bytebase/tree/main/backend/plugin/advisor/config/sql-review.override.yaml for example. Required if the template is not specified."
+// @Param  format        body  string  false  "The response format. Defaults to json." Enums(json, sarif)
+// @Param  filePath      body  string  false  "The file path of the statement used as the location in the SARIF report. Defaults to statement.sql."
 // @Success  200  {array}   advisor.Advice
 // @Failure  400  {object}  echo.HTTPError
 // @Failure  500  {object}  echo.HTTPError
@@ -81,6 +94,10 @@ func (s *Server) sqlCheckController(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Missing required template or override")
 	}
 
+	if request.Format != "" && request.Format != adviseFormatJSON && request.Format != adviseFormatSARIF {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unsupported format %s", request.Format))
+	}
+
 	engineTypeValue, ok := storepb.Engine_value[request.DatabaseType]
 	if !ok {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Database %s is not support", request.DatabaseType))
@@ -129,6 +146,19 @@ func (s *Server) sqlCheckController(c echo.Context) error {
 		},
 	})
 
+	if request.Format == adviseFormatSARIF {
+		filePath := request.FilePath
+		if filePath == "" {
+			filePath = defaultAdviseFilePath
+		}
+		return c.JSON(http.StatusOK, advisor.ConvertToSARIF([]*advisor.FileAdvice{
+			{
+				Path:       filePath,
+				Engine:     engineType,
+				AdviceList: adviceList,
+			},
+		}))
+	}
 	return c.JSON(http.StatusOK, adviceList)
 }
 
@@ -244,6 +244,7 @@ func NewDatabaseMetadata(metadata *storepb.DatabaseSchemaMetadata) *DatabaseMeta
 			internalMaterializedView: make(map[string]*MaterializedViewMetadata),
 			internalFunctions:        make(map[string]*FunctionMetadata),
 			internalProcedures:       make(map[string]*ProcedureMetadata),
+			internalTriggers:         make(map[string]*TriggerMetadata),
 		}
 		for _, table := range schema.Tables {
 			tables, names := buildTablesMetadata(table)
@@ -281,6 +282,12 @@ func NewDatabaseMetadata(metadata *storepb.DatabaseSchemaMetadata) *DatabaseMeta
 				Definition: procedure.Definition,
 			}
 		}
+		for _, trigger := range schema.Triggers {
+			schemaMetadata.internalTriggers[trigger.Name] = &TriggerMetadata{
+				TableName:  trigger.TableName,
+				Definition: trigger.Definition,
+			}
+		}
 		databaseMetadata.internal[schema.Name] = schemaMetadata
 	}
 	return databaseMetadata
@@ -308,6 +315,7 @@ type SchemaMetadata struct {
 	internalMaterializedView map[string]*MaterializedViewMetadata
 	internalFunctions        map[string]*FunctionMetadata
 	internalProcedures       map[string]*ProcedureMetadata
+	internalTriggers         map[string]*TriggerMetadata
 }
 
 // GetTable gets the schema by name.
@@ -339,6 +347,11 @@ func (s *SchemaMetadata) GetFunction(name string) *FunctionMetadata {
 	return s.internalFunctions[name]
 }
 
+// GetTrigger gets the trigger by name.
+func (s *SchemaMetadata) GetTrigger(name string) *TriggerMetadata {
+	return s.internalTriggers[name]
+}
+
 // ListTableNames lists the table names.
 func (s *SchemaMetadata) ListTableNames() []string {
 	var result []string
@@ -372,6 +385,17 @@ func (s *SchemaMetadata) ListForeignTableNames() []string {
 	return result
 }
 
+// ListTriggerNames lists the trigger names.
+func (s *SchemaMetadata) ListTriggerNames() []string {
+	var result []string
+	for triggerName := range s.internalTriggers {
+		result = append(result, triggerName)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
 // ListMaterializedViewNames lists the materialized view names.
 func (s *SchemaMetadata) ListMaterializedViewNames() []string {
 	var result []string
@@ -500,3 +524,9 @@ type FunctionMetadata struct {
 type ProcedureMetadata struct {
 	Definition string
 }
+
+// TriggerMetadata is the metadata for a trigger.
+type TriggerMetadata struct {
+	TableName  string
+	Definition string
+}
@@ -34,6 +34,8 @@ type FindPlanMessage struct {
 	ProjectID  *string
 	ProjectIDs *[]string
 	PipelineID *int
+	// PullRequestURL is the URL of the pull request linked to the plan.
+	PullRequestURL *string
 
 	Limit  *int
 	Offset *int
@@ -134,6 +136,9 @@ func (s *Store) ListPlans(ctx context.Context, find *FindPlanMessage) ([]*PlanMe
 	if v := find.PipelineID; v != nil {
 		where, args = append(where, fmt.Sprintf("plan.pipeline_id = $%d", len(args)+1)), append(args, *v)
 	}
+	if v := find.PullRequestURL; v != nil {
+		where, args = append(where, fmt.Sprintf("plan.config->'vcsSource'->>'pullRequestUrl' = $%d", len(args)+1)), append(args, *v)
+	}
 	query := fmt.Sprintf(`
 		SELECT
 			plan.id,
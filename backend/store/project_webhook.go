@@ -9,9 +9,11 @@ import (
 
 	"github.com/jackc/pgtype"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/bytebase/bytebase/backend/common"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
 // ProjectWebhookMessage is the store model for an project webhook.
@@ -24,6 +26,8 @@ type ProjectWebhookMessage struct {
 	URL string
 	// ActivityList is the list of activities that the webhook is interested in.
 	ActivityList []string
+	// Payload is the signing secret and body template of the webhook.
+	Payload *storepb.ProjectWebhookPayload
 	// Output only fields.
 	//
 	// ID is the unique identifier of the project webhook.
@@ -39,6 +43,7 @@ type UpdateProjectWebhookMessage struct {
 	URL *string
 	// ActivityList is the list of activities that the webhook is interested in.
 	ActivityList []string
+	Payload      *storepb.ProjectWebhookPayload
 }
 
 // FindProjectWebhookMessage is the message for finding project webhooks,
@@ -60,13 +65,23 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 			type,
 			name,
 			url,
-			activity_list
+			activity_list,
+			payload
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, project_id, type, name, url, activity_list
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, project_id, type, name, url, activity_list, payload
 	`
 	var projectWebhook ProjectWebhookMessage
 	var txtArray pgtype.TextArray
+	var payload []byte
+
+	if create.Payload == nil {
+		create.Payload = &storepb.ProjectWebhookPayload{}
+	}
+	createPayload, err := protojson.Marshal(create.Payload)
+	if err != nil {
+		return nil, err
+	}
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -81,6 +96,7 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		create.Title,
 		create.URL,
 		create.ActivityList,
+		createPayload,
 	).Scan(
 		&projectWebhook.ID,
 		&projectWebhook.ProjectID,
@@ -88,6 +104,7 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		&projectWebhook.Title,
 		&projectWebhook.URL,
 		&txtArray,
+		&payload,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
@@ -97,6 +114,11 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 		return nil, err
 	}
+	webhookPayload := &storepb.ProjectWebhookPayload{}
+	if err := protojsonUnmarshaler.Unmarshal(payload, webhookPayload); err != nil {
+		return nil, err
+	}
+	projectWebhook.Payload = webhookPayload
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
 	}
@@ -165,17 +187,25 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if v := update.ActivityList; v != nil {
 		set, args = append(set, fmt.Sprintf("activity_list = $%d", len(args)+1)), append(args, v)
 	}
+	if v := update.Payload; v != nil {
+		payload, err := protojson.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		set, args = append(set, fmt.Sprintf("payload = $%d", len(args)+1)), append(args, payload)
+	}
 
 	args = append(args, projectWebhookID)
 
 	var projectWebhook ProjectWebhookMessage
 	var txtArray pgtype.TextArray
+	var payload []byte
 	// Execute update query with RETURNING.
 	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
 	UPDATE project_webhook
 	SET `+strings.Join(set, ", ")+`
 	WHERE id = $%d
-	RETURNING id, project_id, type, name, url, activity_list
+	RETURNING id, project_id, type, name, url, activity_list, payload
 `, len(args)),
 		args...,
 	).Scan(
@@ -185,6 +215,7 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		&projectWebhook.Title,
 		&projectWebhook.URL,
 		&txtArray,
+		&payload,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("project hook ID not found: %d", projectWebhookID)}
@@ -194,6 +225,11 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 		return nil, err
 	}
+	webhookPayload := &storepb.ProjectWebhookPayload{}
+	if err := protojsonUnmarshaler.Unmarshal(payload, webhookPayload); err != nil {
+		return nil, err
+	}
+	projectWebhook.Payload = webhookPayload
 
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
@@ -241,7 +277,8 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 			type,
 			name,
 			url,
-			activity_list
+			activity_list,
+			payload
 		FROM project_webhook
 		WHERE `+strings.Join(where, " AND "),
 		args...,
@@ -255,6 +292,7 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 	for rows.Next() {
 		var projectWebhook ProjectWebhookMessage
 		var txtArray pgtype.TextArray
+		var payload []byte
 
 		if err := rows.Scan(
 			&projectWebhook.ID,
@@ -262,6 +300,7 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 			&projectWebhook.Title,
 			&projectWebhook.URL,
 			&txtArray,
+			&payload,
 		); err != nil {
 			return nil, err
 		}
@@ -269,6 +308,11 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 		if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 			return nil, err
 		}
+		webhookPayload := &storepb.ProjectWebhookPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payload, webhookPayload); err != nil {
+			return nil, err
+		}
+		projectWebhook.Payload = webhookPayload
 
 		if v := find.ActivityType; v != nil {
 			for _, activity := range projectWebhook.ActivityList {
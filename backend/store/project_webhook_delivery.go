@@ -0,0 +1,285 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// WebhookDeliveryStatus is the status of a webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is the status of the delivery waiting for the next attempt.
+	WebhookDeliveryPending WebhookDeliveryStatus = "PENDING"
+	// WebhookDeliverySucceeded is the status of the delivery accepted by the receiver.
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	// WebhookDeliveryFailed is the status of the delivery exhausting all attempts.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "FAILED"
+)
+
+// ProjectWebhookDeliveryMessage is the store model for a project webhook delivery.
+type ProjectWebhookDeliveryMessage struct {
+	ProjectWebhookUID int
+	Status            WebhookDeliveryStatus
+	// Attempt is the number of attempts made.
+	Attempt         int
+	NextAttemptTime time.Time
+	// Event is the marshalled webhook context.
+	Event   []byte
+	Payload *storepb.WebhookDeliveryPayload
+
+	// Output only fields.
+	UID         int
+	CreatedTime time.Time
+	UpdatedTime time.Time
+}
+
+// FindProjectWebhookDeliveryMessage is the message for finding project webhook deliveries.
+type FindProjectWebhookDeliveryMessage struct {
+	UID               *int
+	ProjectWebhookUID *int
+	Status            *WebhookDeliveryStatus
+	// NextAttemptBefore finds the deliveries whose next attempt is due before the time.
+	NextAttemptBefore *time.Time
+
+	Limit  *int
+	Offset *int
+}
+
+// UpdateProjectWebhookDeliveryMessage is the message for updating a project webhook delivery.
+type UpdateProjectWebhookDeliveryMessage struct {
+	UID int
+
+	Status          *WebhookDeliveryStatus
+	Attempt         *int
+	NextAttemptTime *time.Time
+	Payload         *storepb.WebhookDeliveryPayload
+}
+
+// GetProjectWebhookDelivery gets a project webhook delivery.
+func (s *Store) GetProjectWebhookDelivery(ctx context.Context, find *FindProjectWebhookDeliveryMessage) (*ProjectWebhookDeliveryMessage, error) {
+	deliveries, err := s.ListProjectWebhookDeliveries(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+	if len(deliveries) > 1 {
+		return nil, errors.Errorf("expected 1 project webhook delivery, got %d", len(deliveries))
+	}
+	return deliveries[0], nil
+}
+
+// ListProjectWebhookDeliveries lists project webhook deliveries, the latest first.
+func (s *Store) ListProjectWebhookDeliveries(ctx context.Context, find *FindProjectWebhookDeliveryMessage) ([]*ProjectWebhookDeliveryMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.UID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ProjectWebhookUID; v != nil {
+		where, args = append(where, fmt.Sprintf("project_webhook_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Status; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.NextAttemptBefore; v != nil {
+		where, args = append(where, fmt.Sprintf("next_attempt_ts <= $%d", len(args)+1)), append(args, v.Unix())
+	}
+
+	limitOffsetClause := ""
+	if v := find.Limit; v != nil {
+		limitOffsetClause = fmt.Sprintf(" LIMIT %d", *v)
+	}
+	if v := find.Offset; v != nil {
+		limitOffsetClause += fmt.Sprintf(" OFFSET %d", *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			project_webhook_id,
+			status,
+			attempt,
+			next_attempt_ts,
+			event,
+			payload
+		FROM project_webhook_delivery
+		WHERE %s
+		ORDER BY id DESC
+		%s`, strings.Join(where, " AND "), limitOffsetClause)
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*ProjectWebhookDeliveryMessage
+	for rows.Next() {
+		var delivery ProjectWebhookDeliveryMessage
+		var createdTs, updatedTs, nextAttemptTs int64
+		var payload []byte
+		if err := rows.Scan(
+			&delivery.UID,
+			&createdTs,
+			&updatedTs,
+			&delivery.ProjectWebhookUID,
+			&delivery.Status,
+			&delivery.Attempt,
+			&nextAttemptTs,
+			&delivery.Event,
+			&payload,
+		); err != nil {
+			return nil, err
+		}
+		deliveryPayload := &storepb.WebhookDeliveryPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payload, deliveryPayload); err != nil {
+			return nil, err
+		}
+		delivery.Payload = deliveryPayload
+		delivery.CreatedTime = time.Unix(createdTs, 0)
+		delivery.UpdatedTime = time.Unix(updatedTs, 0)
+		delivery.NextAttemptTime = time.Unix(nextAttemptTs, 0)
+		deliveries = append(deliveries, &delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// CreateProjectWebhookDelivery creates a project webhook delivery.
+func (s *Store) CreateProjectWebhookDelivery(ctx context.Context, create *ProjectWebhookDeliveryMessage) (*ProjectWebhookDeliveryMessage, error) {
+	if create.Payload == nil {
+		create.Payload = &storepb.WebhookDeliveryPayload{}
+	}
+	payload, err := protojson.Marshal(create.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO project_webhook_delivery (
+			project_webhook_id,
+			status,
+			attempt,
+			next_attempt_ts,
+			event,
+			payload
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_ts, updated_ts;
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	var createdTs, updatedTs int64
+	if err := tx.QueryRowContext(ctx, query,
+		create.ProjectWebhookUID,
+		create.Status,
+		create.Attempt,
+		create.NextAttemptTime.Unix(),
+		create.Event,
+		payload,
+	).Scan(
+		&create.UID,
+		&createdTs,
+		&updatedTs,
+	); err != nil {
+		return nil, err
+	}
+	create.CreatedTime = time.Unix(createdTs, 0)
+	create.UpdatedTime = time.Unix(updatedTs, 0)
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return create, nil
+}
+
+// UpdateProjectWebhookDelivery updates a project webhook delivery.
+func (s *Store) UpdateProjectWebhookDelivery(ctx context.Context, patch *UpdateProjectWebhookDeliveryMessage) error {
+	set, args := []string{"updated_ts = $1"}, []any{time.Now().Unix()}
+	if v := patch.Status; v != nil {
+		set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.Attempt; v != nil {
+		set, args = append(set, fmt.Sprintf("attempt = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.NextAttemptTime; v != nil {
+		set, args = append(set, fmt.Sprintf("next_attempt_ts = $%d", len(args)+1)), append(args, v.Unix())
+	}
+	if v := patch.Payload; v != nil {
+		payload, err := protojson.Marshal(v)
+		if err != nil {
+			return err
+		}
+		set, args = append(set, fmt.Sprintf("payload = $%d", len(args)+1)), append(args, payload)
+	}
+	args = append(args, patch.UID)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE project_webhook_delivery
+		SET %s
+		WHERE id = $%d`, strings.Join(set, ", "), len(args)),
+		args...,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteOutdatedProjectWebhookDeliveries deletes the succeeded and failed deliveries whose last attempt is before the given time.
+func (s *Store) DeleteOutdatedProjectWebhookDeliveries(ctx context.Context, before time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The next_attempt_ts of a finished delivery is kept as the scheduled time of its last attempt.
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM
+			project_webhook_delivery
+		WHERE
+			status IN ($1, $2)
+			AND next_attempt_ts < $3`,
+		WebhookDeliverySucceeded,
+		WebhookDeliveryFailed,
+		before.Unix(),
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -45,9 +45,11 @@ type UpdateVCSConnectorMessage struct {
 	UID       int
 
 	// Domain specific fields
-	Branch        *string
-	BaseDirectory *string
-	DatabaseGroup *string
+	Branch           *string
+	BaseDirectory    *string
+	DatabaseGroup    *string
+	FilePathTemplate *string
+	Mode             *storepb.VCSConnector_Mode
 }
 
 // GetVCSConnector gets a VCS connector.
@@ -205,6 +207,12 @@ func (s *Store) UpdateVCSConnector(ctx context.Context, update *UpdateVCSConnect
 	if v := update.DatabaseGroup; v != nil {
 		payloadSet, args = append(payloadSet, fmt.Sprintf("jsonb_build_object('databaseGroup', to_jsonb($%d::TEXT))", len(args)+1)), append(args, *v)
 	}
+	if v := update.FilePathTemplate; v != nil {
+		payloadSet, args = append(payloadSet, fmt.Sprintf("jsonb_build_object('filePathTemplate', to_jsonb($%d::TEXT))", len(args)+1)), append(args, *v)
+	}
+	if v := update.Mode; v != nil {
+		payloadSet, args = append(payloadSet, fmt.Sprintf("jsonb_build_object('mode', to_jsonb($%d::TEXT))", len(args)+1)), append(args, v.String())
+	}
 	if len(payloadSet) != 0 {
 		set = append(set, fmt.Sprintf(`payload = payload || %s`, strings.Join(payloadSet, "||")))
 	}
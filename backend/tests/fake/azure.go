@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
@@ -17,6 +18,13 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/vcs/azure"
 )
 
+const (
+	// azureBotProfileID is the public alias of the authenticated profile, which creates the threads through the API.
+	azureBotProfileID = "mock-az-profile"
+	// azureUserID is the ID of the user adding the threads by AddPullRequestComment.
+	azureUserID = "mock-az-user"
+)
+
 // Azure is a fake implementation of Azure VCS provider.
 type Azure struct {
 	port int
@@ -26,6 +34,8 @@ type Azure struct {
 
 	nextWebhookID int
 	repositories  map[string]*azureRepositoryData
+	// threads stores the pull request threads, each thread has a single comment with the ID 1.
+	threads *pullRequestComments
 }
 
 type azureRepositoryData struct {
@@ -51,6 +61,7 @@ func NewAzure(port int) VCSProvider {
 		client:        &http.Client{},
 		nextWebhookID: 20210113,
 		repositories:  make(map[string]*azureRepositoryData),
+		threads:       newPullRequestComments(),
 	}
 
 	g := e.Group("")
@@ -62,7 +73,9 @@ func NewAzure(port int) VCSProvider {
 
 	repo := e.Group("/:organization/:project/_apis/git/repositories/:repo")
 	repo.GET("/stats/branches", az.getRepositoryBranch)
-	repo.POST("/pullRequests/:pr/threads", az.createIssueComment)
+	repo.GET("/pullRequests/:pr/threads", az.listPullRequestThreads)
+	repo.POST("/pullRequests/:pr/threads", az.createPullRequestThread)
+	repo.PATCH("/pullRequests/:pr/threads/:thread/comments/:comment", az.updatePullRequestThreadComment)
 	repo.GET("/commits/:commit/changes", az.getCommitChanges)
 	repo.GET("/items", az.readRepositoryFile)
 
@@ -79,7 +92,7 @@ func (*Azure) getResponse(c echo.Context, data any) error {
 
 func (az *Azure) getProfile(c echo.Context) error {
 	resp := &azure.Profile{
-		PublicAlias: "mock-az-profile",
+		PublicAlias: azureBotProfileID,
 	}
 	return az.getResponse(c, resp)
 }
@@ -217,12 +230,71 @@ func (az *Azure) getRepositoryBranch(c echo.Context) error {
 	return az.getResponse(c, r.refs[fmt.Sprintf("refs/heads/%s", branchName)])
 }
 
-func (*Azure) createIssueComment(echo.Context) error {
-	return nil
+func (az *Azure) listPullRequestThreads(c echo.Context) error {
+	if _, err := az.validRepository(c); err != nil {
+		return err
+	}
+	resp := &azure.PullRequestThreadList{Value: []*azure.PullRequestThread{}}
+	for _, thread := range az.threads.list(getAzureRepositoryID(c), c.Param("pr")) {
+		resp.Value = append(resp.Value, &azure.PullRequestThread{
+			ID:     thread.id,
+			Status: "active",
+			Comments: []*azure.Comment{
+				{ID: 1, Content: thread.body, CommentType: "text", Author: &azure.IdentityRef{ID: thread.author}},
+			},
+		})
+	}
+	return az.getResponse(c, resp)
+}
+
+func (az *Azure) createPullRequestThread(c echo.Context) error {
+	if _, err := az.validRepository(c); err != nil {
+		return err
+	}
+	var thread azure.PullRequestThread
+	if err := readRequestBody(c, &thread); err != nil {
+		return err
+	}
+	if len(thread.Comments) != 1 {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("expect one comment in the thread, got %d", len(thread.Comments)))
+	}
+	created := az.threads.add(getAzureRepositoryID(c), c.Param("pr"), azureBotProfileID, thread.Comments[0].Content)
+	return az.getResponse(c, &azure.PullRequestThread{
+		ID:     created.id,
+		Status: thread.Status,
+		Comments: []*azure.Comment{
+			{ID: 1, Content: created.body, CommentType: "text", Author: &azure.IdentityRef{ID: azureBotProfileID}},
+		},
+	})
+}
+
+func (az *Azure) updatePullRequestThreadComment(c echo.Context) error {
+	if _, err := az.validRepository(c); err != nil {
+		return err
+	}
+	threadID, err := strconv.ParseInt(c.Param("thread"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid thread ID %q", c.Param("thread")))
+	}
+	if c.Param("comment") != "1" {
+		return c.String(http.StatusNotFound, fmt.Sprintf("comment %q not found in thread %d", c.Param("comment"), threadID))
+	}
+	var comment azure.Comment
+	if err := readRequestBody(c, &comment); err != nil {
+		return err
+	}
+	if !az.threads.update(threadID, comment.Content) {
+		return c.String(http.StatusNotFound, fmt.Sprintf("thread %d not found", threadID))
+	}
+	return az.getResponse(c, &azure.Comment{ID: 1, Content: comment.Content, CommentType: "text"})
+}
+
+func getAzureRepositoryID(c echo.Context) string {
+	return fmt.Sprintf("%s/%s/%s", c.Param("organization"), c.Param("project"), c.Param("repo"))
 }
 
 func (az *Azure) validRepository(c echo.Context) (*azureRepositoryData, error) {
-	repositoryID := fmt.Sprintf("%s/%s/%s", c.Param("organization"), c.Param("project"), c.Param("repo"))
+	repositoryID := getAzureRepositoryID(c)
 	r, ok := az.repositories[repositoryID]
 	if !ok {
 		return nil, c.String(http.StatusNotFound, fmt.Sprintf("Azure repository %q does not exist", repositoryID))
@@ -376,6 +448,15 @@ func (az *Azure) AddPullRequest(repositoryID string, commitID int, files []*vcs.
 	return nil
 }
 
+// AddPullRequestComment adds a thread by another user to the pull request.
+func (az *Azure) AddPullRequestComment(repositoryID string, prID int, comment string) error {
+	if _, ok := az.repositories[repositoryID]; !ok {
+		return errors.Errorf("repository %q does not exist", repositoryID)
+	}
+	az.threads.add(repositoryID, strconv.Itoa(prID), azureUserID, comment)
+	return nil
+}
+
 func (*Azure) validFilePath(filePath string) string {
 	if !strings.HasPrefix(filePath, "/") {
 		return fmt.Sprintf("/%s", filePath)
@@ -18,6 +18,13 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/vcs/bitbucket"
 )
 
+const (
+	// bitbucketBotUserUUID is the UUID of the authenticated user, which creates the comments through the API.
+	bitbucketBotUserUUID = "{5f0b8d9e-2bd1-4c3a-9b1e-1d2f3a4b5c6d}"
+	// bitbucketUserUUID is the UUID of the user adding the comments by AddPullRequestComment.
+	bitbucketUserUUID = "{8a7c6e5d-4b3a-4f2e-8d1c-0b9a8f7e6d5c}"
+)
+
 // Bitbucket is a fake implementation of Bitbucket VCS provider.
 type Bitbucket struct {
 	port int
@@ -27,6 +34,7 @@ type Bitbucket struct {
 
 	nextWebhookID int
 	repositories  map[string]*bitbucketRepositoryData
+	comments      *pullRequestComments
 }
 
 type bitbucketRepositoryData struct {
@@ -54,9 +62,11 @@ func NewBitbucket(port int) VCSProvider {
 		client:        &http.Client{},
 		nextWebhookID: 20210113,
 		repositories:  make(map[string]*bitbucketRepositoryData),
+		comments:      newPullRequestComments(),
 	}
 
 	g := e.Group("/2.0")
+	g.GET("/user", bb.getCurrentUser)
 	g.GET("/user/permissions/repositories", bb.listRepositories)
 	g.POST("/repositories/:owner/:repo/hooks", bb.createRepositoryWebhook)
 	g.DELETE("/repositories/:owner/:repo/hooks/:hook", bb.deleteRepositoryWebhook)
@@ -64,7 +74,10 @@ func NewBitbucket(port int) VCSProvider {
 	g.GET("/repositories/:owner/:repo/src/:ref/:filepath", bb.getRepositoryContent)
 	g.GET("/repositories/:owner/:repo/refs/branches/:branchName", bb.getRepositoryBranch)
 	g.GET("/repositories/:owner/:repo/pullrequests/:prID/diffstat", bb.listPullRequestFile)
-	g.GET("/repositories/:owner/:repo/pullrequests/:prID/comments", bb.createPullRequestComment)
+	g.GET("/repositories/:owner/:repo/pullrequests/:prID/comments", bb.listPullRequestComments)
+	g.POST("/repositories/:owner/:repo/pullrequests/:prID/comments", bb.createPullRequestComment)
+	g.PUT("/repositories/:owner/:repo/pullrequests/:prID/comments/:commentID", bb.updatePullRequestComment)
+	g.POST("/repositories/:owner/:repo/commit/:commitID/statuses/build", bb.createCommitStatus)
 	return bb
 }
 
@@ -174,8 +187,12 @@ func (bb *Bitbucket) getRepositoryBranch(c echo.Context) error {
 	return c.String(http.StatusOK, string(buf))
 }
 
+func getBitbucketRepositoryID(c echo.Context) string {
+	return fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+}
+
 func (bb *Bitbucket) validRepository(c echo.Context) (*bitbucketRepositoryData, error) {
-	repositoryID := fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+	repositoryID := getBitbucketRepositoryID(c)
 	r, ok := bb.repositories[repositoryID]
 	if !ok {
 		return nil, c.String(http.StatusNotFound, fmt.Sprintf("Bitbucket repository %q does not exist", repositoryID))
@@ -315,6 +332,19 @@ func (bb *Bitbucket) AddPullRequest(repositoryID string, prID int, files []*vcs.
 	return nil
 }
 
+// AddPullRequestComment adds a comment by another user to the pull request.
+func (bb *Bitbucket) AddPullRequestComment(repositoryID string, prID int, comment string) error {
+	r, ok := bb.repositories[repositoryID]
+	if !ok {
+		return errors.Errorf("Bitbucket repository %q does not exist", repositoryID)
+	}
+	if _, ok := r.pullRequests[prID]; !ok {
+		return errors.Errorf("Bitbucket pull request %d does not exist", prID)
+	}
+	bb.comments.add(repositoryID, strconv.Itoa(prID), bitbucketUserUUID, comment)
+	return nil
+}
+
 func (bb *Bitbucket) listPullRequestFile(c echo.Context) error {
 	r, err := bb.validRepository(c)
 	if err != nil {
@@ -342,6 +372,59 @@ func (bb *Bitbucket) listPullRequestFile(c echo.Context) error {
 	return c.String(http.StatusOK, string(buf))
 }
 
-func (*Bitbucket) createPullRequestComment(echo.Context) error {
-	return nil
+func (*Bitbucket) getCurrentUser(c echo.Context) error {
+	return writeResponse(c, http.StatusOK, &bitbucket.User{UUID: bitbucketBotUserUUID, DisplayName: "Bytebase Bot", Nickname: "bytebase-bot"})
+}
+
+func (bb *Bitbucket) listPullRequestComments(c echo.Context) error {
+	if _, err := bb.validRepository(c); err != nil {
+		return err
+	}
+	resp := &bitbucket.CommentListResponse{}
+	for _, comment := range bb.comments.list(getBitbucketRepositoryID(c), c.Param("prID")) {
+		resp.Values = append(resp.Values, &bitbucket.Comment{
+			ID:      comment.id,
+			Content: bitbucket.CommentContent{Raw: comment.body},
+			User:    &bitbucket.User{UUID: comment.author},
+		})
+	}
+	return writeResponse(c, http.StatusOK, resp)
+}
+
+func (bb *Bitbucket) createPullRequestComment(c echo.Context) error {
+	if _, err := bb.validRepository(c); err != nil {
+		return err
+	}
+	var comment bitbucket.Comment
+	if err := readRequestBody(c, &comment); err != nil {
+		return err
+	}
+	created := bb.comments.add(getBitbucketRepositoryID(c), c.Param("prID"), bitbucketBotUserUUID, comment.Content.Raw)
+	return writeResponse(c, http.StatusCreated, &bitbucket.Comment{
+		ID:      created.id,
+		Content: bitbucket.CommentContent{Raw: created.body},
+		User:    &bitbucket.User{UUID: bitbucketBotUserUUID},
+	})
+}
+
+func (bb *Bitbucket) updatePullRequestComment(c echo.Context) error {
+	if _, err := bb.validRepository(c); err != nil {
+		return err
+	}
+	commentID, err := strconv.ParseInt(c.Param("commentID"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid comment ID %q", c.Param("commentID")))
+	}
+	var comment bitbucket.Comment
+	if err := readRequestBody(c, &comment); err != nil {
+		return err
+	}
+	if !bb.comments.update(commentID, comment.Content.Raw) {
+		return c.String(http.StatusNotFound, fmt.Sprintf("comment %d not found", commentID))
+	}
+	return writeResponse(c, http.StatusOK, &bitbucket.Comment{ID: commentID, Content: comment.Content})
+}
+
+func (*Bitbucket) createCommitStatus(c echo.Context) error {
+	return c.String(http.StatusCreated, "{}")
 }
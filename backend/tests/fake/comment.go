@@ -0,0 +1,97 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// pullRequestComment is the comment on the pull request of the fake VCS providers.
+type pullRequestComment struct {
+	id int64
+	// author is the provider specific ID of the user creating the comment.
+	author string
+	body   string
+}
+
+// pullRequestComments stores the comments on the pull requests of a fake VCS provider.
+// The comments are accessed by both the API handlers and the tests, so they are guarded by the mutex.
+type pullRequestComments struct {
+	mu     sync.Mutex
+	nextID int64
+	// comments is the map from the repository ID and the pull request ID to the comments.
+	comments map[string][]*pullRequestComment
+}
+
+func newPullRequestComments() *pullRequestComments {
+	return &pullRequestComments{
+		nextID:   1,
+		comments: make(map[string][]*pullRequestComment),
+	}
+}
+
+// add adds the comment by the author to the pull request, and returns the created comment.
+func (s *pullRequestComments) add(repositoryID, pullRequestID, author, body string) pullRequestComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := getPullRequestKey(repositoryID, pullRequestID)
+	comment := &pullRequestComment{id: s.nextID, author: author, body: body}
+	s.nextID++
+	s.comments[key] = append(s.comments[key], comment)
+	return *comment
+}
+
+// list lists the comments on the pull request in the order of creation.
+func (s *pullRequestComments) list(repositoryID, pullRequestID string) []pullRequestComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var comments []pullRequestComment
+	for _, comment := range s.comments[getPullRequestKey(repositoryID, pullRequestID)] {
+		comments = append(comments, *comment)
+	}
+	return comments
+}
+
+// update updates the body of the comment, and returns false if the comment is not found.
+func (s *pullRequestComments) update(id int64, body string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, comments := range s.comments {
+		for _, comment := range comments {
+			if comment.id == id {
+				comment.body = body
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getPullRequestKey(repositoryID, pullRequestID string) string {
+	return fmt.Sprintf("%s#%s", repositoryID, pullRequestID)
+}
+
+// readRequestBody unmarshals the JSON request body into v.
+func readRequestBody(c echo.Context, v any) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("failed to read request body: %v", err))
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("failed to unmarshal request body: %v", err))
+	}
+	return nil
+}
+
+// writeResponse writes data as the JSON response body with the status code.
+func writeResponse(c echo.Context, code int, data any) error {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal response body: %v", err))
+	}
+	return c.String(code, string(buf))
+}
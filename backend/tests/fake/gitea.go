@@ -0,0 +1,446 @@
+package fake
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/plugin/vcs/gitea"
+)
+
+const (
+	// giteaBotUserID is the ID of the authenticated user, which creates the comments through the API.
+	giteaBotUserID = 1
+	// giteaUserID is the ID of the user adding the comments by AddPullRequestComment.
+	giteaUserID = 2
+)
+
+// Gitea is a fake implementation of Gitea VCS provider.
+type Gitea struct {
+	port int
+	echo *echo.Echo
+
+	client *http.Client
+
+	nextWebhookID int
+	repositories  map[string]*giteaRepositoryData
+	comments      *pullRequestComments
+}
+
+type giteaRepositoryData struct {
+	repository *gitea.Repository
+	webhooks   []*gitea.WebhookCreateOrUpdate
+	// files is a map that the full file path is the key and the file content is the
+	// value.
+	files map[string]string
+	// branches is the map for repository branch, the map key is the branch name.
+	branches map[string]*gitea.Branch
+	// pullRequests is the map for repository pull request.
+	// the map key is the pull request index.
+	pullRequests map[int]struct {
+		Files []*gitea.PullRequestFile
+		*gitea.PullRequest
+	}
+}
+
+// NewGitea creates a new fake implementation of Gitea VCS provider.
+func NewGitea(port int) VCSProvider {
+	e := newEchoServer()
+	gt := &Gitea{
+		port:          port,
+		echo:          e,
+		client:        &http.Client{},
+		nextWebhookID: 20210113,
+		repositories:  make(map[string]*giteaRepositoryData),
+		comments:      newPullRequestComments(),
+	}
+
+	g := e.Group("/api/v1")
+	g.GET("/user", gt.getCurrentUser)
+	g.GET("/user/repos", gt.listRepositories)
+	g.POST("/repos/:owner/:repo/hooks", gt.createRepositoryWebhook)
+	g.DELETE("/repos/:owner/:repo/hooks/:hook", gt.deleteRepositoryWebhook)
+	g.GET("/repos/:owner/:repo/raw/*", gt.readRepositoryFile)
+	g.GET("/repos/:owner/:repo/branches/*", gt.getRepositoryBranch)
+	g.GET("/repos/:owner/:repo/pulls/:prID", gt.getPullRequest)
+	g.GET("/repos/:owner/:repo/pulls/:prID/files", gt.listPullRequestFile)
+	g.GET("/repos/:owner/:repo/issues/:prID/comments", gt.listIssueComments)
+	g.POST("/repos/:owner/:repo/issues/:prID/comments", gt.createIssueComment)
+	g.PATCH("/repos/:owner/:repo/issues/comments/:commentID", gt.updateIssueComment)
+	g.POST("/repos/:owner/:repo/statuses/:commitID", gt.createCommitStatus)
+	return gt
+}
+
+func (gt *Gitea) listRepositories(c echo.Context) error {
+	repoList := []*gitea.Repository{}
+	for _, repoData := range gt.repositories {
+		repoList = append(repoList, repoData.repository)
+	}
+	buf, err := json.Marshal(repoList)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal response body for list repository: %v", err))
+	}
+	return c.String(http.StatusOK, string(buf))
+}
+
+func (*Gitea) deleteRepositoryWebhook(c echo.Context) error {
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (gt *Gitea) createRepositoryWebhook(c echo.Context) error {
+	r, err := gt.validRepository(c)
+	if err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to read request body for creating repository webhook: %v", err))
+	}
+
+	var webhookCreate gitea.WebhookCreateOrUpdate
+	if err = json.Unmarshal(body, &webhookCreate); err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to unmarshal request body for creating repository webhook: %v", err))
+	}
+	r.webhooks = append(r.webhooks, &webhookCreate)
+
+	buf, err := json.Marshal(gitea.WebhookInfo{ID: gt.nextWebhookID})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal response body for creating repository webhook: %v", err))
+	}
+	gt.nextWebhookID++
+	return c.String(http.StatusCreated, string(buf))
+}
+
+func (gt *Gitea) readRepositoryFile(c echo.Context) error {
+	r, err := gt.validRepository(c)
+	if err != nil {
+		return err
+	}
+
+	filePathEscaped := c.Param("*")
+	filePath, err := url.PathUnescape(filePathEscaped)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("failed to unescape file path %q: %v", filePathEscaped, err))
+	}
+
+	content, ok := r.files[filePath]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("file %q not found", filePath))
+	}
+	return c.String(http.StatusOK, content)
+}
+
+func (gt *Gitea) getRepositoryBranch(c echo.Context) error {
+	r, err := gt.validRepository(c)
+	if err != nil {
+		return err
+	}
+
+	branchName, err := url.PathUnescape(c.Param("*"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("failed to unescape branch name %q: %v", c.Param("*"), err))
+	}
+	branch, ok := r.branches[branchName]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("branch not found: %v", branchName))
+	}
+
+	buf, err := json.Marshal(branch)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal response body for getting repository branch: %v", err))
+	}
+	return c.String(http.StatusOK, string(buf))
+}
+
+func (gt *Gitea) getPullRequest(c echo.Context) error {
+	r, err := gt.validRepository(c)
+	if err != nil {
+		return err
+	}
+
+	prNumber, err := strconv.Atoi(c.Param("prID"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("The pull request id is invalid: %v", c.Param("prID")))
+	}
+
+	pullRequest, ok := r.pullRequests[prNumber]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("Cannot found the pull request: %v", c.Param("prID")))
+	}
+
+	buf, err := json.Marshal(pullRequest.PullRequest)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal response body: %v", err))
+	}
+	return c.String(http.StatusOK, string(buf))
+}
+
+func (gt *Gitea) listPullRequestFile(c echo.Context) error {
+	r, err := gt.validRepository(c)
+	if err != nil {
+		return err
+	}
+
+	prNumber, err := strconv.Atoi(c.Param("prID"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("The pull request id is invalid: %v", c.Param("prID")))
+	}
+
+	pullRequest, ok := r.pullRequests[prNumber]
+	if !ok {
+		return c.String(http.StatusNotFound, fmt.Sprintf("Cannot found the pull request: %v", c.Param("prID")))
+	}
+
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("Invalid page parameter %v", c.Param("page")))
+	}
+
+	prFiles := []*gitea.PullRequestFile{}
+	if page == 1 {
+		prFiles = pullRequest.Files
+	}
+
+	buf, err := json.Marshal(prFiles)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, fmt.Sprintf("failed to marshal response body: %v", err))
+	}
+	return c.String(http.StatusOK, string(buf))
+}
+
+func (*Gitea) getCurrentUser(c echo.Context) error {
+	return writeResponse(c, http.StatusOK, &gitea.User{ID: giteaBotUserID, Login: "bytebase-bot"})
+}
+
+func (gt *Gitea) listIssueComments(c echo.Context) error {
+	r, err := gt.validRepository(c)
+	if err != nil {
+		return err
+	}
+	comments := []*gitea.Comment{}
+	for _, comment := range gt.comments.list(r.repository.FullName, c.Param("prID")) {
+		userID, err := strconv.ParseInt(comment.author, 10, 64)
+		if err != nil {
+			return c.String(http.StatusInternalServerError, fmt.Sprintf("invalid comment author %q", comment.author))
+		}
+		comments = append(comments, &gitea.Comment{ID: comment.id, Body: comment.body, User: &gitea.User{ID: userID}})
+	}
+	return writeResponse(c, http.StatusOK, comments)
+}
+
+func (gt *Gitea) createIssueComment(c echo.Context) error {
+	r, err := gt.validRepository(c)
+	if err != nil {
+		return err
+	}
+	var comment gitea.Comment
+	if err := readRequestBody(c, &comment); err != nil {
+		return err
+	}
+	created := gt.comments.add(r.repository.FullName, c.Param("prID"), strconv.FormatInt(giteaBotUserID, 10), comment.Body)
+	return writeResponse(c, http.StatusCreated, &gitea.Comment{ID: created.id, Body: created.body, User: &gitea.User{ID: giteaBotUserID}})
+}
+
+func (gt *Gitea) updateIssueComment(c echo.Context) error {
+	if _, err := gt.validRepository(c); err != nil {
+		return err
+	}
+	commentID, err := strconv.ParseInt(c.Param("commentID"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid comment ID %q", c.Param("commentID")))
+	}
+	var comment gitea.Comment
+	if err := readRequestBody(c, &comment); err != nil {
+		return err
+	}
+	if !gt.comments.update(commentID, comment.Body) {
+		return c.String(http.StatusNotFound, fmt.Sprintf("comment %d not found", commentID))
+	}
+	return writeResponse(c, http.StatusOK, &gitea.Comment{ID: commentID, Body: comment.Body})
+}
+
+func (*Gitea) createCommitStatus(c echo.Context) error {
+	return c.String(http.StatusCreated, "{}")
+}
+
+func (gt *Gitea) validRepository(c echo.Context) (*giteaRepositoryData, error) {
+	repositoryID := fmt.Sprintf("%s/%s", c.Param("owner"), c.Param("repo"))
+	r, ok := gt.repositories[repositoryID]
+	if !ok {
+		return nil, c.String(http.StatusNotFound, fmt.Sprintf("Gitea repository %q does not exist", repositoryID))
+	}
+
+	return r, nil
+}
+
+// Run starts the Gitea VCS provider server.
+func (gt *Gitea) Run() error {
+	return gt.echo.Start(fmt.Sprintf(":%d", gt.port))
+}
+
+// Close shuts down the Gitea VCS provider server.
+func (gt *Gitea) Close() error {
+	return gt.echo.Close()
+}
+
+// ListenerAddr returns the Gitea VCS provider server listener address.
+func (gt *Gitea) ListenerAddr() net.Addr {
+	return gt.echo.ListenerAddr()
+}
+
+// CreateRepository creates a Gitea repository with given ID.
+func (gt *Gitea) CreateRepository(repository *vcs.Repository) error {
+	gt.repositories[repository.FullPath] = &giteaRepositoryData{
+		repository: &gitea.Repository{
+			ID:       int64(len(gt.repositories) + 1),
+			Name:     repository.Name,
+			FullName: repository.FullPath,
+		},
+		files:    make(map[string]string),
+		branches: map[string]*gitea.Branch{},
+		pullRequests: map[int]struct {
+			Files []*gitea.PullRequestFile
+			*gitea.PullRequest
+		}{},
+	}
+	return nil
+}
+
+// CreateBranch creates a new branch with the given name.
+func (gt *Gitea) CreateBranch(id, branchName string) error {
+	r, ok := gt.repositories[id]
+	if !ok {
+		return errors.Errorf("gitea repository %q doesn't exist", id)
+	}
+
+	if _, ok := r.branches[branchName]; ok {
+		return errors.Errorf("branch %q already exists", branchName)
+	}
+
+	r.branches[branchName] = &gitea.Branch{
+		Name: branchName,
+		Commit: gitea.BranchCommit{
+			ID: "fake_gitea_commit_sha",
+		},
+	}
+	return nil
+}
+
+// SendWebhookPush sends out a webhook for a push event for the Gitea
+// repository using given payload.
+func (gt *Gitea) SendWebhookPush(repositoryID string, payload []byte) error {
+	r, ok := gt.repositories[repositoryID]
+	if !ok {
+		return errors.Errorf("Gitea repository %q does not exist", repositoryID)
+	}
+
+	// Trigger all webhooks
+	for _, webhook := range r.webhooks {
+		if err := func() error {
+			req, err := http.NewRequest("POST", webhook.Config.URL, bytes.NewReader(payload))
+			if err != nil {
+				return errors.Wrapf(err, "failed to create a new POST request to %q", webhook.Config.URL)
+			}
+
+			m := hmac.New(sha256.New, []byte(webhook.Config.Secret))
+			if _, err := m.Write(payload); err != nil {
+				return errors.Wrap(err, "failed to calculate SHA256 of the webhook secret")
+			}
+			req.Header.Set("X-Gitea-Signature", hex.EncodeToString(m.Sum(nil)))
+			req.Header.Set("X-Gitea-Event", "pull_request")
+
+			resp, err := gt.client.Do(req)
+			if err != nil {
+				return errors.Wrapf(err, "failed to send POST request to %q", webhook.Config.URL)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return errors.Wrap(err, "failed to read response body")
+			}
+			if resp.StatusCode != http.StatusOK {
+				return errors.Errorf("unexpected response status code %d, body: %s", resp.StatusCode, body)
+			}
+			gt.echo.Logger.Infof("SendWebhookPush response body %s\n", body)
+			return nil
+		}(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddFiles adds given files to the Gitea repository.
+func (gt *Gitea) AddFiles(repositoryID string, files map[string]string) error {
+	r, ok := gt.repositories[repositoryID]
+	if !ok {
+		return errors.Errorf("Gitea repository %q does not exist", repositoryID)
+	}
+
+	// Save or overwrite files
+	for path, content := range files {
+		r.files[path] = content
+	}
+	return nil
+}
+
+// AddPullRequest creates a new pull request and add changed files to it.
+func (gt *Gitea) AddPullRequest(repositoryID string, prID int, files []*vcs.PullRequestFile) error {
+	r, ok := gt.repositories[repositoryID]
+	if !ok {
+		return errors.Errorf("gitea repository %q does not exist", repositoryID)
+	}
+
+	pullRequestFiles := []*gitea.PullRequestFile{}
+	headSHA := ""
+	for _, file := range files {
+		status := "added"
+		if file.IsDeleted {
+			status = "deleted"
+		}
+		pullRequestFiles = append(pullRequestFiles, &gitea.PullRequestFile{
+			FileName: file.Path,
+			Status:   status,
+		})
+		headSHA = file.LastCommitID
+	}
+
+	r.pullRequests[prID] = struct {
+		Files []*gitea.PullRequestFile
+		*gitea.PullRequest
+	}{
+		Files: pullRequestFiles,
+		PullRequest: &gitea.PullRequest{
+			Number:  prID,
+			HTMLURL: fmt.Sprintf("https://gitea.com/%s/pulls/%d", repositoryID, prID),
+			Head:    gitea.EventBranch{SHA: headSHA},
+		},
+	}
+	return nil
+}
+
+// AddPullRequestComment adds a comment by another user to the pull request.
+func (gt *Gitea) AddPullRequestComment(repositoryID string, prID int, comment string) error {
+	r, ok := gt.repositories[repositoryID]
+	if !ok {
+		return errors.Errorf("gitea repository %q does not exist", repositoryID)
+	}
+	if _, ok := r.pullRequests[prID]; !ok {
+		return errors.Errorf("gitea pull request %d does not exist", prID)
+	}
+	gt.comments.add(repositoryID, strconv.Itoa(prID), strconv.Itoa(giteaUserID), comment)
+	return nil
+}
@@ -21,6 +21,13 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/vcs/github"
 )
 
+const (
+	// githubBotUserID is the ID of the authenticated user, which creates the comments through the API.
+	githubBotUserID = 1
+	// githubUserID is the ID of the user adding the comments by AddPullRequestComment.
+	githubUserID = 2
+)
+
 // GitHub is a fake implementation of GitHub VCS provider.
 type GitHub struct {
 	port int
@@ -30,6 +37,7 @@ type GitHub struct {
 
 	nextWebhookID int
 	repositories  map[string]*githubRepositoryData
+	comments      *pullRequestComments
 }
 
 type githubRepositoryData struct {
@@ -58,9 +66,11 @@ func NewGitHub(port int) VCSProvider {
 		client:        &http.Client{},
 		nextWebhookID: 20210113,
 		repositories:  make(map[string]*githubRepositoryData),
+		comments:      newPullRequestComments(),
 	}
 
 	g := e.Group("/api/v3")
+	g.GET("/user", gh.getCurrentUser)
 	g.GET("/user/repos", gh.listRepositories)
 	g.POST("/repos/:owner/:repo/hooks", gh.createRepositoryWebhook)
 	g.DELETE("/repos/:owner/:repo/hooks/:hook", gh.deleteRepositoryWebhook)
@@ -68,7 +78,10 @@ func NewGitHub(port int) VCSProvider {
 	g.GET("/repos/:owner/:repo/contents/:filePath", gh.readRepositoryFile)
 	g.GET("/repos/:owner/:repo/git/ref/heads/:branchName", gh.getRepositoryBranch)
 	g.GET("/repos/:owner/:repo/pulls/:prID/files", gh.listPullRequestFile)
+	g.GET("/repos/:owner/:repo/issues/:prID/comments", gh.listIssueComments)
 	g.POST("/repos/:owner/:repo/issues/:prID/comments", gh.createIssueComment)
+	g.PATCH("/repos/:owner/:repo/issues/comments/:commentID", gh.updateIssueComment)
+	g.POST("/repos/:owner/:repo/statuses/:sha", gh.createCommitStatus)
 	return gh
 }
 
@@ -208,8 +221,59 @@ func (gh *GitHub) listPullRequestFile(c echo.Context) error {
 	return c.String(http.StatusOK, string(buf))
 }
 
-func (*GitHub) createIssueComment(echo.Context) error {
-	return nil
+func (*GitHub) getCurrentUser(c echo.Context) error {
+	return writeResponse(c, http.StatusOK, &github.User{ID: githubBotUserID, Login: "bytebase-bot"})
+}
+
+func (gh *GitHub) listIssueComments(c echo.Context) error {
+	r, err := gh.validRepository(c)
+	if err != nil {
+		return err
+	}
+	comments := []*github.Comment{}
+	for _, comment := range gh.comments.list(r.repository.FullName, c.Param("prID")) {
+		userID, err := strconv.ParseInt(comment.author, 10, 64)
+		if err != nil {
+			return c.String(http.StatusInternalServerError, fmt.Sprintf("invalid comment author %q", comment.author))
+		}
+		comments = append(comments, &github.Comment{ID: comment.id, Body: comment.body, User: &github.User{ID: userID}})
+	}
+	return writeResponse(c, http.StatusOK, comments)
+}
+
+func (gh *GitHub) createIssueComment(c echo.Context) error {
+	r, err := gh.validRepository(c)
+	if err != nil {
+		return err
+	}
+	var comment github.Comment
+	if err := readRequestBody(c, &comment); err != nil {
+		return err
+	}
+	created := gh.comments.add(r.repository.FullName, c.Param("prID"), strconv.FormatInt(githubBotUserID, 10), comment.Body)
+	return writeResponse(c, http.StatusCreated, &github.Comment{ID: created.id, Body: created.body, User: &github.User{ID: githubBotUserID}})
+}
+
+func (gh *GitHub) updateIssueComment(c echo.Context) error {
+	if _, err := gh.validRepository(c); err != nil {
+		return err
+	}
+	commentID, err := strconv.ParseInt(c.Param("commentID"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid comment ID %q", c.Param("commentID")))
+	}
+	var comment github.Comment
+	if err := readRequestBody(c, &comment); err != nil {
+		return err
+	}
+	if !gh.comments.update(commentID, comment.Body) {
+		return c.String(http.StatusNotFound, fmt.Sprintf("comment %d not found", commentID))
+	}
+	return writeResponse(c, http.StatusOK, &github.Comment{ID: commentID, Body: comment.Body})
+}
+
+func (*GitHub) createCommitStatus(c echo.Context) error {
+	return c.String(http.StatusCreated, "{}")
 }
 
 func (gh *GitHub) validRepository(c echo.Context) (*githubRepositoryData, error) {
@@ -368,3 +432,16 @@ func (gh *GitHub) AddPullRequest(repositoryID string, prID int, files []*vcs.Pul
 
 	return nil
 }
+
+// AddPullRequestComment adds a comment by another user to the pull request.
+func (gh *GitHub) AddPullRequestComment(repositoryID string, prID int, comment string) error {
+	r, ok := gh.repositories[repositoryID]
+	if !ok {
+		return errors.Errorf("github repository %q does not exist", repositoryID)
+	}
+	if _, ok := r.pullRequests[prID]; !ok {
+		return errors.Errorf("github pull request %d does not exist", prID)
+	}
+	gh.comments.add(repositoryID, strconv.Itoa(prID), strconv.Itoa(githubUserID), comment)
+	return nil
+}
@@ -19,6 +19,13 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/vcs/gitlab"
 )
 
+const (
+	// gitlabBotUserID is the ID of the authenticated user, which creates the notes through the API.
+	gitlabBotUserID = 1
+	// gitlabUserID is the ID of the user adding the notes by AddPullRequestComment.
+	gitlabUserID = 2
+)
+
 // GitLab is a fake implementation of GitLab VCS provider.
 type GitLab struct {
 	port int
@@ -28,6 +35,7 @@ type GitLab struct {
 
 	nextWebhookID int
 	projects      map[string]*projectData
+	notes         *pullRequestComments
 }
 
 type projectData struct {
@@ -56,10 +64,12 @@ func NewGitLab(port int) VCSProvider {
 		client:        &http.Client{},
 		nextWebhookID: 20210113,
 		projects:      map[string]*projectData{},
+		notes:         newPullRequestComments(),
 	}
 
 	// Routes
 	projectGroup := e.Group("/api/v4")
+	projectGroup.GET("/user", gl.getCurrentUser)
 	projectGroup.GET("/projects", gl.listRepositories)
 	projectGroup.POST("/projects/:id/hooks", gl.createProjectHook)
 	projectGroup.DELETE("/projects/:id/hooks/:hook", gl.deleteRepositoryWebhook)
@@ -69,7 +79,10 @@ func NewGitLab(port int) VCSProvider {
 	projectGroup.POST("/projects/:id/repository/branches", gl.createProjectBranch)
 	projectGroup.POST("/projects/:id/merge_requests", gl.createProjectPullRequest)
 	projectGroup.GET("/projects/:id/merge_requests/:mrID/changes", gl.getMergeRequestChanges)
-	projectGroup.GET("/projects/:id/merge_requests/:mrID/notes", gl.createMergeRequestComment)
+	projectGroup.GET("/projects/:id/merge_requests/:mrID/notes", gl.listMergeRequestNotes)
+	projectGroup.POST("/projects/:id/merge_requests/:mrID/notes", gl.createMergeRequestNote)
+	projectGroup.PUT("/projects/:id/merge_requests/:mrID/notes/:noteID", gl.updateMergeRequestNote)
+	projectGroup.POST("/projects/:id/statuses/:commitID", gl.createCommitStatus)
 
 	return gl
 }
@@ -349,8 +362,50 @@ func (gl *GitLab) getMergeRequestChanges(c echo.Context) error {
 	return c.String(http.StatusOK, string(buf))
 }
 
-func (*GitLab) createMergeRequestComment(echo.Context) error {
-	return nil
+func (*GitLab) getCurrentUser(c echo.Context) error {
+	return writeResponse(c, http.StatusOK, &gitlab.User{ID: gitlabBotUserID, Username: "bytebase-bot"})
+}
+
+func (gl *GitLab) listMergeRequestNotes(c echo.Context) error {
+	if _, err := gl.validProject(c); err != nil {
+		return err
+	}
+	notes := []*gitlab.MergeRequestNote{}
+	for _, note := range gl.notes.list(c.Param("id"), c.Param("mrID")) {
+		userID, err := strconv.ParseInt(note.author, 10, 64)
+		if err != nil {
+			return c.String(http.StatusInternalServerError, fmt.Sprintf("invalid note author %q", note.author))
+		}
+		notes = append(notes, &gitlab.MergeRequestNote{ID: note.id, Body: note.body, Author: gitlab.User{ID: userID}})
+	}
+	return writeResponse(c, http.StatusOK, notes)
+}
+
+func (gl *GitLab) createMergeRequestNote(c echo.Context) error {
+	if _, err := gl.validProject(c); err != nil {
+		return err
+	}
+	note := gl.notes.add(c.Param("id"), c.Param("mrID"), strconv.Itoa(gitlabBotUserID), c.QueryParam("body"))
+	return writeResponse(c, http.StatusCreated, &gitlab.MergeRequestNote{ID: note.id, Body: note.body, Author: gitlab.User{ID: gitlabBotUserID}})
+}
+
+func (gl *GitLab) updateMergeRequestNote(c echo.Context) error {
+	if _, err := gl.validProject(c); err != nil {
+		return err
+	}
+	noteID, err := strconv.ParseInt(c.Param("noteID"), 10, 64)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid note ID %q", c.Param("noteID")))
+	}
+	body := c.QueryParam("body")
+	if !gl.notes.update(noteID, body) {
+		return c.String(http.StatusNotFound, fmt.Sprintf("note %d not found", noteID))
+	}
+	return writeResponse(c, http.StatusOK, &gitlab.MergeRequestNote{ID: noteID, Body: body})
+}
+
+func (*GitLab) createCommitStatus(c echo.Context) error {
+	return c.String(http.StatusCreated, "{}")
 }
 
 // SendWebhookPush sends out a webhook for a push event for the GitLab project
@@ -443,3 +498,12 @@ func (gl *GitLab) AddPullRequest(projectID string, mrID int, files []*vcs.PullRe
 	}
 	return nil
 }
+
+// AddPullRequestComment adds a note by another user to the merge request.
+func (gl *GitLab) AddPullRequestComment(projectID string, mrID int, comment string) error {
+	if _, ok := gl.projects[projectID]; !ok {
+		return errors.Errorf("gitlab project %q doesn't exist", projectID)
+	}
+	gl.notes.add(projectID, strconv.Itoa(mrID), strconv.Itoa(gitlabUserID), comment)
+	return nil
+}
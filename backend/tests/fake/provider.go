@@ -26,6 +26,8 @@ type VCSProvider interface {
 	AddFiles(repositoryID string, files map[string]string) error
 	// AddPullRequest creates a new pull request and add changed files to it.
 	AddPullRequest(repositoryID string, prID int, files []*vcs.PullRequestFile) error
+	// AddPullRequestComment adds a comment by another user than the authenticated one to the pull request.
+	AddPullRequestComment(repositoryID string, prID int, comment string) error
 }
 
 // VCSProviderCreator a function to create a new VCSProvider.
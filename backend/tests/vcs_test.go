@@ -13,6 +13,7 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/vcs"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/azure"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/bitbucket"
+	"github.com/bytebase/bytebase/backend/plugin/vcs/gitea"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/github"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/gitlab"
 	"github.com/bytebase/bytebase/backend/tests/fake"
@@ -150,6 +151,34 @@ func TestVCS(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:               "Gitea",
+			vcsProviderCreator: fake.NewGitea,
+			vcsType:            v1pb.VCSType_GITEA,
+			repository: &vcs.Repository{
+				ID:       "octocat/Hello-World",
+				Name:     "octocat/Hello-World",
+				FullPath: "octocat/Hello-World",
+			},
+			webhookPushEvent: gitea.PullRequestPushEvent{
+				Action: "closed",
+				Number: pullRequestID,
+				PullRequest: gitea.EventPullRequest{
+					HTMLURL: fmt.Sprintf("https://gitea.com/test/vcs/pulls/%d", pullRequestID),
+					Title:   pullRequestTitle,
+					Body:    pullRequestDescription,
+					Base: gitea.EventBranch{
+						Ref: branchName,
+						SHA: "cc63b0592388a7ab1b05b005ad8c8dc14ce432b0",
+					},
+					Head: gitea.EventBranch{
+						Ref: "test-branch",
+						SHA: "cc63b0592388a7ab1b05b005ad8c8dc14ce432b1",
+					},
+					Merged: true,
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		// Fix the problem that closure in a for loop will always use the last element.
@@ -59,6 +59,9 @@ export const useRollbackContext = () => {
       case Engine.ORACLE:
         // We don't have a check for oracle similar to the MySQL version check.
         break;
+      case Engine.POSTGRES:
+        // Logical decoding requires wal_level = logical, which is checked when the task runs.
+        break;
       default:
         return "NONE";
     }
@@ -1,4 +1,5 @@
 import BooleanComponent from "./BooleanComponent.vue";
+import CustomCELRuleListComponent from "./CustomCELRuleListComponent.vue";
 import NumberComponent from "./NumberComponent.vue";
 import StringArrayComponent from "./StringArrayComponent.vue";
 import StringComponent from "./StringComponent.vue";
@@ -10,5 +11,6 @@ export {
   BooleanComponent,
   StringArrayComponent,
   TemplateComponent,
+  CustomCELRuleListComponent,
 };
 export * from "./types";
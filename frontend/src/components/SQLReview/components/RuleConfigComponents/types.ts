@@ -1,5 +1,11 @@
 import type { Engine } from "@/types/proto/v1/common";
+import type { CustomCELRule } from "@/types/sqlReview";
 
-export type PayloadValueType = boolean | string | number | string[];
+export type PayloadValueType =
+  | boolean
+  | string
+  | number
+  | string[]
+  | CustomCELRule[];
 
 export type PayloadForEngine = Map<Engine, PayloadValueType[]>;
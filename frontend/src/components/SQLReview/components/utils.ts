@@ -1,5 +1,6 @@
 import { groupBy, cloneDeep } from "lodash-es";
 import type {
+  CustomCELRule,
   RuleConfigComponent,
   RuleTemplate,
   SQLReviewPolicy,
@@ -97,6 +98,15 @@ export const payloadValueListToComponentList = (
             },
           });
           break;
+        case "CUSTOM_CEL_RULE_LIST":
+          list.push({
+            ...component,
+            payload: {
+              ...component.payload,
+              value: allEnginePayload[index] as CustomCELRule[],
+            },
+          });
+          break;
         default:
           list.push({
             ...component,
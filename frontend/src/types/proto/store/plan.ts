@@ -265,6 +265,11 @@ export interface PlanConfig_VCSSource {
    */
   vcsConnector: string;
   pullRequestUrl: string;
+  /**
+   * The head commit of the pull request that the plan is built from.
+   * The plan check results and the rollout progress are reported to its commit status.
+   */
+  lastCommit: string;
 }
 
 function createBasePlanConfig(): PlanConfig {
@@ -1420,7 +1425,7 @@ export const PlanConfig_ExportDataConfig = {
 };
 
 function createBasePlanConfig_VCSSource(): PlanConfig_VCSSource {
-  return { vcsType: VCSType.VCS_TYPE_UNSPECIFIED, vcsConnector: "", pullRequestUrl: "" };
+  return { vcsType: VCSType.VCS_TYPE_UNSPECIFIED, vcsConnector: "", pullRequestUrl: "", lastCommit: "" };
 }
 
 export const PlanConfig_VCSSource = {
@@ -1434,6 +1439,9 @@ export const PlanConfig_VCSSource = {
     if (message.pullRequestUrl !== "") {
       writer.uint32(26).string(message.pullRequestUrl);
     }
+    if (message.lastCommit !== "") {
+      writer.uint32(34).string(message.lastCommit);
+    }
     return writer;
   },
 
@@ -1465,6 +1473,13 @@ export const PlanConfig_VCSSource = {
 
           message.pullRequestUrl = reader.string();
           continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.lastCommit = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1479,6 +1494,7 @@ export const PlanConfig_VCSSource = {
       vcsType: isSet(object.vcsType) ? vCSTypeFromJSON(object.vcsType) : VCSType.VCS_TYPE_UNSPECIFIED,
       vcsConnector: isSet(object.vcsConnector) ? globalThis.String(object.vcsConnector) : "",
       pullRequestUrl: isSet(object.pullRequestUrl) ? globalThis.String(object.pullRequestUrl) : "",
+      lastCommit: isSet(object.lastCommit) ? globalThis.String(object.lastCommit) : "",
     };
   },
 
@@ -1493,6 +1509,9 @@ export const PlanConfig_VCSSource = {
     if (message.pullRequestUrl !== "") {
       obj.pullRequestUrl = message.pullRequestUrl;
     }
+    if (message.lastCommit !== "") {
+      obj.lastCommit = message.lastCommit;
+    }
     return obj;
   },
 
@@ -1504,6 +1523,7 @@ export const PlanConfig_VCSSource = {
     message.vcsType = object.vcsType ?? VCSType.VCS_TYPE_UNSPECIFIED;
     message.vcsConnector = object.vcsConnector ?? "";
     message.pullRequestUrl = object.pullRequestUrl ?? "";
+    message.lastCommit = object.lastCommit ?? "";
     return message;
   },
 };
@@ -0,0 +1,335 @@
+/* eslint-disable */
+import Long from "long";
+import _m0 from "protobufjs/minimal";
+import { Duration } from "../google/protobuf/duration";
+import { Timestamp } from "../google/protobuf/timestamp";
+
+export const protobufPackage = "bytebase.store";
+
+export interface ProjectWebhookPayload {
+  /**
+   * The secret to sign the request body of the custom webhook.
+   * The signature is sent in the X-Bytebase-Signature header.
+   */
+  signingSecret: string;
+  /**
+   * The Go template to render the request body of the custom webhook.
+   * The default request body is used if it's empty.
+   */
+  bodyTemplate: string;
+}
+
+export interface WebhookDeliveryPayload {
+  /** The attempts of the delivery, ordered by the attempt time. */
+  attempts: WebhookDeliveryAttempt[];
+}
+
+export interface WebhookDeliveryAttempt {
+  time: Date | undefined;
+  /** The HTTP status code of the response. It's zero if no response is received. */
+  statusCode: number;
+  latency: Duration | undefined;
+  /** The excerpt of the response body. */
+  responseBody: string;
+  /** The error message if the attempt failed. */
+  error: string;
+}
+
+function createBaseProjectWebhookPayload(): ProjectWebhookPayload {
+  return { signingSecret: "", bodyTemplate: "" };
+}
+
+export const ProjectWebhookPayload = {
+  encode(message: ProjectWebhookPayload, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.signingSecret !== "") {
+      writer.uint32(10).string(message.signingSecret);
+    }
+    if (message.bodyTemplate !== "") {
+      writer.uint32(18).string(message.bodyTemplate);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ProjectWebhookPayload {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseProjectWebhookPayload();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.signingSecret = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.bodyTemplate = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ProjectWebhookPayload {
+    return {
+      signingSecret: isSet(object.signingSecret) ? globalThis.String(object.signingSecret) : "",
+      bodyTemplate: isSet(object.bodyTemplate) ? globalThis.String(object.bodyTemplate) : "",
+    };
+  },
+
+  toJSON(message: ProjectWebhookPayload): unknown {
+    const obj: any = {};
+    if (message.signingSecret !== "") {
+      obj.signingSecret = message.signingSecret;
+    }
+    if (message.bodyTemplate !== "") {
+      obj.bodyTemplate = message.bodyTemplate;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ProjectWebhookPayload>): ProjectWebhookPayload {
+    return ProjectWebhookPayload.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ProjectWebhookPayload>): ProjectWebhookPayload {
+    const message = createBaseProjectWebhookPayload();
+    message.signingSecret = object.signingSecret ?? "";
+    message.bodyTemplate = object.bodyTemplate ?? "";
+    return message;
+  },
+};
+
+function createBaseWebhookDeliveryPayload(): WebhookDeliveryPayload {
+  return { attempts: [] };
+}
+
+export const WebhookDeliveryPayload = {
+  encode(message: WebhookDeliveryPayload, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.attempts) {
+      WebhookDeliveryAttempt.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WebhookDeliveryPayload {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWebhookDeliveryPayload();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.attempts.push(WebhookDeliveryAttempt.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WebhookDeliveryPayload {
+    return {
+      attempts: globalThis.Array.isArray(object?.attempts)
+        ? object.attempts.map((e: any) => WebhookDeliveryAttempt.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: WebhookDeliveryPayload): unknown {
+    const obj: any = {};
+    if (message.attempts?.length) {
+      obj.attempts = message.attempts.map((e) => WebhookDeliveryAttempt.toJSON(e));
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<WebhookDeliveryPayload>): WebhookDeliveryPayload {
+    return WebhookDeliveryPayload.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<WebhookDeliveryPayload>): WebhookDeliveryPayload {
+    const message = createBaseWebhookDeliveryPayload();
+    message.attempts = object.attempts?.map((e) => WebhookDeliveryAttempt.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseWebhookDeliveryAttempt(): WebhookDeliveryAttempt {
+  return { time: undefined, statusCode: 0, latency: undefined, responseBody: "", error: "" };
+}
+
+export const WebhookDeliveryAttempt = {
+  encode(message: WebhookDeliveryAttempt, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.time !== undefined) {
+      Timestamp.encode(toTimestamp(message.time), writer.uint32(10).fork()).ldelim();
+    }
+    if (message.statusCode !== 0) {
+      writer.uint32(16).int32(message.statusCode);
+    }
+    if (message.latency !== undefined) {
+      Duration.encode(message.latency, writer.uint32(26).fork()).ldelim();
+    }
+    if (message.responseBody !== "") {
+      writer.uint32(34).string(message.responseBody);
+    }
+    if (message.error !== "") {
+      writer.uint32(42).string(message.error);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WebhookDeliveryAttempt {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWebhookDeliveryAttempt();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.time = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.statusCode = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.latency = Duration.decode(reader, reader.uint32());
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.responseBody = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.error = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WebhookDeliveryAttempt {
+    return {
+      time: isSet(object.time) ? fromJsonTimestamp(object.time) : undefined,
+      statusCode: isSet(object.statusCode) ? globalThis.Number(object.statusCode) : 0,
+      latency: isSet(object.latency) ? Duration.fromJSON(object.latency) : undefined,
+      responseBody: isSet(object.responseBody) ? globalThis.String(object.responseBody) : "",
+      error: isSet(object.error) ? globalThis.String(object.error) : "",
+    };
+  },
+
+  toJSON(message: WebhookDeliveryAttempt): unknown {
+    const obj: any = {};
+    if (message.time !== undefined) {
+      obj.time = message.time.toISOString();
+    }
+    if (message.statusCode !== 0) {
+      obj.statusCode = Math.round(message.statusCode);
+    }
+    if (message.latency !== undefined) {
+      obj.latency = Duration.toJSON(message.latency);
+    }
+    if (message.responseBody !== "") {
+      obj.responseBody = message.responseBody;
+    }
+    if (message.error !== "") {
+      obj.error = message.error;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<WebhookDeliveryAttempt>): WebhookDeliveryAttempt {
+    return WebhookDeliveryAttempt.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<WebhookDeliveryAttempt>): WebhookDeliveryAttempt {
+    const message = createBaseWebhookDeliveryAttempt();
+    message.time = object.time ?? undefined;
+    message.statusCode = object.statusCode ?? 0;
+    message.latency = (object.latency !== undefined && object.latency !== null)
+      ? Duration.fromPartial(object.latency)
+      : undefined;
+    message.responseBody = object.responseBody ?? "";
+    message.error = object.error ?? "";
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin ? T
+  : T extends Long ? string | number | Long : T extends globalThis.Array<infer U> ? globalThis.Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U> ? ReadonlyArray<DeepPartial<U>>
+  : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+function toTimestamp(date: Date): Timestamp {
+  const seconds = numberToLong(date.getTime() / 1_000);
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = (t.seconds.toNumber() || 0) * 1_000;
+  millis += (t.nanos || 0) / 1_000_000;
+  return new globalThis.Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof globalThis.Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new globalThis.Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
+function numberToLong(number: number) {
+  return Long.fromNumber(number);
+}
+
+if (_m0.util.Long !== Long) {
+  _m0.util.Long = Long as any;
+  _m0.configure();
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -38,6 +38,68 @@ export interface VCSConnector {
    * Format: projects/{project}/databaseGroups/{databaseGroup}
    */
   databaseGroup: string;
+  /**
+   * The template of the file path relative to the base directory, e.g. {{ENV_ID}}/{{DB_NAME}}/{{VERSION}}_{{TYPE}}_{{DESC}}.sql.
+   * If empty, only the files directly under the base directory are observed and applied to all target databases.
+   */
+  filePathTemplate: string;
+  mode: VCSConnector_Mode;
+}
+
+export enum VCSConnector_Mode {
+  /** MODE_UNSPECIFIED - The default mode, which is the same as MIGRATION. */
+  MODE_UNSPECIFIED = "MODE_UNSPECIFIED",
+  /** MIGRATION - The files are versioned migration scripts. */
+  MIGRATION = "MIGRATION",
+  /** SDL - The files hold the desired full schema of the databases, and the changes are generated by diffing them against the database schema. */
+  SDL = "SDL",
+  UNRECOGNIZED = "UNRECOGNIZED",
+}
+
+export function vCSConnector_ModeFromJSON(object: any): VCSConnector_Mode {
+  switch (object) {
+    case 0:
+    case "MODE_UNSPECIFIED":
+      return VCSConnector_Mode.MODE_UNSPECIFIED;
+    case 1:
+    case "MIGRATION":
+      return VCSConnector_Mode.MIGRATION;
+    case 2:
+    case "SDL":
+      return VCSConnector_Mode.SDL;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return VCSConnector_Mode.UNRECOGNIZED;
+  }
+}
+
+export function vCSConnector_ModeToJSON(object: VCSConnector_Mode): string {
+  switch (object) {
+    case VCSConnector_Mode.MODE_UNSPECIFIED:
+      return "MODE_UNSPECIFIED";
+    case VCSConnector_Mode.MIGRATION:
+      return "MIGRATION";
+    case VCSConnector_Mode.SDL:
+      return "SDL";
+    case VCSConnector_Mode.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export function vCSConnector_ModeToNumber(object: VCSConnector_Mode): number {
+  switch (object) {
+    case VCSConnector_Mode.MODE_UNSPECIFIED:
+      return 0;
+    case VCSConnector_Mode.MIGRATION:
+      return 1;
+    case VCSConnector_Mode.SDL:
+      return 2;
+    case VCSConnector_Mode.UNRECOGNIZED:
+    default:
+      return -1;
+  }
 }
 
 function createBaseVCSConnector(): VCSConnector {
@@ -51,6 +113,8 @@ function createBaseVCSConnector(): VCSConnector {
     externalWebhookId: "",
     webhookSecretToken: "",
     databaseGroup: "",
+    filePathTemplate: "",
+    mode: VCSConnector_Mode.MODE_UNSPECIFIED,
   };
 }
 
@@ -83,6 +147,12 @@ export const VCSConnector = {
     if (message.databaseGroup !== "") {
       writer.uint32(74).string(message.databaseGroup);
     }
+    if (message.filePathTemplate !== "") {
+      writer.uint32(82).string(message.filePathTemplate);
+    }
+    if (message.mode !== VCSConnector_Mode.MODE_UNSPECIFIED) {
+      writer.uint32(88).int32(vCSConnector_ModeToNumber(message.mode));
+    }
     return writer;
   },
 
@@ -156,6 +226,20 @@ export const VCSConnector = {
 
           message.databaseGroup = reader.string();
           continue;
+        case 10:
+          if (tag !== 82) {
+            break;
+          }
+
+          message.filePathTemplate = reader.string();
+          continue;
+        case 11:
+          if (tag !== 88) {
+            break;
+          }
+
+          message.mode = vCSConnector_ModeFromJSON(reader.int32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -176,6 +260,8 @@ export const VCSConnector = {
       externalWebhookId: isSet(object.externalWebhookId) ? globalThis.String(object.externalWebhookId) : "",
       webhookSecretToken: isSet(object.webhookSecretToken) ? globalThis.String(object.webhookSecretToken) : "",
       databaseGroup: isSet(object.databaseGroup) ? globalThis.String(object.databaseGroup) : "",
+      filePathTemplate: isSet(object.filePathTemplate) ? globalThis.String(object.filePathTemplate) : "",
+      mode: isSet(object.mode) ? vCSConnector_ModeFromJSON(object.mode) : VCSConnector_Mode.MODE_UNSPECIFIED,
     };
   },
 
@@ -208,6 +294,12 @@ export const VCSConnector = {
     if (message.databaseGroup !== "") {
       obj.databaseGroup = message.databaseGroup;
     }
+    if (message.filePathTemplate !== "") {
+      obj.filePathTemplate = message.filePathTemplate;
+    }
+    if (message.mode !== VCSConnector_Mode.MODE_UNSPECIFIED) {
+      obj.mode = vCSConnector_ModeToJSON(message.mode);
+    }
     return obj;
   },
 
@@ -225,6 +317,8 @@ export const VCSConnector = {
     message.externalWebhookId = object.externalWebhookId ?? "";
     message.webhookSecretToken = object.webhookSecretToken ?? "";
     message.databaseGroup = object.databaseGroup ?? "";
+    message.filePathTemplate = object.filePathTemplate ?? "";
+    message.mode = object.mode ?? VCSConnector_Mode.MODE_UNSPECIFIED;
     return message;
   },
 };
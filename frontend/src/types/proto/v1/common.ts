@@ -270,6 +270,8 @@ export enum VCSType {
   BITBUCKET = "BITBUCKET",
   /** AZURE_DEVOPS - Azure DevOps. Using for Azure DevOps GitOps workflow. */
   AZURE_DEVOPS = "AZURE_DEVOPS",
+  /** GITEA - Gitea type. Using for Gitea and Forgejo. */
+  GITEA = "GITEA",
   UNRECOGNIZED = "UNRECOGNIZED",
 }
 
@@ -290,6 +292,9 @@ export function vCSTypeFromJSON(object: any): VCSType {
     case 4:
     case "AZURE_DEVOPS":
       return VCSType.AZURE_DEVOPS;
+    case 5:
+    case "GITEA":
+      return VCSType.GITEA;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -309,6 +314,8 @@ export function vCSTypeToJSON(object: VCSType): string {
       return "BITBUCKET";
     case VCSType.AZURE_DEVOPS:
       return "AZURE_DEVOPS";
+    case VCSType.GITEA:
+      return "GITEA";
     case VCSType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -327,6 +334,8 @@ export function vCSTypeToNumber(object: VCSType): number {
       return 3;
     case VCSType.AZURE_DEVOPS:
       return 4;
+    case VCSType.GITEA:
+      return 5;
     case VCSType.UNRECOGNIZED:
     default:
       return -1;
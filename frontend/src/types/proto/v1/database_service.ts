@@ -435,6 +435,14 @@ export interface SchemaMetadata {
   tasks: TaskMetadata[];
   /** The materialized_views is the list of materialized views in a schema. */
   materializedViews: MaterializedViewMetadata[];
+  /** The triggers is the list of triggers in a schema. */
+  triggers: TriggerMetadata[];
+  /** The sequences is the list of sequences in a schema. */
+  sequences: SequenceMetadata[];
+  /** The enum_types is the list of user-defined enum types in a schema. */
+  enumTypes: EnumTypeMetadata[];
+  /** The composite_types is the list of user-defined composite types in a schema. */
+  compositeTypes: CompositeTypeMetadata[];
 }
 
 export interface ExternalTableMetadata {
@@ -483,6 +491,8 @@ export interface TableMetadata {
   foreignKeys: ForeignKeyMetadata[];
   /** The partitions is the list of partitions in a table. */
   partitions: TablePartitionMetadata[];
+  /** The check_constraints is the list of check constraints in a table. */
+  checkConstraints: CheckConstraintMetadata[];
 }
 
 /** TablePartitionMetadata is the metadata for table partitions. */
@@ -718,6 +728,83 @@ export interface ProcedureMetadata {
   definition: string;
 }
 
+/** TriggerMetadata is the metadata for triggers. */
+export interface TriggerMetadata {
+  /** The name is the name of a trigger. */
+  name: string;
+  /**
+   * The table_name is the name of the table or view that the trigger is created on.
+   * It is an empty string for triggers that are not bound to a table, such as Oracle schema triggers.
+   */
+  tableName: string;
+  /** The event is the triggering event of a trigger, such as INSERT, UPDATE or DELETE. */
+  event: string;
+  /** The timing is the action timing of a trigger, such as BEFORE, AFTER or INSTEAD OF. */
+  timing: string;
+  /** The definition is the definition of a trigger. */
+  definition: string;
+}
+
+/** SequenceMetadata is the metadata for sequences. */
+export interface SequenceMetadata {
+  /** The name is the name of a sequence. */
+  name: string;
+  /** The data_type is the data type of a sequence, such as integer or bigint. */
+  dataType: string;
+  /** The start is the start value of a sequence. */
+  start: string;
+  /** The min_value is the minimum value of a sequence. */
+  minValue: string;
+  /** The max_value is the maximum value of a sequence. */
+  maxValue: string;
+  /** The increment is the increment value of a sequence. */
+  increment: string;
+  /** The cycle is whether the sequence wraps around when the limit is reached. */
+  cycle: boolean;
+  /** The cache_size is the cache size of a sequence. */
+  cacheSize: string;
+  /** The owner_table is the table that owns the sequence, if any. */
+  ownerTable: string;
+  /** The owner_column is the column that owns the sequence, if any. */
+  ownerColumn: string;
+}
+
+/** EnumTypeMetadata is the metadata for user-defined enum types. */
+export interface EnumTypeMetadata {
+  /** The name is the name of an enum type. */
+  name: string;
+  /** The values are the ordered labels of an enum type. */
+  values: string[];
+  /** The comment is the comment of an enum type. */
+  comment: string;
+}
+
+/** CompositeTypeMetadata is the metadata for user-defined composite types. */
+export interface CompositeTypeMetadata {
+  /** The name is the name of a composite type. */
+  name: string;
+  /** The attributes is the ordered list of attributes in a composite type. */
+  attributes: CompositeTypeAttribute[];
+  /** The comment is the comment of a composite type. */
+  comment: string;
+}
+
+/** CompositeTypeAttribute is an attribute of a composite type. */
+export interface CompositeTypeAttribute {
+  /** The name is the name of an attribute. */
+  name: string;
+  /** The type is the type of an attribute. */
+  type: string;
+}
+
+/** CheckConstraintMetadata is the metadata for check constraints. */
+export interface CheckConstraintMetadata {
+  /** The name is the name of a check constraint. */
+  name: string;
+  /** The expression is the boolean expression of a check constraint. */
+  expression: string;
+}
+
 export interface TaskMetadata {
   /** The name is the name of a task. */
   name: string;
@@ -3173,6 +3260,10 @@ function createBaseSchemaMetadata(): SchemaMetadata {
     streams: [],
     tasks: [],
     materializedViews: [],
+    triggers: [],
+    sequences: [],
+    enumTypes: [],
+    compositeTypes: [],
   };
 }
 
@@ -3205,6 +3296,18 @@ export const SchemaMetadata = {
     for (const v of message.materializedViews) {
       MaterializedViewMetadata.encode(v!, writer.uint32(74).fork()).ldelim();
     }
+    for (const v of message.triggers) {
+      TriggerMetadata.encode(v!, writer.uint32(82).fork()).ldelim();
+    }
+    for (const v of message.sequences) {
+      SequenceMetadata.encode(v!, writer.uint32(90).fork()).ldelim();
+    }
+    for (const v of message.enumTypes) {
+      EnumTypeMetadata.encode(v!, writer.uint32(98).fork()).ldelim();
+    }
+    for (const v of message.compositeTypes) {
+      CompositeTypeMetadata.encode(v!, writer.uint32(106).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -3278,6 +3381,34 @@ export const SchemaMetadata = {
 
           message.materializedViews.push(MaterializedViewMetadata.decode(reader, reader.uint32()));
           continue;
+        case 10:
+          if (tag !== 82) {
+            break;
+          }
+
+          message.triggers.push(TriggerMetadata.decode(reader, reader.uint32()));
+          continue;
+        case 11:
+          if (tag !== 90) {
+            break;
+          }
+
+          message.sequences.push(SequenceMetadata.decode(reader, reader.uint32()));
+          continue;
+        case 12:
+          if (tag !== 98) {
+            break;
+          }
+
+          message.enumTypes.push(EnumTypeMetadata.decode(reader, reader.uint32()));
+          continue;
+        case 13:
+          if (tag !== 106) {
+            break;
+          }
+
+          message.compositeTypes.push(CompositeTypeMetadata.decode(reader, reader.uint32()));
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -3308,6 +3439,18 @@ export const SchemaMetadata = {
       materializedViews: globalThis.Array.isArray(object?.materializedViews)
         ? object.materializedViews.map((e: any) => MaterializedViewMetadata.fromJSON(e))
         : [],
+      triggers: globalThis.Array.isArray(object?.triggers)
+        ? object.triggers.map((e: any) => TriggerMetadata.fromJSON(e))
+        : [],
+      sequences: globalThis.Array.isArray(object?.sequences)
+        ? object.sequences.map((e: any) => SequenceMetadata.fromJSON(e))
+        : [],
+      enumTypes: globalThis.Array.isArray(object?.enumTypes)
+        ? object.enumTypes.map((e: any) => EnumTypeMetadata.fromJSON(e))
+        : [],
+      compositeTypes: globalThis.Array.isArray(object?.compositeTypes)
+        ? object.compositeTypes.map((e: any) => CompositeTypeMetadata.fromJSON(e))
+        : [],
     };
   },
 
@@ -3340,6 +3483,18 @@ export const SchemaMetadata = {
     if (message.materializedViews?.length) {
       obj.materializedViews = message.materializedViews.map((e) => MaterializedViewMetadata.toJSON(e));
     }
+    if (message.triggers?.length) {
+      obj.triggers = message.triggers.map((e) => TriggerMetadata.toJSON(e));
+    }
+    if (message.sequences?.length) {
+      obj.sequences = message.sequences.map((e) => SequenceMetadata.toJSON(e));
+    }
+    if (message.enumTypes?.length) {
+      obj.enumTypes = message.enumTypes.map((e) => EnumTypeMetadata.toJSON(e));
+    }
+    if (message.compositeTypes?.length) {
+      obj.compositeTypes = message.compositeTypes.map((e) => CompositeTypeMetadata.toJSON(e));
+    }
     return obj;
   },
 
@@ -3357,6 +3512,10 @@ export const SchemaMetadata = {
     message.streams = object.streams?.map((e) => StreamMetadata.fromPartial(e)) || [];
     message.tasks = object.tasks?.map((e) => TaskMetadata.fromPartial(e)) || [];
     message.materializedViews = object.materializedViews?.map((e) => MaterializedViewMetadata.fromPartial(e)) || [];
+    message.triggers = object.triggers?.map((e) => TriggerMetadata.fromPartial(e)) || [];
+    message.sequences = object.sequences?.map((e) => SequenceMetadata.fromPartial(e)) || [];
+    message.enumTypes = object.enumTypes?.map((e) => EnumTypeMetadata.fromPartial(e)) || [];
+    message.compositeTypes = object.compositeTypes?.map((e) => CompositeTypeMetadata.fromPartial(e)) || [];
     return message;
   },
 };
@@ -3484,6 +3643,7 @@ function createBaseTableMetadata(): TableMetadata {
     userComment: "",
     foreignKeys: [],
     partitions: [],
+    checkConstraints: [],
   };
 }
 
@@ -3534,6 +3694,9 @@ export const TableMetadata = {
     for (const v of message.partitions) {
       TablePartitionMetadata.encode(v!, writer.uint32(122).fork()).ldelim();
     }
+    for (const v of message.checkConstraints) {
+      CheckConstraintMetadata.encode(v!, writer.uint32(130).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -3649,6 +3812,13 @@ export const TableMetadata = {
 
           message.partitions.push(TablePartitionMetadata.decode(reader, reader.uint32()));
           continue;
+        case 16:
+          if (tag !== 130) {
+            break;
+          }
+
+          message.checkConstraints.push(CheckConstraintMetadata.decode(reader, reader.uint32()));
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -3683,6 +3853,9 @@ export const TableMetadata = {
       partitions: globalThis.Array.isArray(object?.partitions)
         ? object.partitions.map((e: any) => TablePartitionMetadata.fromJSON(e))
         : [],
+      checkConstraints: globalThis.Array.isArray(object?.checkConstraints)
+        ? object.checkConstraints.map((e: any) => CheckConstraintMetadata.fromJSON(e))
+        : [],
     };
   },
 
@@ -3733,6 +3906,9 @@ export const TableMetadata = {
     if (message.partitions?.length) {
       obj.partitions = message.partitions.map((e) => TablePartitionMetadata.toJSON(e));
     }
+    if (message.checkConstraints?.length) {
+      obj.checkConstraints = message.checkConstraints.map((e) => CheckConstraintMetadata.toJSON(e));
+    }
     return obj;
   },
 
@@ -3764,6 +3940,7 @@ export const TableMetadata = {
     message.userComment = object.userComment ?? "";
     message.foreignKeys = object.foreignKeys?.map((e) => ForeignKeyMetadata.fromPartial(e)) || [];
     message.partitions = object.partitions?.map((e) => TablePartitionMetadata.fromPartial(e)) || [];
+    message.checkConstraints = object.checkConstraints?.map((e) => CheckConstraintMetadata.fromPartial(e)) || [];
     return message;
   },
 };
@@ -4649,6 +4826,658 @@ export const ProcedureMetadata = {
   },
 };
 
+function createBaseTriggerMetadata(): TriggerMetadata {
+  return { name: "", tableName: "", event: "", timing: "", definition: "" };
+}
+
+export const TriggerMetadata = {
+  encode(message: TriggerMetadata, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.tableName !== "") {
+      writer.uint32(18).string(message.tableName);
+    }
+    if (message.event !== "") {
+      writer.uint32(26).string(message.event);
+    }
+    if (message.timing !== "") {
+      writer.uint32(34).string(message.timing);
+    }
+    if (message.definition !== "") {
+      writer.uint32(42).string(message.definition);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): TriggerMetadata {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTriggerMetadata();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.tableName = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.event = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.timing = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.definition = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TriggerMetadata {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      tableName: isSet(object.tableName) ? globalThis.String(object.tableName) : "",
+      event: isSet(object.event) ? globalThis.String(object.event) : "",
+      timing: isSet(object.timing) ? globalThis.String(object.timing) : "",
+      definition: isSet(object.definition) ? globalThis.String(object.definition) : "",
+    };
+  },
+
+  toJSON(message: TriggerMetadata): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.tableName !== "") {
+      obj.tableName = message.tableName;
+    }
+    if (message.event !== "") {
+      obj.event = message.event;
+    }
+    if (message.timing !== "") {
+      obj.timing = message.timing;
+    }
+    if (message.definition !== "") {
+      obj.definition = message.definition;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<TriggerMetadata>): TriggerMetadata {
+    return TriggerMetadata.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<TriggerMetadata>): TriggerMetadata {
+    const message = createBaseTriggerMetadata();
+    message.name = object.name ?? "";
+    message.tableName = object.tableName ?? "";
+    message.event = object.event ?? "";
+    message.timing = object.timing ?? "";
+    message.definition = object.definition ?? "";
+    return message;
+  },
+};
+
+function createBaseSequenceMetadata(): SequenceMetadata {
+  return {
+    name: "",
+    dataType: "",
+    start: "",
+    minValue: "",
+    maxValue: "",
+    increment: "",
+    cycle: false,
+    cacheSize: "",
+    ownerTable: "",
+    ownerColumn: "",
+  };
+}
+
+export const SequenceMetadata = {
+  encode(message: SequenceMetadata, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.dataType !== "") {
+      writer.uint32(18).string(message.dataType);
+    }
+    if (message.start !== "") {
+      writer.uint32(26).string(message.start);
+    }
+    if (message.minValue !== "") {
+      writer.uint32(34).string(message.minValue);
+    }
+    if (message.maxValue !== "") {
+      writer.uint32(42).string(message.maxValue);
+    }
+    if (message.increment !== "") {
+      writer.uint32(50).string(message.increment);
+    }
+    if (message.cycle === true) {
+      writer.uint32(56).bool(message.cycle);
+    }
+    if (message.cacheSize !== "") {
+      writer.uint32(66).string(message.cacheSize);
+    }
+    if (message.ownerTable !== "") {
+      writer.uint32(74).string(message.ownerTable);
+    }
+    if (message.ownerColumn !== "") {
+      writer.uint32(82).string(message.ownerColumn);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SequenceMetadata {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSequenceMetadata();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.dataType = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.start = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.minValue = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.maxValue = reader.string();
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.increment = reader.string();
+          continue;
+        case 7:
+          if (tag !== 56) {
+            break;
+          }
+
+          message.cycle = reader.bool();
+          continue;
+        case 8:
+          if (tag !== 66) {
+            break;
+          }
+
+          message.cacheSize = reader.string();
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.ownerTable = reader.string();
+          continue;
+        case 10:
+          if (tag !== 82) {
+            break;
+          }
+
+          message.ownerColumn = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SequenceMetadata {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      dataType: isSet(object.dataType) ? globalThis.String(object.dataType) : "",
+      start: isSet(object.start) ? globalThis.String(object.start) : "",
+      minValue: isSet(object.minValue) ? globalThis.String(object.minValue) : "",
+      maxValue: isSet(object.maxValue) ? globalThis.String(object.maxValue) : "",
+      increment: isSet(object.increment) ? globalThis.String(object.increment) : "",
+      cycle: isSet(object.cycle) ? globalThis.Boolean(object.cycle) : false,
+      cacheSize: isSet(object.cacheSize) ? globalThis.String(object.cacheSize) : "",
+      ownerTable: isSet(object.ownerTable) ? globalThis.String(object.ownerTable) : "",
+      ownerColumn: isSet(object.ownerColumn) ? globalThis.String(object.ownerColumn) : "",
+    };
+  },
+
+  toJSON(message: SequenceMetadata): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.dataType !== "") {
+      obj.dataType = message.dataType;
+    }
+    if (message.start !== "") {
+      obj.start = message.start;
+    }
+    if (message.minValue !== "") {
+      obj.minValue = message.minValue;
+    }
+    if (message.maxValue !== "") {
+      obj.maxValue = message.maxValue;
+    }
+    if (message.increment !== "") {
+      obj.increment = message.increment;
+    }
+    if (message.cycle === true) {
+      obj.cycle = message.cycle;
+    }
+    if (message.cacheSize !== "") {
+      obj.cacheSize = message.cacheSize;
+    }
+    if (message.ownerTable !== "") {
+      obj.ownerTable = message.ownerTable;
+    }
+    if (message.ownerColumn !== "") {
+      obj.ownerColumn = message.ownerColumn;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<SequenceMetadata>): SequenceMetadata {
+    return SequenceMetadata.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<SequenceMetadata>): SequenceMetadata {
+    const message = createBaseSequenceMetadata();
+    message.name = object.name ?? "";
+    message.dataType = object.dataType ?? "";
+    message.start = object.start ?? "";
+    message.minValue = object.minValue ?? "";
+    message.maxValue = object.maxValue ?? "";
+    message.increment = object.increment ?? "";
+    message.cycle = object.cycle ?? false;
+    message.cacheSize = object.cacheSize ?? "";
+    message.ownerTable = object.ownerTable ?? "";
+    message.ownerColumn = object.ownerColumn ?? "";
+    return message;
+  },
+};
+
+function createBaseEnumTypeMetadata(): EnumTypeMetadata {
+  return { name: "", values: [], comment: "" };
+}
+
+export const EnumTypeMetadata = {
+  encode(message: EnumTypeMetadata, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    for (const v of message.values) {
+      writer.uint32(18).string(v!);
+    }
+    if (message.comment !== "") {
+      writer.uint32(26).string(message.comment);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): EnumTypeMetadata {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEnumTypeMetadata();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.values.push(reader.string());
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.comment = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): EnumTypeMetadata {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      values: globalThis.Array.isArray(object?.values) ? object.values.map((e: any) => globalThis.String(e)) : [],
+      comment: isSet(object.comment) ? globalThis.String(object.comment) : "",
+    };
+  },
+
+  toJSON(message: EnumTypeMetadata): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.values?.length) {
+      obj.values = message.values;
+    }
+    if (message.comment !== "") {
+      obj.comment = message.comment;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<EnumTypeMetadata>): EnumTypeMetadata {
+    return EnumTypeMetadata.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<EnumTypeMetadata>): EnumTypeMetadata {
+    const message = createBaseEnumTypeMetadata();
+    message.name = object.name ?? "";
+    message.values = object.values?.map((e) => e) || [];
+    message.comment = object.comment ?? "";
+    return message;
+  },
+};
+
+function createBaseCompositeTypeMetadata(): CompositeTypeMetadata {
+  return { name: "", attributes: [], comment: "" };
+}
+
+export const CompositeTypeMetadata = {
+  encode(message: CompositeTypeMetadata, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    for (const v of message.attributes) {
+      CompositeTypeAttribute.encode(v!, writer.uint32(18).fork()).ldelim();
+    }
+    if (message.comment !== "") {
+      writer.uint32(26).string(message.comment);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CompositeTypeMetadata {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCompositeTypeMetadata();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.attributes.push(CompositeTypeAttribute.decode(reader, reader.uint32()));
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.comment = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CompositeTypeMetadata {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      attributes: globalThis.Array.isArray(object?.attributes)
+        ? object.attributes.map((e: any) => CompositeTypeAttribute.fromJSON(e))
+        : [],
+      comment: isSet(object.comment) ? globalThis.String(object.comment) : "",
+    };
+  },
+
+  toJSON(message: CompositeTypeMetadata): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.attributes?.length) {
+      obj.attributes = message.attributes.map((e) => CompositeTypeAttribute.toJSON(e));
+    }
+    if (message.comment !== "") {
+      obj.comment = message.comment;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<CompositeTypeMetadata>): CompositeTypeMetadata {
+    return CompositeTypeMetadata.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<CompositeTypeMetadata>): CompositeTypeMetadata {
+    const message = createBaseCompositeTypeMetadata();
+    message.name = object.name ?? "";
+    message.attributes = object.attributes?.map((e) => CompositeTypeAttribute.fromPartial(e)) || [];
+    message.comment = object.comment ?? "";
+    return message;
+  },
+};
+
+function createBaseCompositeTypeAttribute(): CompositeTypeAttribute {
+  return { name: "", type: "" };
+}
+
+export const CompositeTypeAttribute = {
+  encode(message: CompositeTypeAttribute, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.type !== "") {
+      writer.uint32(18).string(message.type);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CompositeTypeAttribute {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCompositeTypeAttribute();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.type = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CompositeTypeAttribute {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      type: isSet(object.type) ? globalThis.String(object.type) : "",
+    };
+  },
+
+  toJSON(message: CompositeTypeAttribute): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.type !== "") {
+      obj.type = message.type;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<CompositeTypeAttribute>): CompositeTypeAttribute {
+    return CompositeTypeAttribute.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<CompositeTypeAttribute>): CompositeTypeAttribute {
+    const message = createBaseCompositeTypeAttribute();
+    message.name = object.name ?? "";
+    message.type = object.type ?? "";
+    return message;
+  },
+};
+
+function createBaseCheckConstraintMetadata(): CheckConstraintMetadata {
+  return { name: "", expression: "" };
+}
+
+export const CheckConstraintMetadata = {
+  encode(message: CheckConstraintMetadata, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.expression !== "") {
+      writer.uint32(18).string(message.expression);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CheckConstraintMetadata {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCheckConstraintMetadata();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.expression = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CheckConstraintMetadata {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      expression: isSet(object.expression) ? globalThis.String(object.expression) : "",
+    };
+  },
+
+  toJSON(message: CheckConstraintMetadata): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.expression !== "") {
+      obj.expression = message.expression;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<CheckConstraintMetadata>): CheckConstraintMetadata {
+    return CheckConstraintMetadata.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<CheckConstraintMetadata>): CheckConstraintMetadata {
+    const message = createBaseCheckConstraintMetadata();
+    message.name = object.name ?? "";
+    message.expression = object.expression ?? "";
+    return message;
+  },
+};
+
 function createBaseTaskMetadata(): TaskMetadata {
   return {
     name: "",
@@ -1,8 +1,10 @@
 /* eslint-disable */
 import Long from "long";
 import _m0 from "protobufjs/minimal";
+import { Duration } from "../google/protobuf/duration";
 import { Empty } from "../google/protobuf/empty";
 import { FieldMask } from "../google/protobuf/field_mask";
+import { Timestamp } from "../google/protobuf/timestamp";
 import { Expr } from "../google/type/expr";
 import { State, stateFromJSON, stateToJSON, stateToNumber } from "./common";
 import { IamPolicy } from "./iam_policy";
@@ -486,6 +488,143 @@ export interface TestWebhookResponse {
   error: string;
 }
 
+export interface ListWebhookDeliveriesRequest {
+  /**
+   * The parent webhook, which owns this collection of deliveries.
+   * Format: projects/{project}/webhooks/{webhook}
+   */
+  parent: string;
+  /**
+   * The maximum number of deliveries to return. The service may return fewer than
+   * this value.
+   * If unspecified, at most 10 deliveries will be returned.
+   */
+  pageSize: number;
+  /**
+   * A page token, received from a previous `ListWebhookDeliveries` call.
+   * Provide this to retrieve the subsequent page.
+   *
+   * When paginating, all other parameters provided to `ListWebhookDeliveries` must match
+   * the call that provided the page token.
+   */
+  pageToken: string;
+}
+
+export interface ListWebhookDeliveriesResponse {
+  /** The deliveries from the specified request, the latest first. */
+  deliveries: WebhookDelivery[];
+  /**
+   * A token, which can be sent as `page_token` to retrieve the next page.
+   * If this field is omitted, there are no subsequent pages.
+   */
+  nextPageToken: string;
+}
+
+export interface RedeliverWebhookDeliveryRequest {
+  /**
+   * The name of the delivery to redeliver.
+   * Format: projects/{project}/webhooks/{webhook}/deliveries/{delivery}
+   */
+  name: string;
+}
+
+/**
+ * WebhookDelivery is the delivery of an event to the webhook.
+ * The failed delivery is retried with exponential backoff.
+ */
+export interface WebhookDelivery {
+  /**
+   * The name of the delivery.
+   * Format: projects/{project}/webhooks/{webhook}/deliveries/{delivery}
+   */
+  name: string;
+  status: WebhookDelivery_Status;
+  /** The activity type of the event. */
+  activityType: Activity_Type;
+  /** The title of the event. */
+  title: string;
+  createTime: Date | undefined;
+  /** The time of the next attempt for the pending delivery. */
+  nextAttemptTime: Date | undefined;
+  /** The attempts of the delivery, the latest last. */
+  attempts: WebhookDelivery_Attempt[];
+}
+
+export enum WebhookDelivery_Status {
+  STATUS_UNSPECIFIED = "STATUS_UNSPECIFIED",
+  /** PENDING - The delivery is waiting for the next attempt. */
+  PENDING = "PENDING",
+  /** SUCCEEDED - The delivery is accepted by the webhook. */
+  SUCCEEDED = "SUCCEEDED",
+  /** FAILED - The delivery failed after all attempts. */
+  FAILED = "FAILED",
+  UNRECOGNIZED = "UNRECOGNIZED",
+}
+
+export function webhookDelivery_StatusFromJSON(object: any): WebhookDelivery_Status {
+  switch (object) {
+    case 0:
+    case "STATUS_UNSPECIFIED":
+      return WebhookDelivery_Status.STATUS_UNSPECIFIED;
+    case 1:
+    case "PENDING":
+      return WebhookDelivery_Status.PENDING;
+    case 2:
+    case "SUCCEEDED":
+      return WebhookDelivery_Status.SUCCEEDED;
+    case 3:
+    case "FAILED":
+      return WebhookDelivery_Status.FAILED;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return WebhookDelivery_Status.UNRECOGNIZED;
+  }
+}
+
+export function webhookDelivery_StatusToJSON(object: WebhookDelivery_Status): string {
+  switch (object) {
+    case WebhookDelivery_Status.STATUS_UNSPECIFIED:
+      return "STATUS_UNSPECIFIED";
+    case WebhookDelivery_Status.PENDING:
+      return "PENDING";
+    case WebhookDelivery_Status.SUCCEEDED:
+      return "SUCCEEDED";
+    case WebhookDelivery_Status.FAILED:
+      return "FAILED";
+    case WebhookDelivery_Status.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export function webhookDelivery_StatusToNumber(object: WebhookDelivery_Status): number {
+  switch (object) {
+    case WebhookDelivery_Status.STATUS_UNSPECIFIED:
+      return 0;
+    case WebhookDelivery_Status.PENDING:
+      return 1;
+    case WebhookDelivery_Status.SUCCEEDED:
+      return 2;
+    case WebhookDelivery_Status.FAILED:
+      return 3;
+    case WebhookDelivery_Status.UNRECOGNIZED:
+    default:
+      return -1;
+  }
+}
+
+export interface WebhookDelivery_Attempt {
+  time: Date | undefined;
+  /** The HTTP status code of the response. It's zero if no response is received. */
+  statusCode: number;
+  latency: Duration | undefined;
+  /** The excerpt of the response body. */
+  responseBody: string;
+  /** The error message if the attempt failed. */
+  error: string;
+}
+
 export interface Webhook {
   /**
    * name is the name of the webhook, generated by the server.
@@ -510,6 +649,22 @@ export interface Webhook {
    * - TYPE_ISSUE_COMMENT_CREAT
    */
   notificationTypes: Activity_Type[];
+  /**
+   * signing_secret is the secret to sign the request of the custom webhook with HMAC-SHA256.
+   * The request carries the unix timestamp in the X-Bytebase-Timestamp header, and the signature
+   * "sha256={hex(HMAC-SHA256(signing_secret, timestamp + "." + body))}" in the X-Bytebase-Signature header.
+   * The secret is never returned.
+   */
+  signingSecret: string;
+  /** has_signing_secret is true if the signing secret is set. */
+  hasSigningSecret: boolean;
+  /**
+   * body_template is the Go template to render the request body of the custom webhook.
+   * It's executed with the default request body object, e.g. {{ json .Title }} or {{ .Issue.Name }},
+   * where the json function encodes the value as a JSON string.
+   * The default request body is sent if it's empty.
+   */
+  bodyTemplate: string;
 }
 
 export enum Webhook_Type {
@@ -2775,14 +2930,427 @@ export const TestWebhookRequest = {
             break;
           }
 
-          message.project = reader.string();
+          message.project = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.webhook = Webhook.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TestWebhookRequest {
+    return {
+      project: isSet(object.project) ? globalThis.String(object.project) : "",
+      webhook: isSet(object.webhook) ? Webhook.fromJSON(object.webhook) : undefined,
+    };
+  },
+
+  toJSON(message: TestWebhookRequest): unknown {
+    const obj: any = {};
+    if (message.project !== "") {
+      obj.project = message.project;
+    }
+    if (message.webhook !== undefined) {
+      obj.webhook = Webhook.toJSON(message.webhook);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<TestWebhookRequest>): TestWebhookRequest {
+    return TestWebhookRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<TestWebhookRequest>): TestWebhookRequest {
+    const message = createBaseTestWebhookRequest();
+    message.project = object.project ?? "";
+    message.webhook = (object.webhook !== undefined && object.webhook !== null)
+      ? Webhook.fromPartial(object.webhook)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseTestWebhookResponse(): TestWebhookResponse {
+  return { error: "" };
+}
+
+export const TestWebhookResponse = {
+  encode(message: TestWebhookResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.error !== "") {
+      writer.uint32(10).string(message.error);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): TestWebhookResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTestWebhookResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.error = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TestWebhookResponse {
+    return { error: isSet(object.error) ? globalThis.String(object.error) : "" };
+  },
+
+  toJSON(message: TestWebhookResponse): unknown {
+    const obj: any = {};
+    if (message.error !== "") {
+      obj.error = message.error;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
+    return TestWebhookResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
+    const message = createBaseTestWebhookResponse();
+    message.error = object.error ?? "";
+    return message;
+  },
+};
+
+function createBaseListWebhookDeliveriesRequest(): ListWebhookDeliveriesRequest {
+  return { parent: "", pageSize: 0, pageToken: "" };
+}
+
+export const ListWebhookDeliveriesRequest = {
+  encode(message: ListWebhookDeliveriesRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.parent !== "") {
+      writer.uint32(10).string(message.parent);
+    }
+    if (message.pageSize !== 0) {
+      writer.uint32(16).int32(message.pageSize);
+    }
+    if (message.pageToken !== "") {
+      writer.uint32(26).string(message.pageToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListWebhookDeliveriesRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListWebhookDeliveriesRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.parent = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.pageSize = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.pageToken = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListWebhookDeliveriesRequest {
+    return {
+      parent: isSet(object.parent) ? globalThis.String(object.parent) : "",
+      pageSize: isSet(object.pageSize) ? globalThis.Number(object.pageSize) : 0,
+      pageToken: isSet(object.pageToken) ? globalThis.String(object.pageToken) : "",
+    };
+  },
+
+  toJSON(message: ListWebhookDeliveriesRequest): unknown {
+    const obj: any = {};
+    if (message.parent !== "") {
+      obj.parent = message.parent;
+    }
+    if (message.pageSize !== 0) {
+      obj.pageSize = Math.round(message.pageSize);
+    }
+    if (message.pageToken !== "") {
+      obj.pageToken = message.pageToken;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListWebhookDeliveriesRequest>): ListWebhookDeliveriesRequest {
+    return ListWebhookDeliveriesRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ListWebhookDeliveriesRequest>): ListWebhookDeliveriesRequest {
+    const message = createBaseListWebhookDeliveriesRequest();
+    message.parent = object.parent ?? "";
+    message.pageSize = object.pageSize ?? 0;
+    message.pageToken = object.pageToken ?? "";
+    return message;
+  },
+};
+
+function createBaseListWebhookDeliveriesResponse(): ListWebhookDeliveriesResponse {
+  return { deliveries: [], nextPageToken: "" };
+}
+
+export const ListWebhookDeliveriesResponse = {
+  encode(message: ListWebhookDeliveriesResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.deliveries) {
+      WebhookDelivery.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    if (message.nextPageToken !== "") {
+      writer.uint32(18).string(message.nextPageToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListWebhookDeliveriesResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListWebhookDeliveriesResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.deliveries.push(WebhookDelivery.decode(reader, reader.uint32()));
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.nextPageToken = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListWebhookDeliveriesResponse {
+    return {
+      deliveries: globalThis.Array.isArray(object?.deliveries)
+        ? object.deliveries.map((e: any) => WebhookDelivery.fromJSON(e))
+        : [],
+      nextPageToken: isSet(object.nextPageToken) ? globalThis.String(object.nextPageToken) : "",
+    };
+  },
+
+  toJSON(message: ListWebhookDeliveriesResponse): unknown {
+    const obj: any = {};
+    if (message.deliveries?.length) {
+      obj.deliveries = message.deliveries.map((e) => WebhookDelivery.toJSON(e));
+    }
+    if (message.nextPageToken !== "") {
+      obj.nextPageToken = message.nextPageToken;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListWebhookDeliveriesResponse>): ListWebhookDeliveriesResponse {
+    return ListWebhookDeliveriesResponse.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<ListWebhookDeliveriesResponse>): ListWebhookDeliveriesResponse {
+    const message = createBaseListWebhookDeliveriesResponse();
+    message.deliveries = object.deliveries?.map((e) => WebhookDelivery.fromPartial(e)) || [];
+    message.nextPageToken = object.nextPageToken ?? "";
+    return message;
+  },
+};
+
+function createBaseRedeliverWebhookDeliveryRequest(): RedeliverWebhookDeliveryRequest {
+  return { name: "" };
+}
+
+export const RedeliverWebhookDeliveryRequest = {
+  encode(message: RedeliverWebhookDeliveryRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RedeliverWebhookDeliveryRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRedeliverWebhookDeliveryRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RedeliverWebhookDeliveryRequest {
+    return { name: isSet(object.name) ? globalThis.String(object.name) : "" };
+  },
+
+  toJSON(message: RedeliverWebhookDeliveryRequest): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<RedeliverWebhookDeliveryRequest>): RedeliverWebhookDeliveryRequest {
+    return RedeliverWebhookDeliveryRequest.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<RedeliverWebhookDeliveryRequest>): RedeliverWebhookDeliveryRequest {
+    const message = createBaseRedeliverWebhookDeliveryRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseWebhookDelivery(): WebhookDelivery {
+  return {
+    name: "",
+    status: WebhookDelivery_Status.STATUS_UNSPECIFIED,
+    activityType: Activity_Type.TYPE_UNSPECIFIED,
+    title: "",
+    createTime: undefined,
+    nextAttemptTime: undefined,
+    attempts: [],
+  };
+}
+
+export const WebhookDelivery = {
+  encode(message: WebhookDelivery, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.status !== WebhookDelivery_Status.STATUS_UNSPECIFIED) {
+      writer.uint32(16).int32(webhookDelivery_StatusToNumber(message.status));
+    }
+    if (message.activityType !== Activity_Type.TYPE_UNSPECIFIED) {
+      writer.uint32(24).int32(activity_TypeToNumber(message.activityType));
+    }
+    if (message.title !== "") {
+      writer.uint32(34).string(message.title);
+    }
+    if (message.createTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.createTime), writer.uint32(42).fork()).ldelim();
+    }
+    if (message.nextAttemptTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.nextAttemptTime), writer.uint32(50).fork()).ldelim();
+    }
+    for (const v of message.attempts) {
+      WebhookDelivery_Attempt.encode(v!, writer.uint32(58).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WebhookDelivery {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWebhookDelivery();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.status = webhookDelivery_StatusFromJSON(reader.int32());
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.activityType = activity_TypeFromJSON(reader.int32());
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.createTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
           continue;
-        case 2:
-          if (tag !== 18) {
+        case 6:
+          if (tag !== 50) {
             break;
           }
 
-          message.webhook = Webhook.decode(reader, reader.uint32());
+          message.nextAttemptTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.attempts.push(WebhookDelivery_Attempt.decode(reader, reader.uint32()));
           continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
@@ -2793,53 +3361,94 @@ export const TestWebhookRequest = {
     return message;
   },
 
-  fromJSON(object: any): TestWebhookRequest {
+  fromJSON(object: any): WebhookDelivery {
     return {
-      project: isSet(object.project) ? globalThis.String(object.project) : "",
-      webhook: isSet(object.webhook) ? Webhook.fromJSON(object.webhook) : undefined,
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      status: isSet(object.status)
+        ? webhookDelivery_StatusFromJSON(object.status)
+        : WebhookDelivery_Status.STATUS_UNSPECIFIED,
+      activityType: isSet(object.activityType)
+        ? activity_TypeFromJSON(object.activityType)
+        : Activity_Type.TYPE_UNSPECIFIED,
+      title: isSet(object.title) ? globalThis.String(object.title) : "",
+      createTime: isSet(object.createTime) ? fromJsonTimestamp(object.createTime) : undefined,
+      nextAttemptTime: isSet(object.nextAttemptTime) ? fromJsonTimestamp(object.nextAttemptTime) : undefined,
+      attempts: globalThis.Array.isArray(object?.attempts)
+        ? object.attempts.map((e: any) => WebhookDelivery_Attempt.fromJSON(e))
+        : [],
     };
   },
 
-  toJSON(message: TestWebhookRequest): unknown {
+  toJSON(message: WebhookDelivery): unknown {
     const obj: any = {};
-    if (message.project !== "") {
-      obj.project = message.project;
+    if (message.name !== "") {
+      obj.name = message.name;
     }
-    if (message.webhook !== undefined) {
-      obj.webhook = Webhook.toJSON(message.webhook);
+    if (message.status !== WebhookDelivery_Status.STATUS_UNSPECIFIED) {
+      obj.status = webhookDelivery_StatusToJSON(message.status);
+    }
+    if (message.activityType !== Activity_Type.TYPE_UNSPECIFIED) {
+      obj.activityType = activity_TypeToJSON(message.activityType);
+    }
+    if (message.title !== "") {
+      obj.title = message.title;
+    }
+    if (message.createTime !== undefined) {
+      obj.createTime = message.createTime.toISOString();
+    }
+    if (message.nextAttemptTime !== undefined) {
+      obj.nextAttemptTime = message.nextAttemptTime.toISOString();
+    }
+    if (message.attempts?.length) {
+      obj.attempts = message.attempts.map((e) => WebhookDelivery_Attempt.toJSON(e));
     }
     return obj;
   },
 
-  create(base?: DeepPartial<TestWebhookRequest>): TestWebhookRequest {
-    return TestWebhookRequest.fromPartial(base ?? {});
+  create(base?: DeepPartial<WebhookDelivery>): WebhookDelivery {
+    return WebhookDelivery.fromPartial(base ?? {});
   },
-  fromPartial(object: DeepPartial<TestWebhookRequest>): TestWebhookRequest {
-    const message = createBaseTestWebhookRequest();
-    message.project = object.project ?? "";
-    message.webhook = (object.webhook !== undefined && object.webhook !== null)
-      ? Webhook.fromPartial(object.webhook)
-      : undefined;
+  fromPartial(object: DeepPartial<WebhookDelivery>): WebhookDelivery {
+    const message = createBaseWebhookDelivery();
+    message.name = object.name ?? "";
+    message.status = object.status ?? WebhookDelivery_Status.STATUS_UNSPECIFIED;
+    message.activityType = object.activityType ?? Activity_Type.TYPE_UNSPECIFIED;
+    message.title = object.title ?? "";
+    message.createTime = object.createTime ?? undefined;
+    message.nextAttemptTime = object.nextAttemptTime ?? undefined;
+    message.attempts = object.attempts?.map((e) => WebhookDelivery_Attempt.fromPartial(e)) || [];
     return message;
   },
 };
 
-function createBaseTestWebhookResponse(): TestWebhookResponse {
-  return { error: "" };
+function createBaseWebhookDelivery_Attempt(): WebhookDelivery_Attempt {
+  return { time: undefined, statusCode: 0, latency: undefined, responseBody: "", error: "" };
 }
 
-export const TestWebhookResponse = {
-  encode(message: TestWebhookResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+export const WebhookDelivery_Attempt = {
+  encode(message: WebhookDelivery_Attempt, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.time !== undefined) {
+      Timestamp.encode(toTimestamp(message.time), writer.uint32(10).fork()).ldelim();
+    }
+    if (message.statusCode !== 0) {
+      writer.uint32(16).int32(message.statusCode);
+    }
+    if (message.latency !== undefined) {
+      Duration.encode(message.latency, writer.uint32(26).fork()).ldelim();
+    }
+    if (message.responseBody !== "") {
+      writer.uint32(34).string(message.responseBody);
+    }
     if (message.error !== "") {
-      writer.uint32(10).string(message.error);
+      writer.uint32(42).string(message.error);
     }
     return writer;
   },
 
-  decode(input: _m0.Reader | Uint8Array, length?: number): TestWebhookResponse {
+  decode(input: _m0.Reader | Uint8Array, length?: number): WebhookDelivery_Attempt {
     const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseTestWebhookResponse();
+    const message = createBaseWebhookDelivery_Attempt();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2848,6 +3457,34 @@ export const TestWebhookResponse = {
             break;
           }
 
+          message.time = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.statusCode = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.latency = Duration.decode(reader, reader.uint32());
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.responseBody = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
           message.error = reader.string();
           continue;
       }
@@ -2859,30 +3496,63 @@ export const TestWebhookResponse = {
     return message;
   },
 
-  fromJSON(object: any): TestWebhookResponse {
-    return { error: isSet(object.error) ? globalThis.String(object.error) : "" };
+  fromJSON(object: any): WebhookDelivery_Attempt {
+    return {
+      time: isSet(object.time) ? fromJsonTimestamp(object.time) : undefined,
+      statusCode: isSet(object.statusCode) ? globalThis.Number(object.statusCode) : 0,
+      latency: isSet(object.latency) ? Duration.fromJSON(object.latency) : undefined,
+      responseBody: isSet(object.responseBody) ? globalThis.String(object.responseBody) : "",
+      error: isSet(object.error) ? globalThis.String(object.error) : "",
+    };
   },
 
-  toJSON(message: TestWebhookResponse): unknown {
+  toJSON(message: WebhookDelivery_Attempt): unknown {
     const obj: any = {};
+    if (message.time !== undefined) {
+      obj.time = message.time.toISOString();
+    }
+    if (message.statusCode !== 0) {
+      obj.statusCode = Math.round(message.statusCode);
+    }
+    if (message.latency !== undefined) {
+      obj.latency = Duration.toJSON(message.latency);
+    }
+    if (message.responseBody !== "") {
+      obj.responseBody = message.responseBody;
+    }
     if (message.error !== "") {
       obj.error = message.error;
     }
     return obj;
   },
 
-  create(base?: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
-    return TestWebhookResponse.fromPartial(base ?? {});
+  create(base?: DeepPartial<WebhookDelivery_Attempt>): WebhookDelivery_Attempt {
+    return WebhookDelivery_Attempt.fromPartial(base ?? {});
   },
-  fromPartial(object: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
-    const message = createBaseTestWebhookResponse();
+  fromPartial(object: DeepPartial<WebhookDelivery_Attempt>): WebhookDelivery_Attempt {
+    const message = createBaseWebhookDelivery_Attempt();
+    message.time = object.time ?? undefined;
+    message.statusCode = object.statusCode ?? 0;
+    message.latency = (object.latency !== undefined && object.latency !== null)
+      ? Duration.fromPartial(object.latency)
+      : undefined;
+    message.responseBody = object.responseBody ?? "";
     message.error = object.error ?? "";
     return message;
   },
 };
 
 function createBaseWebhook(): Webhook {
-  return { name: "", type: Webhook_Type.TYPE_UNSPECIFIED, title: "", url: "", notificationTypes: [] };
+  return {
+    name: "",
+    type: Webhook_Type.TYPE_UNSPECIFIED,
+    title: "",
+    url: "",
+    notificationTypes: [],
+    signingSecret: "",
+    hasSigningSecret: false,
+    bodyTemplate: "",
+  };
 }
 
 export const Webhook = {
@@ -2904,6 +3574,15 @@ export const Webhook = {
       writer.int32(activity_TypeToNumber(v));
     }
     writer.ldelim();
+    if (message.signingSecret !== "") {
+      writer.uint32(50).string(message.signingSecret);
+    }
+    if (message.hasSigningSecret === true) {
+      writer.uint32(56).bool(message.hasSigningSecret);
+    }
+    if (message.bodyTemplate !== "") {
+      writer.uint32(66).string(message.bodyTemplate);
+    }
     return writer;
   },
 
@@ -2959,6 +3638,27 @@ export const Webhook = {
           }
 
           break;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.signingSecret = reader.string();
+          continue;
+        case 7:
+          if (tag !== 56) {
+            break;
+          }
+
+          message.hasSigningSecret = reader.bool();
+          continue;
+        case 8:
+          if (tag !== 66) {
+            break;
+          }
+
+          message.bodyTemplate = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -2977,6 +3677,9 @@ export const Webhook = {
       notificationTypes: globalThis.Array.isArray(object?.notificationTypes)
         ? object.notificationTypes.map((e: any) => activity_TypeFromJSON(e))
         : [],
+      signingSecret: isSet(object.signingSecret) ? globalThis.String(object.signingSecret) : "",
+      hasSigningSecret: isSet(object.hasSigningSecret) ? globalThis.Boolean(object.hasSigningSecret) : false,
+      bodyTemplate: isSet(object.bodyTemplate) ? globalThis.String(object.bodyTemplate) : "",
     };
   },
 
@@ -2997,6 +3700,15 @@ export const Webhook = {
     if (message.notificationTypes?.length) {
       obj.notificationTypes = message.notificationTypes.map((e) => activity_TypeToJSON(e));
     }
+    if (message.signingSecret !== "") {
+      obj.signingSecret = message.signingSecret;
+    }
+    if (message.hasSigningSecret === true) {
+      obj.hasSigningSecret = message.hasSigningSecret;
+    }
+    if (message.bodyTemplate !== "") {
+      obj.bodyTemplate = message.bodyTemplate;
+    }
     return obj;
   },
 
@@ -3010,6 +3722,9 @@ export const Webhook = {
     message.title = object.title ?? "";
     message.url = object.url ?? "";
     message.notificationTypes = object.notificationTypes?.map((e) => e) || [];
+    message.signingSecret = object.signingSecret ?? "";
+    message.hasSigningSecret = object.hasSigningSecret ?? false;
+    message.bodyTemplate = object.bodyTemplate ?? "";
     return message;
   },
 };
@@ -6065,6 +6780,146 @@ export const ProjectServiceDefinition = {
         },
       },
     },
+    listWebhookDeliveries: {
+      name: "ListWebhookDeliveries",
+      requestType: ListWebhookDeliveriesRequest,
+      requestStream: false,
+      responseType: ListWebhookDeliveriesResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([6, 112, 97, 114, 101, 110, 116])],
+          578365826: [
+            new Uint8Array([
+              47,
+              18,
+              45,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              119,
+              101,
+              98,
+              104,
+              111,
+              111,
+              107,
+              115,
+              47,
+              42,
+              125,
+              47,
+              100,
+              101,
+              108,
+              105,
+              118,
+              101,
+              114,
+              105,
+              101,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    redeliverWebhookDelivery: {
+      name: "RedeliverWebhookDelivery",
+      requestType: RedeliverWebhookDeliveryRequest,
+      requestStream: false,
+      responseType: WebhookDelivery,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([
+              60,
+              58,
+              1,
+              42,
+              34,
+              55,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              119,
+              101,
+              98,
+              104,
+              111,
+              111,
+              107,
+              115,
+              47,
+              42,
+              47,
+              100,
+              101,
+              108,
+              105,
+              118,
+              101,
+              114,
+              105,
+              101,
+              115,
+              47,
+              42,
+              125,
+              58,
+              114,
+              101,
+              100,
+              101,
+              108,
+              105,
+              118,
+              101,
+              114,
+            ]),
+          ],
+        },
+      },
+    },
     listDatabaseGroups: {
       name: "ListDatabaseGroups",
       requestType: ListDatabaseGroupsRequest,
@@ -7045,6 +7900,32 @@ export type DeepPartial<T> = T extends Builtin ? T
   : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
   : Partial<T>;
 
+function toTimestamp(date: Date): Timestamp {
+  const seconds = numberToLong(date.getTime() / 1_000);
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = (t.seconds.toNumber() || 0) * 1_000;
+  millis += (t.nanos || 0) / 1_000_000;
+  return new globalThis.Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof globalThis.Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new globalThis.Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
+function numberToLong(number: number) {
+  return Long.fromNumber(number);
+}
+
 if (_m0.util.Long !== Long) {
   _m0.util.Long = Long as any;
   _m0.configure();
@@ -154,6 +154,8 @@ export interface Advice {
   column: number;
   /** The advice detail. */
   detail: string;
+  /** The optional suggested fix of the advice. */
+  fix: Advice_Fix | undefined;
 }
 
 export enum Advice_Status {
@@ -218,6 +220,25 @@ export function advice_StatusToNumber(object: Advice_Status): number {
   }
 }
 
+export interface Advice_Position {
+  /** The line number, one-based. */
+  line: number;
+  /** The column number in characters, zero-based. */
+  column: number;
+}
+
+/** Fix is the suggested text edit to fix the advice. */
+export interface Advice_Fix {
+  /** The description of the fix. */
+  title: string;
+  /** The start position of the text to replace. */
+  start: Advice_Position | undefined;
+  /** The end position of the text to replace. */
+  end: Advice_Position | undefined;
+  /** The text replacing the range. */
+  newText: string;
+}
+
 export interface ExportRequest {
   /**
    * The name is the instance name to execute the query against.
@@ -1559,7 +1580,16 @@ export const RowValue = {
 };
 
 function createBaseAdvice(): Advice {
-  return { status: Advice_Status.STATUS_UNSPECIFIED, code: 0, title: "", content: "", line: 0, column: 0, detail: "" };
+  return {
+    status: Advice_Status.STATUS_UNSPECIFIED,
+    code: 0,
+    title: "",
+    content: "",
+    line: 0,
+    column: 0,
+    detail: "",
+    fix: undefined,
+  };
 }
 
 export const Advice = {
@@ -1585,6 +1615,9 @@ export const Advice = {
     if (message.detail !== "") {
       writer.uint32(58).string(message.detail);
     }
+    if (message.fix !== undefined) {
+      Advice_Fix.encode(message.fix, writer.uint32(66).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -1644,6 +1677,13 @@ export const Advice = {
 
           message.detail = reader.string();
           continue;
+        case 8:
+          if (tag !== 66) {
+            break;
+          }
+
+          message.fix = Advice_Fix.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1662,6 +1702,7 @@ export const Advice = {
       line: isSet(object.line) ? globalThis.Number(object.line) : 0,
       column: isSet(object.column) ? globalThis.Number(object.column) : 0,
       detail: isSet(object.detail) ? globalThis.String(object.detail) : "",
+      fix: isSet(object.fix) ? Advice_Fix.fromJSON(object.fix) : undefined,
     };
   },
 
@@ -1688,6 +1729,9 @@ export const Advice = {
     if (message.detail !== "") {
       obj.detail = message.detail;
     }
+    if (message.fix !== undefined) {
+      obj.fix = Advice_Fix.toJSON(message.fix);
+    }
     return obj;
   },
 
@@ -1703,6 +1747,189 @@ export const Advice = {
     message.line = object.line ?? 0;
     message.column = object.column ?? 0;
     message.detail = object.detail ?? "";
+    message.fix = (object.fix !== undefined && object.fix !== null) ? Advice_Fix.fromPartial(object.fix) : undefined;
+    return message;
+  },
+};
+
+function createBaseAdvice_Position(): Advice_Position {
+  return { line: 0, column: 0 };
+}
+
+export const Advice_Position = {
+  encode(message: Advice_Position, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.line !== 0) {
+      writer.uint32(8).int32(message.line);
+    }
+    if (message.column !== 0) {
+      writer.uint32(16).int32(message.column);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Advice_Position {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAdvice_Position();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.line = reader.int32();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.column = reader.int32();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Advice_Position {
+    return {
+      line: isSet(object.line) ? globalThis.Number(object.line) : 0,
+      column: isSet(object.column) ? globalThis.Number(object.column) : 0,
+    };
+  },
+
+  toJSON(message: Advice_Position): unknown {
+    const obj: any = {};
+    if (message.line !== 0) {
+      obj.line = Math.round(message.line);
+    }
+    if (message.column !== 0) {
+      obj.column = Math.round(message.column);
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<Advice_Position>): Advice_Position {
+    return Advice_Position.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<Advice_Position>): Advice_Position {
+    const message = createBaseAdvice_Position();
+    message.line = object.line ?? 0;
+    message.column = object.column ?? 0;
+    return message;
+  },
+};
+
+function createBaseAdvice_Fix(): Advice_Fix {
+  return { title: "", start: undefined, end: undefined, newText: "" };
+}
+
+export const Advice_Fix = {
+  encode(message: Advice_Fix, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.title !== "") {
+      writer.uint32(10).string(message.title);
+    }
+    if (message.start !== undefined) {
+      Advice_Position.encode(message.start, writer.uint32(18).fork()).ldelim();
+    }
+    if (message.end !== undefined) {
+      Advice_Position.encode(message.end, writer.uint32(26).fork()).ldelim();
+    }
+    if (message.newText !== "") {
+      writer.uint32(34).string(message.newText);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Advice_Fix {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAdvice_Fix();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.start = Advice_Position.decode(reader, reader.uint32());
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.end = Advice_Position.decode(reader, reader.uint32());
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.newText = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Advice_Fix {
+    return {
+      title: isSet(object.title) ? globalThis.String(object.title) : "",
+      start: isSet(object.start) ? Advice_Position.fromJSON(object.start) : undefined,
+      end: isSet(object.end) ? Advice_Position.fromJSON(object.end) : undefined,
+      newText: isSet(object.newText) ? globalThis.String(object.newText) : "",
+    };
+  },
+
+  toJSON(message: Advice_Fix): unknown {
+    const obj: any = {};
+    if (message.title !== "") {
+      obj.title = message.title;
+    }
+    if (message.start !== undefined) {
+      obj.start = Advice_Position.toJSON(message.start);
+    }
+    if (message.end !== undefined) {
+      obj.end = Advice_Position.toJSON(message.end);
+    }
+    if (message.newText !== "") {
+      obj.newText = message.newText;
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<Advice_Fix>): Advice_Fix {
+    return Advice_Fix.fromPartial(base ?? {});
+  },
+  fromPartial(object: DeepPartial<Advice_Fix>): Advice_Fix {
+    const message = createBaseAdvice_Fix();
+    message.title = object.title ?? "";
+    message.start = (object.start !== undefined && object.start !== null)
+      ? Advice_Position.fromPartial(object.start)
+      : undefined;
+    message.end = (object.end !== undefined && object.end !== null)
+      ? Advice_Position.fromPartial(object.end)
+      : undefined;
+    message.newText = object.newText ?? "";
     return message;
   },
 };
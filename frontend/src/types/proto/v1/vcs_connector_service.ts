@@ -141,6 +141,76 @@ export interface VCSConnector {
    * Format: projects/{project}/databaseGroups/{databaseGroup}
    */
   databaseGroup: string;
+  /**
+   * The template of the file path relative to the base directory. Optional.
+   * Supported placeholders are {{ENV_ID}}, {{DB_NAME}}, {{VERSION}}, {{TYPE}} and {{DESC}}, and {{VERSION}} is required in the MIGRATION mode.
+   * For example: {{ENV_ID}}/{{DB_NAME}}/{{VERSION}}_{{TYPE}}_{{DESC}}.sql.
+   * If the template contains {{ENV_ID}} or {{DB_NAME}}, each file only applies to the databases it addresses,
+   * and it cannot be used together with the database group.
+   * If empty, only the files directly under the base directory are observed and applied to all target databases.
+   */
+  filePathTemplate: string;
+  mode: VCSConnector_Mode;
+}
+
+export enum VCSConnector_Mode {
+  /** MODE_UNSPECIFIED - The default mode, which is the same as MIGRATION. */
+  MODE_UNSPECIFIED = "MODE_UNSPECIFIED",
+  /** MIGRATION - The files are versioned migration scripts. */
+  MIGRATION = "MIGRATION",
+  /**
+   * SDL - The files hold the desired full schema of the databases.
+   * On pull request, the changes are generated by diffing the files against the latest synced schema and commented back to the pull request.
+   * The file path template cannot contain {{TYPE}}, and {{VERSION}} is optional. The database group is not supported.
+   */
+  SDL = "SDL",
+  UNRECOGNIZED = "UNRECOGNIZED",
+}
+
+export function vCSConnector_ModeFromJSON(object: any): VCSConnector_Mode {
+  switch (object) {
+    case 0:
+    case "MODE_UNSPECIFIED":
+      return VCSConnector_Mode.MODE_UNSPECIFIED;
+    case 1:
+    case "MIGRATION":
+      return VCSConnector_Mode.MIGRATION;
+    case 2:
+    case "SDL":
+      return VCSConnector_Mode.SDL;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return VCSConnector_Mode.UNRECOGNIZED;
+  }
+}
+
+export function vCSConnector_ModeToJSON(object: VCSConnector_Mode): string {
+  switch (object) {
+    case VCSConnector_Mode.MODE_UNSPECIFIED:
+      return "MODE_UNSPECIFIED";
+    case VCSConnector_Mode.MIGRATION:
+      return "MIGRATION";
+    case VCSConnector_Mode.SDL:
+      return "SDL";
+    case VCSConnector_Mode.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export function vCSConnector_ModeToNumber(object: VCSConnector_Mode): number {
+  switch (object) {
+    case VCSConnector_Mode.MODE_UNSPECIFIED:
+      return 0;
+    case VCSConnector_Mode.MIGRATION:
+      return 1;
+    case VCSConnector_Mode.SDL:
+      return 2;
+    case VCSConnector_Mode.UNRECOGNIZED:
+    default:
+      return -1;
+  }
 }
 
 function createBaseCreateVCSConnectorRequest(): CreateVCSConnectorRequest {
@@ -604,6 +674,8 @@ function createBaseVCSConnector(): VCSConnector {
     fullPath: "",
     webUrl: "",
     databaseGroup: "",
+    filePathTemplate: "",
+    mode: VCSConnector_Mode.MODE_UNSPECIFIED,
   };
 }
 
@@ -648,6 +720,12 @@ export const VCSConnector = {
     if (message.databaseGroup !== "") {
       writer.uint32(114).string(message.databaseGroup);
     }
+    if (message.filePathTemplate !== "") {
+      writer.uint32(122).string(message.filePathTemplate);
+    }
+    if (message.mode !== VCSConnector_Mode.MODE_UNSPECIFIED) {
+      writer.uint32(128).int32(vCSConnector_ModeToNumber(message.mode));
+    }
     return writer;
   },
 
@@ -749,6 +827,20 @@ export const VCSConnector = {
 
           message.databaseGroup = reader.string();
           continue;
+        case 15:
+          if (tag !== 122) {
+            break;
+          }
+
+          message.filePathTemplate = reader.string();
+          continue;
+        case 16:
+          if (tag !== 128) {
+            break;
+          }
+
+          message.mode = vCSConnector_ModeFromJSON(reader.int32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -773,6 +865,8 @@ export const VCSConnector = {
       fullPath: isSet(object.fullPath) ? globalThis.String(object.fullPath) : "",
       webUrl: isSet(object.webUrl) ? globalThis.String(object.webUrl) : "",
       databaseGroup: isSet(object.databaseGroup) ? globalThis.String(object.databaseGroup) : "",
+      filePathTemplate: isSet(object.filePathTemplate) ? globalThis.String(object.filePathTemplate) : "",
+      mode: isSet(object.mode) ? vCSConnector_ModeFromJSON(object.mode) : VCSConnector_Mode.MODE_UNSPECIFIED,
     };
   },
 
@@ -817,6 +911,12 @@ export const VCSConnector = {
     if (message.databaseGroup !== "") {
       obj.databaseGroup = message.databaseGroup;
     }
+    if (message.filePathTemplate !== "") {
+      obj.filePathTemplate = message.filePathTemplate;
+    }
+    if (message.mode !== VCSConnector_Mode.MODE_UNSPECIFIED) {
+      obj.mode = vCSConnector_ModeToJSON(message.mode);
+    }
     return obj;
   },
 
@@ -838,6 +938,8 @@ export const VCSConnector = {
     message.fullPath = object.fullPath ?? "";
     message.webUrl = object.webUrl ?? "";
     message.databaseGroup = object.databaseGroup ?? "";
+    message.filePathTemplate = object.filePathTemplate ?? "";
+    message.mode = object.mode ?? VCSConnector_Mode.MODE_UNSPECIFIED;
     return message;
   },
 };
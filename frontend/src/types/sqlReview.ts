@@ -61,6 +61,23 @@ export interface TemplatePayload {
   value?: string;
 }
 
+// CustomCELRule is the user-defined rule expressed in CEL.
+// Used by both the frontend and the backend.
+export interface CustomCELRule {
+  name: string;
+  title: string;
+  expression: string;
+  message: string;
+}
+
+// CustomCELRuleListPayload is the custom CEL rule list type payload configuration options and default value.
+// Used by the frontend.
+export interface CustomCELRuleListPayload {
+  type: "CUSTOM_CEL_RULE_LIST";
+  default: CustomCELRule[];
+  value?: CustomCELRule[];
+}
+
 interface IndividualConfigPayload {
   [key: string]: {
     default: any;
@@ -82,7 +99,8 @@ export interface RuleConfigComponent {
     | NumberPayload
     | TemplatePayload
     | StringArrayPayload
-    | BooleanPayload;
+    | BooleanPayload
+    | CustomCELRuleListPayload;
 }
 
 // The naming format rule payload.
@@ -123,6 +141,12 @@ interface CasePayload {
   upper: boolean;
 }
 
+// The custom CEL rule payload.
+// Used by the backend.
+interface CustomCELPayload {
+  rules: CustomCELRule[];
+}
+
 // The SchemaPolicyRule stores the rule configuration by users.
 // Used by the backend
 export interface SchemaPolicyRule {
@@ -135,7 +159,8 @@ export interface SchemaPolicyRule {
     | CommentFormatPayload
     | NumberValuePayload
     | StringValuePayload
-    | CasePayload;
+    | CasePayload
+    | CustomCELPayload;
   comment: string;
 }
 
@@ -397,6 +422,9 @@ export const convertPolicyRuleToRuleTemplate = (
   const stringArrayComponent = componentList.find(
     (c) => c.payload.type === "STRING_ARRAY"
   );
+  const customCELRuleListComponent = componentList.find(
+    (c) => c.payload.type === "CUSTOM_CEL_RULE_LIST"
+  );
 
   switch (ruleTemplate.type) {
     case "statement.query.minimum-plan-level":
@@ -417,6 +445,25 @@ export const convertPolicyRuleToRuleTemplate = (
         ],
         individualConfigList,
       };
+    // Following rules require CUSTOM_CEL_RULE_LIST component.
+    case "statement.custom-cel":
+      if (!customCELRuleListComponent) {
+        throw new Error(`Invalid rule ${ruleTemplate.type}`);
+      }
+
+      return {
+        ...res,
+        componentList: [
+          {
+            ...customCELRuleListComponent,
+            payload: {
+              ...customCELRuleListComponent.payload,
+              value: (payload as CustomCELPayload).rules,
+            } as CustomCELRuleListPayload,
+          },
+        ],
+        individualConfigList,
+      };
     // Following rules require STRING component.
     case "table.drop-naming-convention":
       if (!stringComponent) {
@@ -658,6 +705,9 @@ const mergeIndividualConfigAsRule = (
   const stringArrayPayload = componentList.find(
     (c) => c.payload.type === "STRING_ARRAY"
   )?.payload as StringArrayPayload | undefined;
+  const customCELRuleListPayload = componentList.find(
+    (c) => c.payload.type === "CUSTOM_CEL_RULE_LIST"
+  )?.payload as CustomCELRuleListPayload | undefined;
 
   switch (template.type) {
     case "statement.query.minimum-plan-level":
@@ -671,6 +721,19 @@ const mergeIndividualConfigAsRule = (
           string: stringPayload.value ?? stringPayload.default,
         },
       };
+    // Following rules require CUSTOM_CEL_RULE_LIST component.
+    case "statement.custom-cel":
+      if (!customCELRuleListPayload) {
+        throw new Error(`Invalid rule ${template.type}`);
+      }
+
+      return {
+        ...base,
+        payload: {
+          rules:
+            customCELRuleListPayload.value ?? customCELRuleListPayload.default,
+        },
+      };
     // Following rules require STRING component.
     case "table.drop-naming-convention":
       if (!stringPayload) {
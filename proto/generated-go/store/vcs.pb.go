@@ -20,6 +20,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type VCSConnector_Mode int32
+
+const (
+	// The default mode, which is the same as MIGRATION.
+	VCSConnector_MODE_UNSPECIFIED VCSConnector_Mode = 0
+	// The files are versioned migration scripts.
+	VCSConnector_MIGRATION VCSConnector_Mode = 1
+	// The files hold the desired full schema of the databases, and the changes are generated by diffing them against the database schema.
+	VCSConnector_SDL VCSConnector_Mode = 2
+)
+
+// Enum value maps for VCSConnector_Mode.
+var (
+	VCSConnector_Mode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "MIGRATION",
+		2: "SDL",
+	}
+	VCSConnector_Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED": 0,
+		"MIGRATION":        1,
+		"SDL":              2,
+	}
+)
+
+func (x VCSConnector_Mode) Enum() *VCSConnector_Mode {
+	p := new(VCSConnector_Mode)
+	*p = x
+	return p
+}
+
+func (x VCSConnector_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VCSConnector_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_vcs_proto_enumTypes[0].Descriptor()
+}
+
+func (VCSConnector_Mode) Type() protoreflect.EnumType {
+	return &file_store_vcs_proto_enumTypes[0]
+}
+
+func (x VCSConnector_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VCSConnector_Mode.Descriptor instead.
+func (VCSConnector_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_store_vcs_proto_rawDescGZIP(), []int{0, 0}
+}
+
 type VCSConnector struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -48,6 +100,10 @@ type VCSConnector struct {
 	// Apply changes to the database group. Optional, if not set, will apply changes to all databases in the project.
 	// Format: projects/{project}/databaseGroups/{databaseGroup}
 	DatabaseGroup string `protobuf:"bytes,9,opt,name=database_group,json=databaseGroup,proto3" json:"database_group,omitempty"`
+	// The template of the file path relative to the base directory, e.g. {{ENV_ID}}/{{DB_NAME}}/{{VERSION}}_{{TYPE}}_{{DESC}}.sql.
+	// If empty, only the files directly under the base directory are observed and applied to all target databases.
+	FilePathTemplate string            `protobuf:"bytes,10,opt,name=file_path_template,json=filePathTemplate,proto3" json:"file_path_template,omitempty"`
+	Mode             VCSConnector_Mode `protobuf:"varint,11,opt,name=mode,proto3,enum=bytebase.store.VCSConnector_Mode" json:"mode,omitempty"`
 }
 
 func (x *VCSConnector) Reset() {
@@ -145,12 +201,26 @@ func (x *VCSConnector) GetDatabaseGroup() string {
 	return ""
 }
 
+func (x *VCSConnector) GetFilePathTemplate() string {
+	if x != nil {
+		return x.FilePathTemplate
+	}
+	return ""
+}
+
+func (x *VCSConnector) GetMode() VCSConnector_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return VCSConnector_MODE_UNSPECIFIED
+}
+
 var File_store_vcs_proto protoreflect.FileDescriptor
 
 var file_store_vcs_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x63, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72,
-	0x65, 0x22, 0xc3, 0x02, 0x0a, 0x0c, 0x56, 0x43, 0x53, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x65, 0x22, 0xde, 0x03, 0x0a, 0x0c, 0x56, 0x43, 0x53, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
 	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x6c, 0x6c,
 	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x75, 0x6c,
@@ -170,9 +240,18 @@ var file_store_vcs_proto_rawDesc = []byte{
 	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
 	0x12, 0x25, 0x0a, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x67, 0x72, 0x6f,
 	0x75, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
-	0x73, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x14, 0x5a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x64, 0x2d, 0x67, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2c, 0x0a, 0x12, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x56, 0x43, 0x53, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x34, 0x0a, 0x04,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x49,
+	0x47, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x44, 0x4c,
+	0x10, 0x02, 0x42, 0x14, 0x5a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2d,
+	0x67, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -187,16 +266,19 @@ func file_store_vcs_proto_rawDescGZIP() []byte {
 	return file_store_vcs_proto_rawDescData
 }
 
+var file_store_vcs_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_store_vcs_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_store_vcs_proto_goTypes = []interface{}{
-	(*VCSConnector)(nil), // 0: bytebase.store.VCSConnector
+	(VCSConnector_Mode)(0), // 0: bytebase.store.VCSConnector.Mode
+	(*VCSConnector)(nil),   // 1: bytebase.store.VCSConnector
 }
 var file_store_vcs_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: bytebase.store.VCSConnector.mode:type_name -> bytebase.store.VCSConnector.Mode
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_store_vcs_proto_init() }
@@ -223,13 +305,14 @@ func file_store_vcs_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_store_vcs_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_store_vcs_proto_goTypes,
 		DependencyIndexes: file_store_vcs_proto_depIdxs,
+		EnumInfos:         file_store_vcs_proto_enumTypes,
 		MessageInfos:      file_store_vcs_proto_msgTypes,
 	}.Build()
 	File_store_vcs_proto = out.File
@@ -774,7 +774,6 @@ type RowValue struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Kind:
-	//
 	//	*RowValue_NullValue
 	//	*RowValue_BoolValue
 	//	*RowValue_BytesValue
@@ -995,6 +994,8 @@ type Advice struct {
 	Column int32 `protobuf:"varint,6,opt,name=column,proto3" json:"column,omitempty"`
 	// The advice detail.
 	Detail string `protobuf:"bytes,7,opt,name=detail,proto3" json:"detail,omitempty"`
+	// The optional suggested fix of the advice.
+	Fix *Advice_Fix `protobuf:"bytes,8,opt,name=fix,proto3" json:"fix,omitempty"`
 }
 
 func (x *Advice) Reset() {
@@ -1078,6 +1079,13 @@ func (x *Advice) GetDetail() string {
 	return ""
 }
 
+func (x *Advice) GetFix() *Advice_Fix {
+	if x != nil {
+		return x.Fix
+	}
+	return nil
+}
+
 type ExportRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1799,12 +1807,12 @@ type SearchQueryHistoriesRequest struct {
 	// filter is the filter to apply on the search query history,
 	// follow the [ebnf](https://en.wikipedia.org/wiki/Extended_Backus%E2%80%93Naur_form) syntax.
 	// Support filter by:
-	//   - database, for example:
-	//     database = "instances/{instance}/databases/{database}"
-	//   - instance, for example:
-	//     instance = "instance/{instance}"
-	//   - type, for example:
-	//     type = "QUERY"
+	// - database, for example:
+	//    database = "instances/{instance}/databases/{database}"
+	// - instance, for example:
+	//    instance = "instance/{instance}"
+	// - type, for example:
+	//    type = "QUERY"
 	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
 }
 
@@ -2027,6 +2035,139 @@ func (x *QueryHistory) GetType() QueryHistory_Type {
 	return QueryHistory_TYPE_UNSPECIFIED
 }
 
+type Advice_Position struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The line number, one-based.
+	Line int32 `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	// The column number in characters, zero-based.
+	Column int32 `protobuf:"varint,2,opt,name=column,proto3" json:"column,omitempty"`
+}
+
+func (x *Advice_Position) Reset() {
+	*x = Advice_Position{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_sql_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Advice_Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Advice_Position) ProtoMessage() {}
+
+func (x *Advice_Position) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_sql_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Advice_Position.ProtoReflect.Descriptor instead.
+func (*Advice_Position) Descriptor() ([]byte, []int) {
+	return file_v1_sql_service_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *Advice_Position) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *Advice_Position) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+// Fix is the suggested text edit to fix the advice.
+type Advice_Fix struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The description of the fix.
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// The start position of the text to replace.
+	Start *Advice_Position `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// The end position of the text to replace.
+	End *Advice_Position `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// The text replacing the range.
+	NewText string `protobuf:"bytes,4,opt,name=new_text,json=newText,proto3" json:"new_text,omitempty"`
+}
+
+func (x *Advice_Fix) Reset() {
+	*x = Advice_Fix{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_sql_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Advice_Fix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Advice_Fix) ProtoMessage() {}
+
+func (x *Advice_Fix) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_sql_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Advice_Fix.ProtoReflect.Descriptor instead.
+func (*Advice_Fix) Descriptor() ([]byte, []int) {
+	return file_v1_sql_service_proto_rawDescGZIP(), []int{9, 1}
+}
+
+func (x *Advice_Fix) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Advice_Fix) GetStart() *Advice_Position {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Advice_Fix) GetEnd() *Advice_Position {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *Advice_Fix) GetNewText() string {
+	if x != nil {
+		return x.NewText
+	}
+	return ""
+}
+
 var File_v1_sql_service_proto protoreflect.FileDescriptor
 
 var file_v1_sql_service_proto_rawDesc = []byte{
@@ -2155,7 +2296,7 @@ var file_v1_sql_service_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x0a,
 	0x76, 0x61, 0x6c, 0x75, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69,
-	0x6e, 0x64, 0x22, 0x8b, 0x02, 0x0a, 0x06, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x12, 0x32, 0x0a,
+	0x6e, 0x64, 0x22, 0x8b, 0x04, 0x0a, 0x06, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x12, 0x32, 0x0a,
 	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e,
 	0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x76, 0x69,
 	0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
@@ -2167,7 +2308,23 @@ var file_v1_sql_service_proto_rawDesc = []byte{
 	0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c,
 	0x75, 0x6d, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
 	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x45, 0x0a, 0x06, 0x53, 0x74, 0x61,
+	0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x29, 0x0a, 0x03, 0x66, 0x69, 0x78,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x46, 0x69, 0x78, 0x52,
+	0x03, 0x66, 0x69, 0x78, 0x1a, 0x36, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x1a, 0x9a, 0x01, 0x0a,
+	0x03, 0x46, 0x69, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x79, 0x74, 0x65,
+	0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2e,
+	0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x62, 0x79,
+	0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6e, 0x65, 0x77, 0x54, 0x65, 0x78, 0x74, 0x22, 0x45, 0x0a, 0x06, 0x53, 0x74, 0x61,
 	0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e,
 	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53,
 	0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e,
@@ -2407,7 +2564,7 @@ func file_v1_sql_service_proto_rawDescGZIP() []byte {
 }
 
 var file_v1_sql_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_v1_sql_service_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_v1_sql_service_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_v1_sql_service_proto_goTypes = []interface{}{
 	(Advice_Status)(0),                   // 0: bytebase.v1.Advice.Status
 	(CheckRequest_ChangeType)(0),         // 1: bytebase.v1.CheckRequest.ChangeType
@@ -2437,67 +2594,72 @@ var file_v1_sql_service_proto_goTypes = []interface{}{
 	(*SearchQueryHistoriesRequest)(nil),  // 25: bytebase.v1.SearchQueryHistoriesRequest
 	(*SearchQueryHistoriesResponse)(nil), // 26: bytebase.v1.SearchQueryHistoriesResponse
 	(*QueryHistory)(nil),                 // 27: bytebase.v1.QueryHistory
-	(*durationpb.Duration)(nil),          // 28: google.protobuf.Duration
-	(structpb.NullValue)(0),              // 29: google.protobuf.NullValue
-	(*structpb.Value)(nil),               // 30: google.protobuf.Value
-	(ExportFormat)(0),                    // 31: bytebase.v1.ExportFormat
-	(Engine)(0),                          // 32: bytebase.v1.Engine
-	(*DatabaseMetadata)(nil),             // 33: bytebase.v1.DatabaseMetadata
-	(*timestamppb.Timestamp)(nil),        // 34: google.protobuf.Timestamp
+	(*Advice_Position)(nil),              // 28: bytebase.v1.Advice.Position
+	(*Advice_Fix)(nil),                   // 29: bytebase.v1.Advice.Fix
+	(*durationpb.Duration)(nil),          // 30: google.protobuf.Duration
+	(structpb.NullValue)(0),              // 31: google.protobuf.NullValue
+	(*structpb.Value)(nil),               // 32: google.protobuf.Value
+	(ExportFormat)(0),                    // 33: bytebase.v1.ExportFormat
+	(Engine)(0),                          // 34: bytebase.v1.Engine
+	(*DatabaseMetadata)(nil),             // 35: bytebase.v1.DatabaseMetadata
+	(*timestamppb.Timestamp)(nil),        // 36: google.protobuf.Timestamp
 }
 var file_v1_sql_service_proto_depIdxs = []int32{
-	28, // 0: bytebase.v1.ExecuteRequest.timeout:type_name -> google.protobuf.Duration
+	30, // 0: bytebase.v1.ExecuteRequest.timeout:type_name -> google.protobuf.Duration
 	9,  // 1: bytebase.v1.ExecuteResponse.results:type_name -> bytebase.v1.QueryResult
 	12, // 2: bytebase.v1.ExecuteResponse.advices:type_name -> bytebase.v1.Advice
-	28, // 3: bytebase.v1.AdminExecuteRequest.timeout:type_name -> google.protobuf.Duration
+	30, // 3: bytebase.v1.AdminExecuteRequest.timeout:type_name -> google.protobuf.Duration
 	9,  // 4: bytebase.v1.AdminExecuteResponse.results:type_name -> bytebase.v1.QueryResult
-	28, // 5: bytebase.v1.QueryRequest.timeout:type_name -> google.protobuf.Duration
+	30, // 5: bytebase.v1.QueryRequest.timeout:type_name -> google.protobuf.Duration
 	9,  // 6: bytebase.v1.QueryResponse.results:type_name -> bytebase.v1.QueryResult
 	12, // 7: bytebase.v1.QueryResponse.advices:type_name -> bytebase.v1.Advice
 	10, // 8: bytebase.v1.QueryResult.rows:type_name -> bytebase.v1.QueryRow
-	28, // 9: bytebase.v1.QueryResult.latency:type_name -> google.protobuf.Duration
+	30, // 9: bytebase.v1.QueryResult.latency:type_name -> google.protobuf.Duration
 	11, // 10: bytebase.v1.QueryRow.values:type_name -> bytebase.v1.RowValue
-	29, // 11: bytebase.v1.RowValue.null_value:type_name -> google.protobuf.NullValue
-	30, // 12: bytebase.v1.RowValue.value_value:type_name -> google.protobuf.Value
+	31, // 11: bytebase.v1.RowValue.null_value:type_name -> google.protobuf.NullValue
+	32, // 12: bytebase.v1.RowValue.value_value:type_name -> google.protobuf.Value
 	0,  // 13: bytebase.v1.Advice.status:type_name -> bytebase.v1.Advice.Status
-	31, // 14: bytebase.v1.ExportRequest.format:type_name -> bytebase.v1.ExportFormat
-	32, // 15: bytebase.v1.DifferPreviewRequest.engine:type_name -> bytebase.v1.Engine
-	33, // 16: bytebase.v1.DifferPreviewRequest.new_metadata:type_name -> bytebase.v1.DatabaseMetadata
-	32, // 17: bytebase.v1.PrettyRequest.engine:type_name -> bytebase.v1.Engine
-	33, // 18: bytebase.v1.CheckRequest.metadata:type_name -> bytebase.v1.DatabaseMetadata
-	1,  // 19: bytebase.v1.CheckRequest.change_type:type_name -> bytebase.v1.CheckRequest.ChangeType
-	12, // 20: bytebase.v1.CheckResponse.advices:type_name -> bytebase.v1.Advice
-	33, // 21: bytebase.v1.StringifyMetadataRequest.metadata:type_name -> bytebase.v1.DatabaseMetadata
-	32, // 22: bytebase.v1.StringifyMetadataRequest.engine:type_name -> bytebase.v1.Engine
-	27, // 23: bytebase.v1.SearchQueryHistoriesResponse.query_histories:type_name -> bytebase.v1.QueryHistory
-	34, // 24: bytebase.v1.QueryHistory.create_time:type_name -> google.protobuf.Timestamp
-	28, // 25: bytebase.v1.QueryHistory.duration:type_name -> google.protobuf.Duration
-	2,  // 26: bytebase.v1.QueryHistory.type:type_name -> bytebase.v1.QueryHistory.Type
-	7,  // 27: bytebase.v1.SQLService.Query:input_type -> bytebase.v1.QueryRequest
-	3,  // 28: bytebase.v1.SQLService.Execute:input_type -> bytebase.v1.ExecuteRequest
-	5,  // 29: bytebase.v1.SQLService.AdminExecute:input_type -> bytebase.v1.AdminExecuteRequest
-	25, // 30: bytebase.v1.SQLService.SearchQueryHistories:input_type -> bytebase.v1.SearchQueryHistoriesRequest
-	13, // 31: bytebase.v1.SQLService.Export:input_type -> bytebase.v1.ExportRequest
-	15, // 32: bytebase.v1.SQLService.DifferPreview:input_type -> bytebase.v1.DifferPreviewRequest
-	19, // 33: bytebase.v1.SQLService.Check:input_type -> bytebase.v1.CheckRequest
-	21, // 34: bytebase.v1.SQLService.ParseMyBatisMapper:input_type -> bytebase.v1.ParseMyBatisMapperRequest
-	17, // 35: bytebase.v1.SQLService.Pretty:input_type -> bytebase.v1.PrettyRequest
-	23, // 36: bytebase.v1.SQLService.StringifyMetadata:input_type -> bytebase.v1.StringifyMetadataRequest
-	8,  // 37: bytebase.v1.SQLService.Query:output_type -> bytebase.v1.QueryResponse
-	4,  // 38: bytebase.v1.SQLService.Execute:output_type -> bytebase.v1.ExecuteResponse
-	6,  // 39: bytebase.v1.SQLService.AdminExecute:output_type -> bytebase.v1.AdminExecuteResponse
-	26, // 40: bytebase.v1.SQLService.SearchQueryHistories:output_type -> bytebase.v1.SearchQueryHistoriesResponse
-	14, // 41: bytebase.v1.SQLService.Export:output_type -> bytebase.v1.ExportResponse
-	16, // 42: bytebase.v1.SQLService.DifferPreview:output_type -> bytebase.v1.DifferPreviewResponse
-	20, // 43: bytebase.v1.SQLService.Check:output_type -> bytebase.v1.CheckResponse
-	22, // 44: bytebase.v1.SQLService.ParseMyBatisMapper:output_type -> bytebase.v1.ParseMyBatisMapperResponse
-	18, // 45: bytebase.v1.SQLService.Pretty:output_type -> bytebase.v1.PrettyResponse
-	24, // 46: bytebase.v1.SQLService.StringifyMetadata:output_type -> bytebase.v1.StringifyMetadataResponse
-	37, // [37:47] is the sub-list for method output_type
-	27, // [27:37] is the sub-list for method input_type
-	27, // [27:27] is the sub-list for extension type_name
-	27, // [27:27] is the sub-list for extension extendee
-	0,  // [0:27] is the sub-list for field type_name
+	29, // 14: bytebase.v1.Advice.fix:type_name -> bytebase.v1.Advice.Fix
+	33, // 15: bytebase.v1.ExportRequest.format:type_name -> bytebase.v1.ExportFormat
+	34, // 16: bytebase.v1.DifferPreviewRequest.engine:type_name -> bytebase.v1.Engine
+	35, // 17: bytebase.v1.DifferPreviewRequest.new_metadata:type_name -> bytebase.v1.DatabaseMetadata
+	34, // 18: bytebase.v1.PrettyRequest.engine:type_name -> bytebase.v1.Engine
+	35, // 19: bytebase.v1.CheckRequest.metadata:type_name -> bytebase.v1.DatabaseMetadata
+	1,  // 20: bytebase.v1.CheckRequest.change_type:type_name -> bytebase.v1.CheckRequest.ChangeType
+	12, // 21: bytebase.v1.CheckResponse.advices:type_name -> bytebase.v1.Advice
+	35, // 22: bytebase.v1.StringifyMetadataRequest.metadata:type_name -> bytebase.v1.DatabaseMetadata
+	34, // 23: bytebase.v1.StringifyMetadataRequest.engine:type_name -> bytebase.v1.Engine
+	27, // 24: bytebase.v1.SearchQueryHistoriesResponse.query_histories:type_name -> bytebase.v1.QueryHistory
+	36, // 25: bytebase.v1.QueryHistory.create_time:type_name -> google.protobuf.Timestamp
+	30, // 26: bytebase.v1.QueryHistory.duration:type_name -> google.protobuf.Duration
+	2,  // 27: bytebase.v1.QueryHistory.type:type_name -> bytebase.v1.QueryHistory.Type
+	28, // 28: bytebase.v1.Advice.Fix.start:type_name -> bytebase.v1.Advice.Position
+	28, // 29: bytebase.v1.Advice.Fix.end:type_name -> bytebase.v1.Advice.Position
+	7,  // 30: bytebase.v1.SQLService.Query:input_type -> bytebase.v1.QueryRequest
+	3,  // 31: bytebase.v1.SQLService.Execute:input_type -> bytebase.v1.ExecuteRequest
+	5,  // 32: bytebase.v1.SQLService.AdminExecute:input_type -> bytebase.v1.AdminExecuteRequest
+	25, // 33: bytebase.v1.SQLService.SearchQueryHistories:input_type -> bytebase.v1.SearchQueryHistoriesRequest
+	13, // 34: bytebase.v1.SQLService.Export:input_type -> bytebase.v1.ExportRequest
+	15, // 35: bytebase.v1.SQLService.DifferPreview:input_type -> bytebase.v1.DifferPreviewRequest
+	19, // 36: bytebase.v1.SQLService.Check:input_type -> bytebase.v1.CheckRequest
+	21, // 37: bytebase.v1.SQLService.ParseMyBatisMapper:input_type -> bytebase.v1.ParseMyBatisMapperRequest
+	17, // 38: bytebase.v1.SQLService.Pretty:input_type -> bytebase.v1.PrettyRequest
+	23, // 39: bytebase.v1.SQLService.StringifyMetadata:input_type -> bytebase.v1.StringifyMetadataRequest
+	8,  // 40: bytebase.v1.SQLService.Query:output_type -> bytebase.v1.QueryResponse
+	4,  // 41: bytebase.v1.SQLService.Execute:output_type -> bytebase.v1.ExecuteResponse
+	6,  // 42: bytebase.v1.SQLService.AdminExecute:output_type -> bytebase.v1.AdminExecuteResponse
+	26, // 43: bytebase.v1.SQLService.SearchQueryHistories:output_type -> bytebase.v1.SearchQueryHistoriesResponse
+	14, // 44: bytebase.v1.SQLService.Export:output_type -> bytebase.v1.ExportResponse
+	16, // 45: bytebase.v1.SQLService.DifferPreview:output_type -> bytebase.v1.DifferPreviewResponse
+	20, // 46: bytebase.v1.SQLService.Check:output_type -> bytebase.v1.CheckResponse
+	22, // 47: bytebase.v1.SQLService.ParseMyBatisMapper:output_type -> bytebase.v1.ParseMyBatisMapperResponse
+	18, // 48: bytebase.v1.SQLService.Pretty:output_type -> bytebase.v1.PrettyResponse
+	24, // 49: bytebase.v1.SQLService.StringifyMetadata:output_type -> bytebase.v1.StringifyMetadataResponse
+	40, // [40:50] is the sub-list for method output_type
+	30, // [30:40] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_v1_sql_service_proto_init() }
@@ -2808,6 +2970,30 @@ func file_v1_sql_service_proto_init() {
 				return nil
 			}
 		}
+		file_v1_sql_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Advice_Position); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_sql_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Advice_Fix); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_v1_sql_service_proto_msgTypes[4].OneofWrappers = []interface{}{}
 	file_v1_sql_service_proto_msgTypes[8].OneofWrappers = []interface{}{
@@ -2830,7 +3016,7 @@ func file_v1_sql_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_sql_service_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   25,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},